@@ -26,6 +26,7 @@ const K8sRecommendedAppAnnotations = "app.kubernetes.io/part-of"
 const K8sRecommendedManagedByAnnotations = "app.kubernetes.io/managed-by"
 const PreDeploymentEvaluationAnnotation = "keptn.sh/pre-deployment-evaluations"
 const PostDeploymentEvaluationAnnotation = "keptn.sh/post-deployment-evaluations"
+const CanaryEvaluationAnnotation = "keptn.sh/canary-evaluations"
 const SchedulingGateRemoved = "keptn.sh/scheduling-gate-removed"
 const TaskNameAnnotation = "keptn.sh/task-name"
 const NamespaceEnabledAnnotation = "keptn.sh/lifecycle-toolkit"
@@ -37,6 +38,12 @@ const AppTypeAnnotation = "keptn.sh/app-type"
 const KeptnGate = "keptn-prechecks-gate"
 const ContainerNameAnnotation = "keptn.sh/container"
 const MetadataAnnotation = "keptn.sh/metadata"
+const KnativeServiceLabel = "serving.knative.dev/service"
+const KnativeRevisionLabel = "serving.knative.dev/revision"
+const TaskApprovalAnnotation = "keptn.sh/approval"
+const TaskApprovedByAnnotation = "keptn.sh/approved-by"
+const TaskApprovalApproved = "approved"
+const TaskApprovalRejected = "rejected"
 
 const MinKeptnNameLen = 80
 const MaxK8sObjectLength = 253
@@ -48,7 +55,7 @@ const (
 	AppTypeMultiService  AppType = "multi-service"
 )
 
-// KeptnState  is a string containing current Phase state  (Progressing/Succeeded/Failed/Unknown/Pending/Deprecated/Warning)
+// KeptnState  is a string containing current Phase state  (Progressing/Succeeded/Failed/Unknown/Pending/Deprecated/Warning/Skipped)
 type KeptnState string
 
 const (
@@ -59,10 +66,11 @@ const (
 	StatePending     KeptnState = "Pending"
 	StateDeprecated  KeptnState = "Deprecated"
 	StateWarning     KeptnState = "Warning"
+	StateSkipped     KeptnState = "Skipped"
 )
 
 func (k KeptnState) IsCompleted() bool {
-	return k == StateSucceeded || k == StateFailed || k == StateDeprecated || k == StateWarning
+	return k == StateSucceeded || k == StateFailed || k == StateDeprecated || k == StateWarning || k == StateSkipped
 }
 
 func (k KeptnState) IsSucceeded() bool {
@@ -85,6 +93,10 @@ func (k KeptnState) IsWarning() bool {
 	return k == StateWarning
 }
 
+func (k KeptnState) IsSkipped() bool {
+	return k == StateSkipped
+}
+
 type StatusSummary struct {
 	Total       int
 	Progressing int
@@ -93,6 +105,7 @@ type StatusSummary struct {
 	Pending     int
 	Unknown     int
 	Deprecated  int
+	Skipped     int
 }
 
 func UpdateStatusSummary(status KeptnState, summary StatusSummary) StatusSummary {
@@ -109,12 +122,14 @@ func UpdateStatusSummary(status KeptnState, summary StatusSummary) StatusSummary
 		summary.Pending++
 	case StateUnknown:
 		summary.Unknown++
+	case StateSkipped:
+		summary.Skipped++
 	}
 	return summary
 }
 
 func (s StatusSummary) GetTotalCount() int {
-	return s.Failed + s.Succeeded + s.Progressing + s.Pending + s.Unknown + s.Deprecated
+	return s.Failed + s.Succeeded + s.Progressing + s.Pending + s.Unknown + s.Deprecated + s.Skipped
 }
 
 func GetOverallState(s StatusSummary) KeptnState {
@@ -165,6 +180,7 @@ const PostDeploymentCheckType CheckType = "post"
 const PromotionCheckType CheckType = "promotion"
 const PreDeploymentEvaluationCheckType CheckType = "pre-eval"
 const PostDeploymentEvaluationCheckType CheckType = "post-eval"
+const CanaryEvaluationCheckType CheckType = "canary-eval"
 
 type KeptnMeters struct {
 	TaskCount          metric.Int64Counter
@@ -227,5 +243,10 @@ func MergeMaps(m1 map[string]string, m2 map[string]string) map[string]string {
 
 // IsOwnerSupported returns whether the owner of the given object is supported to be considered a KeptnWorkload
 func IsOwnerSupported(owner metav1.OwnerReference) bool {
-	return owner.Kind == "ReplicaSet" || owner.Kind == "Deployment" || owner.Kind == "StatefulSet" || owner.Kind == "DaemonSet" || owner.Kind == "Rollout"
+	switch owner.Kind {
+	case "ReplicaSet", "Deployment", "StatefulSet", "DaemonSet", "Rollout", "Job", "CronJob":
+		return true
+	default:
+		return false
+	}
 }
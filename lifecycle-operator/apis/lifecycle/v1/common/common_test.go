@@ -31,6 +31,10 @@ func TestKeptnState_IsCompleted(t *testing.T) {
 			State: StateDeprecated,
 			Want:  true,
 		},
+		{
+			State: StateSkipped,
+			Want:  true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
@@ -148,6 +152,27 @@ func TestKeptnState_IsDeprecated(t *testing.T) {
 	}
 }
 
+func TestKeptnState_IsSkipped(t *testing.T) {
+	tests := []struct {
+		State KeptnState
+		Want  bool
+	}{
+		{
+			State: StateFailed,
+			Want:  false,
+		},
+		{
+			State: StateSkipped,
+			Want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			require.Equal(t, tt.State.IsSkipped(), tt.Want)
+		})
+	}
+}
+
 func TestKeptnKeptnState_IsPending(t *testing.T) {
 	tests := []struct {
 		State KeptnState
@@ -170,38 +195,42 @@ func TestKeptnKeptnState_IsPending(t *testing.T) {
 }
 
 func Test_UpdateStatusSummary(t *testing.T) {
-	emmptySummary := StatusSummary{0, 0, 0, 0, 0, 0, 0}
+	emmptySummary := StatusSummary{0, 0, 0, 0, 0, 0, 0, 0}
 	tests := []struct {
 		State KeptnState
 		Want  StatusSummary
 	}{
 		{
 			State: StateProgressing,
-			Want:  StatusSummary{0, 1, 0, 0, 0, 0, 0},
+			Want:  StatusSummary{0, 1, 0, 0, 0, 0, 0, 0},
 		},
 		{
 			State: StateFailed,
-			Want:  StatusSummary{0, 0, 1, 0, 0, 0, 0},
+			Want:  StatusSummary{0, 0, 1, 0, 0, 0, 0, 0},
 		},
 		{
 			State: StateSucceeded,
-			Want:  StatusSummary{0, 0, 0, 1, 0, 0, 0},
+			Want:  StatusSummary{0, 0, 0, 1, 0, 0, 0, 0},
 		},
 		{
 			State: StatePending,
-			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0},
+			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0, 0},
 		},
 		{
 			State: "",
-			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0},
+			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0, 0},
 		},
 		{
 			State: StateUnknown,
-			Want:  StatusSummary{0, 0, 0, 0, 0, 1, 0},
+			Want:  StatusSummary{0, 0, 0, 0, 0, 1, 0, 0},
 		},
 		{
 			State: StateDeprecated,
-			Want:  StatusSummary{0, 0, 0, 0, 0, 0, 1},
+			Want:  StatusSummary{0, 0, 0, 0, 0, 0, 1, 0},
+		},
+		{
+			State: StateSkipped,
+			Want:  StatusSummary{0, 0, 0, 0, 0, 0, 0, 1},
 		},
 	}
 	for _, tt := range tests {
@@ -212,8 +241,8 @@ func Test_UpdateStatusSummary(t *testing.T) {
 }
 
 func Test_GetTotalCount(t *testing.T) {
-	summary := StatusSummary{2, 0, 2, 1, 0, 3, 5}
-	require.Equal(t, summary.GetTotalCount(), 11)
+	summary := StatusSummary{2, 0, 2, 1, 0, 3, 5, 1}
+	require.Equal(t, summary.GetTotalCount(), 12)
 }
 
 func Test_GeOverallState(t *testing.T) {
@@ -224,42 +253,42 @@ func Test_GeOverallState(t *testing.T) {
 	}{
 		{
 			Name:    "failed",
-			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0},
+			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0, 0},
 			Want:    StateFailed,
 		},
 		{
 			Name:    "deprecated",
-			Summary: StatusSummary{0, 0, 0, 0, 0, 0, 1},
+			Summary: StatusSummary{0, 0, 0, 0, 0, 0, 1, 0},
 			Want:    StateFailed,
 		},
 		{
 			Name:    "progressing",
-			Summary: StatusSummary{0, 1, 0, 0, 0, 0, 0},
+			Summary: StatusSummary{0, 1, 0, 0, 0, 0, 0, 0},
 			Want:    StateProgressing,
 		},
 		{
 			Name:    "pending",
-			Summary: StatusSummary{0, 0, 0, 0, 1, 0, 0},
+			Summary: StatusSummary{0, 0, 0, 0, 1, 0, 0, 0},
 			Want:    StatePending,
 		},
 		{
 			Name:    "unknown",
-			Summary: StatusSummary{0, 0, 0, 0, 0, 1, 0},
+			Summary: StatusSummary{0, 0, 0, 0, 0, 1, 0, 0},
 			Want:    StateUnknown,
 		},
 		{
 			Name:    "unknown totalcount",
-			Summary: StatusSummary{5, 0, 0, 0, 0, 1, 0},
+			Summary: StatusSummary{5, 0, 0, 0, 0, 1, 0, 0},
 			Want:    StateUnknown,
 		},
 		{
 			Name:    "succeeded",
-			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0},
+			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0, 0},
 			Want:    StateSucceeded,
 		},
 		{
 			Name:    "pending total count",
-			Summary: StatusSummary{2, 0, 0, 1, 0, 0, 0},
+			Summary: StatusSummary{2, 0, 0, 1, 0, 0, 0, 0},
 			Want:    StatePending,
 		},
 	}
@@ -279,25 +308,25 @@ func Test_GeOverallStateBlockedDeployment(t *testing.T) {
 	}{
 		{
 			Name:    "failed blocking",
-			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0},
+			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0, 0},
 			Block:   true,
 			Want:    StateFailed,
 		},
 		{
 			Name:    "succeeded blocking",
-			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0},
+			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0, 0},
 			Block:   true,
 			Want:    StateSucceeded,
 		},
 		{
 			Name:    "failed non-blocking",
-			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0},
+			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0, 0},
 			Block:   false,
 			Want:    StateWarning,
 		},
 		{
 			Name:    "succeeded non-blocking",
-			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0},
+			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0, 0},
 			Block:   false,
 			Want:    StateSucceeded,
 		},
@@ -533,12 +562,30 @@ func TestIsOwnerSupported(t *testing.T) {
 			want: true,
 		},
 		{
-			name: "Job-> false",
+			name: "Job-> true",
 			args: args{
 				owner: v1.OwnerReference{
 					Kind: "Job",
 				},
 			},
+			want: true,
+		},
+		{
+			name: "CronJob-> true",
+			args: args{
+				owner: v1.OwnerReference{
+					Kind: "CronJob",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Pod-> false",
+			args: args{
+				owner: v1.OwnerReference{
+					Kind: "Pod",
+				},
+			},
 			want: false,
 		},
 	}
@@ -19,18 +19,21 @@ var phases = []KeptnPhaseType{
 	PhaseWorkloadPreEvaluation,
 	PhaseWorkloadPostEvaluation,
 	PhaseWorkloadDeployment,
+	PhaseWorkloadCanaryEvaluation,
 	PhaseAppPreDeployment,
 	PhaseAppPostDeployment,
 	PhaseAppPreEvaluation,
 	PhaseAppPostEvaluation,
 	PhasePromotion,
 	PhaseAppDeployment,
+	PhaseAppRollback,
 	PhaseReconcileEvaluation,
 	PhaseReconcileTask,
 	PhaseReconcileWorkload,
 	PhaseUpdateWorkload,
 	PhaseCreateEvaluation,
 	PhaseCreateTask,
+	PhaseTaskApproval,
 	PhaseCreateAppCreationRequest,
 	PhaseCreateWorkload,
 	PhaseCreateWorkloadVersion,
@@ -92,17 +95,20 @@ var (
 	PhaseWorkloadPreEvaluation    = KeptnPhaseType{LongName: "Workload Pre-Deployment Evaluations", ShortName: "WorkloadPreDeployEvaluations"}
 	PhaseWorkloadPostEvaluation   = KeptnPhaseType{LongName: "Workload Post-Deployment Evaluations", ShortName: "WorkloadPostDeployEvaluations"}
 	PhaseWorkloadDeployment       = KeptnPhaseType{LongName: "Workload Deployment", ShortName: "WorkloadDeploy"}
+	PhaseWorkloadCanaryEvaluation = KeptnPhaseType{LongName: "Workload Canary Evaluations", ShortName: "WorkloadCanaryEvaluations"}
 	PhaseAppPreDeployment         = KeptnPhaseType{LongName: "App Pre-Deployment Tasks", ShortName: "AppPreDeployTasks"}
 	PhaseAppPostDeployment        = KeptnPhaseType{LongName: "App Post-Deployment Tasks", ShortName: "AppPostDeployTasks"}
 	PhaseAppPreEvaluation         = KeptnPhaseType{LongName: "App Pre-Deployment Evaluations", ShortName: "AppPreDeployEvaluations"}
 	PhaseAppPostEvaluation        = KeptnPhaseType{LongName: "App Post-Deployment Evaluations", ShortName: "AppPostDeployEvaluations"}
 	PhasePromotion                = KeptnPhaseType{LongName: "Promotion Tasks", ShortName: "PromotionTasks"}
 	PhaseAppDeployment            = KeptnPhaseType{LongName: "App Deployment", ShortName: "AppDeploy"}
+	PhaseAppRollback              = KeptnPhaseType{LongName: "App Rollback", ShortName: "AppRollback"}
 	PhaseReconcileEvaluation      = KeptnPhaseType{LongName: "Reconcile Evaluation", ShortName: "ReconcileEvaluation"}
 	PhaseReconcileTask            = KeptnPhaseType{LongName: "Reconcile Task", ShortName: "ReconcileTask"}
 	PhaseReconcileWorkload        = KeptnPhaseType{LongName: "Reconcile Workloads", ShortName: "ReconcileWorkload"}
 	PhaseCreateEvaluation         = KeptnPhaseType{LongName: "Create Evaluation", ShortName: "CreateEvaluation"}
 	PhaseCreateTask               = KeptnPhaseType{LongName: "Create Task", ShortName: "CreateTask"}
+	PhaseTaskApproval             = KeptnPhaseType{LongName: "Task Approval", ShortName: "TaskApproval"}
 	PhaseCreateAppCreationRequest = KeptnPhaseType{LongName: "Create AppCreationRequest", ShortName: "CreateAppCreationRequest"}
 	PhaseCreateWorkload           = KeptnPhaseType{LongName: "Create Workload", ShortName: "CreateWorkload"}
 	PhaseUpdateWorkload           = KeptnPhaseType{LongName: "Update Workload", ShortName: "UpdateWorkload"}
@@ -135,4 +141,9 @@ var (
 	PhaseStateReconcileError   = "ReconcileError"
 	PhaseStateReconcileTimeout = "ReconcileTimeout"
 	PhaseStateNotFound         = "NotFound"
+	PhaseStateSkipped          = "Skipped"
+	PhaseStateApproved         = "Approved"
+	PhaseStateRejected         = "Rejected"
+	PhaseStatePromoted         = "Promoted"
+	PhaseStateAborted          = "Aborted"
 )
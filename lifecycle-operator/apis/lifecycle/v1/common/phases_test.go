@@ -333,4 +333,5 @@ func TestGetShortPhaseName(t *testing.T) {
 	require.Equal(t, "WorkloadPreDeployTasks", GetShortPhaseName("WorkloadPreDeployTasks"))
 	require.Equal(t, "WorkloadPreDeployTasks", GetShortPhaseName("Workload Pre-Deployment Tasks"))
 	require.Equal(t, "", GetShortPhaseName("Workload Pre-Deploycdddment Tasks"))
+	require.Equal(t, "AppRollback", GetShortPhaseName("App Rollback"))
 }
@@ -43,6 +43,22 @@ type DeploymentTaskSpec struct {
 	// The items of this list refer to the names of KeptnTaskDefinitions
 	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
 	PromotionTasks []string `json:"promotionTasks,omitempty"`
+	// TaskDependencies defines the order in which the pre-deployment, post-deployment and promotion tasks
+	// of the KeptnApp are executed.
+	// Tasks without dependencies are started immediately, all other tasks are only started once all the tasks
+	// they depend on have succeeded. If one of these tasks fails, the depending task is skipped.
+	// +optional
+	TaskDependencies []TaskDependency `json:"taskDependencies,omitempty"`
+}
+
+// TaskDependency defines the tasks that have to succeed before a task of a KeptnApp is started
+type TaskDependency struct {
+	// TaskDefinition is the name of the KeptnTaskDefinition of the task the dependencies are defined for.
+	TaskDefinition string `json:"taskDefinition"`
+	// RunAfter is a list of names of KeptnTaskDefinitions that have to succeed before the task is started.
+	// Only tasks of the same phase are considered, dependencies on tasks of other phases are ignored.
+	// +optional
+	RunAfter []string `json:"runAfter,omitempty"`
 }
 
 // KeptnAppContextSpec defines the desired state of KeptnAppContext
@@ -57,6 +73,22 @@ type KeptnAppContextSpec struct {
 	// SpanLinks are links to OpenTelemetry span IDs for tracking. These links establish relationships between spans across different services, enabling distributed tracing.
 	// For more information on OpenTelemetry span links, refer to the documentation: https://opentelemetry.io/docs/concepts/signals/traces/#span-links
 	SpanLinks []string `json:"spanLinks,omitempty"`
+
+	// +optional
+	// RollbackPolicy defines whether the workloads of a KeptnAppVersion are restored to the previous version
+	// of the KeptnApp if its post-deployment tasks or evaluations fail.
+	RollbackPolicy *RollbackPolicy `json:"rollbackPolicy,omitempty"`
+}
+
+// RollbackPolicy defines the rollback behavior of a KeptnAppVersion
+type RollbackPolicy struct {
+	// Enabled indicates whether the workloads of a failed KeptnAppVersion are rolled back
+	// to the pod templates recorded for the KeptnWorkloadVersions of the previous version of the KeptnApp.
+	// A rollback is only performed if the KeptnAppVersion has a PreviousVersion and
+	// its PostDeploymentStatus or PostDeploymentEvaluationStatus ends in Failed.
+	// +kubebuilder:default:=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 // KeptnAppContextStatus defines the observed state of KeptnAppContext
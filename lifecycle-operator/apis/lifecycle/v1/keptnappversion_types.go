@@ -71,6 +71,14 @@ type KeptnAppVersionStatus struct {
 	// WorkloadStatus contains the current status of each KeptnWorkload that is part of the KeptnAppVersion.
 	// +optional
 	WorkloadStatus []WorkloadStatus `json:"workloadStatus,omitempty"`
+	// RollbackStatus indicates the current status of the KeptnAppVersion's Rollback phase.
+	// +kubebuilder:default:=Pending
+	// +optional
+	RollbackStatus common.KeptnState `json:"rollbackStatus,omitempty"`
+	// RollbackWorkloadStatus contains the rollback status of each KeptnWorkload of the previous version of the KeptnApp.
+	// The contained workload references point to the versions the workloads have been rolled back to.
+	// +optional
+	RollbackWorkloadStatus []WorkloadStatus `json:"rollbackWorkloadStatus,omitempty"`
 	// CurrentPhase indicates the current phase of the KeptnAppVersion.
 	// +optional
 	CurrentPhase string `json:"currentPhase,omitempty"`
@@ -128,6 +136,7 @@ type WorkloadStatus struct {
 // +kubebuilder:printcolumn:name="PostDeploymentStatus",priority=1,type=string,JSONPath=`.status.postDeploymentStatus`
 // +kubebuilder:printcolumn:name="PostDeploymentEvaluationStatus",priority=1,type=string,JSONPath=`.status.postDeploymentEvaluationStatus`
 // +kubebuilder:printcolumn:name="PromotionStatus",priority=1,type=string,JSONPath=`.status.promotionStatus`
+// +kubebuilder:printcolumn:name="RollbackStatus",priority=1,type=string,JSONPath=`.status.rollbackStatus`
 
 // KeptnAppVersion is the Schema for the keptnappversions API
 type KeptnAppVersion struct {
@@ -247,6 +256,23 @@ func (a KeptnAppVersion) IsPromotionSucceeded() bool {
 	return a.Status.PromotionStatus.IsSucceeded()
 }
 
+func (a KeptnAppVersion) IsRollbackEnabled() bool {
+	return a.Spec.RollbackPolicy != nil && a.Spec.RollbackPolicy.Enabled && a.Spec.PreviousVersion != ""
+}
+
+func (a KeptnAppVersion) IsRollbackCompleted() bool {
+	return a.Status.RollbackStatus.IsCompleted()
+}
+
+// IsRollbackRequired returns true if the rollback policy of the KeptnAppVersion is enabled,
+// its post-deployment tasks or evaluations have failed and no rollback has been completed yet
+func (a KeptnAppVersion) IsRollbackRequired() bool {
+	if !a.IsRollbackEnabled() || a.IsRollbackCompleted() {
+		return false
+	}
+	return a.IsPostDeploymentFailed() || a.IsPostDeploymentEvaluationFailed()
+}
+
 func (a KeptnAppVersion) AreWorkloadsCompleted() bool {
 	return a.Status.WorkloadOverallStatus.IsCompleted()
 }
@@ -333,6 +359,10 @@ func (a KeptnAppVersion) GetPromotionTasks() []string {
 	return a.Spec.PromotionTasks
 }
 
+func (a KeptnAppVersion) GetTaskDependencies() []TaskDependency {
+	return a.Spec.TaskDependencies
+}
+
 func (a KeptnAppVersion) GetPreDeploymentTaskStatus() []ItemStatus {
 	return a.Status.PreDeploymentTaskStatus
 }
@@ -407,13 +437,16 @@ func (a KeptnAppVersion) GenerateTask(taskDefinition KeptnTaskDefinition, checkT
 				AppVersion: a.GetVersion(),
 				TaskType:   string(checkType),
 				ObjectType: "App",
+				Outputs:    collectTaskOutputs(a.Status.PreDeploymentTaskStatus, a.Status.PostDeploymentTaskStatus, a.Status.PromotionTaskStatus),
 			},
 			TaskDefinition:   taskDefinition.Name,
 			Parameters:       TaskParameters{},
 			SecureParameters: SecureParameters{},
 			Type:             checkType,
 			Retries:          taskDefinition.Spec.Retries,
-			Timeout:          taskDefinition.Spec.Timeout,
+			Timeout:          taskDefinition.GetTaskTimeout(),
+			Approval:         taskDefinition.Spec.Approval,
+			HTTP:             taskDefinition.Spec.HTTP,
 		},
 	}
 }
@@ -427,8 +460,18 @@ func (a KeptnAppVersion) GenerateEvaluation(evaluationDefinition KeptnEvaluation
 		Spec: KeptnEvaluationSpec{
 			AppVersion:           a.Spec.Version,
 			AppName:              a.Spec.AppName,
+			PreviousVersion:      a.Spec.PreviousVersion,
 			EvaluationDefinition: evaluationDefinition.Name,
 			Type:                 checkType,
+			Context: TaskContext{
+				AppName:    a.GetParentName(),
+				AppVersion: a.GetVersion(),
+				TaskType:   string(checkType),
+				ObjectType: "App",
+				Metadata:   a.Spec.Metadata,
+				Outputs:    collectTaskOutputs(a.Status.PreDeploymentTaskStatus, a.Status.PostDeploymentTaskStatus, a.Status.PromotionTaskStatus),
+			},
+			TimeframeStart: a.Status.StartTime,
 			FailureConditions: FailureConditions{
 				RetryInterval: evaluationDefinition.Spec.FailureConditions.RetryInterval,
 				Retries:       evaluationDefinition.Spec.FailureConditions.Retries,
@@ -470,20 +513,23 @@ func (a *KeptnAppVersion) DeprecateRemainingPhases(phase common.KeptnPhaseType)
 	if phase == common.PhasePromotion {
 		return
 	}
-	// deprecate promotion tasks when post evaluation failed
+	// deprecate promotion tasks when post evaluation failed, and the rollback if it is not enabled
 	if phase == common.PhaseAppPostEvaluation {
 		a.Status.PromotionStatus = common.StateDeprecated
+		a.deprecateRollbackIfDisabled()
 	}
-	// deprecate post evaluation when post tasks failed
+	// deprecate post evaluation when post tasks failed, and the rollback if it is not enabled
 	if phase == common.PhaseAppPostDeployment {
 		a.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
+		a.deprecateRollbackIfDisabled()
 	}
 	// deprecate post evaluation and tasks when app deployment failed
 	if phase == common.PhaseAppDeployment {
 		a.Status.PostDeploymentStatus = common.StateDeprecated
 		a.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
+		a.Status.RollbackStatus = common.StateDeprecated
 	}
 	// deprecate app deployment, post tasks and evaluations if app pre-eval failed
 	if phase == common.PhaseAppPreEvaluation {
@@ -491,6 +537,7 @@ func (a *KeptnAppVersion) DeprecateRemainingPhases(phase common.KeptnPhaseType)
 		a.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
 		a.Status.WorkloadOverallStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
+		a.Status.RollbackStatus = common.StateDeprecated
 	}
 	// deprecate pre evaluations, app deployment and post tasks and evaluations when pre-tasks failed
 	if phase == common.PhaseAppPreDeployment {
@@ -499,6 +546,7 @@ func (a *KeptnAppVersion) DeprecateRemainingPhases(phase common.KeptnPhaseType)
 		a.Status.WorkloadOverallStatus = common.StateDeprecated
 		a.Status.PreDeploymentEvaluationStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
+		a.Status.RollbackStatus = common.StateDeprecated
 	}
 	// deprecate completely everything
 	if phase == common.PhaseDeprecated {
@@ -508,12 +556,21 @@ func (a *KeptnAppVersion) DeprecateRemainingPhases(phase common.KeptnPhaseType)
 		a.Status.PreDeploymentEvaluationStatus = common.StateDeprecated
 		a.Status.PreDeploymentStatus = common.StateDeprecated
 		a.Status.PromotionStatus = common.StateDeprecated
+		a.Status.RollbackStatus = common.StateDeprecated
 		a.Status.Status = common.StateDeprecated
 		return
 	}
 	a.Status.Status = common.StateFailed
 }
 
+// deprecateRollbackIfDisabled deprecates the rollback phase if it will never be started,
+// as the rollback policy of the KeptnAppVersion is not enabled
+func (a *KeptnAppVersion) deprecateRollbackIfDisabled() {
+	if !a.IsRollbackEnabled() {
+		a.Status.RollbackStatus = common.StateDeprecated
+	}
+}
+
 func (a *KeptnAppVersion) SetPhaseTraceID(phase string, carrier propagation.MapCarrier) {
 	if a.Status.PhaseTraceIDs == nil {
 		a.Status.PhaseTraceIDs = common.PhaseTraceID{}
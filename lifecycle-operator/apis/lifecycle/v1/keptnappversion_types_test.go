@@ -73,6 +73,9 @@ func TestKeptnAppVersion(t *testing.T) {
 					PreDeploymentEvaluations:  []string{"task5", "task6"},
 					PostDeploymentEvaluations: []string{"task7", "task8"},
 					PromotionTasks:            []string{"task9", "task10"},
+					TaskDependencies: []TaskDependency{
+						{TaskDefinition: "task2", RunAfter: []string{"task1"}},
+					},
 				},
 			},
 			PreviousVersion: "prev",
@@ -163,6 +166,7 @@ func TestKeptnAppVersion(t *testing.T) {
 	require.Equal(t, []string{"task5", "task6"}, app.GetPreDeploymentEvaluations())
 	require.Equal(t, []string{"task7", "task8"}, app.GetPostDeploymentEvaluations())
 	require.Equal(t, []string{"task9", "task10"}, app.GetPromotionTasks())
+	require.Equal(t, []TaskDependency{{TaskDefinition: "task2", RunAfter: []string{"task1"}}}, app.GetTaskDependencies())
 
 	require.Equal(t, []ItemStatus{
 		{
@@ -285,8 +289,16 @@ func TestKeptnAppVersion(t *testing.T) {
 	require.Equal(t, KeptnEvaluationSpec{
 		AppVersion:           app.GetVersion(),
 		AppName:              app.GetParentName(),
+		PreviousVersion:      app.GetPreviousVersion(),
 		EvaluationDefinition: "eval-def",
 		Type:                 common.PostDeploymentCheckType,
+		Context: TaskContext{
+			AppName:    app.GetParentName(),
+			AppVersion: app.GetVersion(),
+			TaskType:   string(common.PostDeploymentCheckType),
+			ObjectType: "App",
+		},
+		TimeframeStart: app.Status.StartTime,
 		FailureConditions: FailureConditions{
 			RetryInterval: v1.Duration{
 				Duration: 5 * time.Second,
@@ -356,6 +368,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 			PostDeploymentEvaluationStatus: common.StatePending,
 			PromotionStatus:                common.StatePending,
 			WorkloadOverallStatus:          common.StatePending,
+			RollbackStatus:                 common.StatePending,
 			Status:                         common.StatePending,
 		},
 	}
@@ -376,6 +389,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StatePending,
 					PromotionStatus:                common.StatePending,
 					WorkloadOverallStatus:          common.StatePending,
+					RollbackStatus:                 common.StatePending,
 					Status:                         common.StatePending,
 				},
 			},
@@ -391,6 +405,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StatePending,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StatePending,
+					RollbackStatus:                 common.StateDeprecated,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -406,6 +421,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StatePending,
+					RollbackStatus:                 common.StateDeprecated,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -421,6 +437,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StatePending,
+					RollbackStatus:                 common.StateDeprecated,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -436,6 +453,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StateDeprecated,
+					RollbackStatus:                 common.StateDeprecated,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -451,6 +469,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StateDeprecated,
+					RollbackStatus:                 common.StateDeprecated,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -466,6 +485,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StateDeprecated,
+					RollbackStatus:                 common.StateDeprecated,
 					Status:                         common.StateDeprecated,
 				},
 			},
@@ -481,12 +501,24 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StatePending,
 					PromotionStatus:                common.StatePending,
 					WorkloadOverallStatus:          common.StatePending,
+					RollbackStatus:                 common.StatePending,
 					Status:                         common.StateFailed,
 				},
 			},
 		},
 	}
 
+	rollbackApp := app
+	rollbackApp.Spec = KeptnAppVersionSpec{
+		PreviousVersion:     "1.0.0",
+		KeptnAppContextSpec: KeptnAppContextSpec{RollbackPolicy: &RollbackPolicy{Enabled: true}},
+	}
+	for _, phase := range []common.KeptnPhaseType{common.PhaseAppPostDeployment, common.PhaseAppPostEvaluation} {
+		want := rollbackApp
+		want.DeprecateRemainingPhases(phase)
+		require.Equal(t, common.StatePending, want.Status.RollbackStatus)
+	}
+
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
 			tt.app.DeprecateRemainingPhases(tt.phase)
@@ -532,6 +564,85 @@ func TestKeptnAppVersion_SetPhaseTraceID(t *testing.T) {
 	}, app)
 }
 
+func TestKeptnAppVersion_IsRollbackRequired(t *testing.T) {
+	tests := []struct {
+		name string
+		app  KeptnAppVersion
+		want bool
+	}{
+		{
+			name: "rollback policy not set",
+			app: KeptnAppVersion{
+				Spec:   KeptnAppVersionSpec{PreviousVersion: "1.0.0"},
+				Status: KeptnAppVersionStatus{PostDeploymentStatus: common.StateFailed},
+			},
+			want: false,
+		},
+		{
+			name: "no previous version",
+			app: KeptnAppVersion{
+				Spec: KeptnAppVersionSpec{
+					KeptnAppContextSpec: KeptnAppContextSpec{RollbackPolicy: &RollbackPolicy{Enabled: true}},
+				},
+				Status: KeptnAppVersionStatus{PostDeploymentStatus: common.StateFailed},
+			},
+			want: false,
+		},
+		{
+			name: "post-deployment tasks failed",
+			app: KeptnAppVersion{
+				Spec: KeptnAppVersionSpec{
+					KeptnAppContextSpec: KeptnAppContextSpec{RollbackPolicy: &RollbackPolicy{Enabled: true}},
+					PreviousVersion:     "1.0.0",
+				},
+				Status: KeptnAppVersionStatus{PostDeploymentStatus: common.StateFailed},
+			},
+			want: true,
+		},
+		{
+			name: "post-deployment evaluations failed",
+			app: KeptnAppVersion{
+				Spec: KeptnAppVersionSpec{
+					KeptnAppContextSpec: KeptnAppContextSpec{RollbackPolicy: &RollbackPolicy{Enabled: true}},
+					PreviousVersion:     "1.0.0",
+				},
+				Status: KeptnAppVersionStatus{PostDeploymentEvaluationStatus: common.StateFailed},
+			},
+			want: true,
+		},
+		{
+			name: "pre-deployment tasks failed",
+			app: KeptnAppVersion{
+				Spec: KeptnAppVersionSpec{
+					KeptnAppContextSpec: KeptnAppContextSpec{RollbackPolicy: &RollbackPolicy{Enabled: true}},
+					PreviousVersion:     "1.0.0",
+				},
+				Status: KeptnAppVersionStatus{PreDeploymentStatus: common.StateFailed},
+			},
+			want: false,
+		},
+		{
+			name: "rollback already completed",
+			app: KeptnAppVersion{
+				Spec: KeptnAppVersionSpec{
+					KeptnAppContextSpec: KeptnAppContextSpec{RollbackPolicy: &RollbackPolicy{Enabled: true}},
+					PreviousVersion:     "1.0.0",
+				},
+				Status: KeptnAppVersionStatus{
+					PostDeploymentStatus: common.StateFailed,
+					RollbackStatus:       common.StateFailed,
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.app.IsRollbackRequired())
+		})
+	}
+}
+
 func TestKeptnAppVersionList(t *testing.T) {
 	list := KeptnAppVersionList{
 		Items: []KeptnAppVersion{
@@ -40,6 +40,10 @@ type KeptnEvaluationSpec struct {
 	// AppVersion defines the version of the KeptnApp for which the KeptnEvaluation is done.
 	// +optional
 	AppVersion string `json:"appVersion,omitempty"`
+	// PreviousVersion defines the version of the KeptnApp or KeptnWorkload that has been deployed
+	// prior to the version for which the KeptnEvaluation is done.
+	// +optional
+	PreviousVersion string `json:"previousVersion,omitempty"`
 	// EvaluationDefinition refers to the name of the KeptnEvaluationDefinition
 	// which includes the objectives for the KeptnEvaluation.
 	// The KeptnEvaluationDefinition can be
@@ -48,6 +52,14 @@ type KeptnEvaluationSpec struct {
 	// Type indicates whether the KeptnEvaluation is part of the pre- or postDeployment phase.
 	// +optional
 	Type common.CheckType `json:"checkType,omitempty"`
+	// Context contains contextual information about the KeptnApp or KeptnWorkload for which the KeptnEvaluation is done.
+	// It is passed as arguments to the Analysis if the KeptnEvaluationDefinition refers to an AnalysisDefinition.
+	// +optional
+	Context TaskContext `json:"context,omitempty"`
+	// TimeframeStart is the start of the time frame of the Analysis that is created if the KeptnEvaluationDefinition
+	// refers to an AnalysisDefinition. It is set to the start of the deployment of the KeptnApp or KeptnWorkload.
+	// +optional
+	TimeframeStart metav1.Time `json:"timeframeStart,omitempty"`
 	// FailureConditions represent the failure conditions (number of retries and retry interval)
 	// for the evaluation to be considered as failed
 	FailureConditions `json:",inline"`
@@ -185,7 +197,7 @@ func (e *KeptnEvaluation) AddEvaluationStatus(objective Objective) {
 	if e.Status.EvaluationStatus == nil {
 		e.Status.EvaluationStatus = make(map[string]EvaluationStatusItem)
 	}
-	e.Status.EvaluationStatus[objective.KeptnMetricRef.Name] = evaluationStatusItem
+	e.Status.EvaluationStatus[objective.GetKey()] = evaluationStatusItem
 
 }
 
@@ -17,14 +17,33 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// defaultErrorBudgetTarget is the target for the remaining error budget of a ServiceLevelObjective
+// if an Objective does not specify one
+const defaultErrorBudgetTarget = ">0"
+
+// notAnomalousTarget is the human-readable representation of an Objective requiring a KeptnMetric not to be anomalous
+const notAnomalousTarget = "not anomalous"
+
 // KeptnEvaluationDefinitionSpec defines the desired state of KeptnEvaluationDefinition
+// +kubebuilder:validation:XValidation:rule="(has(self.objectives) && size(self.objectives) > 0) || has(self.analysisDefinition)",message="either objectives or analysisDefinition must be set"
 type KeptnEvaluationDefinitionSpec struct {
 	// Objectives is a list of objectives that have to be met for a KeptnEvaluation referencing this
 	// KeptnEvaluationDefinition to be successful.
-	Objectives []Objective `json:"objectives"`
+	// Either Objectives or AnalysisDefinition must be set.
+	// +optional
+	Objectives []Objective `json:"objectives,omitempty"`
+	// AnalysisDefinition refers to an AnalysisDefinition of the metrics-operator.
+	// If set, a KeptnEvaluation referencing this KeptnEvaluationDefinition creates an Analysis
+	// for the time frame since the start of the deployment and is only successful if the Analysis passes.
+	// +optional
+	AnalysisDefinition *AnalysisDefinitionReference `json:"analysisDefinition,omitempty"`
 	// FailureConditions represent the failure conditions (number of retries and retry interval)
 	// for the evaluation to be considered as failed
 	FailureConditions `json:",inline"`
@@ -49,10 +68,132 @@ type FailureConditions struct {
 
 type Objective struct {
 	// KeptnMetricRef references the KeptnMetric that should be evaluated.
-	KeptnMetricRef KeptnMetricReference `json:"keptnMetricRef"`
+	// Either KeptnMetricRef, TaskOutputRef or ServiceLevelObjectiveRef must be set.
+	// +optional
+	KeptnMetricRef KeptnMetricReference `json:"keptnMetricRef,omitempty"`
+	// TaskOutputRef references an output of a KeptnTask that has been executed before the KeptnEvaluation
+	// for the same KeptnApp or KeptnWorkload. If set, the output is evaluated instead of a KeptnMetric.
+	// +optional
+	TaskOutputRef *TaskOutputReference `json:"taskOutputRef,omitempty"`
+	// ServiceLevelObjectiveRef references a ServiceLevelObjective of the metrics-operator.
+	// If set, the remaining error budget of the ServiceLevelObjective in percent is evaluated instead of a KeptnMetric.
+	// If neither EvaluationTarget nor Target is set, the objective fails once the error budget is exhausted.
+	// +optional
+	ServiceLevelObjectiveRef *ServiceLevelObjectiveReference `json:"serviceLevelObjectiveRef,omitempty"`
 	// EvaluationTarget specifies the target value for the references KeptnMetric.
 	// Needs to start with either '<' or '>', followed by the target value (e.g. '<10').
-	EvaluationTarget string `json:"evaluationTarget"`
+	// Either EvaluationTarget or Target must be set.
+	// +optional
+	EvaluationTarget string `json:"evaluationTarget,omitempty"`
+	// Target specifies a compound target for the referenced KeptnMetric, consisting of
+	// operators that are combined with 'and' (AllOf) and 'or' (AnyOf).
+	// Target is only considered if EvaluationTarget is not set.
+	// +optional
+	Target *Target `json:"target,omitempty"`
+	// NotAnomalous indicates that the referenced KeptnMetric must not be flagged as anomalous
+	// by the anomaly detection of the metrics-operator. If EvaluationTarget or Target is set as well,
+	// the value of the KeptnMetric must meet the target in addition.
+	// +optional
+	NotAnomalous bool `json:"notAnomalous,omitempty"`
+}
+
+// Target describes a compound target for the value of a KeptnMetric.
+// The target is met if all operators of AllOf and at least one operator of AnyOf are fulfilled.
+// Empty lists are ignored, but at least one of them must contain an operator.
+type Target struct {
+	// AllOf is a list of operators that all have to be fulfilled.
+	// +optional
+	AllOf []TargetOperator `json:"allOf,omitempty"`
+	// AnyOf is a list of operators of which at least one has to be fulfilled.
+	// +optional
+	AnyOf []TargetOperator `json:"anyOf,omitempty"`
+}
+
+// TargetOperator is an Operator of a Target. Exactly one operator must be set.
+// If RelativeTo is set, the operator is not applied to the value itself, but to its deviation in percent
+// from the referenced value, e.g. 'lessThanOrEqual: 10' is fulfilled if the value is at most 10% higher
+// than the referenced value.
+type TargetOperator struct {
+	Operator `json:",inline"`
+	// RelativeTo references the value from which the deviation in percent is compared by the operator.
+	// +optional
+	RelativeTo *RelativeValue `json:"relativeTo,omitempty"`
+}
+
+// RelativeValue references a value to which the value of a KeptnMetric is compared.
+// Exactly one of KeptnMetricRef and PreviousVersion must be set.
+type RelativeValue struct {
+	// KeptnMetricRef references a KeptnMetric whose current value is used for comparison.
+	// +optional
+	KeptnMetricRef *KeptnMetricReference `json:"keptnMetricRef,omitempty"`
+	// PreviousVersion indicates that the value recorded for the same objective by the KeptnEvaluation
+	// of the previous version of the KeptnApp or KeptnWorkload is used for comparison.
+	// +optional
+	PreviousVersion bool `json:"previousVersion,omitempty"`
+}
+
+// Operator specifies the supported operators for value comparisons
+type Operator struct {
+	// LessThanOrEqual represents '<=' operator
+	// +optional
+	LessThanOrEqual *OperatorValue `json:"lessThanOrEqual,omitempty"`
+	// LessThan represents '<' operator
+	// +optional
+	LessThan *OperatorValue `json:"lessThan,omitempty"`
+	// GreaterThan represents '>' operator
+	// +optional
+	GreaterThan *OperatorValue `json:"greaterThan,omitempty"`
+	// GreaterThanOrEqual represents '>=' operator
+	// +optional
+	GreaterThanOrEqual *OperatorValue `json:"greaterThanOrEqual,omitempty"`
+	// EqualTo represents '==' operator
+	// +optional
+	EqualTo *OperatorValue `json:"equalTo,omitempty"`
+	// InRange represents operator checking the value is inclusively in the defined range, e.g. 2 <= x <= 5
+	// +optional
+	InRange *RangeValue `json:"inRange,omitempty"`
+	// NotInRange represents operator checking the value is exclusively out of the defined range, e.g. x < 2 AND x > 5
+	// +optional
+	NotInRange *RangeValue `json:"notInRange,omitempty"`
+}
+
+// OperatorValue represents the value to which the result is compared
+type OperatorValue struct {
+	// FixedValue defines the value for comparison
+	FixedValue resource.Quantity `json:"fixedValue"`
+}
+
+// RangeValue represents a range which the value should fit
+type RangeValue struct {
+	// LowBound defines the lower bound of the range
+	LowBound resource.Quantity `json:"lowBound"`
+	// HighBound defines the higher bound of the range
+	HighBound resource.Quantity `json:"highBound"`
+}
+
+type AnalysisDefinitionReference struct {
+	// Name is the name of the referenced AnalysisDefinition.
+	Name string `json:"name"`
+	// Namespace is the namespace where the referenced AnalysisDefinition is located.
+	// If not set, the namespace of the KeptnEvaluation is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type TaskOutputReference struct {
+	// TaskDefinition is the name of the KeptnTaskDefinition whose KeptnTask produced the output.
+	TaskDefinition string `json:"taskDefinition"`
+	// Key is the key of the output in the outputs of the KeptnTask.
+	Key string `json:"key"`
+}
+
+type ServiceLevelObjectiveReference struct {
+	// Name is the name of the referenced ServiceLevelObjective.
+	Name string `json:"name"`
+	// Namespace is the namespace where the referenced ServiceLevelObjective is located.
+	// If not set, the namespace of the KeptnEvaluation is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type KeptnMetricReference struct {
@@ -92,6 +233,102 @@ type KeptnEvaluationDefinitionList struct {
 	Items           []KeptnEvaluationDefinition `json:"items"`
 }
 
+// GetKey returns the key under which the result of the Objective is stored in the status of a KeptnEvaluation
+func (o Objective) GetKey() string {
+	if o.TaskOutputRef != nil {
+		return "taskOutput/" + o.TaskOutputRef.TaskDefinition + "/" + o.TaskOutputRef.Key
+	}
+	if o.ServiceLevelObjectiveRef != nil {
+		return "slo/" + o.ServiceLevelObjectiveRef.Name
+	}
+	return o.KeptnMetricRef.Name
+}
+
+// GetEvaluationTarget returns a human-readable representation of the target of the Objective
+func (o Objective) GetEvaluationTarget() string {
+	target := o.GetValueTarget()
+	if !o.NotAnomalous {
+		return target
+	}
+	if target == "" {
+		return notAnomalousTarget
+	}
+	return target + " and " + notAnomalousTarget
+}
+
+// GetValueTarget returns a human-readable representation of the target for the value of the Objective
+func (o Objective) GetValueTarget() string {
+	if o.EvaluationTarget == "" && o.Target == nil && o.ServiceLevelObjectiveRef != nil {
+		// a ServiceLevelObjective without target only requires some error budget to be left
+		return defaultErrorBudgetTarget
+	}
+	if o.EvaluationTarget != "" || o.Target == nil {
+		return o.EvaluationTarget
+	}
+	return o.Target.String()
+}
+
+func (t Target) String() string {
+	parts := make([]string, 0, len(t.AllOf)+1)
+	for _, op := range t.AllOf {
+		parts = append(parts, op.String())
+	}
+	if len(t.AnyOf) > 0 {
+		anyOf := make([]string, 0, len(t.AnyOf))
+		for _, op := range t.AnyOf {
+			anyOf = append(anyOf, op.String())
+		}
+		if len(parts) > 0 && len(anyOf) > 1 {
+			parts = append(parts, "("+strings.Join(anyOf, " or ")+")")
+		} else {
+			parts = append(parts, strings.Join(anyOf, " or "))
+		}
+	}
+	return strings.Join(parts, " and ")
+}
+
+func (o Operator) String() string {
+	switch {
+	case o.LessThanOrEqual != nil:
+		return "<=" + o.LessThanOrEqual.String()
+	case o.LessThan != nil:
+		return "<" + o.LessThan.String()
+	case o.GreaterThan != nil:
+		return ">" + o.GreaterThan.String()
+	case o.GreaterThanOrEqual != nil:
+		return ">=" + o.GreaterThanOrEqual.String()
+	case o.EqualTo != nil:
+		return "==" + o.EqualTo.String()
+	case o.InRange != nil:
+		return fmt.Sprintf("in [%s,%s]", o.InRange.LowBound.String(), o.InRange.HighBound.String())
+	case o.NotInRange != nil:
+		return fmt.Sprintf("not in [%s,%s]", o.NotInRange.LowBound.String(), o.NotInRange.HighBound.String())
+	default:
+		return ""
+	}
+}
+
+func (o TargetOperator) String() string {
+	if o.RelativeTo == nil {
+		return o.Operator.String()
+	}
+	return o.Operator.String() + "% from " + o.RelativeTo.String()
+}
+
+func (v RelativeValue) String() string {
+	if v.KeptnMetricRef != nil {
+		return "metric(" + v.KeptnMetricRef.Name + ")"
+	}
+	if v.PreviousVersion {
+		return "previousVersion"
+	}
+	return ""
+}
+
+func (v OperatorValue) String() string {
+	return v.FixedValue.String()
+}
+
 func init() {
 	SchemeBuilder.Register(&KeptnEvaluationDefinition{}, &KeptnEvaluationDefinitionList{})
 }
@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestObjective_GetEvaluationTarget(t *testing.T) {
+	fixedValue := resource.MustParse("300")
+
+	tests := []struct {
+		name      string
+		objective Objective
+		want      string
+	}{
+		{
+			name:      "evaluation target",
+			objective: Objective{EvaluationTarget: "<10"},
+			want:      "<10",
+		},
+		{
+			name: "evaluation target takes precedence",
+			objective: Objective{
+				EvaluationTarget: "<10",
+				Target:           &Target{AllOf: []TargetOperator{{Operator: Operator{LessThan: &OperatorValue{FixedValue: fixedValue}}}}},
+			},
+			want: "<10",
+		},
+		{
+			name: "all of",
+			objective: Objective{
+				Target: &Target{
+					AllOf: []TargetOperator{
+						{Operator: Operator{LessThan: &OperatorValue{FixedValue: fixedValue}}},
+						{
+							Operator:   Operator{LessThanOrEqual: &OperatorValue{FixedValue: resource.MustParse("10")}},
+							RelativeTo: &RelativeValue{PreviousVersion: true},
+						},
+					},
+				},
+			},
+			want: "<300 and <=10% from previousVersion",
+		},
+		{
+			name: "all of and any of",
+			objective: Objective{
+				Target: &Target{
+					AllOf: []TargetOperator{
+						{Operator: Operator{InRange: &RangeValue{LowBound: resource.MustParse("1"), HighBound: resource.MustParse("5")}}},
+					},
+					AnyOf: []TargetOperator{
+						{
+							Operator:   Operator{GreaterThan: &OperatorValue{FixedValue: resource.MustParse("-5")}},
+							RelativeTo: &RelativeValue{KeptnMetricRef: &KeptnMetricReference{Name: "baseline"}},
+						},
+						{Operator: Operator{NotInRange: &RangeValue{LowBound: resource.MustParse("2"), HighBound: resource.MustParse("3")}}},
+					},
+				},
+			},
+			want: "in [1,5] and (>-5% from metric(baseline) or not in [2,3])",
+		},
+		{
+			name: "any of",
+			objective: Objective{
+				Target: &Target{
+					AnyOf: []TargetOperator{
+						{Operator: Operator{EqualTo: &OperatorValue{FixedValue: fixedValue}}},
+						{Operator: Operator{GreaterThanOrEqual: &OperatorValue{FixedValue: fixedValue}}},
+					},
+				},
+			},
+			want: "==300 or >=300",
+		},
+		{
+			name:      "service level objective without target",
+			objective: Objective{ServiceLevelObjectiveRef: &ServiceLevelObjectiveReference{Name: "availability"}},
+			want:      ">0",
+		},
+		{
+			name: "service level objective with evaluation target",
+			objective: Objective{
+				ServiceLevelObjectiveRef: &ServiceLevelObjectiveReference{Name: "availability"},
+				EvaluationTarget:         ">25",
+			},
+			want: ">25",
+		},
+		{
+			name:      "not anomalous",
+			objective: Objective{KeptnMetricRef: KeptnMetricReference{Name: "latency"}, NotAnomalous: true},
+			want:      "not anomalous",
+		},
+		{
+			name: "not anomalous with evaluation target",
+			objective: Objective{
+				KeptnMetricRef:   KeptnMetricReference{Name: "latency"},
+				EvaluationTarget: "<10",
+				NotAnomalous:     true,
+			},
+			want: "<10 and not anomalous",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.objective.GetEvaluationTarget())
+		})
+	}
+}
@@ -60,6 +60,14 @@ type KeptnTaskSpec struct {
 	// +kubebuilder:validation:Type:=string
 	// +optional
 	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// Approval indicates that the KeptnTask is a manual approval gate that does not execute a Job.
+	// It is copied from the KeptnTaskDefinition when the KeptnTask is created.
+	// +optional
+	Approval *ApprovalSpec `json:"approval,omitempty"`
+	// HTTP contains the HTTP request that is sent for the KeptnTask instead of executing a Job.
+	// It is copied from the KeptnTaskDefinition when the KeptnTask is created.
+	// +optional
+	HTTP *HTTPSpec `json:"http,omitempty"`
 }
 
 type TaskContext struct {
@@ -84,6 +92,10 @@ type TaskContext struct {
 	// +optional
 	// Metadata contains additional key-value pairs for contextual information.
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// Outputs contains the outputs of the KeptnTasks of the KeptnApp or KeptnWorkload that have succeeded
+	// before the KeptnTask or KeptnEvaluation has been created, keyed by the name of their KeptnTaskDefinition.
+	// +optional
+	Outputs map[string]map[string]string `json:"outputs,omitempty"`
 }
 
 type TaskParameters struct {
@@ -125,6 +137,30 @@ type KeptnTaskStatus struct {
 	// Reason contains more information about the reason for the last transition of the Job executing the KeptnTask.
 	// +optional
 	Reason string `json:"reason,omitempty"`
+	// Outputs contains the key-value pairs the function of the KeptnTask has written as a JSON object to the
+	// file referenced by the KEPTN_TASK_OUTPUT environment variable.
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+	// Approval contains the decision taken for a KeptnTask that is a manual approval gate.
+	// +optional
+	Approval *ApprovalStatus `json:"approval,omitempty"`
+	// Attempts is the number of times the HTTP request of the KeptnTask has been sent.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+}
+
+type ApprovalStatus struct {
+	// Decision is either 'approved' or 'rejected'.
+	Decision string `json:"decision"`
+	// DecidedBy is the user who approved or rejected the KeptnTask,
+	// as stated in the 'keptn.sh/approved-by' annotation.
+	// The annotation is set by whoever takes the decision and is not authenticated,
+	// the audit log of the cluster records who actually set the 'keptn.sh/approval' annotation.
+	// +optional
+	DecidedBy string `json:"decidedBy,omitempty"`
+	// DecisionTime represents the time at which the decision has been detected.
+	// +optional
+	DecisionTime metav1.Time `json:"decisionTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -270,6 +306,16 @@ func (t KeptnTask) GetEventAnnotations() map[string]string {
 	}
 }
 
+// IsApprovalTask returns true if the KeptnTask is a manual approval gate
+func (t KeptnTask) IsApprovalTask() bool {
+	return t.Spec.Approval != nil
+}
+
+// IsHTTPTask returns true if the KeptnTask sends an HTTP request instead of executing a Job
+func (t KeptnTask) IsHTTPTask() bool {
+	return t.Spec.HTTP != nil
+}
+
 func (t KeptnTask) GetActiveDeadlineSeconds() *int64 {
 	deadline, _ := time.ParseDuration(t.Spec.Timeout.Duration.String())
 	seconds := int64(deadline.Seconds())
@@ -32,6 +32,13 @@ type KeptnTaskDefinitionSpec struct {
 	// Container contains the definition for the container that is to be used in Job.
 	// +optional
 	Container *ContainerSpec `json:"container,omitempty"`
+	// Approval defines a manual approval gate. KeptnTasks based on this KeptnTaskDefinition do not execute a Job,
+	// but wait until they are approved or rejected via the 'keptn.sh/approval' annotation.
+	// +optional
+	Approval *ApprovalSpec `json:"approval,omitempty"`
+	// HTTP defines an HTTP request that is sent directly by the lifecycle operator instead of executing a Job.
+	// +optional
+	HTTP *HTTPSpec `json:"http,omitempty"`
 	// Retries specifies how many times a job executing the KeptnTaskDefinition should be restarted in the case
 	// of an unsuccessful attempt.
 	// +kubebuilder:default:=10
@@ -120,6 +127,70 @@ type ContainerSpec struct {
 	*v1.Container `json:",inline"`
 }
 
+type ApprovalSpec struct {
+	// Message is a description of what has to be approved. It is included in the events
+	// that are sent when the approval is requested.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Timeout specifies the maximum time to wait for the approval. It replaces the Timeout of the KeptnTaskDefinition,
+	// which is meant for Jobs. If set to 0, the KeptnTask waits for the approval without a timeout.
+	// +kubebuilder:default:="24h"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+type HTTPSpec struct {
+	// Method is the HTTP method of the request.
+	// +kubebuilder:validation:Enum:=GET;HEAD;POST;PUT;PATCH;DELETE
+	// +kubebuilder:default:=GET
+	// +optional
+	Method string `json:"method,omitempty"`
+	// URL is the URL the request is sent to.
+	// It may contain Go template expressions that are rendered with the fields of the KEPTN_CONTEXT
+	// of the KeptnTask, e.g. '{{ .appName }}'. More info on go templating - https://pkg.go.dev/text/template
+	URL string `json:"url"`
+	// Headers contains the headers of the request.
+	// +optional
+	Headers []HTTPHeader `json:"headers,omitempty"`
+	// Body is the body of the request.
+	// It may contain Go template expressions that are rendered with the fields of the KEPTN_CONTEXT
+	// of the KeptnTask, e.g. '{"version": "{{ .appVersion }}"}'.
+	// +optional
+	Body string `json:"body,omitempty"`
+	// ExpectedStatusCodes contains the status codes of a successful response.
+	// If not set, every 2xx status code is considered to be successful.
+	// +optional
+	ExpectedStatusCodes []int `json:"expectedStatusCodes,omitempty"`
+	// Assertions contains conditions on the JSON body of the response
+	// that have to be fulfilled for the request to be successful.
+	// +optional
+	Assertions []HTTPAssertion `json:"assertions,omitempty"`
+}
+
+type HTTPHeader struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+	// Value is the value of the header.
+	// +optional
+	Value string `json:"value,omitempty"`
+	// SecretKeyRef references a key of a secret in the namespace of the KeptnTask containing
+	// the value of the header. If set, Value is ignored.
+	// +optional
+	SecretKeyRef *v1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+type HTTPAssertion struct {
+	// JSONPath is a JSONPath expression that is evaluated on the body of the response,
+	// e.g. '{.status}'. More info on JSONPath - https://kubernetes.io/docs/reference/kubectl/jsonpath/
+	JSONPath string `json:"jsonPath"`
+	// Value is the expected result of the JSONPath expression.
+	// If not set, the assertion is fulfilled if the JSONPath expression yields any result.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
 type AutomountServiceAccountTokenSpec struct {
 	Type *bool `json:"type"`
 }
@@ -185,3 +256,12 @@ func (d *KeptnTaskDefinition) GetAutomountServiceAccountToken() *bool {
 	}
 	return d.Spec.AutomountServiceAccountToken.Type
 }
+
+// GetTaskTimeout returns the timeout of the KeptnTasks created for the KeptnTaskDefinition,
+// which is the timeout of the approval for manual approval gates
+func (d *KeptnTaskDefinition) GetTaskTimeout() metav1.Duration {
+	if d.Spec.Approval != nil {
+		return d.Spec.Approval.Timeout
+	}
+	return d.Spec.Timeout
+}
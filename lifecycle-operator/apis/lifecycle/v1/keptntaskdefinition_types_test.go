@@ -2,8 +2,10 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestTaskDefinition_GetServiceAccountNoName(t *testing.T) {
@@ -38,3 +40,18 @@ func TestTaskDefinition_GetAutomountServiceAccountToken(t *testing.T) {
 	}
 	require.True(t, *d.GetAutomountServiceAccountToken())
 }
+
+func TestTaskDefinition_GetTaskTimeout(t *testing.T) {
+	d := &KeptnTaskDefinition{
+		Spec: KeptnTaskDefinitionSpec{
+			Timeout: metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+	require.Equal(t, 5*time.Minute, d.GetTaskTimeout().Duration)
+
+	d.Spec.Approval = &ApprovalSpec{Timeout: metav1.Duration{Duration: 24 * time.Hour}}
+	require.Equal(t, 24*time.Hour, d.GetTaskTimeout().Duration)
+
+	d.Spec.Approval = &ApprovalSpec{}
+	require.Zero(t, d.GetTaskTimeout().Duration)
+}
@@ -83,7 +83,7 @@ func (r *KeptnTaskDefinition) validateFields() *field.Error {
 		return field.Invalid(
 			field.NewPath("spec"),
 			r.Spec,
-			errors.New("Forbidden! Either Container, Python, or Deno field must be defined").Error(),
+			errors.New("Forbidden! Either Container, Python, Deno, Approval, or HTTP field must be defined").Error(),
 		)
 	}
 
@@ -91,7 +91,7 @@ func (r *KeptnTaskDefinition) validateFields() *field.Error {
 		return field.Invalid(
 			field.NewPath("spec"),
 			r.Spec,
-			errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+			errors.New("Forbidden! Only one of Container, Python, Deno, Approval, or HTTP field can be defined").Error(),
 		)
 	}
 
@@ -109,5 +109,11 @@ func countSpec(r *KeptnTaskDefinition) int {
 	if r.Spec.Deno != nil {
 		count++
 	}
+	if r.Spec.Approval != nil {
+		count++
+	}
+	if r.Spec.HTTP != nil {
+		count++
+	}
 	return count
 }
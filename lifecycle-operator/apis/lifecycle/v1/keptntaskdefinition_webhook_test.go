@@ -29,6 +29,11 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 		Deno:   &RuntimeSpec{},
 	}
 
+	specWithContainerAndApproval := KeptnTaskDefinitionSpec{
+		Container: &ContainerSpec{},
+		Approval:  &ApprovalSpec{},
+	}
+
 	emptySpec := KeptnTaskDefinitionSpec{}
 
 	tests := []struct {
@@ -47,7 +52,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					emptySpec,
-					errors.New("Forbidden! Either Container, Python, or Deno field must be defined").Error(),
+					errors.New("Forbidden! Either Container, Python, Deno, Approval, or HTTP field must be defined").Error(),
 				)},
 			),
 			verb: "create",
@@ -73,6 +78,34 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 			},
 			verb: "create",
 		},
+		{
+			name: "with-approval-only",
+			spec: KeptnTaskDefinitionSpec{
+				Approval: &ApprovalSpec{},
+			},
+			verb: "create",
+		},
+		{
+			name: "with-http-only",
+			spec: KeptnTaskDefinitionSpec{
+				HTTP: &HTTPSpec{URL: "http://example.com"},
+			},
+			verb: "create",
+		},
+		{
+			name: "with-both-container-and-approval",
+			spec: specWithContainerAndApproval,
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnTaskDefinition"},
+				"with-both-container-and-approval",
+				[]*field.Error{field.Invalid(
+					field.NewPath("spec"),
+					specWithContainerAndApproval,
+					errors.New("Forbidden! Only one of Container, Python, Deno, Approval, or HTTP field can be defined").Error(),
+				)},
+			),
+		},
 		{
 			name: "with-both-container-and-python",
 			spec: specWithContainerAndPython,
@@ -83,7 +116,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndPython,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, Approval, or HTTP field can be defined").Error(),
 				)},
 			),
 		},
@@ -96,7 +129,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndPython,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, Approval, or HTTP field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -115,7 +148,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndDeno,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, Approval, or HTTP field can be defined").Error(),
 				)},
 			),
 		},
@@ -128,7 +161,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndDeno,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, Approval, or HTTP field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -146,7 +179,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithPythonAndDeno,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, Approval, or HTTP field can be defined").Error(),
 				)},
 			),
 		},
@@ -159,7 +192,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithPythonAndDeno,
-					errors.New("Forbidden! Only one of Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Container, Python, Deno, Approval, or HTTP field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
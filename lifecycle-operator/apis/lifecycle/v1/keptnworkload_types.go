@@ -55,8 +55,15 @@ type KeptnWorkloadSpec struct {
 	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
 	// +optional
 	PostDeploymentEvaluations []string `json:"postDeploymentEvaluations,omitempty"`
+	// CanaryEvaluations is a list of all evaluations to be performed
+	// at each pause step of the canary strategy of the Argo Rollout owning the KeptnWorkload.
+	// If all evaluations of a step succeed, the Rollout is promoted to the next step, otherwise it is aborted.
+	// The items of this list refer to the names of KeptnEvaluationDefinitions
+	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
+	// +optional
+	CanaryEvaluations []string `json:"canaryEvaluations,omitempty"`
 	// ResourceReference is a reference to the Kubernetes resource
-	// (Deployment, DaemonSet, StatefulSet or ReplicaSet) the KeptnWorkload is representing.
+	// (Deployment, DaemonSet, StatefulSet, ReplicaSet or Job) the KeptnWorkload is representing.
 	ResourceReference ResourceReference `json:"resourceReference"`
 	// +optional
 	// Metadata contains additional key-value pairs for contextual information.
@@ -105,6 +112,10 @@ type ResourceReference struct {
 	UID  types.UID `json:"uid"`
 	Kind string    `json:"kind"`
 	Name string    `json:"name"`
+	// APIVersion is the API version of the parent resource.
+	// It is used to distinguish custom workload kinds with the same kind in different API groups.
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
 }
 
 func init() {
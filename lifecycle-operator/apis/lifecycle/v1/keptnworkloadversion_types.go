@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -44,6 +45,27 @@ type ItemStatus struct {
 	// EndTime represents the time at which the Item (Evaluation/Task) started.
 	// +optional
 	EndTime metav1.Time `json:"endTime,omitempty"`
+	// Outputs contains the outputs of the Task.
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// collectTaskOutputs returns the outputs of all succeeded tasks of the given statuses,
+// keyed by the name of their KeptnTaskDefinition
+func collectTaskOutputs(statuses ...[]ItemStatus) map[string]map[string]string {
+	var outputs map[string]map[string]string
+	for _, items := range statuses {
+		for _, item := range items {
+			if !item.Status.IsSucceeded() || len(item.Outputs) == 0 {
+				continue
+			}
+			if outputs == nil {
+				outputs = map[string]map[string]string{}
+			}
+			outputs[item.DefinitionName] = item.Outputs
+		}
+	}
+	return outputs
 }
 
 // KeptnWorkloadVersionSpec defines the desired state of KeptnWorkloadVersion
@@ -120,6 +142,50 @@ type KeptnWorkloadVersionStatus struct {
 	// DeploymentStartTime represents the start time of the deployment phase
 	// +optional
 	DeploymentStartTime metav1.Time `json:"deploymentStartTime,omitempty"`
+	// DeployedTemplate contains the pod template of the workload resource observed when the deployment phase
+	// of the KeptnWorkloadVersion succeeded. It is used to restore the workload if a later version of the KeptnApp is rolled back.
+	// +optional
+	DeployedTemplate *DeployedTemplate `json:"deployedTemplate,omitempty"`
+	// CanaryStatus indicates the progress of the canary steps of the Argo Rollout owning the KeptnWorkloadVersion.
+	// It is only set if canaryEvaluations are defined for the KeptnWorkloadVersion.
+	// +optional
+	CanaryStatus *CanaryStatus `json:"canaryStatus,omitempty"`
+}
+
+// CanaryStatus contains the progress of the canary steps of an Argo Rollout
+type CanaryStatus struct {
+	// CurrentStepIndex is the index of the canary step the Argo Rollout is currently executing.
+	// +optional
+	CurrentStepIndex *int32 `json:"currentStepIndex,omitempty"`
+	// StepCount is the number of steps defined in the canary strategy of the Argo Rollout.
+	// +optional
+	StepCount int32 `json:"stepCount,omitempty"`
+	// Steps contains the state of the canaryEvaluations of each pause step reached by the Argo Rollout.
+	// +optional
+	Steps []CanaryStepStatus `json:"steps,omitempty"`
+}
+
+// CanaryStepStatus contains the state of the canaryEvaluations of a single canary pause step
+type CanaryStepStatus struct {
+	// StepIndex is the index of the pause step in the canary strategy of the Argo Rollout.
+	StepIndex int32 `json:"stepIndex"`
+	// Status indicates whether the canaryEvaluations of the step succeeded.
+	// +kubebuilder:default:=Pending
+	// +optional
+	Status common.KeptnState `json:"status,omitempty"`
+	// EvaluationStatus indicates the current state of each canaryEvaluation of the step.
+	// +optional
+	EvaluationStatus []ItemStatus `json:"evaluationStatus,omitempty"`
+}
+
+// DeployedTemplate contains the pod template that has been deployed for a KeptnWorkloadVersion
+type DeployedTemplate struct {
+	// Owner is a reference to the Deployment, StatefulSet, DaemonSet or Argo Rollout owning the pod template.
+	Owner ResourceReference `json:"owner"`
+	// Template is the recorded pod template of the owner.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Template runtime.RawExtension `json:"template"`
 }
 
 // +kubebuilder:object:root=true
@@ -244,6 +310,19 @@ func (w KeptnWorkloadVersion) IsDeploymentFailed() bool {
 	return w.Status.DeploymentStatus.IsFailed()
 }
 
+// IsCanaryFailed returns whether the canaryEvaluations of any canary step of the KeptnWorkloadVersion have failed
+func (w KeptnWorkloadVersion) IsCanaryFailed() bool {
+	if w.Status.CanaryStatus == nil {
+		return false
+	}
+	for _, step := range w.Status.CanaryStatus.Steps {
+		if step.Status.IsFailed() {
+			return true
+		}
+	}
+	return false
+}
+
 func (w *KeptnWorkloadVersion) SetStartTime() {
 	if w.Status.StartTime.IsZero() {
 		w.Status.StartTime = metav1.NewTime(time.Now().UTC())
@@ -358,6 +437,11 @@ func (w KeptnWorkloadVersion) GetPromotionTasks() []string {
 	return []string{}
 }
 
+func (w KeptnWorkloadVersion) GetTaskDependencies() []TaskDependency {
+	// task dependencies are not included in Workloads, but we need the implementation of this method to fulfil the PhaseItem interface
+	return []TaskDependency{}
+}
+
 func (w KeptnWorkloadVersion) GetPromotionTaskStatus() []ItemStatus {
 	// promotion tasks are not included in Workloads, but we need the implementation of this method to fulfil the PhaseItem interface
 	return []ItemStatus{}
@@ -410,13 +494,16 @@ func (w KeptnWorkloadVersion) GenerateTask(taskDefinition KeptnTaskDefinition, c
 				WorkloadVersion: w.GetVersion(),
 				TaskType:        string(checkType),
 				ObjectType:      "Workload",
+				Outputs:         collectTaskOutputs(w.Status.PreDeploymentTaskStatus, w.Status.PostDeploymentTaskStatus),
 			},
 			TaskDefinition:   taskDefinition.Name,
 			Parameters:       TaskParameters{},
 			SecureParameters: SecureParameters{},
 			Type:             checkType,
 			Retries:          taskDefinition.Spec.Retries,
-			Timeout:          taskDefinition.Spec.Timeout,
+			Timeout:          taskDefinition.GetTaskTimeout(),
+			Approval:         taskDefinition.Spec.Approval,
+			HTTP:             taskDefinition.Spec.HTTP,
 		},
 	}
 }
@@ -431,8 +518,19 @@ func (w KeptnWorkloadVersion) GenerateEvaluation(evaluationDefinition KeptnEvalu
 			AppName:              w.GetAppName(),
 			WorkloadVersion:      w.GetVersion(),
 			Workload:             w.GetParentName(),
+			PreviousVersion:      w.Spec.PreviousVersion,
 			EvaluationDefinition: evaluationDefinition.Name,
 			Type:                 checkType,
+			Context: TaskContext{
+				WorkloadName:    w.GetParentName(),
+				AppName:         w.GetAppName(),
+				WorkloadVersion: w.GetVersion(),
+				TaskType:        string(checkType),
+				ObjectType:      "Workload",
+				Metadata:        w.Status.AppContextMetadata,
+				Outputs:         collectTaskOutputs(w.Status.PreDeploymentTaskStatus, w.Status.PostDeploymentTaskStatus),
+			},
+			TimeframeStart: w.getEvaluationTimeframeStart(checkType),
 			FailureConditions: FailureConditions{
 				RetryInterval: evaluationDefinition.Spec.RetryInterval,
 				Retries:       evaluationDefinition.Spec.Retries,
@@ -441,6 +539,15 @@ func (w KeptnWorkloadVersion) GenerateEvaluation(evaluationDefinition KeptnEvalu
 	}
 }
 
+// getEvaluationTimeframeStart returns the start of the deployment phase for post-deployment and canary evaluations,
+// and the start of the KeptnWorkloadVersion otherwise
+func (w KeptnWorkloadVersion) getEvaluationTimeframeStart(checkType common.CheckType) metav1.Time {
+	if (checkType == common.PostDeploymentEvaluationCheckType || checkType == common.CanaryEvaluationCheckType) && w.IsDeploymentStartTimeSet() {
+		return w.Status.DeploymentStartTime
+	}
+	return w.Status.StartTime
+}
+
 func (w KeptnWorkloadVersion) GetSpanAttributes() []attribute.KeyValue {
 	return []attribute.KeyValue{
 		common.AppName.String(w.Spec.AppName),
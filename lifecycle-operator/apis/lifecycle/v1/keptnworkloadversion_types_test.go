@@ -268,8 +268,17 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 		AppName:              workload.GetAppName(),
 		WorkloadVersion:      workload.GetVersion(),
 		Workload:             workload.GetParentName(),
+		PreviousVersion:      workload.GetPreviousVersion(),
 		EvaluationDefinition: "eval-def",
 		Type:                 common.PostDeploymentCheckType,
+		Context: TaskContext{
+			WorkloadName:    workload.GetParentName(),
+			AppName:         workload.GetAppName(),
+			WorkloadVersion: workload.GetVersion(),
+			TaskType:        string(common.PostDeploymentCheckType),
+			ObjectType:      "Workload",
+		},
+		TimeframeStart: workload.Status.StartTime,
 		FailureConditions: FailureConditions{
 			RetryInterval: v1.Duration{
 				Duration: 5 * time.Second,
@@ -305,6 +314,11 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 		[]ItemStatus{},
 		workload.GetPromotionTaskStatus(),
 	)
+
+	require.Equal(t,
+		[]TaskDependency{},
+		workload.GetTaskDependencies(),
+	)
 }
 
 //nolint:dupl
@@ -470,6 +484,22 @@ func TestKeptnWorkloadVersion_SetPhaseTraceID(t *testing.T) {
 	}, app)
 }
 
+func TestKeptnWorkloadVersion_IsCanaryFailed(t *testing.T) {
+	workloadVersion := KeptnWorkloadVersion{}
+	require.False(t, workloadVersion.IsCanaryFailed())
+
+	workloadVersion.Status.CanaryStatus = &CanaryStatus{
+		Steps: []CanaryStepStatus{
+			{StepIndex: 1, Status: common.StateSucceeded},
+			{StepIndex: 3, Status: common.StateProgressing},
+		},
+	}
+	require.False(t, workloadVersion.IsCanaryFailed())
+
+	workloadVersion.Status.CanaryStatus.Steps[1].Status = common.StateFailed
+	require.True(t, workloadVersion.IsCanaryFailed())
+}
+
 func TestKeptnWorkloadVersionList(t *testing.T) {
 	list := KeptnWorkloadVersionList{
 		Items: []KeptnWorkloadVersion{
@@ -491,3 +521,21 @@ func TestKeptnWorkloadVersionList(t *testing.T) {
 	require.Equal(t, "obj1", got[0].GetName())
 	require.Equal(t, "obj2", got[1].GetName())
 }
+
+func Test_collectTaskOutputs(t *testing.T) {
+	require.Nil(t, collectTaskOutputs([]ItemStatus{{DefinitionName: "task", Status: common.StateSucceeded}}))
+
+	outputs := collectTaskOutputs(
+		[]ItemStatus{
+			{DefinitionName: "pre-task", Status: common.StateSucceeded, Outputs: map[string]string{"image": "my-image"}},
+			{DefinitionName: "failed-task", Status: common.StateFailed, Outputs: map[string]string{"error": "timeout"}},
+		},
+		[]ItemStatus{
+			{DefinitionName: "post-task", Status: common.StateSucceeded, Outputs: map[string]string{"errorRate": "0.1"}},
+		},
+	)
+	require.Equal(t, map[string]map[string]string{
+		"pre-task":  {"image": "my-image"},
+		"post-task": {"errorRate": "0.1"},
+	}, outputs)
+}
@@ -27,6 +27,53 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnalysisDefinitionReference) DeepCopyInto(out *AnalysisDefinitionReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalysisDefinitionReference.
+func (in *AnalysisDefinitionReference) DeepCopy() *AnalysisDefinitionReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalysisDefinitionReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalSpec) DeepCopyInto(out *ApprovalSpec) {
+	*out = *in
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalSpec.
+func (in *ApprovalSpec) DeepCopy() *ApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalStatus) DeepCopyInto(out *ApprovalStatus) {
+	*out = *in
+	in.DecisionTime.DeepCopyInto(&out.DecisionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalStatus.
+func (in *ApprovalStatus) DeepCopy() *ApprovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutomountServiceAccountTokenSpec) DeepCopyInto(out *AutomountServiceAccountTokenSpec) {
 	*out = *in
@@ -47,6 +94,55 @@ func (in *AutomountServiceAccountTokenSpec) DeepCopy() *AutomountServiceAccountT
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	if in.CurrentStepIndex != nil {
+		in, out := &in.CurrentStepIndex, &out.CurrentStepIndex
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]CanaryStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStepStatus) DeepCopyInto(out *CanaryStepStatus) {
+	*out = *in
+	if in.EvaluationStatus != nil {
+		in, out := &in.EvaluationStatus, &out.EvaluationStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStepStatus.
+func (in *CanaryStepStatus) DeepCopy() *CanaryStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
 	*out = *in
@@ -82,6 +178,23 @@ func (in *ContainerSpec) DeepCopy() *ContainerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeployedTemplate) DeepCopyInto(out *DeployedTemplate) {
+	*out = *in
+	out.Owner = in.Owner
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployedTemplate.
+func (in *DeployedTemplate) DeepCopy() *DeployedTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DeployedTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentTaskSpec) DeepCopyInto(out *DeploymentTaskSpec) {
 	*out = *in
@@ -110,6 +223,13 @@ func (in *DeploymentTaskSpec) DeepCopyInto(out *DeploymentTaskSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TaskDependencies != nil {
+		in, out := &in.TaskDependencies, &out.TaskDependencies
+		*out = make([]TaskDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentTaskSpec.
@@ -183,6 +303,73 @@ func (in *FunctionStatus) DeepCopy() *FunctionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPAssertion) DeepCopyInto(out *HTTPAssertion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPAssertion.
+func (in *HTTPAssertion) DeepCopy() *HTTPAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSpec) DeepCopyInto(out *HTTPSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]HTTPAssertion, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSpec.
+func (in *HTTPSpec) DeepCopy() *HTTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HttpReference) DeepCopyInto(out *HttpReference) {
 	*out = *in
@@ -218,6 +405,13 @@ func (in *ItemStatus) DeepCopyInto(out *ItemStatus) {
 	*out = *in
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ItemStatus.
@@ -332,6 +526,11 @@ func (in *KeptnAppContextSpec) DeepCopyInto(out *KeptnAppContextSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(RollbackPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnAppContextSpec.
@@ -590,6 +789,11 @@ func (in *KeptnAppVersionStatus) DeepCopyInto(out *KeptnAppVersionStatus) {
 		*out = make([]WorkloadStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.RollbackWorkloadStatus != nil {
+		in, out := &in.RollbackWorkloadStatus, &out.RollbackWorkloadStatus
+		*out = make([]WorkloadStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.PreDeploymentTaskStatus != nil {
 		in, out := &in.PreDeploymentTaskStatus, &out.PreDeploymentTaskStatus
 		*out = make([]ItemStatus, len(*in))
@@ -662,7 +866,7 @@ func (in *KeptnEvaluation) DeepCopyInto(out *KeptnEvaluation) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -748,7 +952,14 @@ func (in *KeptnEvaluationDefinitionSpec) DeepCopyInto(out *KeptnEvaluationDefini
 	if in.Objectives != nil {
 		in, out := &in.Objectives, &out.Objectives
 		*out = make([]Objective, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnalysisDefinition != nil {
+		in, out := &in.AnalysisDefinition, &out.AnalysisDefinition
+		*out = new(AnalysisDefinitionReference)
+		**out = **in
 	}
 	out.FailureConditions = in.FailureConditions
 }
@@ -798,6 +1009,8 @@ func (in *KeptnEvaluationList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeptnEvaluationSpec) DeepCopyInto(out *KeptnEvaluationSpec) {
 	*out = *in
+	in.Context.DeepCopyInto(&out.Context)
+	in.TimeframeStart.DeepCopyInto(&out.TimeframeStart)
 	out.FailureConditions = in.FailureConditions
 }
 
@@ -954,6 +1167,16 @@ func (in *KeptnTaskDefinitionSpec) DeepCopyInto(out *KeptnTaskDefinitionSpec) {
 		*out = new(ContainerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalSpec)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Retries != nil {
 		in, out := &in.Retries, &out.Retries
 		*out = new(int32)
@@ -1052,6 +1275,16 @@ func (in *KeptnTaskSpec) DeepCopyInto(out *KeptnTaskSpec) {
 		**out = **in
 	}
 	out.Timeout = in.Timeout
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalSpec)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskSpec.
@@ -1069,6 +1302,18 @@ func (in *KeptnTaskStatus) DeepCopyInto(out *KeptnTaskStatus) {
 	*out = *in
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskStatus.
@@ -1178,6 +1423,11 @@ func (in *KeptnWorkloadSpec) DeepCopyInto(out *KeptnWorkloadSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CanaryEvaluations != nil {
+		in, out := &in.CanaryEvaluations, &out.CanaryEvaluations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.ResourceReference = in.ResourceReference
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
@@ -1354,6 +1604,16 @@ func (in *KeptnWorkloadVersionStatus) DeepCopyInto(out *KeptnWorkloadVersionStat
 		}
 	}
 	in.DeploymentStartTime.DeepCopyInto(&out.DeploymentStartTime)
+	if in.DeployedTemplate != nil {
+		in, out := &in.DeployedTemplate, &out.DeployedTemplate
+		*out = new(DeployedTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CanaryStatus != nil {
+		in, out := &in.CanaryStatus, &out.CanaryStatus
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnWorkloadVersionStatus.
@@ -1370,6 +1630,21 @@ func (in *KeptnWorkloadVersionStatus) DeepCopy() *KeptnWorkloadVersionStatus {
 func (in *Objective) DeepCopyInto(out *Objective) {
 	*out = *in
 	out.KeptnMetricRef = in.KeptnMetricRef
+	if in.TaskOutputRef != nil {
+		in, out := &in.TaskOutputRef, &out.TaskOutputRef
+		*out = new(TaskOutputReference)
+		**out = **in
+	}
+	if in.ServiceLevelObjectiveRef != nil {
+		in, out := &in.ServiceLevelObjectiveRef, &out.ServiceLevelObjectiveRef
+		*out = new(ServiceLevelObjectiveReference)
+		**out = **in
+	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(Target)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Objective.
@@ -1382,6 +1657,109 @@ func (in *Objective) DeepCopy() *Objective {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Operator) DeepCopyInto(out *Operator) {
+	*out = *in
+	if in.LessThanOrEqual != nil {
+		in, out := &in.LessThanOrEqual, &out.LessThanOrEqual
+		*out = new(OperatorValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LessThan != nil {
+		in, out := &in.LessThan, &out.LessThan
+		*out = new(OperatorValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GreaterThan != nil {
+		in, out := &in.GreaterThan, &out.GreaterThan
+		*out = new(OperatorValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GreaterThanOrEqual != nil {
+		in, out := &in.GreaterThanOrEqual, &out.GreaterThanOrEqual
+		*out = new(OperatorValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EqualTo != nil {
+		in, out := &in.EqualTo, &out.EqualTo
+		*out = new(OperatorValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InRange != nil {
+		in, out := &in.InRange, &out.InRange
+		*out = new(RangeValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NotInRange != nil {
+		in, out := &in.NotInRange, &out.NotInRange
+		*out = new(RangeValue)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Operator.
+func (in *Operator) DeepCopy() *Operator {
+	if in == nil {
+		return nil
+	}
+	out := new(Operator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorValue) DeepCopyInto(out *OperatorValue) {
+	*out = *in
+	out.FixedValue = in.FixedValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorValue.
+func (in *OperatorValue) DeepCopy() *OperatorValue {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RangeValue) DeepCopyInto(out *RangeValue) {
+	*out = *in
+	out.LowBound = in.LowBound.DeepCopy()
+	out.HighBound = in.HighBound.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RangeValue.
+func (in *RangeValue) DeepCopy() *RangeValue {
+	if in == nil {
+		return nil
+	}
+	out := new(RangeValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelativeValue) DeepCopyInto(out *RelativeValue) {
+	*out = *in
+	if in.KeptnMetricRef != nil {
+		in, out := &in.KeptnMetricRef, &out.KeptnMetricRef
+		*out = new(KeptnMetricReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelativeValue.
+func (in *RelativeValue) DeepCopy() *RelativeValue {
+	if in == nil {
+		return nil
+	}
+	out := new(RelativeValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
@@ -1397,6 +1775,21 @@ func (in *ResourceReference) DeepCopy() *ResourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackPolicy) DeepCopyInto(out *RollbackPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackPolicy.
+func (in *RollbackPolicy) DeepCopy() *RollbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuntimeSpec) DeepCopyInto(out *RuntimeSpec) {
 	*out = *in
@@ -1448,6 +1841,71 @@ func (in *ServiceAccountSpec) DeepCopy() *ServiceAccountSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceLevelObjectiveReference) DeepCopyInto(out *ServiceLevelObjectiveReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjectiveReference.
+func (in *ServiceLevelObjectiveReference) DeepCopy() *ServiceLevelObjectiveReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceLevelObjectiveReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Target) DeepCopyInto(out *Target) {
+	*out = *in
+	if in.AllOf != nil {
+		in, out := &in.AllOf, &out.AllOf
+		*out = make([]TargetOperator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]TargetOperator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Target.
+func (in *Target) DeepCopy() *Target {
+	if in == nil {
+		return nil
+	}
+	out := new(Target)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetOperator) DeepCopyInto(out *TargetOperator) {
+	*out = *in
+	in.Operator.DeepCopyInto(&out.Operator)
+	if in.RelativeTo != nil {
+		in, out := &in.RelativeTo, &out.RelativeTo
+		*out = new(RelativeValue)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetOperator.
+func (in *TargetOperator) DeepCopy() *TargetOperator {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetOperator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskContext) DeepCopyInto(out *TaskContext) {
 	*out = *in
@@ -1458,6 +1916,24 @@ func (in *TaskContext) DeepCopyInto(out *TaskContext) {
 			(*out)[key] = val
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskContext.
@@ -1470,6 +1946,41 @@ func (in *TaskContext) DeepCopy() *TaskContext {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskDependency) DeepCopyInto(out *TaskDependency) {
+	*out = *in
+	if in.RunAfter != nil {
+		in, out := &in.RunAfter, &out.RunAfter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDependency.
+func (in *TaskDependency) DeepCopy() *TaskDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskOutputReference) DeepCopyInto(out *TaskOutputReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskOutputReference.
+func (in *TaskOutputReference) DeepCopy() *TaskOutputReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskOutputReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskParameters) DeepCopyInto(out *TaskParameters) {
 	*out = *in
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // KeptnConfigSpec defines the desired state of KeptnConfig
@@ -55,6 +56,39 @@ type KeptnConfigSpec struct {
 	// +kubebuilder:default:=false
 	// +optional
 	RestApiEnabled bool `json:"restApiEnabled,omitempty"`
+
+	// CustomWorkloadKinds contains additional kinds of resources that own pods and should be treated as workloads
+	// by Keptn, in addition to the natively supported Deployments, StatefulSets, DaemonSets, ReplicaSets, Argo Rollouts,
+	// Jobs and CronJobs.
+	// +optional
+	CustomWorkloadKinds []CustomWorkloadKind `json:"customWorkloadKinds,omitempty"`
+}
+
+// CustomWorkloadKind describes a kind of resource that owns pods, such as an OpenKruise CloneSet or
+// the resource of an in-house operator.
+// Pods owned by this kind of resource inherit the Keptn annotations and labels of their owner,
+// and the owner is considered to be deployed when ReadyJSONPath yields ReadyValue.
+type CustomWorkloadKind struct {
+	// Group is the API group of the resource, e.g. 'apps.kruise.io'.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Version is the API version of the resource, e.g. 'v1alpha1'.
+	Version string `json:"version"`
+	// Kind is the kind of the resource, e.g. 'CloneSet'.
+	Kind string `json:"kind"`
+	// ReadyJSONPath is a JSONPath expression that is evaluated on the resource to determine whether it is deployed,
+	// e.g. '{.status.conditions[?(@.type=="Ready")].status}'.
+	// More info on JSONPath - https://kubernetes.io/docs/reference/kubectl/jsonpath/
+	ReadyJSONPath string `json:"readyJSONPath"`
+	// ReadyValue is the value ReadyJSONPath must yield for the resource to be considered deployed.
+	// +kubebuilder:default:="True"
+	// +optional
+	ReadyValue string `json:"readyValue,omitempty"`
+}
+
+// GroupVersionKind returns the GroupVersionKind of the CustomWorkloadKind
+func (c CustomWorkloadKind) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: c.Group, Version: c.Version, Kind: c.Kind}
 }
 
 // +kubebuilder:object:root=true
@@ -24,12 +24,27 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomWorkloadKind) DeepCopyInto(out *CustomWorkloadKind) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomWorkloadKind.
+func (in *CustomWorkloadKind) DeepCopy() *CustomWorkloadKind {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomWorkloadKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeptnConfig) DeepCopyInto(out *KeptnConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnConfig.
@@ -86,6 +101,11 @@ func (in *KeptnConfigList) DeepCopyObject() runtime.Object {
 func (in *KeptnConfigSpec) DeepCopyInto(out *KeptnConfigSpec) {
 	*out = *in
 	out.ObservabilityTimeout = in.ObservabilityTimeout
+	if in.CustomWorkloadKinds != nil {
+		in, out := &in.CustomWorkloadKinds, &out.CustomWorkloadKinds
+		*out = make([]CustomWorkloadKind, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnConfigSpec.
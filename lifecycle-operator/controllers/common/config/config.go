@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,6 +24,8 @@ type IConfig interface {
 	GetObservabilityTimeout() metav1.Duration
 	SetRestApiEnabled(value bool)
 	GetRestApiEnabled() bool
+	SetCustomWorkloadKinds(kinds []optionsv1alpha1.CustomWorkloadKind)
+	GetCustomWorkloadKinds() []optionsv1alpha1.CustomWorkloadKind
 }
 
 type ControllerConfig struct {
@@ -32,6 +35,10 @@ type ControllerConfig struct {
 	blockDeployment                bool
 	observabilityTimeout           metav1.Duration
 	restApiEnabled                 bool
+	customWorkloadKinds            []optionsv1alpha1.CustomWorkloadKind
+	// mtx guards the custom workload kinds, which are updated by the KeptnConfig controller
+	// while the pod mutating webhook and the workload version controller read them
+	mtx sync.RWMutex
 }
 
 var instance *ControllerConfig
@@ -97,3 +104,36 @@ func (o *ControllerConfig) SetRestApiEnabled(value bool) {
 func (o *ControllerConfig) GetRestApiEnabled() bool {
 	return o.restApiEnabled
 }
+
+func (o *ControllerConfig) SetCustomWorkloadKinds(kinds []optionsv1alpha1.CustomWorkloadKind) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	o.customWorkloadKinds = copyCustomWorkloadKinds(kinds)
+}
+
+// GetCustomWorkloadKinds returns a copy of the custom workload kinds
+func (o *ControllerConfig) GetCustomWorkloadKinds() []optionsv1alpha1.CustomWorkloadKind {
+	o.mtx.RLock()
+	defer o.mtx.RUnlock()
+	return copyCustomWorkloadKinds(o.customWorkloadKinds)
+}
+
+func copyCustomWorkloadKinds(kinds []optionsv1alpha1.CustomWorkloadKind) []optionsv1alpha1.CustomWorkloadKind {
+	if kinds == nil {
+		return nil
+	}
+	return append(make([]optionsv1alpha1.CustomWorkloadKind, 0, len(kinds)), kinds...)
+}
+
+// GetCustomWorkloadKind returns the CustomWorkloadKind with the given kind, and optionally API version
+func GetCustomWorkloadKind(cfg IConfig, apiVersion string, kind string) (optionsv1alpha1.CustomWorkloadKind, bool) {
+	for _, customKind := range cfg.GetCustomWorkloadKinds() {
+		if customKind.Kind != kind {
+			continue
+		}
+		if apiVersion == "" || customKind.GroupVersionKind().GroupVersion().String() == apiVersion {
+			return customKind, true
+		}
+	}
+	return optionsv1alpha1.CustomWorkloadKind{}, false
+}
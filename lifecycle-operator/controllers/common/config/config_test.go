@@ -1,9 +1,11 @@
 package config
 
 import (
+	"sync"
 	"testing"
 	"time"
 
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -81,3 +83,65 @@ func TestConfig_SetAndGetObservabilityTimeout(t *testing.T) {
 		Duration: time.Duration(10 * time.Minute),
 	}, i.GetObservabilityTimeout())
 }
+
+func TestConfig_GetCustomWorkloadKind(t *testing.T) {
+	i := &ControllerConfig{}
+
+	i.SetCustomWorkloadKinds([]optionsv1alpha1.CustomWorkloadKind{
+		{Group: "apps.kruise.io", Version: "v1alpha1", Kind: "CloneSet"},
+		{Version: "v1", Kind: "MyWorkload"},
+	})
+
+	kind, ok := GetCustomWorkloadKind(i, "apps.kruise.io/v1alpha1", "CloneSet")
+	require.True(t, ok)
+	require.Equal(t, "apps.kruise.io", kind.Group)
+
+	_, ok = GetCustomWorkloadKind(i, "", "CloneSet")
+	require.True(t, ok)
+
+	_, ok = GetCustomWorkloadKind(i, "v1", "MyWorkload")
+	require.True(t, ok)
+
+	_, ok = GetCustomWorkloadKind(i, "apps.kruise.io/v1beta1", "CloneSet")
+	require.False(t, ok)
+
+	_, ok = GetCustomWorkloadKind(i, "apps/v1", "Deployment")
+	require.False(t, ok)
+}
+
+func TestConfig_CustomWorkloadKinds_ReturnsCopy(t *testing.T) {
+	i := &ControllerConfig{}
+
+	kinds := []optionsv1alpha1.CustomWorkloadKind{{Group: "apps.kruise.io", Version: "v1alpha1", Kind: "CloneSet"}}
+	i.SetCustomWorkloadKinds(kinds)
+	kinds[0].Kind = "StatefulSet"
+
+	got := i.GetCustomWorkloadKinds()
+	require.Equal(t, "CloneSet", got[0].Kind)
+	got[0].Kind = "StatefulSet"
+	require.Equal(t, "CloneSet", i.GetCustomWorkloadKinds()[0].Kind)
+
+	i.SetCustomWorkloadKinds(nil)
+	require.Nil(t, i.GetCustomWorkloadKinds())
+}
+
+func TestConfig_CustomWorkloadKinds_Concurrent(t *testing.T) {
+	i := &ControllerConfig{}
+
+	var wg sync.WaitGroup
+	for n := 0; n < 10; n++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			i.SetCustomWorkloadKinds([]optionsv1alpha1.CustomWorkloadKind{{Version: "v1", Kind: "MyWorkload"}})
+		}()
+		go func() {
+			defer wg.Done()
+			GetCustomWorkloadKind(i, "", "MyWorkload")
+		}()
+	}
+	wg.Wait()
+
+	_, ok := GetCustomWorkloadKind(i, "v1", "MyWorkload")
+	require.True(t, ok)
+}
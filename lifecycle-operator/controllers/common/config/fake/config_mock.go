@@ -4,6 +4,7 @@
 package fake
 
 import (
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sync"
 	"time"
@@ -79,6 +80,12 @@ type MockConfig struct {
 	// GetRestApiFunc mocks the GetRestApiEnabled method.
 	GetRestApiEnabledFunc func() bool
 
+	// SetCustomWorkloadKindsFunc mocks the SetCustomWorkloadKinds method.
+	SetCustomWorkloadKindsFunc func(kinds []optionsv1alpha1.CustomWorkloadKind)
+
+	// GetCustomWorkloadKindsFunc mocks the GetCustomWorkloadKinds method.
+	GetCustomWorkloadKindsFunc func() []optionsv1alpha1.CustomWorkloadKind
+
 	// SetCreationRequestTimeoutFunc mocks the SetCreationRequestTimeout method.
 	SetCreationRequestTimeoutFunc func(value time.Duration)
 
@@ -153,6 +160,16 @@ func (mock *MockConfig) SetRestApiEnabled(value bool) {
 	mock.SetRestApiEnabledFunc(value)
 }
 
+// SetCustomWorkloadKinds calls SetCustomWorkloadKindsFunc.
+func (mock *MockConfig) SetCustomWorkloadKinds(kinds []optionsv1alpha1.CustomWorkloadKind) {
+	mock.SetCustomWorkloadKindsFunc(kinds)
+}
+
+// GetCustomWorkloadKinds calls GetCustomWorkloadKindsFunc.
+func (mock *MockConfig) GetCustomWorkloadKinds() []optionsv1alpha1.CustomWorkloadKind {
+	return mock.GetCustomWorkloadKindsFunc()
+}
+
 // GetBlockDeployment calls GetBlockDeploymentFunc.
 func (mock *MockConfig) GetBlockDeployment() bool {
 	if mock.GetBlockDeploymentFunc == nil {
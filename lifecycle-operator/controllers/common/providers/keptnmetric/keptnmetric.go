@@ -7,6 +7,7 @@ import (
 	"github.com/go-logr/logr"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,6 +25,13 @@ func (p *KeptnMetricProvider) FetchData(ctx context.Context, objective apilifecy
 		return "", nil, err
 	}
 
+	if results, ok, _ := unstructured.NestedSlice(metric.UnstructuredContent(), "status", "results"); ok && len(results) > 0 {
+		// the query of the KeptnMetric returned more than one series, which cannot be compared to a single target
+		err := fmt.Errorf("%w: %s", controllererrors.ErrMultipleSeries, objective.KeptnMetricRef.Name)
+		p.Log.Error(err, "KeptnMetric has no single value")
+		return "", nil, err
+	}
+
 	value, ok, err := unstructured.NestedString(metric.UnstructuredContent(), "status", "value")
 	if !ok || err != nil || value == "" {
 		err := fmt.Errorf("empty value for: %s", objective.KeptnMetricRef.Name)
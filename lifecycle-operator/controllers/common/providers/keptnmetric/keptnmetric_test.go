@@ -74,6 +74,25 @@ func Test_keptnmetric(t *testing.T) {
 			outraw:    []byte("1"),
 			wantError: false,
 		},
+		{
+			name: "KeptnMetric with multiple series",
+			metric: &metricsapi.KeptnMetric{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "metric",
+					Namespace: "default",
+				},
+				Status: metricsapi.KeptnMetricStatus{
+					RawValue: []byte(`[{"labels":{"pod":"pod-1"},"value":"1"},{"labels":{"pod":"pod-2"},"value":"2"}]`),
+					Results: []metricsapi.MetricResult{
+						{Labels: map[string]string{"pod": "pod-1"}, Value: "1"},
+						{Labels: map[string]string{"pod": "pod-2"}, Value: "2"},
+					},
+				},
+			},
+			out:       "",
+			outraw:    []byte(nil),
+			wantError: true,
+		},
 	}
 	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
 
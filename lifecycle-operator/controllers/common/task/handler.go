@@ -3,6 +3,8 @@ package task
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -39,6 +41,9 @@ type CreateTaskAttributes struct {
 	SpanName   string
 	Definition apilifecycle.KeptnTaskDefinition
 	CheckType  apicommon.CheckType
+	// Outputs contains the outputs of tasks that have succeeded in the current reconciliation
+	// and are therefore not yet part of the status of the reconciled object
+	Outputs map[string]map[string]string
 }
 
 //nolint:gocognit,gocyclo
@@ -52,11 +57,17 @@ func (r Handler) ReconcileTasks(ctx context.Context, phaseCtx context.Context, r
 
 	tasks, statuses := r.setupTasks(taskCreateAttributes, piWrapper)
 
+	runAfter, err := getRunAfter(piWrapper.GetTaskDependencies(), tasks)
+	if err != nil {
+		return nil, apicommon.StatusSummary{}, err
+	}
+
 	var summary apicommon.StatusSummary
 	summary.Total = len(tasks)
 	// Check current state of the PrePostDeploymentTasks
-	var newStatus []apilifecycle.ItemStatus
-	for _, taskDefinitionName := range tasks {
+	// tasks are visited after the tasks they depend on, so that their updated state is used within the same pass
+	updatedStatus := make(map[string]apilifecycle.ItemStatus, len(tasks))
+	for _, taskDefinitionName := range getExecutionOrder(tasks, runAfter) {
 		oldstatus := common.GetOldStatus(taskDefinitionName, statuses)
 
 		taskStatus := common.GetItemStatus(taskDefinitionName, statuses)
@@ -67,9 +78,9 @@ func (r Handler) ReconcileTasks(ctx context.Context, phaseCtx context.Context, r
 			r.EventSender.Emit(phase, "Normal", reconcileObject, apicommon.PhaseStateStatusChanged, fmt.Sprintf("task status changed from %s to %s", oldstatus, taskStatus.Status), piWrapper.GetVersion())
 		}
 
-		// Check if task has already succeeded or failed
-		if taskStatus.Status == apicommon.StateSucceeded || taskStatus.Status == apicommon.StateFailed {
-			newStatus = append(newStatus, taskStatus)
+		// Check if task has already succeeded, failed or has been skipped
+		if taskStatus.Status == apicommon.StateSucceeded || taskStatus.Status == apicommon.StateFailed || taskStatus.Status == apicommon.StateSkipped {
+			updatedStatus[taskDefinitionName] = taskStatus
 			continue
 		}
 
@@ -84,8 +95,19 @@ func (r Handler) ReconcileTasks(ctx context.Context, phaseCtx context.Context, r
 			taskExists = true
 		}
 
-		// Create new Task if it does not exist
+		// Create new Task if it does not exist and all tasks it depends on have succeeded
 		if !taskExists {
+			dependencyState := getDependencyState(runAfter[taskDefinitionName], updatedStatus, statuses)
+			if dependencyState.IsFailed() {
+				taskStatus.Status = apicommon.StateSkipped
+				taskStatus.SetEndTime()
+				r.EventSender.Emit(phase, "Warning", reconcileObject, apicommon.PhaseStateSkipped, fmt.Sprintf("task '%s' has been skipped, as a task it depends on did not succeed", taskDefinitionName), piWrapper.GetVersion())
+			}
+			if !dependencyState.IsSucceeded() {
+				updatedStatus[taskDefinitionName] = taskStatus
+				continue
+			}
+			taskCreateAttributes.Outputs = getTaskOutputs(updatedStatus)
 			err := r.handleTaskNotExists(
 				ctx,
 				phaseCtx,
@@ -117,11 +139,15 @@ func (r Handler) ReconcileTasks(ctx context.Context, phaseCtx context.Context, r
 			r.handleTaskExists(phaseCtx, task, &taskStatus)
 		}
 		// Update state of the Check
-		newStatus = append(newStatus, taskStatus)
+		updatedStatus[taskDefinitionName] = taskStatus
 	}
 
-	for _, ns := range newStatus {
-		summary = apicommon.UpdateStatusSummary(ns.Status, summary)
+	var newStatus []apilifecycle.ItemStatus
+	for _, taskDefinitionName := range tasks {
+		if taskStatus, ok := updatedStatus[taskDefinitionName]; ok {
+			newStatus = append(newStatus, taskStatus)
+			summary = apicommon.UpdateStatusSummary(taskStatus.Status, summary)
+		}
 	}
 
 	return newStatus, summary, nil
@@ -138,6 +164,7 @@ func (r Handler) CreateKeptnTask(ctx, phaseCtx context.Context, namespace string
 
 	newTask := piWrapper.GenerateTask(taskCreateAttributes.Definition, taskCreateAttributes.CheckType)
 	injectKeptnContext(phaseCtx, &newTask)
+	injectTaskOutputs(taskCreateAttributes.Outputs, &newTask)
 	err = controllerutil.SetControllerReference(reconcileObject, &newTask, r.Scheme)
 	if err != nil {
 		r.Log.Error(err, "could not set controller reference:")
@@ -164,6 +191,15 @@ func injectKeptnContext(phaseCtx context.Context, newTask *apilifecycle.KeptnTas
 	}
 }
 
+func injectTaskOutputs(outputs map[string]map[string]string, newTask *apilifecycle.KeptnTask) {
+	for taskDefinition, taskOutputs := range outputs {
+		if newTask.Spec.Context.Outputs == nil {
+			newTask.Spec.Context.Outputs = map[string]map[string]string{}
+		}
+		newTask.Spec.Context.Outputs[taskDefinition] = taskOutputs
+	}
+}
+
 func (r Handler) setTaskFailureEvents(task *apilifecycle.KeptnTask, spanTrace trace.Span) {
 	spanTrace.AddEvent(fmt.Sprintf("task '%s' failed with reason: '%s'", task.Name, task.Status.Message), trace.WithTimestamp(time.Now().UTC()))
 }
@@ -186,6 +222,102 @@ func (r Handler) setupTasks(taskCreateAttributes CreateTaskAttributes, piWrapper
 	return tasks, statuses
 }
 
+// getRunAfter returns the tasks each of the given tasks depends on.
+// Dependencies on tasks that are not part of the given tasks are ignored.
+func getRunAfter(dependencies []apilifecycle.TaskDependency, tasks []string) (map[string][]string, error) {
+	runAfter := map[string][]string{}
+	for _, dependency := range dependencies {
+		if !slices.Contains(tasks, dependency.TaskDefinition) {
+			continue
+		}
+		for _, task := range dependency.RunAfter {
+			if slices.Contains(tasks, task) && !slices.Contains(runAfter[dependency.TaskDefinition], task) {
+				runAfter[dependency.TaskDefinition] = append(runAfter[dependency.TaskDefinition], task)
+			}
+		}
+	}
+
+	// tasks that are part of a cycle would never be started, so the dependencies are rejected
+	visited := map[string]bool{}
+	var visit func(task string, path []string) error
+	visit = func(task string, path []string) error {
+		if slices.Contains(path, task) {
+			return fmt.Errorf("%w: %s", controllererrors.ErrTaskDependencyCycle, strings.Join(append(path, task), " -> "))
+		}
+		if visited[task] {
+			return nil
+		}
+		visited[task] = true
+		for _, dependency := range runAfter[task] {
+			if err := visit(dependency, append(path, task)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, task := range tasks {
+		if err := visit(task, nil); err != nil {
+			return nil, err
+		}
+	}
+	return runAfter, nil
+}
+
+// getExecutionOrder returns the given tasks ordered so that each task comes after the tasks it depends on.
+// Tasks without dependencies between each other keep their order.
+func getExecutionOrder(tasks []string, runAfter map[string][]string) []string {
+	order := make([]string, 0, len(tasks))
+	var visit func(task string)
+	visit = func(task string) {
+		if slices.Contains(order, task) {
+			return
+		}
+		for _, dependency := range runAfter[task] {
+			visit(dependency)
+		}
+		order = append(order, task)
+	}
+	for _, task := range tasks {
+		visit(task)
+	}
+	return order
+}
+
+// getTaskOutputs returns the outputs of the succeeded tasks of the given statuses, keyed by the name of their KeptnTaskDefinition
+func getTaskOutputs(statuses map[string]apilifecycle.ItemStatus) map[string]map[string]string {
+	var outputs map[string]map[string]string
+	for taskDefinition, status := range statuses {
+		if !status.Status.IsSucceeded() || len(status.Outputs) == 0 {
+			continue
+		}
+		if outputs == nil {
+			outputs = map[string]map[string]string{}
+		}
+		outputs[taskDefinition] = status.Outputs
+	}
+	return outputs
+}
+
+// getDependencyState returns Succeeded if all the given tasks have succeeded, Failed if any of them
+// has failed or has been skipped, and Pending otherwise.
+// The status of a task in the current reconciliation takes precedence over its previous status.
+func getDependencyState(dependencies []string, newStatus map[string]apilifecycle.ItemStatus, oldStatus []apilifecycle.ItemStatus) apicommon.KeptnState {
+	state := apicommon.StateSucceeded
+	for _, dependency := range dependencies {
+		status, ok := newStatus[dependency]
+		if !ok {
+			status = common.GetItemStatus(dependency, oldStatus)
+		}
+		switch {
+		case status.Status.IsFailed() || status.Status.IsSkipped():
+			return apicommon.StateFailed
+		case !status.Status.IsSucceeded():
+			state = apicommon.StatePending
+		}
+	}
+	return state
+}
+
 func (r Handler) handleTaskNotExists(ctx context.Context, phaseCtx context.Context, taskCreateAttributes CreateTaskAttributes, taskName string, piWrapper *interfaces.PhaseItemWrapper, reconcileObject client.Object, task *apilifecycle.KeptnTask, taskStatus *apilifecycle.ItemStatus) error {
 	definition, err := common.GetTaskDefinition(r.Client, r.Log, ctx, taskName, piWrapper.GetNamespace())
 	if err != nil {
@@ -215,6 +347,7 @@ func (r Handler) handleTaskExists(phaseCtx context.Context, task *apilifecycle.K
 	taskStatus.Status = task.Status.Status
 	if taskStatus.Status.IsCompleted() {
 		if taskStatus.Status.IsSucceeded() {
+			taskStatus.Outputs = task.Status.Outputs
 			spanTaskTrace.AddEvent(task.Name + " has finished")
 			spanTaskTrace.SetStatus(codes.Ok, "Finished")
 		} else {
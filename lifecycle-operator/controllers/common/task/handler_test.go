@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -542,6 +543,352 @@ func TestTaskHandler_createTask(t *testing.T) {
 	}
 }
 
+func TestTaskHandler_taskDependencies(t *testing.T) {
+	dependencies := []apilifecycle.TaskDependency{
+		{TaskDefinition: "warmup", RunAfter: []string{"migrate"}},
+		{TaskDefinition: "notify", RunAfter: []string{"warmup", "other-phase-task"}},
+	}
+
+	tests := []struct {
+		name        string
+		oldStatus   []apilifecycle.ItemStatus
+		tasks       []client.Object
+		wantStatus  []apilifecycle.ItemStatus
+		wantSummary apicommon.StatusSummary
+	}{
+		{
+			name: "only tasks without dependencies are started",
+			wantStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "notify", Status: apicommon.StatePending},
+				{DefinitionName: "warmup", Status: apicommon.StatePending},
+				{DefinitionName: "migrate", Status: apicommon.StatePending, Name: "pre-migrate-"},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 3, Pending: 3},
+		},
+		{
+			name: "task is started after its dependencies have succeeded",
+			oldStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "migrate", Status: apicommon.StateSucceeded, Name: "pre-migrate-1"},
+			},
+			wantStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "notify", Status: apicommon.StatePending},
+				{DefinitionName: "warmup", Status: apicommon.StatePending, Name: "pre-warmup-"},
+				{DefinitionName: "migrate", Status: apicommon.StateSucceeded, Name: "pre-migrate-1"},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 3, Pending: 2, Succeeded: 1},
+		},
+		{
+			name: "all downstream tasks are skipped if a dependency failed",
+			oldStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "migrate", Status: apicommon.StateFailed, Name: "pre-migrate-1"},
+			},
+			wantStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "notify", Status: apicommon.StateSkipped},
+				{DefinitionName: "warmup", Status: apicommon.StateSkipped},
+				{DefinitionName: "migrate", Status: apicommon.StateFailed, Name: "pre-migrate-1"},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 3, Failed: 1, Skipped: 2},
+		},
+		{
+			name: "all downstream tasks are skipped if a dependency fails in the same reconciliation",
+			oldStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "migrate", Status: apicommon.StateProgressing, Name: "pre-migrate-1"},
+			},
+			tasks: []client.Object{
+				&apilifecycle.KeptnTask{
+					ObjectMeta: v1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "pre-migrate-1",
+					},
+					Status: apilifecycle.KeptnTaskStatus{
+						Status: apicommon.StateFailed,
+					},
+				},
+			},
+			wantStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "notify", Status: apicommon.StateSkipped},
+				{DefinitionName: "warmup", Status: apicommon.StateSkipped},
+				{DefinitionName: "migrate", Status: apicommon.StateFailed, Name: "pre-migrate-1"},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 3, Failed: 1, Skipped: 2},
+		},
+		{
+			name: "skipped tasks are propagated",
+			oldStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "warmup", Status: apicommon.StateSkipped},
+				{DefinitionName: "migrate", Status: apicommon.StateFailed, Name: "pre-migrate-1"},
+			},
+			wantStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "notify", Status: apicommon.StateSkipped},
+				{DefinitionName: "warmup", Status: apicommon.StateSkipped},
+				{DefinitionName: "migrate", Status: apicommon.StateFailed, Name: "pre-migrate-1"},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 3, Failed: 1, Skipped: 2},
+		},
+	}
+	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := apilifecycle.AddToScheme(scheme.Scheme)
+			require.Nil(t, err)
+
+			appVersion := &apilifecycle.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: apilifecycle.KeptnAppVersionSpec{
+					KeptnAppContextSpec: apilifecycle.KeptnAppContextSpec{
+						DeploymentTaskSpec: apilifecycle.DeploymentTaskSpec{
+							PreDeploymentTasks: []string{"notify", "warmup", "migrate"},
+							TaskDependencies:   dependencies,
+						},
+					},
+				},
+				Status: apilifecycle.KeptnAppVersionStatus{
+					PreDeploymentTaskStatus: tt.oldStatus,
+				},
+			}
+
+			initObjs := append([]client.Object{}, tt.tasks...)
+			for _, name := range []string{"notify", "warmup", "migrate"} {
+				initObjs = append(initObjs, &apilifecycle.KeptnTaskDefinition{
+					ObjectMeta: v1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      name,
+					},
+				})
+			}
+
+			handler := Handler{
+				SpanHandler: &telemetryfake.ISpanHandlerMock{
+					GetSpanFunc: func(ctx context.Context, tracer telemetry.ITracer, reconcileObject client.Object, phase string, links ...trace.Link) (context.Context, trace.Span, error) {
+						return context.TODO(), trace.SpanFromContext(context.TODO()), nil
+					},
+					UnbindSpanFunc: func(reconcileObject client.Object, phase string) error {
+						return nil
+					},
+				},
+				Log:         ctrl.Log.WithName("controller"),
+				EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+				Client:      fake.NewClientBuilder().WithObjects(initObjs...).Build(),
+				Tracer:      noop.NewTracerProvider().Tracer("tracer"),
+				Scheme:      scheme.Scheme,
+			}
+
+			status, summary, err := handler.ReconcileTasks(context.TODO(), context.TODO(), appVersion, CreateTaskAttributes{
+				CheckType: apicommon.PreDeploymentCheckType,
+			})
+			require.Nil(t, err)
+			require.Len(t, status, len(tt.wantStatus))
+			for j, item := range status {
+				require.Equal(t, tt.wantStatus[j].DefinitionName, item.DefinitionName)
+				require.Equal(t, tt.wantStatus[j].Status, item.Status)
+				require.True(t, strings.HasPrefix(item.Name, tt.wantStatus[j].Name))
+				require.Equal(t, tt.wantStatus[j].Name == "", item.Name == "")
+			}
+			require.Equal(t, tt.wantSummary, summary)
+		})
+	}
+}
+
+func TestTaskHandler_taskOutputs(t *testing.T) {
+	err := apilifecycle.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
+
+	appVersion := &apilifecycle.KeptnAppVersion{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "namespace",
+		},
+		Spec: apilifecycle.KeptnAppVersionSpec{
+			KeptnAppContextSpec: apilifecycle.KeptnAppContextSpec{
+				DeploymentTaskSpec: apilifecycle.DeploymentTaskSpec{
+					PreDeploymentTasks: []string{"warmup", "migrate"},
+					TaskDependencies: []apilifecycle.TaskDependency{
+						{TaskDefinition: "warmup", RunAfter: []string{"migrate"}},
+					},
+				},
+			},
+		},
+		Status: apilifecycle.KeptnAppVersionStatus{
+			PreDeploymentTaskStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "migrate", Status: apicommon.StateProgressing, Name: "pre-migrate-1"},
+			},
+		},
+	}
+
+	initObjs := []client.Object{
+		&apilifecycle.KeptnTask{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace: "namespace",
+				Name:      "pre-migrate-1",
+			},
+			Status: apilifecycle.KeptnTaskStatus{
+				Status:  apicommon.StateSucceeded,
+				Outputs: map[string]string{"schemaVersion": "42"},
+			},
+		},
+	}
+	for _, name := range []string{"warmup", "migrate"} {
+		initObjs = append(initObjs, &apilifecycle.KeptnTaskDefinition{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace: "namespace",
+				Name:      name,
+			},
+		})
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(initObjs...).Build()
+	handler := Handler{
+		SpanHandler: &telemetryfake.ISpanHandlerMock{
+			GetSpanFunc: func(ctx context.Context, tracer telemetry.ITracer, reconcileObject client.Object, phase string, links ...trace.Link) (context.Context, trace.Span, error) {
+				return context.TODO(), trace.SpanFromContext(context.TODO()), nil
+			},
+			UnbindSpanFunc: func(reconcileObject client.Object, phase string) error {
+				return nil
+			},
+		},
+		Log:         ctrl.Log.WithName("controller"),
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Client:      fakeClient,
+		Tracer:      noop.NewTracerProvider().Tracer("tracer"),
+		Scheme:      scheme.Scheme,
+	}
+
+	// the outputs of the finished task are stored in the status of the KeptnAppVersion
+	status, _, err := handler.ReconcileTasks(context.TODO(), context.TODO(), appVersion, CreateTaskAttributes{
+		CheckType: apicommon.PreDeploymentCheckType,
+	})
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"schemaVersion": "42"}, status[1].Outputs)
+
+	// the dependent task is created with the outputs of the finished task
+	appVersion.Status.PreDeploymentTaskStatus = status
+	status, _, err = handler.ReconcileTasks(context.TODO(), context.TODO(), appVersion, CreateTaskAttributes{
+		CheckType: apicommon.PreDeploymentCheckType,
+	})
+	require.Nil(t, err)
+	require.NotEmpty(t, status[0].Name)
+
+	warmupTask := &apilifecycle.KeptnTask{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "namespace", Name: status[0].Name}, warmupTask)
+	require.Nil(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"migrate": {"schemaVersion": "42"},
+	}, warmupTask.Spec.Context.Outputs)
+}
+
+func TestTaskHandler_taskOutputsOfDependencyFinishedInSameReconciliation(t *testing.T) {
+	err := apilifecycle.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
+
+	appVersion := &apilifecycle.KeptnAppVersion{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "namespace",
+		},
+		Spec: apilifecycle.KeptnAppVersionSpec{
+			KeptnAppContextSpec: apilifecycle.KeptnAppContextSpec{
+				DeploymentTaskSpec: apilifecycle.DeploymentTaskSpec{
+					PreDeploymentTasks: []string{"migrate", "warmup"},
+					TaskDependencies: []apilifecycle.TaskDependency{
+						{TaskDefinition: "warmup", RunAfter: []string{"migrate"}},
+					},
+				},
+			},
+		},
+		Status: apilifecycle.KeptnAppVersionStatus{
+			PreDeploymentTaskStatus: []apilifecycle.ItemStatus{
+				{DefinitionName: "migrate", Status: apicommon.StateProgressing, Name: "pre-migrate-1"},
+			},
+		},
+	}
+
+	initObjs := []client.Object{
+		&apilifecycle.KeptnTask{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace: "namespace",
+				Name:      "pre-migrate-1",
+			},
+			Status: apilifecycle.KeptnTaskStatus{
+				Status:  apicommon.StateSucceeded,
+				Outputs: map[string]string{"schemaVersion": "42"},
+			},
+		},
+	}
+	for _, name := range []string{"warmup", "migrate"} {
+		initObjs = append(initObjs, &apilifecycle.KeptnTaskDefinition{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace: "namespace",
+				Name:      name,
+			},
+		})
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(initObjs...).Build()
+	handler := Handler{
+		SpanHandler: &telemetryfake.ISpanHandlerMock{
+			GetSpanFunc: func(ctx context.Context, tracer telemetry.ITracer, reconcileObject client.Object, phase string, links ...trace.Link) (context.Context, trace.Span, error) {
+				return context.TODO(), trace.SpanFromContext(context.TODO()), nil
+			},
+			UnbindSpanFunc: func(reconcileObject client.Object, phase string) error {
+				return nil
+			},
+		},
+		Log:         ctrl.Log.WithName("controller"),
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Client:      fakeClient,
+		Tracer:      noop.NewTracerProvider().Tracer("tracer"),
+		Scheme:      scheme.Scheme,
+	}
+
+	// the dependent task is created in the same reconciliation in which its dependency has succeeded,
+	// with the outputs that are not yet part of the status of the KeptnAppVersion
+	status, summary, err := handler.ReconcileTasks(context.TODO(), context.TODO(), appVersion, CreateTaskAttributes{
+		CheckType: apicommon.PreDeploymentCheckType,
+	})
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StatusSummary{Total: 2, Pending: 1, Succeeded: 1}, summary)
+	require.Equal(t, map[string]string{"schemaVersion": "42"}, status[0].Outputs)
+	require.NotEmpty(t, status[1].Name)
+
+	warmupTask := &apilifecycle.KeptnTask{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "namespace", Name: status[1].Name}, warmupTask)
+	require.Nil(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"migrate": {"schemaVersion": "42"},
+	}, warmupTask.Spec.Context.Outputs)
+}
+
+func Test_getRunAfter(t *testing.T) {
+	runAfter, err := getRunAfter([]apilifecycle.TaskDependency{
+		{TaskDefinition: "b", RunAfter: []string{"a", "a", "unknown"}},
+		{TaskDefinition: "c", RunAfter: []string{"a", "b"}},
+		{TaskDefinition: "unknown", RunAfter: []string{"a"}},
+	}, []string{"a", "b", "c"})
+	require.Nil(t, err)
+	require.Equal(t, map[string][]string{
+		"b": {"a"},
+		"c": {"a", "b"},
+	}, runAfter)
+
+	_, err = getRunAfter([]apilifecycle.TaskDependency{
+		{TaskDefinition: "a", RunAfter: []string{"c"}},
+		{TaskDefinition: "b", RunAfter: []string{"a"}},
+		{TaskDefinition: "c", RunAfter: []string{"b"}},
+	}, []string{"a", "b", "c"})
+	require.ErrorIs(t, err, controllererrors.ErrTaskDependencyCycle)
+}
+
+func Test_getExecutionOrder(t *testing.T) {
+	order := getExecutionOrder([]string{"c", "d", "b", "a"}, map[string][]string{
+		"b": {"a"},
+		"c": {"b"},
+	})
+	require.Equal(t, []string{"a", "b", "c", "d"}, order)
+}
+
 func Test_injectKeptnContext(t *testing.T) {
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
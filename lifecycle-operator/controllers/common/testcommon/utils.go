@@ -3,6 +3,7 @@ package testcommon
 import (
 	"fmt"
 
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
@@ -32,6 +33,7 @@ func SetupSchemes() {
 	utilruntime.Must(apiv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(apilifecycle.AddToScheme(scheme.Scheme))
 	utilruntime.Must(optionsv1alpha1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(argov1alpha1.AddToScheme(scheme.Scheme))
 }
 
 func GetApp(name string) *apilifecycle.KeptnApp {
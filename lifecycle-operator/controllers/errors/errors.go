@@ -18,9 +18,25 @@ var ErrInvalidOperator = fmt.Errorf("invalid operator")
 var ErrCannotMarshalParams = fmt.Errorf("could not marshal parameters")
 var ErrNoTaskDefinitionSpec = fmt.Errorf("the TaskDefinition specs are empty")
 var ErrUnsupportedWorkloadVersionResourceReference = fmt.Errorf("unsupported Resource Reference")
+var ErrWorkloadResourceFailed = fmt.Errorf("workload resource has failed")
 var ErrCannotGetKeptnTaskDefinition = fmt.Errorf("cannot retrieve KeptnTaskDefinition")
 var ErrCannotGetKeptnEvaluationDefinition = fmt.Errorf("cannot retrieve KeptnEvaluationDefinition")
+var ErrNoPreviousVersion = fmt.Errorf("no previous version set for KeptnEvaluation")
+var ErrNoPreviousVersionValue = fmt.Errorf("no value recorded by a KeptnEvaluation of the previous version")
 var ErrNoMatchingAppVersionFound = fmt.Errorf("no matching KeptnAppVersion found")
+var ErrNoDeployedTemplate = fmt.Errorf("no deployed pod template recorded for KeptnWorkloadVersion")
+var ErrWorkloadOwnerReplaced = fmt.Errorf("workload resource has been replaced since the pod template was recorded")
+var ErrTaskDependencyCycle = fmt.Errorf("task dependencies contain a cycle")
+var ErrInvalidTaskOutputs = fmt.Errorf("task outputs must be a JSON object")
+var ErrTaskOutputNotFound = fmt.Errorf("task output not found")
+var ErrNoErrorBudget = fmt.Errorf("no error budget computed for ServiceLevelObjective")
+var ErrErrorBudgetOutdated = fmt.Errorf("error budget of ServiceLevelObjective has not been updated recently")
+var ErrMultipleSeries = fmt.Errorf("KeptnMetric has multiple series, only KeptnMetrics with a single value can be evaluated")
+var ErrNoAnomalyDetection = fmt.Errorf("anomaly detection is not enabled for KeptnMetric")
+var ErrAnomalyDetectionPending = fmt.Errorf("anomaly detection of KeptnMetric has no result yet")
+var ErrNotAnomalousRequiresMetric = fmt.Errorf("notAnomalous can only be used for objectives referencing a KeptnMetric")
+var ErrUnexpectedHTTPStatusCode = fmt.Errorf("unexpected HTTP status code")
+var ErrHTTPAssertionFailed = fmt.Errorf("HTTP assertion failed")
 
 var ErrCannotRetrieveConfigMsg = "could not retrieve KeptnConfig: %w"
 var ErrCannotRetrieveInstancesMsg = "could not retrieve instances: %w"
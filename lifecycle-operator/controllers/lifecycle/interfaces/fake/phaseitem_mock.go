@@ -87,6 +87,9 @@ import (
 //			GetStateFunc: func() apicommon.KeptnState {
 //				panic("mock out the GetState method")
 //			},
+//			GetTaskDependenciesFunc: func() []apilifecycle.TaskDependency {
+//				panic("mock out the GetTaskDependencies method")
+//			},
 //			GetVersionFunc: func() string {
 //				panic("mock out the GetVersion method")
 //			},
@@ -178,6 +181,9 @@ type PhaseItemMock struct {
 	// GetStateFunc mocks the GetState method.
 	GetStateFunc func() apicommon.KeptnState
 
+	// GetTaskDependenciesFunc mocks the GetTaskDependencies method.
+	GetTaskDependenciesFunc func() []apilifecycle.TaskDependency
+
 	// GetVersionFunc mocks the GetVersion method.
 	GetVersionFunc func() string
 
@@ -274,6 +280,9 @@ type PhaseItemMock struct {
 		// GetState holds details about calls to the GetState method.
 		GetState []struct {
 		}
+		// GetTaskDependencies holds details about calls to the GetTaskDependencies method.
+		GetTaskDependencies []struct {
+		}
 		// GetVersion holds details about calls to the GetVersion method.
 		GetVersion []struct {
 		}
@@ -319,6 +328,7 @@ type PhaseItemMock struct {
 	lockGetSpanAttributes                     sync.RWMutex
 	lockGetStartTime                          sync.RWMutex
 	lockGetState                              sync.RWMutex
+	lockGetTaskDependencies                   sync.RWMutex
 	lockGetVersion                            sync.RWMutex
 	lockIsEndTimeSet                          sync.RWMutex
 	lockSetCurrentPhase                       sync.RWMutex
@@ -970,6 +980,33 @@ func (mock *PhaseItemMock) GetStateCalls() []struct {
 	return calls
 }
 
+// GetTaskDependencies calls GetTaskDependenciesFunc.
+func (mock *PhaseItemMock) GetTaskDependencies() []apilifecycle.TaskDependency {
+	if mock.GetTaskDependenciesFunc == nil {
+		panic("PhaseItemMock.GetTaskDependenciesFunc: method is nil but PhaseItem.GetTaskDependencies was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetTaskDependencies.Lock()
+	mock.calls.GetTaskDependencies = append(mock.calls.GetTaskDependencies, callInfo)
+	mock.lockGetTaskDependencies.Unlock()
+	return mock.GetTaskDependenciesFunc()
+}
+
+// GetTaskDependenciesCalls gets all the calls that were made to GetTaskDependencies.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetTaskDependenciesCalls())
+func (mock *PhaseItemMock) GetTaskDependenciesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetTaskDependencies.RLock()
+	calls = mock.calls.GetTaskDependencies
+	mock.lockGetTaskDependencies.RUnlock()
+	return calls
+}
+
 // GetVersion calls GetVersionFunc.
 func (mock *PhaseItemMock) GetVersion() string {
 	if mock.GetVersionFunc == nil {
@@ -31,6 +31,7 @@ type PhaseItem interface {
 	GetPreDeploymentTasks() []string
 	GetPostDeploymentTasks() []string
 	GetPromotionTasks() []string
+	GetTaskDependencies() []apilifecycle.TaskDependency
 	GetPreDeploymentTaskStatus() []apilifecycle.ItemStatus
 	GetPostDeploymentTaskStatus() []apilifecycle.ItemStatus
 	GetPromotionTaskStatus() []apilifecycle.ItemStatus
@@ -165,6 +166,10 @@ func (pw PhaseItemWrapper) GetPromotionTasks() []string {
 	return pw.Obj.GetPromotionTasks()
 }
 
+func (pw PhaseItemWrapper) GetTaskDependencies() []apilifecycle.TaskDependency {
+	return pw.Obj.GetTaskDependencies()
+}
+
 func (pw PhaseItemWrapper) GetPromotionTaskStatus() []apilifecycle.ItemStatus {
 	return pw.Obj.GetPromotionTaskStatus()
 }
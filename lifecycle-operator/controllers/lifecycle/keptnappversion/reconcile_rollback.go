@@ -0,0 +1,206 @@
+package keptnappversion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/phase"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"go.opentelemetry.io/otel/codes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rollbackIfRequired starts the rollback of the KeptnAppVersion if the phase that has just been handled
+// left the KeptnAppVersion in a state that requires a rollback. Otherwise, the result of the phase is returned.
+func (r *KeptnAppVersionReconciler) rollbackIfRequired(ctx context.Context, ctxAppTrace context.Context, appVersion *apilifecycle.KeptnAppVersion, result phase.PhaseResult, err error) (ctrl.Result, error) {
+	if err != nil || !appVersion.IsRollbackRequired() {
+		return result.Result, err
+	}
+	return r.reconcileRollback(ctx, ctxAppTrace, appVersion)
+}
+
+func (r *KeptnAppVersionReconciler) reconcileRollback(ctx context.Context, ctxAppTrace context.Context, appVersion *apilifecycle.KeptnAppVersion) (ctrl.Result, error) {
+	rollbackPhase := apicommon.PhaseAppRollback
+	if appVersion.Status.CurrentPhase != rollbackPhase.ShortName {
+		r.EventSender.Emit(rollbackPhase, "Normal", appVersion, apicommon.PhaseStateStarted, "has started", appVersion.GetVersion())
+		appVersion.SetCurrentPhase(rollbackPhase.ShortName)
+	}
+
+	_, spanRollbackTrace, err := r.SpanHandler.GetSpan(ctxAppTrace, r.getTracer(), appVersion, rollbackPhase.ShortName)
+	if err != nil {
+		r.Log.Error(err, "could not get span")
+	}
+
+	state, err := r.rollbackWorkloads(ctx, appVersion)
+	if err != nil {
+		spanRollbackTrace.AddEvent(rollbackPhase.LongName + " could not get reconciled")
+		r.EventSender.Emit(rollbackPhase, "Warning", appVersion, apicommon.PhaseStateReconcileError, "could not get reconciled", appVersion.GetVersion())
+		return ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
+	}
+
+	appVersion.Status.RollbackStatus = state
+	if err := r.Client.Status().Update(ctx, appVersion); err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if state.IsFailed() {
+		spanRollbackTrace.AddEvent(rollbackPhase.LongName + " has failed")
+		spanRollbackTrace.SetStatus(codes.Error, "Failed")
+		r.EventSender.Emit(rollbackPhase, "Warning", appVersion, apicommon.PhaseStateFailed, "has failed", appVersion.GetVersion())
+	} else {
+		spanRollbackTrace.AddEvent(rollbackPhase.LongName + " has succeeded")
+		spanRollbackTrace.SetStatus(codes.Ok, "Succeeded")
+		r.EventSender.Emit(rollbackPhase, "Normal", appVersion, apicommon.PhaseStateFinished, "has finished", appVersion.GetVersion())
+	}
+	spanRollbackTrace.End()
+	if err := r.SpanHandler.UnbindSpan(appVersion, rollbackPhase.ShortName); err != nil {
+		r.Log.Error(err, controllererrors.ErrCouldNotUnbindSpan, appVersion.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// rollbackWorkloads restores the pod templates of all workloads of the previous version of the KeptnApp
+// that have been deployed in a different version by the given KeptnAppVersion
+func (r *KeptnAppVersionReconciler) rollbackWorkloads(ctx context.Context, appVersion *apilifecycle.KeptnAppVersion) (apicommon.KeptnState, error) {
+	previousAppVersion, err := r.getPreviousAppVersion(ctx, appVersion)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+	if previousAppVersion == nil {
+		r.EventSender.Emit(apicommon.PhaseAppRollback, "Warning", appVersion, apicommon.PhaseStateNotFound, fmt.Sprintf("could not find a succeeded KeptnAppVersion for previous version: %s ", appVersion.Spec.PreviousVersion), appVersion.GetVersion())
+		return apicommon.StateFailed, nil
+	}
+
+	var summary apicommon.StatusSummary
+	newStatus := make([]apilifecycle.WorkloadStatus, 0, len(previousAppVersion.Spec.Workloads))
+	for _, w := range previousAppVersion.Spec.Workloads {
+		if isWorkloadDeployed(appVersion, w) {
+			continue
+		}
+		workloadStatus, err := r.rollbackWorkload(ctx, appVersion, w)
+		if err != nil {
+			return apicommon.StateUnknown, err
+		}
+		newStatus = append(newStatus, apilifecycle.WorkloadStatus{
+			Workload: w,
+			Status:   workloadStatus,
+		})
+		summary = apicommon.UpdateStatusSummary(workloadStatus, summary)
+	}
+	summary.Total = len(newStatus)
+
+	appVersion.Status.RollbackWorkloadStatus = newStatus
+	return apicommon.GetOverallState(summary), nil
+}
+
+func (r *KeptnAppVersionReconciler) rollbackWorkload(ctx context.Context, appVersion *apilifecycle.KeptnAppVersion, workload apilifecycle.KeptnWorkloadRef) (apicommon.KeptnState, error) {
+	workloadVersion := &apilifecycle.KeptnWorkloadVersion{}
+	workloadVersionName := getWorkloadVersionName(appVersion.Spec.AppName, workload.Name, workload.Version)
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: appVersion.Namespace, Name: workloadVersionName}, workloadVersion)
+	if k8serrors.IsNotFound(err) {
+		r.EventSender.Emit(apicommon.PhaseAppRollback, "Warning", appVersion, apicommon.PhaseStateNotFound, fmt.Sprintf("could not find KeptnWorkloadVersion: %s ", workloadVersionName), appVersion.GetVersion())
+		return apicommon.StateFailed, nil
+	}
+	if err != nil {
+		return apicommon.StateUnknown, fmt.Errorf(controllererrors.ErrCannotRetrieveWorkloadVersionMsg, err)
+	}
+
+	err = r.restoreDeployedTemplate(ctx, appVersion.Namespace, workloadVersion.Status.DeployedTemplate)
+	if err == nil {
+		return apicommon.StateSucceeded, nil
+	}
+	if k8serrors.IsNotFound(err) || isUnrecoverableRollbackError(err) {
+		r.Log.Error(err, "could not roll back workload", "workloadVersion", workloadVersionName)
+		r.EventSender.Emit(apicommon.PhaseAppRollback, "Warning", appVersion, apicommon.PhaseStateFailed, fmt.Sprintf("could not roll back KeptnWorkload %s to version %s: %s ", workload.Name, workload.Version, err.Error()), appVersion.GetVersion())
+		return apicommon.StateFailed, nil
+	}
+	return apicommon.StateUnknown, err
+}
+
+func (r *KeptnAppVersionReconciler) restoreDeployedTemplate(ctx context.Context, namespace string, deployedTemplate *apilifecycle.DeployedTemplate) error {
+	if deployedTemplate == nil {
+		return controllererrors.ErrNoDeployedTemplate
+	}
+
+	template := corev1.PodTemplateSpec{}
+	if err := json.Unmarshal(deployedTemplate.Template.Raw, &template); err != nil {
+		return fmt.Errorf("%w: %w", controllererrors.ErrNoDeployedTemplate, err)
+	}
+
+	var owner client.Object
+	var ownerTemplate *corev1.PodTemplateSpec
+	switch deployedTemplate.Owner.Kind {
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		owner, ownerTemplate = deployment, &deployment.Spec.Template
+	case "StatefulSet":
+		sts := &appsv1.StatefulSet{}
+		owner, ownerTemplate = sts, &sts.Spec.Template
+	case "DaemonSet":
+		ds := &appsv1.DaemonSet{}
+		owner, ownerTemplate = ds, &ds.Spec.Template
+	case "Rollout":
+		rollout := &argov1alpha1.Rollout{}
+		owner, ownerTemplate = rollout, &rollout.Spec.Template
+	default:
+		return controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	}
+
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: deployedTemplate.Owner.Name}, owner); err != nil {
+		return err
+	}
+	if owner.GetUID() != deployedTemplate.Owner.UID {
+		return controllererrors.ErrWorkloadOwnerReplaced
+	}
+
+	*ownerTemplate = template
+	return r.Client.Update(ctx, owner)
+}
+
+// getPreviousAppVersion returns the most recently finished, succeeded KeptnAppVersion of the previous version of the KeptnApp,
+// or nil if no such KeptnAppVersion exists
+func (r *KeptnAppVersionReconciler) getPreviousAppVersion(ctx context.Context, appVersion *apilifecycle.KeptnAppVersion) (*apilifecycle.KeptnAppVersion, error) {
+	appVersionList := &apilifecycle.KeptnAppVersionList{}
+	if err := r.Client.List(ctx, appVersionList, client.InNamespace(appVersion.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var previousAppVersion *apilifecycle.KeptnAppVersion
+	for i := range appVersionList.Items {
+		item := &appVersionList.Items[i]
+		if item.Spec.AppName != appVersion.Spec.AppName || item.Spec.Version != appVersion.Spec.PreviousVersion || !item.Status.Status.IsSucceeded() {
+			continue
+		}
+		if previousAppVersion == nil || item.Status.EndTime.After(previousAppVersion.Status.EndTime.Time) {
+			previousAppVersion = item
+		}
+	}
+	return previousAppVersion, nil
+}
+
+func isWorkloadDeployed(appVersion *apilifecycle.KeptnAppVersion, workload apilifecycle.KeptnWorkloadRef) bool {
+	for _, w := range appVersion.Spec.Workloads {
+		if w.Name == workload.Name && w.Version == workload.Version {
+			return true
+		}
+	}
+	return false
+}
+
+func isUnrecoverableRollbackError(err error) bool {
+	return errors.Is(err, controllererrors.ErrNoDeployedTemplate) ||
+		errors.Is(err, controllererrors.ErrWorkloadOwnerReplaced) ||
+		errors.Is(err, controllererrors.ErrUnsupportedWorkloadVersionResourceReference)
+}
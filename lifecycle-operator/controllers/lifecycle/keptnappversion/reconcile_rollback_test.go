@@ -0,0 +1,175 @@
+package keptnappversion
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestKeptnAppVersionReconciler_reconcileRollback(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-deployment",
+			Namespace: "default",
+			UID:       "deployment-uid",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: podTemplate("image:v2"),
+		},
+	}
+
+	rawTemplate, err := json.Marshal(podTemplate("image:v1"))
+	require.Nil(t, err)
+
+	workloadVersion := &apilifecycle.KeptnWorkloadVersion{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "myapp-workload-v1",
+			Namespace: "default",
+		},
+		Status: apilifecycle.KeptnWorkloadVersionStatus{
+			DeployedTemplate: &apilifecycle.DeployedTemplate{
+				Owner: apilifecycle.ResourceReference{
+					UID:  "deployment-uid",
+					Kind: "Deployment",
+					Name: "my-deployment",
+				},
+				Template: runtime.RawExtension{Raw: rawTemplate},
+			},
+		},
+	}
+
+	previousAppVersion := testcommon.ReturnAppVersion("default", "myapp", "1.0.0", []apilifecycle.KeptnWorkloadRef{
+		{Name: "workload", Version: "v1"},
+		{Name: "unchanged", Version: "v1"},
+	}, apilifecycle.KeptnAppVersionStatus{Status: apicommon.StateSucceeded})
+
+	appVersion := returnFailedAppVersionWithRollback()
+
+	r, eventChannel, _ := setupReconciler(deployment, workloadVersion, previousAppVersion, appVersion)
+
+	result, err := r.reconcileRollback(context.TODO(), context.TODO(), appVersion)
+	require.Nil(t, err)
+	require.False(t, result.Requeue)
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-deployment"}, deployment)
+	require.Nil(t, err)
+	require.Equal(t, "image:v1", deployment.Spec.Template.Spec.Containers[0].Image)
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: appVersion.Namespace, Name: appVersion.Name}, appVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateSucceeded, appVersion.Status.RollbackStatus)
+	require.Equal(t, apicommon.PhaseAppRollback.ShortName, appVersion.Status.CurrentPhase)
+	require.Equal(t, []apilifecycle.WorkloadStatus{
+		{
+			Workload: apilifecycle.KeptnWorkloadRef{Name: "workload", Version: "v1"},
+			Status:   apicommon.StateSucceeded,
+		},
+	}, appVersion.Status.RollbackWorkloadStatus)
+
+	require.Contains(t, <-eventChannel, "AppRollbackStarted")
+	require.Contains(t, <-eventChannel, "AppRollbackFinished")
+}
+
+func TestKeptnAppVersionReconciler_reconcileRollback_WorkloadVersionNotFound(t *testing.T) {
+	previousAppVersion := testcommon.ReturnAppVersion("default", "myapp", "1.0.0", []apilifecycle.KeptnWorkloadRef{
+		{Name: "workload", Version: "v1"},
+	}, apilifecycle.KeptnAppVersionStatus{Status: apicommon.StateSucceeded})
+
+	appVersion := returnFailedAppVersionWithRollback()
+
+	r, eventChannel, _ := setupReconciler(previousAppVersion, appVersion)
+
+	result, err := r.reconcileRollback(context.TODO(), context.TODO(), appVersion)
+	require.Nil(t, err)
+	require.False(t, result.Requeue)
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: appVersion.Namespace, Name: appVersion.Name}, appVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateFailed, appVersion.Status.RollbackStatus)
+
+	require.Contains(t, <-eventChannel, "AppRollbackStarted")
+	require.Contains(t, <-eventChannel, "AppRollbackNotFound")
+	require.Contains(t, <-eventChannel, "AppRollbackFailed")
+}
+
+func TestKeptnAppVersionReconciler_reconcileRollback_PreviousAppVersionNotFound(t *testing.T) {
+	appVersion := returnFailedAppVersionWithRollback()
+
+	r, eventChannel, _ := setupReconciler(appVersion)
+
+	_, err := r.reconcileRollback(context.TODO(), context.TODO(), appVersion)
+	require.Nil(t, err)
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: appVersion.Namespace, Name: appVersion.Name}, appVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateFailed, appVersion.Status.RollbackStatus)
+
+	require.Contains(t, <-eventChannel, "AppRollbackStarted")
+	require.Contains(t, <-eventChannel, "AppRollbackNotFound")
+}
+
+func TestKeptnAppVersionReconciler_restoreDeployedTemplate_OwnerReplaced(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-deployment",
+			Namespace: "default",
+			UID:       "new-uid",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: podTemplate("image:v2"),
+		},
+	}
+	rawTemplate, err := json.Marshal(podTemplate("image:v1"))
+	require.Nil(t, err)
+
+	r, _, _ := setupReconciler(deployment)
+
+	err = r.restoreDeployedTemplate(context.TODO(), "default", &apilifecycle.DeployedTemplate{
+		Owner: apilifecycle.ResourceReference{
+			UID:  "old-uid",
+			Kind: "Deployment",
+			Name: "my-deployment",
+		},
+		Template: runtime.RawExtension{Raw: rawTemplate},
+	})
+	require.True(t, isUnrecoverableRollbackError(err))
+
+	err = r.restoreDeployedTemplate(context.TODO(), "default", nil)
+	require.True(t, isUnrecoverableRollbackError(err))
+}
+
+func returnFailedAppVersionWithRollback() *apilifecycle.KeptnAppVersion {
+	appVersion := testcommon.ReturnAppVersion("default", "myapp", "2.0.0", []apilifecycle.KeptnWorkloadRef{
+		{Name: "workload", Version: "v2"},
+		{Name: "unchanged", Version: "v1"},
+	}, apilifecycle.KeptnAppVersionStatus{
+		Status:               apicommon.StateFailed,
+		PostDeploymentStatus: apicommon.StateFailed,
+	})
+	appVersion.Spec.PreviousVersion = "1.0.0"
+	appVersion.Spec.RollbackPolicy = &apilifecycle.RollbackPolicy{Enabled: true}
+	return appVersion
+}
+
+func podTemplate(image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: v1.ObjectMeta{
+			Labels: map[string]string{"app": "my-app"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Image: image},
+			},
+		},
+	}
+}
@@ -0,0 +1,168 @@
+package keptnevaluation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	analysisStatusPrefix    = "analysis/"
+	analysisStateCompleted  = "Completed"
+	defaultAnalysisDuration = "5m"
+)
+
+// evaluateAnalysis creates an Analysis for the AnalysisDefinition referenced by the KeptnEvaluationDefinition
+// and maps the result of the Analysis into the status of the KeptnEvaluation
+func (r *KeptnEvaluationReconciler) evaluateAnalysis(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, definitionRef apilifecycle.AnalysisDefinitionReference, statusSummary apicommon.StatusSummary, newStatus map[string]apilifecycle.EvaluationStatusItem) (map[string]apilifecycle.EvaluationStatusItem, apicommon.StatusSummary) {
+	key := analysisStatusPrefix + definitionRef.Name
+	if item, ok := evaluation.Status.EvaluationStatus[key]; ok && item.Status.IsSucceeded() {
+		newStatus[key] = item
+		return newStatus, apicommon.UpdateStatusSummary(apicommon.StateSucceeded, statusSummary)
+	}
+
+	statusItem := r.getAnalysisResult(ctx, evaluation, definitionRef)
+	newStatus[key] = statusItem
+	return newStatus, apicommon.UpdateStatusSummary(statusItem.Status, statusSummary)
+}
+
+func (r *KeptnEvaluationReconciler) getAnalysisResult(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, definitionRef apilifecycle.AnalysisDefinitionReference) apilifecycle.EvaluationStatusItem {
+	analysis, err := r.getOrCreateAnalysis(ctx, evaluation, definitionRef)
+	if err != nil {
+		r.Log.Error(err, "Could not retrieve Analysis")
+		return apilifecycle.EvaluationStatusItem{Status: apicommon.StateFailed, Message: err.Error()}
+	}
+
+	state, _, _ := unstructured.NestedString(analysis.Object, "status", "state")
+	if state != analysisStateCompleted {
+		return apilifecycle.EvaluationStatusItem{
+			Status:  apicommon.StateProgressing,
+			Message: fmt.Sprintf("waiting for Analysis '%s' to complete", analysis.GetName()),
+		}
+	}
+
+	statusItem := apilifecycle.EvaluationStatusItem{
+		Status: apicommon.StateFailed,
+		Value:  getAnalysisScore(analysis),
+	}
+	pass, _, _ := unstructured.NestedBool(analysis.Object, "status", "pass")
+	warning, _, _ := unstructured.NestedBool(analysis.Object, "status", "warning")
+	switch {
+	case pass:
+		statusItem.Status = apicommon.StateSucceeded
+		statusItem.Message = fmt.Sprintf("Analysis '%s' passed", analysis.GetName())
+	case warning:
+		statusItem.Status = apicommon.StateSucceeded
+		statusItem.Message = fmt.Sprintf("Analysis '%s' passed with warning", analysis.GetName())
+		r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Warning", evaluation, apicommon.PhaseStateFinished, statusItem.Message, "")
+	default:
+		statusItem.Message = fmt.Sprintf("Analysis '%s' did not pass", analysis.GetName())
+		// the Analysis is deleted so that the next retry of the KeptnEvaluation analyses the metrics again
+		if err := r.Client.Delete(ctx, analysis); err != nil && !errors.IsNotFound(err) {
+			r.Log.Error(err, "Could not delete failed Analysis")
+		}
+	}
+	return statusItem
+}
+
+func (r *KeptnEvaluationReconciler) getOrCreateAnalysis(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, definitionRef apilifecycle.AnalysisDefinitionReference) (*unstructured.Unstructured, error) {
+	analysis := newAnalysis()
+	err := r.Client.Get(ctx, types.NamespacedName{Name: evaluation.Name, Namespace: evaluation.Namespace}, analysis)
+	if err == nil {
+		return analysis, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	analysis = newAnalysis()
+	analysis.SetName(evaluation.Name)
+	analysis.SetNamespace(evaluation.Namespace)
+
+	definitionNamespace := definitionRef.Namespace
+	if definitionNamespace == "" {
+		definitionNamespace = evaluation.Namespace
+	}
+	analysis.Object["spec"] = map[string]interface{}{
+		"timeframe": getAnalysisTimeframe(evaluation.Spec.TimeframeStart.Time, time.Now().UTC()),
+		"args":      getAnalysisArgs(evaluation.Spec.Context),
+		"analysisDefinition": map[string]interface{}{
+			"name":      definitionRef.Name,
+			"namespace": definitionNamespace,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(evaluation, analysis, r.Scheme); err != nil {
+		r.Log.Error(err, "could not set controller reference for Analysis: "+analysis.GetName())
+	}
+
+	if err := r.Client.Create(ctx, analysis); err != nil {
+		return nil, err
+	}
+	r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Normal", evaluation, apicommon.PhaseStateStarted, fmt.Sprintf("created Analysis '%s'", analysis.GetName()), "")
+	return analysis, nil
+}
+
+func newAnalysis() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       "Analysis",
+			"apiVersion": "metrics.keptn.sh/v1",
+		},
+	}
+}
+
+// getAnalysisTimeframe returns the time frame between the given start and end time,
+// or a recent time frame if no valid start time is available
+func getAnalysisTimeframe(from time.Time, to time.Time) map[string]interface{} {
+	if from.IsZero() || !from.Before(to) {
+		return map[string]interface{}{
+			"recent": defaultAnalysisDuration,
+		}
+	}
+	return map[string]interface{}{
+		"from": from.UTC().Format(time.RFC3339),
+		"to":   to.UTC().Format(time.RFC3339),
+	}
+}
+
+func getAnalysisArgs(taskContext apilifecycle.TaskContext) map[string]interface{} {
+	args := map[string]interface{}{}
+	for key, value := range taskContext.Metadata {
+		args[key] = value
+	}
+	for key, value := range map[string]string{
+		"appName":         taskContext.AppName,
+		"appVersion":      taskContext.AppVersion,
+		"workloadName":    taskContext.WorkloadName,
+		"workloadVersion": taskContext.WorkloadVersion,
+		"taskType":        taskContext.TaskType,
+		"objectType":      taskContext.ObjectType,
+	} {
+		if value != "" {
+			args[key] = value
+		}
+	}
+	return args
+}
+
+// getAnalysisScore returns the total score of the Analysis, or an empty string if it is not available
+func getAnalysisScore(analysis *unstructured.Unstructured) string {
+	raw, _, _ := unstructured.NestedString(analysis.Object, "status", "raw")
+	result := struct {
+		TotalScore *float64 `json:"totalScore"`
+	}{}
+	if err := json.Unmarshal([]byte(raw), &result); err != nil || result.TotalScore == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*result.TotalScore, 'f', -1, 64)
+}
@@ -0,0 +1,259 @@
+package keptnevaluation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	metricsapi "github.com/keptn/lifecycle-toolkit/lifecycle-operator/test/api/metrics/v1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+)
+
+func TestKeptnEvaluationReconciler_Reconcile_CreateAnalysis(t *testing.T) {
+	const namespace = "my-namespace"
+	timeframeStart := metav1.NewTime(time.Now().Add(-10 * time.Minute).UTC().Truncate(time.Second))
+
+	evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-definition",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationDefinitionSpec{
+			AnalysisDefinition: &apilifecycle.AnalysisDefinitionReference{
+				Name: "my-analysis-definition",
+			},
+		},
+	}
+
+	evaluation := &apilifecycle.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationSpec{
+			EvaluationDefinition: evaluationDefinition.Name,
+			Context: apilifecycle.TaskContext{
+				AppName:    "my-app",
+				AppVersion: "1.0.0",
+				TaskType:   string(apicommon.PostDeploymentCheckType),
+				ObjectType: "App",
+				Metadata:   map[string]string{"stage": "dev"},
+			},
+			TimeframeStart: timeframeStart,
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 10,
+			},
+		},
+	}
+
+	reconciler, fakeClient := setupReconcilerAndClient(t, evaluationDefinition, evaluation)
+
+	reconcile, err := reconciler.Reconcile(context.TODO(), controllerruntime.Request{
+		NamespacedName: types.NamespacedName{Namespace: namespace, Name: evaluation.Name},
+	})
+	require.Nil(t, err)
+	require.True(t, reconcile.Requeue)
+
+	analysis := &metricsapi.Analysis{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: evaluation.Name}, analysis)
+	require.Nil(t, err)
+
+	require.Equal(t, metricsapi.ObjectReference{Name: "my-analysis-definition", Namespace: namespace}, analysis.Spec.AnalysisDefinition)
+	require.Equal(t, map[string]string{
+		"appName":    "my-app",
+		"appVersion": "1.0.0",
+		"taskType":   "post",
+		"objectType": "App",
+		"stage":      "dev",
+	}, analysis.Spec.Args)
+	require.True(t, timeframeStart.Equal(&analysis.Spec.Timeframe.From))
+	require.False(t, analysis.Spec.Timeframe.To.IsZero())
+	require.Len(t, analysis.OwnerReferences, 1)
+	require.Equal(t, evaluation.Name, analysis.OwnerReferences[0].Name)
+
+	updatedEvaluation := &apilifecycle.KeptnEvaluation{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: evaluation.Name}, updatedEvaluation)
+	require.Nil(t, err)
+
+	require.Equal(t, apicommon.StateProgressing, updatedEvaluation.Status.OverallStatus)
+	require.Equal(t, apicommon.StateProgressing, updatedEvaluation.Status.EvaluationStatus["analysis/my-analysis-definition"].Status)
+	require.Equal(t, 1, updatedEvaluation.Status.RetryCount)
+}
+
+func TestKeptnEvaluationReconciler_Reconcile_AnalysisRetries(t *testing.T) {
+	const namespace = "my-namespace"
+
+	tests := []struct {
+		name           string
+		status         metricsapi.AnalysisStatus
+		wantRetryCount int
+	}{
+		{
+			name:           "running analysis is counted as retry",
+			status:         metricsapi.AnalysisStatus{State: "Progressing"},
+			wantRetryCount: 1,
+		},
+		{
+			name:           "pending analysis is counted as retry",
+			status:         metricsapi.AnalysisStatus{State: "Pending"},
+			wantRetryCount: 1,
+		},
+		{
+			name:           "failed analysis is counted as retry",
+			status:         metricsapi.AnalysisStatus{State: "Completed"},
+			wantRetryCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-definition",
+					Namespace: namespace,
+				},
+				Spec: apilifecycle.KeptnEvaluationDefinitionSpec{
+					AnalysisDefinition: &apilifecycle.AnalysisDefinitionReference{
+						Name: "my-analysis-definition",
+					},
+				},
+			}
+			evaluation := &apilifecycle.KeptnEvaluation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-evaluation",
+					Namespace: namespace,
+				},
+				Spec: apilifecycle.KeptnEvaluationSpec{
+					EvaluationDefinition: evaluationDefinition.Name,
+					FailureConditions: apilifecycle.FailureConditions{
+						Retries: 10,
+					},
+				},
+			}
+			analysis := &metricsapi.Analysis{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      evaluation.Name,
+					Namespace: namespace,
+				},
+				Status: tt.status,
+			}
+
+			reconciler, fakeClient := setupReconcilerAndClient(t, evaluationDefinition, evaluation, analysis)
+
+			_, err := reconciler.Reconcile(context.TODO(), controllerruntime.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: evaluation.Name},
+			})
+			require.Nil(t, err)
+
+			updatedEvaluation := &apilifecycle.KeptnEvaluation{}
+			err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: evaluation.Name}, updatedEvaluation)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantRetryCount, updatedEvaluation.Status.RetryCount)
+		})
+	}
+}
+
+func TestKeptnEvaluationReconciler_getAnalysisResult(t *testing.T) {
+	const namespace = "my-namespace"
+
+	tests := []struct {
+		name            string
+		status          metricsapi.AnalysisStatus
+		want            apilifecycle.EvaluationStatusItem
+		analysisDeleted bool
+	}{
+		{
+			name:   "analysis not completed",
+			status: metricsapi.AnalysisStatus{State: "Progressing"},
+			want: apilifecycle.EvaluationStatusItem{
+				Status:  apicommon.StateProgressing,
+				Message: "waiting for Analysis 'my-evaluation' to complete",
+			},
+		},
+		{
+			name: "analysis passed",
+			status: metricsapi.AnalysisStatus{
+				State: "Completed",
+				Pass:  true,
+				Raw:   `{"totalScore":2,"maximumScore":2}`,
+			},
+			want: apilifecycle.EvaluationStatusItem{
+				Value:   "2",
+				Status:  apicommon.StateSucceeded,
+				Message: "Analysis 'my-evaluation' passed",
+			},
+		},
+		{
+			name: "analysis passed with warning",
+			status: metricsapi.AnalysisStatus{
+				State:   "Completed",
+				Warning: true,
+				Raw:     `{"totalScore":1.5,"maximumScore":2}`,
+			},
+			want: apilifecycle.EvaluationStatusItem{
+				Value:   "1.5",
+				Status:  apicommon.StateSucceeded,
+				Message: "Analysis 'my-evaluation' passed with warning",
+			},
+		},
+		{
+			name: "analysis failed",
+			status: metricsapi.AnalysisStatus{
+				State: "Completed",
+				Raw:   "invalid",
+			},
+			want: apilifecycle.EvaluationStatusItem{
+				Status:  apicommon.StateFailed,
+				Message: "Analysis 'my-evaluation' did not pass",
+			},
+			analysisDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluation := &apilifecycle.KeptnEvaluation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-evaluation",
+					Namespace: namespace,
+				},
+			}
+			analysis := &metricsapi.Analysis{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      evaluation.Name,
+					Namespace: namespace,
+				},
+				Status: tt.status,
+			}
+
+			reconciler, fakeClient := setupReconcilerAndClient(t, evaluation, analysis)
+
+			got := reconciler.getAnalysisResult(context.TODO(), evaluation, apilifecycle.AnalysisDefinitionReference{Name: "my-analysis-definition"})
+			require.Equal(t, tt.want, got)
+
+			err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: analysis.Name}, analysis)
+			require.Equal(t, tt.analysisDeleted, errors.IsNotFound(err))
+		})
+	}
+}
+
+func Test_getAnalysisTimeframe(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(t, map[string]interface{}{
+		"from": "2024-01-01T11:50:00Z",
+		"to":   "2024-01-01T12:00:00Z",
+	}, getAnalysisTimeframe(now.Add(-10*time.Minute), now))
+
+	require.Equal(t, map[string]interface{}{
+		"recent": "5m",
+	}, getAnalysisTimeframe(time.Time{}, now))
+
+	require.Equal(t, map[string]interface{}{
+		"recent": "5m",
+	}, getAnalysisTimeframe(now, now))
+}
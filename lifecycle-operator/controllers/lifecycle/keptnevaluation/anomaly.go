@@ -0,0 +1,45 @@
+package keptnevaluation
+
+import (
+	"context"
+	"fmt"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/providers/keptnmetric"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// anomalousConditionType is the type of the condition the metrics-operator sets on a KeptnMetric
+// if its latest value has been detected as anomaly
+const anomalousConditionType = "Anomalous"
+
+// isAnomalous returns whether the latest value of the KeptnMetric referenced by the objective has been
+// flagged as anomaly. As long as the anomaly detection has not collected enough history, ErrAnomalyDetectionPending
+// is returned, so that the objective is neither met nor failed until the metric can be checked.
+func isAnomalous(ctx context.Context, provider *keptnmetric.KeptnMetricProvider, objective apilifecycle.Objective, namespace string) (bool, error) {
+	if objective.TaskOutputRef != nil || objective.ServiceLevelObjectiveRef != nil {
+		return false, controllererrors.ErrNotAnomalousRequiresMetric
+	}
+	metric, err := provider.GetKeptnMetric(ctx, objective, namespace)
+	if err != nil {
+		return false, err
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(metric.UnstructuredContent(), "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != anomalousConditionType {
+			continue
+		}
+		switch condition["status"] {
+		case "True":
+			return true, nil
+		case "False":
+			return false, nil
+		default:
+			return false, fmt.Errorf("%w: %s: %v", controllererrors.ErrAnomalyDetectionPending, objective.KeptnMetricRef.Name, condition["message"])
+		}
+	}
+	return false, fmt.Errorf("%w: %s", controllererrors.ErrNoAnomalyDetection, objective.KeptnMetricRef.Name)
+}
@@ -10,12 +10,13 @@ import (
 
 func checkValue(objective apilifecycle.Objective, item *apilifecycle.EvaluationStatusItem) (bool, error) {
 
-	if len(item.Value) == 0 || len(objective.EvaluationTarget) == 0 {
+	evaluationTarget := objective.GetValueTarget()
+	if len(item.Value) == 0 || len(evaluationTarget) == 0 {
 		return false, fmt.Errorf("no values")
 	}
 
-	eval := objective.EvaluationTarget[1:]
-	sign := objective.EvaluationTarget[:1]
+	eval := evaluationTarget[1:]
+	sign := evaluationTarget[:1]
 
 	resultValue, err := strconv.ParseFloat(item.Value, 64)
 	if err != nil || math.IsNaN(resultValue) {
@@ -37,3 +38,109 @@ func checkValue(objective apilifecycle.Objective, item *apilifecycle.EvaluationS
 		return false, fmt.Errorf("invalid operator")
 	}
 }
+
+// valueResolver resolves the value of a RelativeValue that refers to another KeptnMetric or to the previous version
+type valueResolver func(value apilifecycle.RelativeValue) (float64, error)
+
+// checkTarget checks if the given value meets all operators of the AllOf list
+// and at least one operator of the AnyOf list of the target
+func checkTarget(target apilifecycle.Target, value float64, resolve valueResolver) (bool, error) {
+	if len(target.AllOf) == 0 && len(target.AnyOf) == 0 {
+		return false, fmt.Errorf("no operators")
+	}
+	if math.IsNaN(value) {
+		return false, nil
+	}
+
+	for _, operator := range target.AllOf {
+		fulfilled, err := checkTargetOperator(operator, value, resolve)
+		if err != nil || !fulfilled {
+			return false, err
+		}
+	}
+
+	if len(target.AnyOf) == 0 {
+		return true, nil
+	}
+	for _, operator := range target.AnyOf {
+		fulfilled, err := checkTargetOperator(operator, value, resolve)
+		if err != nil {
+			return false, err
+		}
+		if fulfilled {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkTargetOperator checks the operator against the given value or, if the operator is relative to another value,
+// against the deviation of the given value from the other value in percent
+func checkTargetOperator(operator apilifecycle.TargetOperator, value float64, resolve valueResolver) (bool, error) {
+	if operator.RelativeTo == nil {
+		return checkOperator(operator.Operator, value)
+	}
+
+	deviation, err := getDeviation(*operator.RelativeTo, value, resolve)
+	if err != nil {
+		return false, err
+	}
+	return checkOperator(operator.Operator, deviation)
+}
+
+func checkOperator(operator apilifecycle.Operator, value float64) (bool, error) {
+	if countOperators(operator) != 1 {
+		return false, fmt.Errorf("invalid operator")
+	}
+
+	switch {
+	case operator.LessThanOrEqual != nil:
+		return value <= operator.LessThanOrEqual.FixedValue.AsApproximateFloat64(), nil
+	case operator.LessThan != nil:
+		return value < operator.LessThan.FixedValue.AsApproximateFloat64(), nil
+	case operator.GreaterThan != nil:
+		return value > operator.GreaterThan.FixedValue.AsApproximateFloat64(), nil
+	case operator.GreaterThanOrEqual != nil:
+		return value >= operator.GreaterThanOrEqual.FixedValue.AsApproximateFloat64(), nil
+	case operator.EqualTo != nil:
+		return value == operator.EqualTo.FixedValue.AsApproximateFloat64(), nil
+	case operator.InRange != nil:
+		return value >= operator.InRange.LowBound.AsApproximateFloat64() && value <= operator.InRange.HighBound.AsApproximateFloat64(), nil
+	default:
+		return value < operator.NotInRange.LowBound.AsApproximateFloat64() || value > operator.NotInRange.HighBound.AsApproximateFloat64(), nil
+	}
+}
+
+// getDeviation returns the deviation of the given value from the referenced value in percent
+func getDeviation(relativeTo apilifecycle.RelativeValue, value float64, resolve valueResolver) (float64, error) {
+	if (relativeTo.KeptnMetricRef != nil) == relativeTo.PreviousVersion {
+		return 0, fmt.Errorf("exactly one of keptnMetricRef and previousVersion must be set")
+	}
+
+	reference, err := resolve(relativeTo)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(reference) || reference == 0 {
+		return 0, fmt.Errorf("deviation from %v cannot be computed", reference)
+	}
+	return (value - reference) / math.Abs(reference) * 100, nil
+}
+
+func countOperators(operator apilifecycle.Operator) int {
+	count := 0
+	for _, isSet := range []bool{
+		operator.LessThanOrEqual != nil,
+		operator.LessThan != nil,
+		operator.GreaterThan != nil,
+		operator.GreaterThanOrEqual != nil,
+		operator.EqualTo != nil,
+		operator.InRange != nil,
+		operator.NotInRange != nil,
+	} {
+		if isSet {
+			count++
+		}
+	}
+	return count
+}
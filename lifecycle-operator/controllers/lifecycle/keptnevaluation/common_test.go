@@ -1,10 +1,12 @@
 package keptnevaluation
 
 import (
+	"fmt"
 	"testing"
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestCheckValue(t *testing.T) {
@@ -222,3 +224,158 @@ func TestCheckValue(t *testing.T) {
 
 	}
 }
+
+func TestCheckTarget(t *testing.T) {
+	fixed := func(value string) *apilifecycle.OperatorValue {
+		return &apilifecycle.OperatorValue{FixedValue: resource.MustParse(value)}
+	}
+	previousVersion := &apilifecycle.RelativeValue{PreviousVersion: true}
+	resolve := func(value apilifecycle.RelativeValue) (float64, error) {
+		if value.PreviousVersion {
+			return 200, nil
+		}
+		if value.KeptnMetricRef.Name == "zero" {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("unexpected reference")
+	}
+
+	tests := []struct {
+		name   string
+		target apilifecycle.Target
+		value  float64
+		result bool
+		err    bool
+	}{
+		{
+			name:   "no operators",
+			target: apilifecycle.Target{},
+			value:  1,
+			result: false,
+			err:    true,
+		},
+		{
+			name: "multiple operators in one item",
+			target: apilifecycle.Target{
+				AllOf: []apilifecycle.TargetOperator{{Operator: apilifecycle.Operator{LessThan: fixed("5"), GreaterThan: fixed("1")}}},
+			},
+			value:  3,
+			result: false,
+			err:    true,
+		},
+		{
+			name: "empty relative value",
+			target: apilifecycle.Target{
+				AllOf: []apilifecycle.TargetOperator{{Operator: apilifecycle.Operator{LessThan: fixed("5")}, RelativeTo: &apilifecycle.RelativeValue{}}},
+			},
+			value:  3,
+			result: false,
+			err:    true,
+		},
+		{
+			name: "in range",
+			target: apilifecycle.Target{
+				AllOf: []apilifecycle.TargetOperator{{Operator: apilifecycle.Operator{InRange: &apilifecycle.RangeValue{LowBound: resource.MustParse("2"), HighBound: resource.MustParse("5")}}}},
+			},
+			value:  5,
+			result: true,
+		},
+		{
+			name: "not in range",
+			target: apilifecycle.Target{
+				AllOf: []apilifecycle.TargetOperator{{Operator: apilifecycle.Operator{NotInRange: &apilifecycle.RangeValue{LowBound: resource.MustParse("2"), HighBound: resource.MustParse("5")}}}},
+			},
+			value:  5,
+			result: false,
+		},
+		{
+			name: "all of fulfilled",
+			target: apilifecycle.Target{
+				AllOf: []apilifecycle.TargetOperator{
+					{Operator: apilifecycle.Operator{LessThan: fixed("300")}},
+					{Operator: apilifecycle.Operator{LessThanOrEqual: fixed("10")}, RelativeTo: previousVersion},
+				},
+			},
+			value:  220,
+			result: true,
+		},
+		{
+			name: "all of not fulfilled",
+			target: apilifecycle.Target{
+				AllOf: []apilifecycle.TargetOperator{
+					{Operator: apilifecycle.Operator{LessThan: fixed("300")}},
+					{Operator: apilifecycle.Operator{LessThanOrEqual: fixed("10")}, RelativeTo: previousVersion},
+				},
+			},
+			value:  221,
+			result: false,
+		},
+		{
+			name: "deviation from previous version in range",
+			target: apilifecycle.Target{
+				AllOf: []apilifecycle.TargetOperator{
+					{Operator: apilifecycle.Operator{InRange: &apilifecycle.RangeValue{LowBound: resource.MustParse("-10"), HighBound: resource.MustParse("10")}}, RelativeTo: previousVersion},
+				},
+			},
+			value:  180,
+			result: true,
+		},
+		{
+			name: "any of fulfilled",
+			target: apilifecycle.Target{
+				AnyOf: []apilifecycle.TargetOperator{
+					{Operator: apilifecycle.Operator{EqualTo: fixed("0")}},
+					{Operator: apilifecycle.Operator{GreaterThan: fixed("100")}},
+				},
+			},
+			value:  101,
+			result: true,
+		},
+		{
+			name: "any of not fulfilled",
+			target: apilifecycle.Target{
+				AllOf: []apilifecycle.TargetOperator{{Operator: apilifecycle.Operator{GreaterThan: fixed("1")}}},
+				AnyOf: []apilifecycle.TargetOperator{
+					{Operator: apilifecycle.Operator{EqualTo: fixed("0")}},
+					{Operator: apilifecycle.Operator{GreaterThan: fixed("100")}},
+				},
+			},
+			value:  50,
+			result: false,
+		},
+		{
+			name: "deviation from zero",
+			target: apilifecycle.Target{
+				AllOf: []apilifecycle.TargetOperator{
+					{Operator: apilifecycle.Operator{LessThan: fixed("0")}, RelativeTo: &apilifecycle.RelativeValue{KeptnMetricRef: &apilifecycle.KeptnMetricReference{Name: "zero"}}},
+				},
+			},
+			value:  1,
+			result: false,
+			err:    true,
+		},
+		{
+			name: "resolution error",
+			target: apilifecycle.Target{
+				AllOf: []apilifecycle.TargetOperator{
+					{Operator: apilifecycle.Operator{LessThan: fixed("0")}, RelativeTo: &apilifecycle.RelativeValue{KeptnMetricRef: &apilifecycle.KeptnMetricReference{Name: "metric"}}},
+				},
+			},
+			value:  1,
+			result: false,
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, e := checkTarget(tt.target, tt.value, resolve)
+			require.Equal(t, tt.result, r)
+			if tt.err {
+				require.NotNil(t, e)
+			} else {
+				require.Nil(t, e)
+			}
+		})
+	}
+}
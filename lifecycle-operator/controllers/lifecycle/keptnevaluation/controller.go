@@ -18,6 +18,7 @@ package keptnevaluation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -27,8 +28,9 @@ import (
 	controllercommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/providers/keptnmetric"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"go.opentelemetry.io/otel/metric"
-	"k8s.io/apimachinery/pkg/api/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -51,6 +53,8 @@ type KeptnEvaluationReconciler struct {
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnevaluations/finalizers,verbs=update
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnevaluationdefinitions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=metrics.keptn.sh,resources=keptnmetrics,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=analyses,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=servicelevelobjectives,verbs=get;list;watch
 
 // role
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
@@ -66,7 +70,7 @@ func (r *KeptnEvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	evaluation := &apilifecycle.KeptnEvaluation{}
 
 	if err := r.Client.Get(ctx, req.NamespacedName, evaluation); err != nil {
-		if errors.IsNotFound(err) {
+		if k8serrors.IsNotFound(err) {
 			// taking down all associated K8s resources is handled by K8s
 			r.Log.Info("KeptnEvaluation resource not found. Ignoring since object must be deleted", "requestInfo", requestInfo)
 			return ctrl.Result{}, nil
@@ -85,7 +89,7 @@ func (r *KeptnEvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if !evaluation.Status.OverallStatus.IsSucceeded() {
 		evaluationDefinition, err := controllercommon.GetEvaluationDefinition(r.Client, r.Log, ctx, evaluation.Spec.EvaluationDefinition, req.NamespacedName.Namespace)
 		if err != nil {
-			if errors.IsNotFound(err) {
+			if k8serrors.IsNotFound(err) {
 				r.Log.Info("KeptnEvaluation not found", "requestInfo", requestInfo, "evaluationDefinition", evaluation.Spec.EvaluationDefinition)
 				return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
 			}
@@ -135,6 +139,9 @@ func (r *KeptnEvaluationReconciler) handleEvaluationExceededRetries(ctx context.
 
 func (r *KeptnEvaluationReconciler) performEvaluation(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, evaluationDefinition *apilifecycle.KeptnEvaluationDefinition) *apilifecycle.KeptnEvaluation {
 	statusSummary := apicommon.StatusSummary{Total: len(evaluationDefinition.Spec.Objectives)}
+	if evaluationDefinition.Spec.AnalysisDefinition != nil {
+		statusSummary.Total++
+	}
 	newStatus := make(map[string]apilifecycle.EvaluationStatusItem)
 
 	if evaluation.Status.EvaluationStatus == nil {
@@ -150,6 +157,12 @@ func (r *KeptnEvaluationReconciler) performEvaluation(ctx context.Context, evalu
 		newStatus, statusSummary = r.evaluateObjective(ctx, evaluation, statusSummary, newStatus, query, provider)
 	}
 
+	if evaluationDefinition.Spec.AnalysisDefinition != nil {
+		newStatus, statusSummary = r.evaluateAnalysis(ctx, evaluation, *evaluationDefinition.Spec.AnalysisDefinition, statusSummary, newStatus)
+	}
+
+	// waiting for a running Analysis counts as a retry as well, so that the evaluation fails
+	// if the Analysis does not complete before the retries are exhausted
 	evaluation.Status.RetryCount++
 	evaluation.Status.EvaluationStatus = newStatus
 	if apicommon.GetOverallState(statusSummary) == apicommon.StateSucceeded {
@@ -162,12 +175,12 @@ func (r *KeptnEvaluationReconciler) performEvaluation(ctx context.Context, evalu
 }
 
 func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, statusSummary apicommon.StatusSummary, newStatus map[string]apilifecycle.EvaluationStatusItem, objective apilifecycle.Objective, provider *keptnmetric.KeptnMetricProvider) (map[string]apilifecycle.EvaluationStatusItem, apicommon.StatusSummary) {
-	if _, ok := evaluation.Status.EvaluationStatus[objective.KeptnMetricRef.Name]; !ok {
+	if _, ok := evaluation.Status.EvaluationStatus[objective.GetKey()]; !ok {
 		evaluation.AddEvaluationStatus(objective)
 	}
-	if evaluation.Status.EvaluationStatus[objective.KeptnMetricRef.Name].Status.IsSucceeded() {
+	if evaluation.Status.EvaluationStatus[objective.GetKey()].Status.IsSucceeded() {
 		statusSummary = apicommon.UpdateStatusSummary(apicommon.StateSucceeded, statusSummary)
-		newStatus[objective.KeptnMetricRef.Name] = evaluation.Status.EvaluationStatus[objective.KeptnMetricRef.Name]
+		newStatus[objective.GetKey()] = evaluation.Status.EvaluationStatus[objective.GetKey()]
 		return newStatus, statusSummary
 	}
 	// resolving the SLI value
@@ -175,7 +188,7 @@ func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evalu
 		Status: apicommon.StateFailed,
 	}
 
-	value, _, err := provider.FetchData(ctx, objective, evaluation.Namespace)
+	value, err := r.fetchObjectiveValue(ctx, evaluation, objective, provider)
 	if err != nil {
 		statusItem.Message = err.Error()
 		r.Log.Error(err, "Could not fetch data")
@@ -184,8 +197,11 @@ func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evalu
 
 	statusItem.Value = value
 	// Evaluating SLO
-	check, err := checkValue(objective, statusItem)
+	check, err := r.checkObjective(ctx, evaluation, objective, statusItem, provider)
 	if err != nil {
+		if errors.Is(err, controllererrors.ErrAnomalyDetectionPending) {
+			statusItem.Status = apicommon.StatePending
+		}
 		statusItem.Message = err.Error()
 		r.Log.Error(err, "Could not check objective result")
 		return updateStatusSummary(statusSummary, statusItem, newStatus, objective)
@@ -193,16 +209,31 @@ func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evalu
 	// if there is no error, we set the message depending on if the value passed the objective, or not
 	if check {
 		statusItem.Status = apicommon.StateSucceeded
-		statusItem.Message = fmt.Sprintf("value '%s' met objective '%s'", value, objective.EvaluationTarget)
+		statusItem.Message = fmt.Sprintf("value '%s' met objective '%s'", value, objective.GetEvaluationTarget())
 	} else {
-		statusItem.Message = fmt.Sprintf("value '%s' did not meet objective '%s'", value, objective.EvaluationTarget)
+		statusItem.Message = fmt.Sprintf("value '%s' did not meet objective '%s'", value, objective.GetEvaluationTarget())
 	}
 	return updateStatusSummary(statusSummary, statusItem, newStatus, objective)
 }
 
+func (r *KeptnEvaluationReconciler) fetchObjectiveValue(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, objective apilifecycle.Objective, provider *keptnmetric.KeptnMetricProvider) (string, error) {
+	if objective.ServiceLevelObjectiveRef != nil {
+		return r.getErrorBudgetRemaining(ctx, evaluation, *objective.ServiceLevelObjectiveRef)
+	}
+	if objective.TaskOutputRef == nil {
+		value, _, err := provider.FetchData(ctx, objective, evaluation.Namespace)
+		return value, err
+	}
+	value, ok := evaluation.Spec.Context.Outputs[objective.TaskOutputRef.TaskDefinition][objective.TaskOutputRef.Key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s of task %s", controllererrors.ErrTaskOutputNotFound, objective.TaskOutputRef.Key, objective.TaskOutputRef.TaskDefinition)
+	}
+	return value, nil
+}
+
 func updateStatusSummary(statusSummary apicommon.StatusSummary, statusItem *apilifecycle.EvaluationStatusItem, newStatus map[string]apilifecycle.EvaluationStatusItem, objective apilifecycle.Objective) (map[string]apilifecycle.EvaluationStatusItem, apicommon.StatusSummary) {
 	statusSummary = apicommon.UpdateStatusSummary(statusItem.Status, statusSummary)
-	newStatus[objective.KeptnMetricRef.Name] = *statusItem
+	newStatus[objective.GetKey()] = *statusItem
 	return newStatus, statusSummary
 }
 
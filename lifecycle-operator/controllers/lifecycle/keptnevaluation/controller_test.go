@@ -4,6 +4,7 @@ package keptnevaluation
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
@@ -11,9 +12,11 @@ import (
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	metricsapi "github.com/keptn/lifecycle-toolkit/lifecycle-operator/test/api/metrics/v1"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/sdk/metric"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -245,6 +248,537 @@ func TestKeptnEvaluationReconciler_Reconcile_SucceedEvaluation_withDefinitionInD
 	require.Equal(t, "value '10' met objective '<11'", updatedEvaluation.Status.EvaluationStatus[metric.Name].Message)
 }
 
+func TestKeptnEvaluationReconciler_Reconcile_SucceedEvaluation_withTarget(t *testing.T) {
+
+	const namespace = "my-namespace"
+	metric := &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-metric",
+			Namespace: namespace,
+		},
+		Status: metricsapi.KeptnMetricStatus{
+			Value:    "105",
+			RawValue: []byte("105"),
+		},
+	}
+
+	thresholdMetric := &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-threshold",
+			Namespace: namespace,
+		},
+		Status: metricsapi.KeptnMetricStatus{
+			Value:    "300",
+			RawValue: []byte("300"),
+		},
+	}
+
+	evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-definition",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationDefinitionSpec{
+			Objectives: []apilifecycle.Objective{
+				{
+					KeptnMetricRef: apilifecycle.KeptnMetricReference{
+						Name:      metric.Name,
+						Namespace: namespace,
+					},
+					Target: &apilifecycle.Target{
+						AllOf: []apilifecycle.TargetOperator{
+							{
+								Operator: apilifecycle.Operator{
+									LessThan: &apilifecycle.OperatorValue{FixedValue: resource.MustParse("0")},
+								},
+								RelativeTo: &apilifecycle.RelativeValue{
+									KeptnMetricRef: &apilifecycle.KeptnMetricReference{Name: thresholdMetric.Name},
+								},
+							},
+							{
+								Operator: apilifecycle.Operator{
+									LessThanOrEqual: &apilifecycle.OperatorValue{FixedValue: resource.MustParse("10")},
+								},
+								RelativeTo: &apilifecycle.RelativeValue{PreviousVersion: true},
+							},
+						},
+					},
+				},
+			},
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	previousEvaluation := &apilifecycle.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-previous-evaluation",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationSpec{
+			AppName:              "my-app",
+			AppVersion:           "1.0.0",
+			EvaluationDefinition: evaluationDefinition.Name,
+			Type:                 apicommon.PostDeploymentCheckType,
+		},
+		Status: apilifecycle.KeptnEvaluationStatus{
+			EvaluationStatus: map[string]apilifecycle.EvaluationStatusItem{
+				metric.Name: {
+					Value:  "100",
+					Status: apicommon.StateSucceeded,
+				},
+			},
+			OverallStatus: apicommon.StateSucceeded,
+		},
+	}
+
+	evaluation := &apilifecycle.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationSpec{
+			AppName:              "my-app",
+			AppVersion:           "2.0.0",
+			PreviousVersion:      "1.0.0",
+			EvaluationDefinition: evaluationDefinition.Name,
+			Type:                 apicommon.PostDeploymentCheckType,
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	reconciler, fakeClient := setupReconcilerAndClient(t, metric, thresholdMetric, evaluationDefinition, previousEvaluation, evaluation)
+
+	request := controllerruntime.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+			Name:      evaluation.Name,
+		},
+	}
+
+	reconcile, err := reconciler.Reconcile(context.TODO(), request)
+
+	require.Nil(t, err)
+	require.False(t, reconcile.Requeue)
+
+	updatedEvaluation := &apilifecycle.KeptnEvaluation{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      evaluation.Name,
+	}, updatedEvaluation)
+
+	require.Nil(t, err)
+
+	require.Equal(t, apicommon.StateSucceeded, updatedEvaluation.Status.EvaluationStatus[metric.Name].Status)
+	require.Equal(t, "value '105' met objective '<0% from metric(my-threshold) and <=10% from previousVersion'", updatedEvaluation.Status.EvaluationStatus[metric.Name].Message)
+}
+
+func TestKeptnEvaluationReconciler_Reconcile_FailEvaluation_withTargetWithoutPreviousVersion(t *testing.T) {
+
+	const namespace = "my-namespace"
+	metric := &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-metric",
+			Namespace: namespace,
+		},
+		Status: metricsapi.KeptnMetricStatus{
+			Value:    "105",
+			RawValue: []byte("105"),
+		},
+	}
+
+	evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-definition",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationDefinitionSpec{
+			Objectives: []apilifecycle.Objective{
+				{
+					KeptnMetricRef: apilifecycle.KeptnMetricReference{
+						Name:      metric.Name,
+						Namespace: namespace,
+					},
+					Target: &apilifecycle.Target{
+						AllOf: []apilifecycle.TargetOperator{
+							{
+								Operator: apilifecycle.Operator{
+									LessThanOrEqual: &apilifecycle.OperatorValue{FixedValue: resource.MustParse("0")},
+								},
+								RelativeTo: &apilifecycle.RelativeValue{PreviousVersion: true},
+							},
+						},
+					},
+				},
+			},
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	evaluation := &apilifecycle.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationSpec{
+			AppName:              "my-app",
+			AppVersion:           "2.0.0",
+			PreviousVersion:      "1.0.0",
+			EvaluationDefinition: evaluationDefinition.Name,
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	reconciler, fakeClient := setupReconcilerAndClient(t, metric, evaluationDefinition, evaluation)
+
+	request := controllerruntime.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+			Name:      evaluation.Name,
+		},
+	}
+
+	reconcile, err := reconciler.Reconcile(context.TODO(), request)
+
+	require.Nil(t, err)
+	require.True(t, reconcile.Requeue)
+
+	updatedEvaluation := &apilifecycle.KeptnEvaluation{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      evaluation.Name,
+	}, updatedEvaluation)
+
+	require.Nil(t, err)
+
+	require.Equal(t, apicommon.StateFailed, updatedEvaluation.Status.EvaluationStatus[metric.Name].Status)
+	require.Contains(t, updatedEvaluation.Status.EvaluationStatus[metric.Name].Message, controllererrors.ErrNoPreviousVersionValue.Error())
+}
+
+func TestKeptnEvaluationReconciler_Reconcile_withTaskOutputRef(t *testing.T) {
+
+	const namespace = "my-namespace"
+
+	evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-definition",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationDefinitionSpec{
+			Objectives: []apilifecycle.Objective{
+				{
+					TaskOutputRef: &apilifecycle.TaskOutputReference{
+						TaskDefinition: "load-test",
+						Key:            "errorRate",
+					},
+					EvaluationTarget: "<1",
+				},
+				{
+					TaskOutputRef: &apilifecycle.TaskOutputReference{
+						TaskDefinition: "load-test",
+						Key:            "latency",
+					},
+					EvaluationTarget: "<100",
+				},
+			},
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	evaluation := &apilifecycle.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationSpec{
+			EvaluationDefinition: evaluationDefinition.Name,
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+			Context: apilifecycle.TaskContext{
+				Outputs: map[string]map[string]string{
+					"load-test": {
+						"errorRate": "0.5",
+					},
+				},
+			},
+		},
+	}
+
+	reconciler, fakeClient := setupReconcilerAndClient(t, evaluationDefinition, evaluation)
+
+	request := controllerruntime.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+			Name:      evaluation.Name,
+		},
+	}
+
+	reconcile, err := reconciler.Reconcile(context.TODO(), request)
+
+	require.Nil(t, err)
+	require.True(t, reconcile.Requeue)
+
+	updatedEvaluation := &apilifecycle.KeptnEvaluation{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      evaluation.Name,
+	}, updatedEvaluation)
+
+	require.Nil(t, err)
+
+	require.Equal(t, apicommon.StateSucceeded, updatedEvaluation.Status.EvaluationStatus["taskOutput/load-test/errorRate"].Status)
+	require.Equal(t, "value '0.5' met objective '<1'", updatedEvaluation.Status.EvaluationStatus["taskOutput/load-test/errorRate"].Message)
+	require.Equal(t, apicommon.StateFailed, updatedEvaluation.Status.EvaluationStatus["taskOutput/load-test/latency"].Status)
+	require.Contains(t, updatedEvaluation.Status.EvaluationStatus["taskOutput/load-test/latency"].Message, controllererrors.ErrTaskOutputNotFound.Error())
+}
+
+func TestKeptnEvaluationReconciler_Reconcile_withServiceLevelObjectiveRef(t *testing.T) {
+
+	const namespace = "my-namespace"
+
+	evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-definition",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationDefinitionSpec{
+			Objectives: []apilifecycle.Objective{
+				{
+					ServiceLevelObjectiveRef: &apilifecycle.ServiceLevelObjectiveReference{
+						Name: "availability",
+					},
+				},
+				{
+					ServiceLevelObjectiveRef: &apilifecycle.ServiceLevelObjectiveReference{
+						Name: "latency",
+					},
+				},
+				{
+					ServiceLevelObjectiveRef: &apilifecycle.ServiceLevelObjectiveReference{
+						Name: "errors",
+					},
+					EvaluationTarget: ">10",
+				},
+				{
+					ServiceLevelObjectiveRef: &apilifecycle.ServiceLevelObjectiveReference{
+						Name: "not-computed",
+					},
+				},
+				{
+					ServiceLevelObjectiveRef: &apilifecycle.ServiceLevelObjectiveReference{
+						Name: "query-failed",
+					},
+				},
+				{
+					ServiceLevelObjectiveRef: &apilifecycle.ServiceLevelObjectiveReference{
+						Name: "outdated",
+					},
+				},
+			},
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	evaluation := &apilifecycle.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationSpec{
+			EvaluationDefinition: evaluationDefinition.Name,
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	newSLO := func(name, errorBudgetRemaining string) *metricsapi.ServiceLevelObjective {
+		return &metricsapi.ServiceLevelObjective{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Status: metricsapi.ServiceLevelObjectiveStatus{
+				ErrorBudgetRemaining: errorBudgetRemaining,
+				LastUpdated:          metav1.NewTime(time.Now().UTC().Add(-time.Minute)),
+			},
+		}
+	}
+	queryFailedSLO := newSLO("query-failed", "50")
+	queryFailedSLO.Status.ErrMsg = "provider unavailable"
+	outdatedSLO := newSLO("outdated", "50")
+	outdatedSLO.Spec.FetchIntervalSeconds = 10
+	outdatedSLO.Status.LastUpdated = metav1.NewTime(time.Now().UTC().Add(-time.Minute))
+
+	reconciler, fakeClient := setupReconcilerAndClient(
+		t,
+		evaluationDefinition,
+		evaluation,
+		newSLO("availability", "42.5"),
+		newSLO("latency", "-3"),
+		newSLO("errors", "5"),
+		newSLO("not-computed", ""),
+		queryFailedSLO,
+		outdatedSLO,
+	)
+
+	request := controllerruntime.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+			Name:      evaluation.Name,
+		},
+	}
+
+	reconcile, err := reconciler.Reconcile(context.TODO(), request)
+
+	require.Nil(t, err)
+	require.True(t, reconcile.Requeue)
+
+	updatedEvaluation := &apilifecycle.KeptnEvaluation{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      evaluation.Name,
+	}, updatedEvaluation)
+
+	require.Nil(t, err)
+
+	require.Equal(t, apicommon.StateSucceeded, updatedEvaluation.Status.EvaluationStatus["slo/availability"].Status)
+	require.Equal(t, "value '42.5' met objective '>0'", updatedEvaluation.Status.EvaluationStatus["slo/availability"].Message)
+	require.Equal(t, apicommon.StateFailed, updatedEvaluation.Status.EvaluationStatus["slo/latency"].Status)
+	require.Equal(t, "value '-3' did not meet objective '>0'", updatedEvaluation.Status.EvaluationStatus["slo/latency"].Message)
+	require.Equal(t, apicommon.StateFailed, updatedEvaluation.Status.EvaluationStatus["slo/errors"].Status)
+	require.Equal(t, "value '5' did not meet objective '>10'", updatedEvaluation.Status.EvaluationStatus["slo/errors"].Message)
+	require.Equal(t, apicommon.StateFailed, updatedEvaluation.Status.EvaluationStatus["slo/not-computed"].Status)
+	require.Contains(t, updatedEvaluation.Status.EvaluationStatus["slo/not-computed"].Message, controllererrors.ErrNoErrorBudget.Error())
+	require.Equal(t, apicommon.StateFailed, updatedEvaluation.Status.EvaluationStatus["slo/query-failed"].Status)
+	require.Contains(t, updatedEvaluation.Status.EvaluationStatus["slo/query-failed"].Message, "provider unavailable")
+	require.Equal(t, apicommon.StateFailed, updatedEvaluation.Status.EvaluationStatus["slo/outdated"].Status)
+	require.Contains(t, updatedEvaluation.Status.EvaluationStatus["slo/outdated"].Message, controllererrors.ErrErrorBudgetOutdated.Error())
+}
+
+func TestKeptnEvaluationReconciler_Reconcile_withNotAnomalous(t *testing.T) {
+
+	const namespace = "my-namespace"
+
+	evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-definition",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationDefinitionSpec{
+			Objectives: []apilifecycle.Objective{
+				{
+					KeptnMetricRef: apilifecycle.KeptnMetricReference{Name: "normal", Namespace: namespace},
+					NotAnomalous:   true,
+				},
+				{
+					KeptnMetricRef:   apilifecycle.KeptnMetricReference{Name: "anomalous", Namespace: namespace},
+					EvaluationTarget: "<100",
+					NotAnomalous:     true,
+				},
+				{
+					KeptnMetricRef:   apilifecycle.KeptnMetricReference{Name: "learning", Namespace: namespace},
+					EvaluationTarget: "<100",
+					NotAnomalous:     true,
+				},
+				{
+					KeptnMetricRef: apilifecycle.KeptnMetricReference{Name: "no-detection", Namespace: namespace},
+					NotAnomalous:   true,
+				},
+			},
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	evaluation := &apilifecycle.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: namespace,
+		},
+		Spec: apilifecycle.KeptnEvaluationSpec{
+			EvaluationDefinition: evaluationDefinition.Name,
+			FailureConditions: apilifecycle.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	newMetric := func(name string, conditions ...metav1.Condition) *metricsapi.KeptnMetric {
+		return &metricsapi.KeptnMetric{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Status: metricsapi.KeptnMetricStatus{
+				Value:      "10",
+				RawValue:   []byte("10"),
+				Conditions: conditions,
+			},
+		}
+	}
+	newCondition := func(status metav1.ConditionStatus) metav1.Condition {
+		return metav1.Condition{
+			Type:               "Anomalous",
+			Status:             status,
+			Reason:             "Test",
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+
+	reconciler, fakeClient := setupReconcilerAndClient(
+		t,
+		evaluationDefinition,
+		evaluation,
+		newMetric("normal", newCondition(metav1.ConditionFalse)),
+		newMetric("anomalous", newCondition(metav1.ConditionTrue)),
+		newMetric("learning", newCondition(metav1.ConditionUnknown)),
+		newMetric("no-detection"),
+	)
+
+	request := controllerruntime.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+			Name:      evaluation.Name,
+		},
+	}
+
+	reconcile, err := reconciler.Reconcile(context.TODO(), request)
+
+	require.Nil(t, err)
+	require.True(t, reconcile.Requeue)
+
+	updatedEvaluation := &apilifecycle.KeptnEvaluation{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      evaluation.Name,
+	}, updatedEvaluation)
+
+	require.Nil(t, err)
+
+	require.Equal(t, apicommon.StateSucceeded, updatedEvaluation.Status.EvaluationStatus["normal"].Status)
+	require.Equal(t, "value '10' met objective 'not anomalous'", updatedEvaluation.Status.EvaluationStatus["normal"].Message)
+	require.Equal(t, apicommon.StateFailed, updatedEvaluation.Status.EvaluationStatus["anomalous"].Status)
+	require.Equal(t, "value '10' did not meet objective '<100 and not anomalous'", updatedEvaluation.Status.EvaluationStatus["anomalous"].Message)
+	require.Equal(t, apicommon.StatePending, updatedEvaluation.Status.EvaluationStatus["learning"].Status)
+	require.Contains(t, updatedEvaluation.Status.EvaluationStatus["learning"].Message, controllererrors.ErrAnomalyDetectionPending.Error())
+	require.Equal(t, apicommon.StateFailed, updatedEvaluation.Status.EvaluationStatus["no-detection"].Status)
+	require.Contains(t, updatedEvaluation.Status.EvaluationStatus["no-detection"].Message, controllererrors.ErrNoAnomalyDetection.Error())
+}
+
 func setupReconcilerAndClient(t *testing.T, objects ...client.Object) (*KeptnEvaluationReconciler, client.Client) {
 	scheme := runtime.NewScheme()
 
@@ -0,0 +1,65 @@
+package keptnevaluation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// defaultSLOFetchInterval is the update frequency of a ServiceLevelObjective which does not specify one
+	defaultSLOFetchInterval = 60 * time.Second
+	// sloMaxMissedUpdates is the number of fetch intervals after which the status of a ServiceLevelObjective is outdated
+	sloMaxMissedUpdates = 3
+)
+
+// getErrorBudgetRemaining returns the percentage of the error budget of the referenced ServiceLevelObjective
+// which has not been consumed yet
+func (r *KeptnEvaluationReconciler) getErrorBudgetRemaining(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, sloRef apilifecycle.ServiceLevelObjectiveReference) (string, error) {
+	namespace := sloRef.Namespace
+	if namespace == "" {
+		namespace = evaluation.Namespace
+	}
+
+	slo := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       "ServiceLevelObjective",
+			"apiVersion": "metrics.keptn.sh/v1",
+		},
+	}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: sloRef.Name, Namespace: namespace}, slo); err != nil {
+		return "", fmt.Errorf("could not retrieve ServiceLevelObjective '%s': %w", sloRef.Name, err)
+	}
+
+	if errMsg, _, _ := unstructured.NestedString(slo.Object, "status", "errMsg"); errMsg != "" {
+		return "", fmt.Errorf("%w: %s: %s", controllererrors.ErrNoErrorBudget, sloRef.Name, errMsg)
+	}
+	value, _, _ := unstructured.NestedString(slo.Object, "status", "errorBudgetRemaining")
+	if value == "" {
+		return "", fmt.Errorf("%w: %s", controllererrors.ErrNoErrorBudget, sloRef.Name)
+	}
+	if isErrorBudgetOutdated(slo, time.Now().UTC()) {
+		return "", fmt.Errorf("%w: %s", controllererrors.ErrErrorBudgetOutdated, sloRef.Name)
+	}
+	return value, nil
+}
+
+// isErrorBudgetOutdated returns true if the status of the ServiceLevelObjective has not been updated
+// within the last few fetch intervals, e.g. because the metrics-operator is not running
+func isErrorBudgetOutdated(slo *unstructured.Unstructured, now time.Time) bool {
+	lastUpdatedStr, _, _ := unstructured.NestedString(slo.Object, "status", "lastUpdated")
+	lastUpdated, err := time.Parse(time.RFC3339, lastUpdatedStr)
+	if err != nil {
+		return true
+	}
+	fetchInterval := defaultSLOFetchInterval
+	if seconds, found, _ := unstructured.NestedInt64(slo.Object, "spec", "fetchIntervalSeconds"); found && seconds > 0 {
+		fetchInterval = time.Duration(seconds) * time.Second
+	}
+	return now.Sub(lastUpdated) > sloMaxMissedUpdates*fetchInterval
+}
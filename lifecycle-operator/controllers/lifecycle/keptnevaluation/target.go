@@ -0,0 +1,105 @@
+package keptnevaluation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/providers/keptnmetric"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkObjective checks the value of the status item against the EvaluationTarget of the objective,
+// or against its compound Target if no EvaluationTarget is set.
+// If the objective requires the KeptnMetric not to be anomalous, this is checked first.
+func (r *KeptnEvaluationReconciler) checkObjective(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, objective apilifecycle.Objective, item *apilifecycle.EvaluationStatusItem, provider *keptnmetric.KeptnMetricProvider) (bool, error) {
+	if objective.NotAnomalous {
+		anomalous, err := isAnomalous(ctx, provider, objective, evaluation.Namespace)
+		if err != nil || anomalous {
+			return false, err
+		}
+		if objective.EvaluationTarget == "" && objective.Target == nil {
+			return true, nil
+		}
+	}
+	if objective.EvaluationTarget != "" || objective.Target == nil {
+		return checkValue(objective, item)
+	}
+
+	value, err := strconv.ParseFloat(item.Value, 64)
+	if err != nil {
+		return false, err
+	}
+
+	resolve := func(relativeTo apilifecycle.RelativeValue) (float64, error) {
+		if relativeTo.KeptnMetricRef != nil {
+			return fetchMetricValue(ctx, provider, *relativeTo.KeptnMetricRef, evaluation.Namespace)
+		}
+		return r.getPreviousVersionValue(ctx, evaluation, objective)
+	}
+
+	return checkTarget(*objective.Target, value, resolve)
+}
+
+func fetchMetricValue(ctx context.Context, provider *keptnmetric.KeptnMetricProvider, metricRef apilifecycle.KeptnMetricReference, namespace string) (float64, error) {
+	value, _, err := provider.FetchData(ctx, apilifecycle.Objective{KeptnMetricRef: metricRef}, namespace)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// getPreviousVersionValue returns the value that has been recorded for the objective by the most recent
+// KeptnEvaluation of the previous version of the KeptnApp or KeptnWorkload
+func (r *KeptnEvaluationReconciler) getPreviousVersionValue(ctx context.Context, evaluation *apilifecycle.KeptnEvaluation, objective apilifecycle.Objective) (float64, error) {
+	if evaluation.Spec.PreviousVersion == "" {
+		return 0, controllererrors.ErrNoPreviousVersion
+	}
+
+	evaluationList := &apilifecycle.KeptnEvaluationList{}
+	if err := r.Client.List(ctx, evaluationList, client.InNamespace(evaluation.Namespace)); err != nil {
+		return 0, err
+	}
+
+	var previousItem *apilifecycle.EvaluationStatusItem
+	var previousEvaluation *apilifecycle.KeptnEvaluation
+	for i := range evaluationList.Items {
+		candidate := &evaluationList.Items[i]
+		if !isEvaluationOfPreviousVersion(evaluation, candidate) {
+			continue
+		}
+		item, ok := candidate.Status.EvaluationStatus[objective.GetKey()]
+		if !ok || item.Value == "" {
+			continue
+		}
+		if previousEvaluation == nil || candidate.CreationTimestamp.After(previousEvaluation.CreationTimestamp.Time) {
+			previousEvaluation = candidate
+			previousItem = &item
+		}
+	}
+	if previousItem == nil {
+		return 0, fmt.Errorf("%w: %s", controllererrors.ErrNoPreviousVersionValue, evaluation.Spec.PreviousVersion)
+	}
+
+	value, err := strconv.ParseFloat(previousItem.Value, 64)
+	if err != nil || math.IsNaN(value) {
+		return 0, fmt.Errorf("%w: %s", controllererrors.ErrNoPreviousVersionValue, evaluation.Spec.PreviousVersion)
+	}
+	return value, nil
+}
+
+func isEvaluationOfPreviousVersion(evaluation *apilifecycle.KeptnEvaluation, candidate *apilifecycle.KeptnEvaluation) bool {
+	if candidate.Spec.AppName != evaluation.Spec.AppName ||
+		candidate.Spec.Workload != evaluation.Spec.Workload ||
+		candidate.Spec.EvaluationDefinition != evaluation.Spec.EvaluationDefinition ||
+		candidate.Spec.Type != evaluation.Spec.Type {
+		return false
+	}
+	if evaluation.Spec.Workload != "" {
+		return candidate.Spec.WorkloadVersion == evaluation.Spec.PreviousVersion
+	}
+	return candidate.Spec.AppVersion == evaluation.Spec.PreviousVersion
+}
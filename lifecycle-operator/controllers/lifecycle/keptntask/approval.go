@@ -0,0 +1,70 @@
+package keptntask
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reconcileApproval waits for a KeptnTask that is a manual approval gate to be approved or rejected
+// via the 'keptn.sh/approval' annotation, or to reach its timeout
+func (r *KeptnTaskReconciler) reconcileApproval(task *apilifecycle.KeptnTask) {
+	if task.Status.Status != apicommon.StateProgressing {
+		task.Status.Status = apicommon.StateProgressing
+		r.EventSender.Emit(apicommon.PhaseTaskApproval, "Normal", task, apicommon.PhaseStateStarted, getApprovalRequestMessage(task), "")
+	}
+
+	decision := strings.ToLower(task.Annotations[apicommon.TaskApprovalAnnotation])
+	switch decision {
+	case apicommon.TaskApprovalApproved:
+		setApprovalDecision(task, decision)
+		task.Status.Status = apicommon.StateSucceeded
+		r.EventSender.Emit(apicommon.PhaseTaskApproval, "Normal", task, apicommon.PhaseStateApproved, getApprovalDecisionMessage("has been approved", task), "")
+	case apicommon.TaskApprovalRejected:
+		setApprovalDecision(task, decision)
+		task.Status.Status = apicommon.StateFailed
+		task.Status.Message = getApprovalDecisionMessage("KeptnTask has been rejected", task)
+		r.EventSender.Emit(apicommon.PhaseTaskApproval, "Warning", task, apicommon.PhaseStateRejected, getApprovalDecisionMessage("has been rejected", task), "")
+	default:
+		if isTaskTimedOut(task) {
+			task.Status.Status = apicommon.StateFailed
+			task.Status.Message = "KeptnTask has not been approved within its timeout"
+			r.EventSender.Emit(apicommon.PhaseTaskApproval, "Warning", task, apicommon.PhaseStateReconcileTimeout, "has reached timeout", "")
+		}
+	}
+}
+
+func setApprovalDecision(task *apilifecycle.KeptnTask, decision string) {
+	task.Status.Approval = &apilifecycle.ApprovalStatus{
+		Decision:     decision,
+		DecidedBy:    task.Annotations[apicommon.TaskApprovedByAnnotation],
+		DecisionTime: metav1.NewTime(time.Now().UTC()),
+	}
+}
+
+// isTaskTimedOut returns true if more time than the timeout of the KeptnTask has passed since its start time.
+// A KeptnTask without a timeout or without a start time never times out.
+func isTaskTimedOut(task *apilifecycle.KeptnTask) bool {
+	if task.Spec.Timeout.Duration == 0 || !task.IsStartTimeSet() {
+		return false
+	}
+	return time.Now().UTC().After(task.Status.StartTime.Add(task.Spec.Timeout.Duration))
+}
+
+func getApprovalRequestMessage(task *apilifecycle.KeptnTask) string {
+	if task.Spec.Approval.Message == "" {
+		return "is waiting for approval"
+	}
+	return fmt.Sprintf("is waiting for approval: %s", task.Spec.Approval.Message)
+}
+
+func getApprovalDecisionMessage(prefix string, task *apilifecycle.KeptnTask) string {
+	if task.Status.Approval == nil || task.Status.Approval.DecidedBy == "" {
+		return prefix
+	}
+	return fmt.Sprintf("%s by %s", prefix, task.Status.Approval.DecidedBy)
+}
@@ -0,0 +1,102 @@
+package keptntask
+
+import (
+	"testing"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestKeptnTaskReconciler_reconcileApproval(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		startTime    time.Time
+		wantStatus   apicommon.KeptnState
+		wantApproval *apilifecycle.ApprovalStatus
+		wantEvents   []string
+	}{
+		{
+			name:       "waiting for approval",
+			startTime:  time.Now().UTC(),
+			wantStatus: apicommon.StateProgressing,
+			wantEvents: []string{"TaskApprovalStarted"},
+		},
+		{
+			name: "approved",
+			annotations: map[string]string{
+				apicommon.TaskApprovalAnnotation:   "Approved",
+				apicommon.TaskApprovedByAnnotation: "jane",
+			},
+			startTime:    time.Now().UTC(),
+			wantStatus:   apicommon.StateSucceeded,
+			wantApproval: &apilifecycle.ApprovalStatus{Decision: apicommon.TaskApprovalApproved, DecidedBy: "jane"},
+			wantEvents:   []string{"TaskApprovalStarted", "TaskApprovalApproved"},
+		},
+		{
+			name: "rejected",
+			annotations: map[string]string{
+				apicommon.TaskApprovalAnnotation: apicommon.TaskApprovalRejected,
+			},
+			startTime:    time.Now().UTC(),
+			wantStatus:   apicommon.StateFailed,
+			wantApproval: &apilifecycle.ApprovalStatus{Decision: apicommon.TaskApprovalRejected},
+			wantEvents:   []string{"TaskApprovalStarted", "TaskApprovalRejected"},
+		},
+		{
+			name:       "timed out",
+			startTime:  time.Now().UTC().Add(-10 * time.Minute),
+			wantStatus: apicommon.StateFailed,
+			wantEvents: []string{"TaskApprovalStarted", "TaskApprovalReconcileTimeout"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(100)
+			r := &KeptnTaskReconciler{
+				EventSender: eventsender.NewK8sSender(recorder),
+				Log:         ctrl.Log.WithName("task-controller"),
+			}
+
+			task := &apilifecycle.KeptnTask{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-task",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+				Spec: apilifecycle.KeptnTaskSpec{
+					TaskDefinition: "approve-production",
+					Timeout:        metav1.Duration{Duration: 5 * time.Minute},
+					Approval:       &apilifecycle.ApprovalSpec{Message: "promote to production"},
+				},
+				Status: apilifecycle.KeptnTaskStatus{
+					Status:    apicommon.StatePending,
+					StartTime: metav1.NewTime(tt.startTime),
+				},
+			}
+
+			r.reconcileApproval(task)
+
+			require.Equal(t, tt.wantStatus, task.Status.Status)
+			if tt.wantApproval == nil {
+				require.Nil(t, task.Status.Approval)
+			} else {
+				require.NotNil(t, task.Status.Approval)
+				require.Equal(t, tt.wantApproval.Decision, task.Status.Approval.Decision)
+				require.Equal(t, tt.wantApproval.DecidedBy, task.Status.Approval.DecidedBy)
+				require.False(t, task.Status.Approval.DecisionTime.IsZero())
+			}
+
+			require.Len(t, recorder.Events, len(tt.wantEvents))
+			for _, event := range tt.wantEvents {
+				require.Contains(t, <-recorder.Events, event)
+			}
+		})
+	}
+}
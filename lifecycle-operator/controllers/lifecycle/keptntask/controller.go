@@ -49,6 +49,7 @@ type KeptnTaskReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;get;update;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs/status,verbs=get;list
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
 
 func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	requestInfo := controllercommon.GetRequestInfo(req)
@@ -74,6 +75,21 @@ func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}()
 
+	if task.IsApprovalTask() {
+		if !task.Status.Status.IsCompleted() {
+			r.reconcileApproval(task)
+			return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		}
+		return r.finishTask(ctx, task, requestInfo)
+	}
+
+	if task.IsHTTPTask() {
+		if !task.Status.Status.IsCompleted() {
+			return r.reconcileHTTPTask(ctx, task), nil
+		}
+		return r.finishTask(ctx, task, requestInfo)
+	}
+
 	job, err := r.getJob(ctx, task.Status.JobName, req.Namespace)
 	if err != nil && !errors.IsNotFound(err) {
 		r.Log.Error(err, "Could not check if job is running")
@@ -91,10 +107,14 @@ func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	if !task.Status.Status.IsCompleted() {
-		r.updateTaskStatus(job, task)
+		r.updateTaskStatus(ctx, job, task)
 		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
 	}
 
+	return r.finishTask(ctx, task, requestInfo)
+}
+
+func (r *KeptnTaskReconciler) finishTask(ctx context.Context, task *apilifecycle.KeptnTask, requestInfo map[string]string) (ctrl.Result, error) {
 	r.Log.Info("Finished Reconciling KeptnTask", "requestInfo", requestInfo)
 
 	// Task is completed at this place
@@ -117,7 +137,8 @@ func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 // SetupWithManager sets up the controller with the Manager.
 func (r *KeptnTaskReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		// predicate disabling the auto reconciliation after updating the object status
-		For(&apilifecycle.KeptnTask{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// predicate disabling the auto reconciliation after updating the object status,
+		// changes of the annotations are still considered to react to the approval of a KeptnTask
+		For(&apilifecycle.KeptnTask{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Complete(r)
 }
@@ -0,0 +1,208 @@
+package keptntask
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	httpTaskRetryInterval   = 10 * time.Second
+	httpTaskRequestTimeout  = 10 * time.Second
+	maxHTTPTaskResponseSize = 1 << 20
+)
+
+// reconcileHTTPTask sends the HTTP request of the KeptnTask and verifies the response.
+// Unsuccessful requests are repeated until the retries or the timeout of the KeptnTask are exhausted.
+func (r *KeptnTaskReconciler) reconcileHTTPTask(ctx context.Context, task *apilifecycle.KeptnTask) ctrl.Result {
+	if isTaskTimedOut(task) {
+		task.Status.Status = apicommon.StateFailed
+		task.Status.Message = "HTTP request did not succeed within the timeout of the KeptnTask"
+		r.EventSender.Emit(apicommon.PhaseReconcileTask, "Warning", task, apicommon.PhaseStateReconcileTimeout, "has reached timeout", "")
+		return ctrl.Result{Requeue: true}
+	}
+
+	task.Status.Status = apicommon.StateProgressing
+	task.Status.Attempts++
+	err := r.sendHTTPRequest(ctx, task)
+	if err == nil {
+		task.Status.Status = apicommon.StateSucceeded
+		task.Status.Message = ""
+		return ctrl.Result{Requeue: true}
+	}
+
+	task.Status.Message = err.Error()
+	if task.Spec.Retries != nil && task.Status.Attempts > *task.Spec.Retries {
+		r.Log.Error(err, "HTTP request of KeptnTask failed", "task", task.Name)
+		task.Status.Status = apicommon.StateFailed
+		r.EventSender.Emit(apicommon.PhaseReconcileTask, "Warning", task, apicommon.PhaseStateFailed, fmt.Sprintf("HTTP request failed: %s ", err.Error()), "")
+		return ctrl.Result{Requeue: true}
+	}
+	r.Log.Info("HTTP request of KeptnTask failed, retrying", "task", task.Name, "attempt", task.Status.Attempts, "error", err.Error())
+	return ctrl.Result{Requeue: true, RequeueAfter: httpTaskRetryInterval}
+}
+
+func (r *KeptnTaskReconciler) sendHTTPRequest(ctx context.Context, task *apilifecycle.KeptnTask) error {
+	spec := task.Spec.HTTP
+
+	templateData, err := getHTTPTemplateData(task.Spec.Context)
+	if err != nil {
+		return err
+	}
+	url, err := renderHTTPTemplate("url", spec.URL, templateData)
+	if err != nil {
+		return err
+	}
+	body, err := renderHTTPTemplate("body", spec.Body, templateData)
+	if err != nil {
+		return err
+	}
+
+	method := spec.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	// the request is sent during the reconciliation, so it must not block the controller for long
+	reqCtx, cancel := context.WithTimeout(ctx, getHTTPRequestTimeout(task, time.Now().UTC()))
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, method, url, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for _, header := range spec.Headers {
+		value, err := r.getHeaderValue(ctx, header, task.Namespace)
+		if err != nil {
+			return err
+		}
+		req.Header.Set(header.Name, value)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(io.LimitReader(res.Body, maxHTTPTaskResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if !isExpectedStatusCode(res.StatusCode, spec.ExpectedStatusCodes) {
+		return fmt.Errorf("%w: %d", controllererrors.ErrUnexpectedHTTPStatusCode, res.StatusCode)
+	}
+	return checkHTTPAssertions(resBody, spec.Assertions)
+}
+
+// getHTTPRequestTimeout returns the timeout of a single HTTP request,
+// which must not exceed the time left until the timeout of the KeptnTask
+func getHTTPRequestTimeout(task *apilifecycle.KeptnTask, now time.Time) time.Duration {
+	timeout := httpTaskRequestTimeout
+	if task.Spec.Timeout.Duration == 0 || !task.IsStartTimeSet() {
+		return timeout
+	}
+	if remaining := task.Status.StartTime.Add(task.Spec.Timeout.Duration).Sub(now); remaining < timeout {
+		return remaining
+	}
+	return timeout
+}
+
+func (r *KeptnTaskReconciler) getHeaderValue(ctx context.Context, header apilifecycle.HTTPHeader, namespace string) (string, error) {
+	if header.SecretKeyRef == nil {
+		return header.Value, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: header.SecretKeyRef.Name, Namespace: namespace}, secret); err != nil {
+		return "", fmt.Errorf("could not retrieve secret for header %s: %w", header.Name, err)
+	}
+	value, ok := secret.Data[header.SecretKeyRef.Key]
+	if !ok {
+		return "", fmt.Errorf("could not find key %s in secret %s for header %s", header.SecretKeyRef.Key, header.SecretKeyRef.Name, header.Name)
+	}
+	return string(value), nil
+}
+
+// getHTTPTemplateData converts the context of the KeptnTask into the same structure as the KEPTN_CONTEXT
+// environment variable of Jobs, so that templates can refer to its fields by their JSON names
+func getHTTPTemplateData(taskContext apilifecycle.TaskContext) (map[string]interface{}, error) {
+	jsonContext, err := json.Marshal(taskContext)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]interface{}{}
+	if err := json.Unmarshal(jsonContext, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func renderHTTPTemplate(name string, text string, data map[string]interface{}) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("could not parse %s template: %w", name, err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("could not render %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+func isExpectedStatusCode(statusCode int, expectedStatusCodes []int) bool {
+	if len(expectedStatusCodes) == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+	for _, expected := range expectedStatusCodes {
+		if statusCode == expected {
+			return true
+		}
+	}
+	return false
+}
+
+func checkHTTPAssertions(body []byte, assertions []apilifecycle.HTTPAssertion) error {
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return fmt.Errorf("%w: response is no valid JSON: %w", controllererrors.ErrHTTPAssertionFailed, err)
+	}
+
+	for _, assertion := range assertions {
+		jp := jsonpath.New("assertion")
+		if err := jp.Parse(assertion.JSONPath); err != nil {
+			return fmt.Errorf("could not parse JSONPath %s: %w", assertion.JSONPath, err)
+		}
+		results, err := jp.FindResults(data)
+		if err != nil || len(results) == 0 || len(results[0]) == 0 {
+			return fmt.Errorf("%w: %s yields no result", controllererrors.ErrHTTPAssertionFailed, assertion.JSONPath)
+		}
+		if assertion.Value == "" {
+			continue
+		}
+		buf := &bytes.Buffer{}
+		if err := jp.PrintResults(buf, results[0]); err != nil {
+			return err
+		}
+		if buf.String() != assertion.Value {
+			return fmt.Errorf("%w: %s is '%s', expected '%s'", controllererrors.ErrHTTPAssertionFailed, assertion.JSONPath, buf.String(), assertion.Value)
+		}
+	}
+	return nil
+}
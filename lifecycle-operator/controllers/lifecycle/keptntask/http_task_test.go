@@ -0,0 +1,274 @@
+package keptntask
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKeptnTaskReconciler_reconcileHTTPTask(t *testing.T) {
+	var receivedBody, receivedToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receivedBody = string(body)
+		receivedToken = r.Header.Get("Authorization")
+		if r.URL.Path != "/freeze/my-app" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"freeze":{"active":false}}`))
+	}))
+	defer server.Close()
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "freeze-api",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{"token": []byte("Bearer my-token")},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeHTTPTask(&apilifecycle.HTTPSpec{
+		Method: http.MethodPost,
+		URL:    server.URL + "/freeze/{{ .appName }}",
+		Headers: []apilifecycle.HTTPHeader{
+			{
+				Name: "Authorization",
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "freeze-api"},
+					Key:                  "token",
+				},
+			},
+		},
+		Body:                `{"version":"{{ .appVersion }}"}`,
+		ExpectedStatusCodes: []int{http.StatusOK},
+		Assertions: []apilifecycle.HTTPAssertion{
+			{JSONPath: "{.freeze.active}", Value: "false"},
+		},
+	})
+
+	result := r.reconcileHTTPTask(context.TODO(), task)
+
+	require.True(t, result.Requeue)
+	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
+	require.Equal(t, int32(1), task.Status.Attempts)
+	require.Equal(t, `{"version":"1.0.0"}`, receivedBody)
+	require.Equal(t, "Bearer my-token", receivedToken)
+
+	// requests that do not meet the expectations are retried until the retries are exhausted
+	task = makeHTTPTask(&apilifecycle.HTTPSpec{
+		URL: server.URL + "/unknown",
+	})
+
+	result = r.reconcileHTTPTask(context.TODO(), task)
+
+	require.Equal(t, httpTaskRetryInterval, result.RequeueAfter)
+	require.Equal(t, apicommon.StateProgressing, task.Status.Status)
+	require.Contains(t, task.Status.Message, controllererrors.ErrUnexpectedHTTPStatusCode.Error())
+
+	r.reconcileHTTPTask(context.TODO(), task)
+
+	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Equal(t, int32(2), task.Status.Attempts)
+
+	// the request is not sent anymore once the timeout is reached
+	task = makeHTTPTask(&apilifecycle.HTTPSpec{
+		URL: server.URL + "/freeze/my-app",
+	})
+	task.Status.StartTime = metav1.NewTime(time.Now().UTC().Add(-10 * time.Minute))
+
+	r.reconcileHTTPTask(context.TODO(), task)
+
+	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Equal(t, int32(0), task.Status.Attempts)
+}
+
+func TestKeptnTaskReconciler_reconcileHTTPTask_RequestTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	r := &KeptnTaskReconciler{
+		Client:      fake.NewClientBuilder().Build(),
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+	}
+
+	// the request is cancelled once the timeout of the KeptnTask is reached
+	task := makeHTTPTask(&apilifecycle.HTTPSpec{
+		URL: server.URL,
+	})
+	task.Status.StartTime = metav1.NewTime(time.Now().UTC().Add(-5*time.Minute + 100*time.Millisecond))
+
+	start := time.Now()
+	result := r.reconcileHTTPTask(context.TODO(), task)
+
+	require.Less(t, time.Since(start), httpTaskRequestTimeout)
+	require.Equal(t, httpTaskRetryInterval, result.RequeueAfter)
+	require.Equal(t, apicommon.StateProgressing, task.Status.Status)
+	require.Contains(t, task.Status.Message, context.DeadlineExceeded.Error())
+}
+
+func Test_getHTTPRequestTimeout(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name      string
+		timeout   time.Duration
+		startTime time.Time
+		want      time.Duration
+	}{
+		{
+			name:      "no task timeout",
+			startTime: now,
+			want:      httpTaskRequestTimeout,
+		},
+		{
+			name:    "task not started",
+			timeout: 5 * time.Minute,
+			want:    httpTaskRequestTimeout,
+		},
+		{
+			name:      "task timeout far away",
+			timeout:   5 * time.Minute,
+			startTime: now,
+			want:      httpTaskRequestTimeout,
+		},
+		{
+			name:      "task timeout reached soon",
+			timeout:   5 * time.Minute,
+			startTime: now.Add(-5*time.Minute + 3*time.Second),
+			want:      3 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &apilifecycle.KeptnTask{
+				Spec: apilifecycle.KeptnTaskSpec{
+					Timeout: metav1.Duration{Duration: tt.timeout},
+				},
+				Status: apilifecycle.KeptnTaskStatus{
+					StartTime: metav1.NewTime(tt.startTime),
+				},
+			}
+			require.Equal(t, tt.want, getHTTPRequestTimeout(task, now))
+		})
+	}
+}
+
+func Test_checkHTTPAssertions(t *testing.T) {
+	body := []byte(`{"status":"ok","items":[{"name":"a"}]}`)
+
+	tests := []struct {
+		name       string
+		body       []byte
+		assertions []apilifecycle.HTTPAssertion
+		wantErr    error
+	}{
+		{
+			name: "no assertions",
+			body: []byte("not json"),
+		},
+		{
+			name: "value matches",
+			body: body,
+			assertions: []apilifecycle.HTTPAssertion{
+				{JSONPath: "{.status}", Value: "ok"},
+				{JSONPath: "{.items[0].name}", Value: "a"},
+			},
+		},
+		{
+			name: "result exists",
+			body: body,
+			assertions: []apilifecycle.HTTPAssertion{
+				{JSONPath: "{.items[0]}"},
+			},
+		},
+		{
+			name: "value does not match",
+			body: body,
+			assertions: []apilifecycle.HTTPAssertion{
+				{JSONPath: "{.status}", Value: "failed"},
+			},
+			wantErr: controllererrors.ErrHTTPAssertionFailed,
+		},
+		{
+			name: "missing key",
+			body: body,
+			assertions: []apilifecycle.HTTPAssertion{
+				{JSONPath: "{.unknown}"},
+			},
+			wantErr: controllererrors.ErrHTTPAssertionFailed,
+		},
+		{
+			name: "no JSON body",
+			body: []byte("not json"),
+			assertions: []apilifecycle.HTTPAssertion{
+				{JSONPath: "{.status}"},
+			},
+			wantErr: controllererrors.ErrHTTPAssertionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHTTPAssertions(tt.body, tt.assertions)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
+
+func makeHTTPTask(spec *apilifecycle.HTTPSpec) *apilifecycle.KeptnTask {
+	retries := int32(1)
+	return &apilifecycle.KeptnTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-task",
+			Namespace: "default",
+		},
+		Spec: apilifecycle.KeptnTaskSpec{
+			TaskDefinition: "check-freeze",
+			Context: apilifecycle.TaskContext{
+				AppName:    "my-app",
+				AppVersion: "1.0.0",
+			},
+			Retries: &retries,
+			Timeout: metav1.Duration{Duration: 5 * time.Minute},
+			HTTP:    spec,
+		},
+		Status: apilifecycle.KeptnTaskStatus{
+			Status:    apicommon.StatePending,
+			StartTime: metav1.NewTime(time.Now().UTC()),
+		},
+	}
+}
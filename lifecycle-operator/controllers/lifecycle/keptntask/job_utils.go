@@ -2,7 +2,9 @@ package keptntask
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
@@ -15,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -70,11 +73,12 @@ func (r *KeptnTaskReconciler) createFunctionJob(ctx context.Context, req ctrl.Re
 	return job.Name, nil
 }
 
-func (r *KeptnTaskReconciler) updateTaskStatus(job *batchv1.Job, task *apilifecycle.KeptnTask) {
+func (r *KeptnTaskReconciler) updateTaskStatus(ctx context.Context, job *batchv1.Job, task *apilifecycle.KeptnTask) {
 	if len(job.Status.Conditions) > 0 {
 		if hasJobCondition(job.Status.Conditions, batchv1.JobComplete) ||
 			hasJobCondition(job.Status.Conditions, batchv1.JobSuccessCriteriaMet) {
 			task.Status.Status = apicommon.StateSucceeded
+			r.collectTaskOutputs(ctx, job, task)
 		} else if hasJobCondition(job.Status.Conditions, batchv1.JobFailed) ||
 			hasJobCondition(job.Status.Conditions, batchv1.JobFailureTarget) {
 			task.Status.Status = apicommon.StateFailed
@@ -84,6 +88,67 @@ func (r *KeptnTaskReconciler) updateTaskStatus(job *batchv1.Job, task *apilifecy
 	}
 }
 
+// collectTaskOutputs stores the outputs the function of the KeptnTask has written to the termination message
+// of its container in the status of the KeptnTask
+func (r *KeptnTaskReconciler) collectTaskOutputs(ctx context.Context, job *batchv1.Job, task *apilifecycle.KeptnTask) {
+	message, err := r.getTerminationMessage(ctx, job)
+	if err != nil {
+		r.Log.Error(err, "could not retrieve outputs of KeptnTask", "task", task.Name)
+		return
+	}
+
+	outputs, err := parseTaskOutputs(message)
+	if err != nil {
+		r.Log.Error(err, "could not parse outputs of KeptnTask", "task", task.Name)
+		r.EventSender.Emit(apicommon.PhaseReconcileTask, "Warning", task, apicommon.PhaseStateFailed, fmt.Sprintf("could not parse outputs of KeptnTask: %s ", err.Error()), "")
+		return
+	}
+	task.Status.Outputs = outputs
+}
+
+func (r *KeptnTaskReconciler) getTerminationMessage(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return "", err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			terminated := containerStatus.State.Terminated
+			if terminated != nil && terminated.ExitCode == 0 {
+				return terminated.Message, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// parseTaskOutputs parses the JSON object written by the function of a KeptnTask.
+// Values that are not strings are stored as their JSON representation.
+func parseTaskOutputs(message string) (map[string]string, error) {
+	if strings.TrimSpace(message) == "" {
+		return nil, nil
+	}
+
+	rawOutputs := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(message), &rawOutputs); err != nil {
+		return nil, fmt.Errorf("%w: %w", controllererrors.ErrInvalidTaskOutputs, err)
+	}
+
+	outputs := make(map[string]string, len(rawOutputs))
+	for key, rawValue := range rawOutputs {
+		var value string
+		if err := json.Unmarshal(rawValue, &value); err != nil {
+			value = string(rawValue)
+		}
+		outputs[key] = value
+	}
+	return outputs, nil
+}
+
 func hasJobCondition(conditions []batchv1.JobCondition, searched batchv1.JobConditionType) bool {
 	for _, v := range conditions {
 		if v.Type == searched {
@@ -166,7 +231,25 @@ func (r *KeptnTaskReconciler) generateJob(ctx context.Context, task *apilifecycl
 		job.Spec.Template.Spec.Volumes = []corev1.Volume{*volume}
 	}
 
+	addTaskOutputEnvVar(container)
 	job.Spec.Template.Spec.Containers = []corev1.Container{*container}
 
 	return job, nil
 }
+
+// addTaskOutputEnvVar lets the function of the KeptnTask know where its outputs have to be written to.
+// The outputs are written to the termination message of the container, so they can be collected from the
+// status of the pod once the Job has finished.
+func addTaskOutputEnvVar(container *corev1.Container) {
+	if container.TerminationMessagePath == "" {
+		container.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	}
+	container.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	for i, envVar := range container.Env {
+		if envVar.Name == TaskOutputEnvVar {
+			container.Env[i].Value = container.TerminationMessagePath
+			return
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: TaskOutputEnvVar, Value: container.TerminationMessagePath})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
@@ -73,7 +74,7 @@ func TestKeptnTaskReconciler_createJob(t *testing.T) {
 	require.Equal(t, namespace, resultingJob.Namespace)
 	require.NotEmpty(t, resultingJob.OwnerReferences)
 	require.Len(t, resultingJob.Spec.Template.Spec.Containers, 1)
-	require.Len(t, resultingJob.Spec.Template.Spec.Containers[0].Env, 5)
+	require.Len(t, resultingJob.Spec.Template.Spec.Containers[0].Env, 6)
 	require.Equal(t, map[string]string{
 		"label1": "label2",
 	}, resultingJob.Labels)
@@ -139,7 +140,7 @@ func TestKeptnTaskReconciler_createJob_withTaskDefInDefaultNamespace(t *testing.
 	require.Equal(t, namespace, resultingJob.Namespace)
 	require.NotEmpty(t, resultingJob.OwnerReferences)
 	require.Len(t, resultingJob.Spec.Template.Spec.Containers, 1)
-	require.Len(t, resultingJob.Spec.Template.Spec.Containers[0].Env, 5)
+	require.Len(t, resultingJob.Spec.Template.Spec.Containers[0].Env, 6)
 	require.Equal(t, map[string]string{
 		"label1": "label2",
 	}, resultingJob.Labels)
@@ -186,7 +187,7 @@ func TestKeptnTaskReconciler_updateTaskStatus(t *testing.T) {
 
 	task.Status.JobName = job.Name
 
-	r.updateTaskStatus(job, task)
+	r.updateTaskStatus(context.TODO(), job, task)
 
 	require.Equal(t, apicommon.StateFailed, task.Status.Status)
 
@@ -197,7 +198,7 @@ func TestKeptnTaskReconciler_updateTaskStatus(t *testing.T) {
 		},
 	}
 
-	r.updateTaskStatus(job, task)
+	r.updateTaskStatus(context.TODO(), job, task)
 
 	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
 }
@@ -236,7 +237,7 @@ func TestKeptnTaskReconciler_updateTaskStatusK8s31(t *testing.T) {
 
 	task.Status.JobName = job.Name
 
-	r.updateTaskStatus(job, task)
+	r.updateTaskStatus(context.TODO(), job, task)
 
 	require.Equal(t, apicommon.StateFailed, task.Status.Status)
 
@@ -247,7 +248,7 @@ func TestKeptnTaskReconciler_updateTaskStatusK8s31(t *testing.T) {
 		},
 	}
 
-	r.updateTaskStatus(job, task)
+	r.updateTaskStatus(context.TODO(), job, task)
 
 	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
 }
@@ -316,6 +317,103 @@ func TestKeptnTaskReconciler_generateJob(t *testing.T) {
 		"keptn.sh/version":   "",
 		"keptn.sh/workload":  "my-workload",
 	}, resultingJob.Annotations)
+
+	container := resultingJob.Spec.Template.Spec.Containers[0]
+	require.Equal(t, v1.TerminationMessageReadFile, container.TerminationMessagePolicy)
+	require.Contains(t, container.Env, v1.EnvVar{Name: TaskOutputEnvVar, Value: container.TerminationMessagePath})
+}
+
+func TestKeptnTaskReconciler_updateTaskStatus_collectsOutputs(t *testing.T) {
+	namespace := "default"
+
+	job := makeJob("my.job", namespace, batchv1.JobStatus{
+		Conditions: []batchv1.JobCondition{
+			{
+				Type: batchv1.JobComplete,
+			},
+		},
+	})
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my.job-abcde",
+			Namespace: namespace,
+			Labels:    map[string]string{batchv1.JobNameLabel: job.Name},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodSucceeded,
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: "keptn-function-runner",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							ExitCode: 0,
+							Message:  `{"image":"my-image:1.0","replicas":3}`,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(job, pod).Build()
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, "my-task-definition")
+	task.Status.JobName = job.Name
+
+	r.updateTaskStatus(context.TODO(), job, task)
+
+	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
+	require.Equal(t, map[string]string{
+		"image":    "my-image:1.0",
+		"replicas": "3",
+	}, task.Status.Outputs)
+}
+
+func Test_parseTaskOutputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name:    "empty message",
+			message: "",
+			want:    nil,
+		},
+		{
+			name:    "string and non-string values",
+			message: `{"version":"1.2.0","healthy":true,"nested":{"a":1}}`,
+			want: map[string]string{
+				"version": "1.2.0",
+				"healthy": "true",
+				"nested":  `{"a":1}`,
+			},
+		},
+		{
+			name:    "no JSON object",
+			message: "some log output",
+			wantErr: controllererrors.ErrInvalidTaskOutputs,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTaskOutputs(tt.message)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
 }
 
 func makeJob(name, namespace string, status batchv1.JobStatus) *batchv1.Job {
@@ -40,6 +40,7 @@ type RuntimeExecutionParams struct {
 
 const (
 	KeptnContextEnvVar = "KEPTN_CONTEXT"
+	TaskOutputEnvVar   = "KEPTN_TASK_OUTPUT"
 	SecureData         = "SECURE_DATA"
 	Data               = "DATA"
 	CmdArgs            = "CMD_ARGS"
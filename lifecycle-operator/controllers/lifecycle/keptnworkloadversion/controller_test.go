@@ -2,6 +2,7 @@ package keptnworkloadversion
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -10,7 +11,9 @@ import (
 
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	fakeconfig "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config/fake"
 	keptncontext "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/context"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/evaluation"
 	evaluationfake "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/evaluation/fake"
@@ -27,7 +30,10 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -223,6 +229,7 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_ReadyStatefulSet(t *
 	require.Nil(t, err)
 	require.Equal(t, apicommon.StateSucceeded, keptnState)
 	require.False(t, workloadVersion.Status.DeploymentStartTime.IsZero())
+	require.Equal(t, apilifecycle.ResourceReference{UID: "mystat", Kind: "StatefulSet", Name: "mystat"}, workloadVersion.Status.DeployedTemplate.Owner)
 }
 
 func TestKeptnWorkloadVersionReconciler_reconcileDeployment_ReadyDaemonSet(t *testing.T) {
@@ -242,12 +249,269 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_ReadyDaemonSet(t *te
 	require.False(t, workloadVersion.Status.DeploymentStartTime.IsZero())
 }
 
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_Job(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []batchv1.JobCondition
+		want       apicommon.KeptnState
+	}{
+		{
+			name: "running job",
+			want: apicommon.StateProgressing,
+		},
+		{
+			name: "completed job",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			},
+			want: apicommon.StateSucceeded,
+		},
+		{
+			name: "failed job",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"},
+			},
+			want: apicommon.StateFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "myjob",
+					Namespace: "default",
+					UID:       "myjob",
+				},
+				Status: batchv1.JobStatus{
+					Conditions: tt.conditions,
+				},
+			}
+			workloadVersion := makeWorkloadVersionWithRef(job.ObjectMeta, "Job")
+
+			fakeRecorder := record.NewFakeRecorder(100)
+			r := &KeptnWorkloadVersionReconciler{
+				Client:      testcommon.NewTestClient(job, workloadVersion),
+				EventSender: eventsender.NewK8sSender(fakeRecorder),
+			}
+
+			keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, keptnState)
+			if tt.want == apicommon.StateFailed {
+				require.Contains(t, <-fakeRecorder.Events, "BackoffLimitExceeded")
+			}
+		})
+	}
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_KnativeRevision(t *testing.T) {
+	tests := []struct {
+		name        string
+		readyStatus string
+		want        apicommon.KeptnState
+	}{
+		{
+			name:        "revision not ready",
+			readyStatus: "Unknown",
+			want:        apicommon.StateProgressing,
+		},
+		{
+			name:        "revision ready",
+			readyStatus: "True",
+			want:        apicommon.StateSucceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// knative services scaled to zero have no available replicas
+			rep := int32(0)
+			replicaSet := makeReplicaSet("my-service-00001-deployment-1234", "default", &rep, 0)
+			replicaSet.Labels = map[string]string{
+				apicommon.KnativeRevisionLabel: "my-service-00001",
+			}
+			revision := &unstructured.Unstructured{}
+			revision.SetGroupVersionKind(knativeRevisionGVK)
+			revision.SetName("my-service-00001")
+			revision.SetNamespace("default")
+			require.Nil(t, unstructured.SetNestedSlice(revision.Object, []interface{}{
+				map[string]interface{}{"type": "Ready", "status": tt.readyStatus},
+			}, "status", "conditions"))
+			workloadVersion := makeWorkloadVersionWithRef(replicaSet.ObjectMeta, "ReplicaSet")
+
+			r := &KeptnWorkloadVersionReconciler{
+				Client: testcommon.NewTestClient(replicaSet, revision, workloadVersion),
+			}
+
+			keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, keptnState)
+		})
+	}
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_CustomWorkloadKind(t *testing.T) {
+	customKind := optionsv1alpha1.CustomWorkloadKind{
+		Group:         "apps.kruise.io",
+		Version:       "v1alpha1",
+		Kind:          "CloneSet",
+		ReadyJSONPath: `{.status.conditions[?(@.type=="Ready")].status}`,
+		ReadyValue:    "True",
+	}
+	tests := []struct {
+		name          string
+		readyJSONPath string
+		apiVersion    string
+		conditions    []interface{}
+		want          apicommon.KeptnState
+		wantErr       bool
+	}{
+		{
+			name:       "no conditions",
+			conditions: []interface{}{},
+			want:       apicommon.StateProgressing,
+		},
+		{
+			name: "not ready",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "False"},
+			},
+			want: apicommon.StateProgressing,
+		},
+		{
+			name: "ready",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Progressing", "status": "False"},
+				map[string]interface{}{"type": "Ready", "status": "True"},
+			},
+			want: apicommon.StateSucceeded,
+		},
+		{
+			name:          "invalid JSONPath",
+			readyJSONPath: "{.status.conditions[",
+			conditions:    []interface{}{},
+			want:          apicommon.StateUnknown,
+			wantErr:       true,
+		},
+		{
+			name:       "different API version",
+			apiVersion: "apps.kruise.io/v1beta1",
+			conditions: []interface{}{},
+			want:       apicommon.StateUnknown,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind := customKind
+			if tt.readyJSONPath != "" {
+				kind.ReadyJSONPath = tt.readyJSONPath
+			}
+			cloneSet := &unstructured.Unstructured{}
+			cloneSet.SetGroupVersionKind(kind.GroupVersionKind())
+			cloneSet.SetName("my-cloneset")
+			cloneSet.SetNamespace("default")
+			cloneSet.SetUID("my-cloneset")
+			require.Nil(t, unstructured.SetNestedSlice(cloneSet.Object, tt.conditions, "status", "conditions"))
+			workloadVersion := makeWorkloadVersionWithRef(metav1.ObjectMeta{Name: "my-cloneset", UID: "my-cloneset"}, "CloneSet")
+			workloadVersion.Spec.ResourceReference.APIVersion = "apps.kruise.io/v1alpha1"
+			if tt.apiVersion != "" {
+				workloadVersion.Spec.ResourceReference.APIVersion = tt.apiVersion
+			}
+
+			r := &KeptnWorkloadVersionReconciler{
+				Client: testcommon.NewTestClient(cloneSet, workloadVersion),
+				Config: &fakeconfig.MockConfig{
+					GetCustomWorkloadKindsFunc: func() []optionsv1alpha1.CustomWorkloadKind {
+						return []optionsv1alpha1.CustomWorkloadKind{kind}
+					},
+				},
+			}
+
+			keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+			if tt.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+			require.Equal(t, tt.want, keptnState)
+		})
+	}
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_RecordsTemplateOfDeployment(t *testing.T) {
+
+	rep := int32(1)
+	isController := true
+	replicaSet := makeReplicaSet("myrep", "default", &rep, 1)
+	replicaSet.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "mydeployment",
+			UID:        "mydeployment",
+			Controller: &isController,
+		},
+	}
+	replicaSet.Spec.Template = corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app":                                  "my-app",
+				appsv1.DefaultDeploymentUniqueLabelKey: "12345",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "my-container", Image: "my-image:1.0.0"}},
+		},
+	}
+	workloadVersion := makeWorkloadVersionWithRef(replicaSet.ObjectMeta, "ReplicaSet")
+
+	fakeClient := testcommon.NewTestClient(replicaSet, workloadVersion)
+
+	r := &KeptnWorkloadVersionReconciler{
+		Client: fakeClient,
+	}
+
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateSucceeded, keptnState)
+	require.NotNil(t, workloadVersion.Status.DeployedTemplate)
+	require.Equal(t, apilifecycle.ResourceReference{UID: "mydeployment", Kind: "Deployment", Name: "mydeployment"}, workloadVersion.Status.DeployedTemplate.Owner)
+
+	template := &corev1.PodTemplateSpec{}
+	require.Nil(t, json.Unmarshal(workloadVersion.Status.DeployedTemplate.Template.Raw, template))
+	require.Equal(t, map[string]string{"app": "my-app"}, template.Labels)
+	require.Equal(t, "my-image:1.0.0", template.Spec.Containers[0].Image)
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_ReplicaSetWithoutOwnerRecordsNoTemplate(t *testing.T) {
+
+	rep := int32(1)
+	replicaSet := makeReplicaSet("myrep", "default", &rep, 1)
+	workloadVersion := makeWorkloadVersionWithRef(replicaSet.ObjectMeta, "ReplicaSet")
+
+	fakeClient := testcommon.NewTestClient(replicaSet, workloadVersion)
+
+	r := &KeptnWorkloadVersionReconciler{
+		Client: fakeClient,
+	}
+
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateSucceeded, keptnState)
+	require.Nil(t, workloadVersion.Status.DeployedTemplate)
+}
+
 func TestKeptnWorkloadVersionReconciler_reconcileDeployment_UnsupportedReferenceKind(t *testing.T) {
 
 	workloadVersion := makeWorkloadVersionWithRef(metav1.ObjectMeta{}, "Unknown")
 	fakeClient := testcommon.NewTestClient(workloadVersion)
 	r := &KeptnWorkloadVersionReconciler{
 		Client: fakeClient,
+		Config: &fakeconfig.MockConfig{
+			GetCustomWorkloadKindsFunc: func() []optionsv1alpha1.CustomWorkloadKind {
+				return nil
+			},
+		},
 	}
 
 	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
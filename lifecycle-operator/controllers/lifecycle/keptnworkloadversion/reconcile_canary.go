@@ -0,0 +1,189 @@
+package keptnworkloadversion
+
+import (
+	"context"
+	"fmt"
+
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	controllercommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// isCanaryRolloutRunning follows the canary steps of the Argo Rollout owning the given ReplicaSet.
+// Whenever the Rollout is paused at a canary step, the canaryEvaluations of the KeptnWorkloadVersion are executed,
+// and the Rollout is promoted to the next step if they succeed, or aborted if they fail.
+func (r *KeptnWorkloadVersionReconciler) isCanaryRolloutRunning(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion, rep *appsv1.ReplicaSet, rolloutName string) (bool, error) {
+	rollout := &argov1alpha1.Rollout{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: rolloutName, Namespace: workloadVersion.Namespace}, rollout)
+	if err != nil {
+		return false, err
+	}
+
+	isRunning := rollout.Status.Replicas == rollout.Status.UpdatedReplicas && rollout.Status.Phase == argov1alpha1.RolloutPhaseHealthy
+
+	// only follow the canary steps if the Rollout is currently rolling out the ReplicaSet of the KeptnWorkloadVersion
+	if rollout.Spec.Strategy.Canary == nil || rep.Labels[argov1alpha1.DefaultRolloutUniqueLabelKey] != rollout.Status.CurrentPodHash {
+		return isRunning, nil
+	}
+
+	if workloadVersion.Status.CanaryStatus == nil {
+		workloadVersion.Status.CanaryStatus = &apilifecycle.CanaryStatus{}
+	}
+	canaryStatus := workloadVersion.Status.CanaryStatus
+	canaryStatus.StepCount = int32(len(rollout.Spec.Strategy.Canary.Steps))
+	if rollout.Status.CurrentStepIndex != nil {
+		currentStepIndex := *rollout.Status.CurrentStepIndex
+		canaryStatus.CurrentStepIndex = &currentStepIndex
+	}
+
+	if rollout.Status.Abort || !isPausedAtCanaryStep(rollout) {
+		return isRunning, nil
+	}
+
+	step := getCanaryStepStatus(canaryStatus, *rollout.Status.CurrentStepIndex)
+	if !step.Status.IsCompleted() {
+		if err := r.reconcileCanaryStepEvaluations(ctx, workloadVersion, step); err != nil {
+			return false, err
+		}
+	}
+
+	switch {
+	case step.Status.IsSucceeded():
+		if err := r.promoteRollout(ctx, rollout); err != nil {
+			return false, err
+		}
+		r.EventSender.Emit(apicommon.PhaseWorkloadCanaryEvaluation, "Normal", workloadVersion, apicommon.PhaseStatePromoted, fmt.Sprintf("promoted Rollout %s after canary step %d", rollout.Name, step.StepIndex), workloadVersion.GetVersion())
+	case step.Status.IsFailed():
+		if err := r.abortRollout(ctx, rollout); err != nil {
+			return false, err
+		}
+		r.EventSender.Emit(apicommon.PhaseWorkloadCanaryEvaluation, "Warning", workloadVersion, apicommon.PhaseStateAborted, fmt.Sprintf("aborted Rollout %s at canary step %d", rollout.Name, step.StepIndex), workloadVersion.GetVersion())
+	}
+
+	return false, nil
+}
+
+// reconcileCanaryStepEvaluations creates the KeptnEvaluations of the given canary step and updates their state
+func (r *KeptnWorkloadVersionReconciler) reconcileCanaryStepEvaluations(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion, step *apilifecycle.CanaryStepStatus) error {
+	summary := apicommon.StatusSummary{Total: len(workloadVersion.Spec.CanaryEvaluations)}
+	newStatus := make([]apilifecycle.ItemStatus, 0, len(workloadVersion.Spec.CanaryEvaluations))
+
+	for _, evaluationName := range workloadVersion.Spec.CanaryEvaluations {
+		evaluationStatus := controllercommon.GetItemStatus(evaluationName, step.EvaluationStatus)
+
+		if !evaluationStatus.Status.IsCompleted() {
+			if err := r.reconcileCanaryEvaluation(ctx, workloadVersion, &evaluationStatus); err != nil {
+				return err
+			}
+		}
+
+		newStatus = append(newStatus, evaluationStatus)
+		summary = apicommon.UpdateStatusSummary(evaluationStatus.Status, summary)
+	}
+
+	step.EvaluationStatus = newStatus
+	step.Status = apicommon.GetOverallState(summary)
+	return nil
+}
+
+func (r *KeptnWorkloadVersionReconciler) reconcileCanaryEvaluation(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion, evaluationStatus *apilifecycle.ItemStatus) error {
+	if evaluationStatus.Name != "" {
+		evaluation := &apilifecycle.KeptnEvaluation{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: evaluationStatus.Name, Namespace: workloadVersion.Namespace}, evaluation)
+		if err == nil {
+			evaluationStatus.Status = evaluation.Status.OverallStatus
+			if evaluationStatus.Status.IsCompleted() {
+				evaluationStatus.SetEndTime()
+			}
+			return nil
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		evaluationStatus.Name = ""
+	}
+
+	definition, err := controllercommon.GetEvaluationDefinition(r.Client, r.Log, ctx, evaluationStatus.DefinitionName, workloadVersion.Namespace)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		// without its definition the canary evaluation can never succeed, so the canary step fails
+		r.Log.Info("EvaluationDefinition for canary Evaluation not found",
+			"evaluationDefinition", evaluationStatus.DefinitionName,
+			"namespace", workloadVersion.Namespace,
+		)
+		evaluationStatus.Status = apicommon.StateFailed
+		evaluationStatus.SetStartTime()
+		evaluationStatus.SetEndTime()
+		r.EventSender.Emit(apicommon.PhaseCreateEvaluation, "Warning", workloadVersion, apicommon.PhaseStateNotFound, fmt.Sprintf("could not find KeptnEvaluationDefinition %s", evaluationStatus.DefinitionName), workloadVersion.GetVersion())
+		return nil
+	}
+
+	evaluation := workloadVersion.GenerateEvaluation(*definition, apicommon.CanaryEvaluationCheckType)
+	if err := controllerutil.SetControllerReference(workloadVersion, &evaluation, r.Scheme); err != nil {
+		r.Log.Error(err, "could not set controller reference:")
+	}
+	if err := r.Client.Create(ctx, &evaluation); err != nil {
+		r.EventSender.Emit(apicommon.PhaseCreateEvaluation, "Warning", workloadVersion, apicommon.PhaseStateFailed, "could not create KeptnEvaluation", workloadVersion.GetVersion())
+		return err
+	}
+
+	evaluationStatus.Name = evaluation.Name
+	evaluationStatus.Status = apicommon.StatePending
+	evaluationStatus.SetStartTime()
+	return nil
+}
+
+// promoteRollout resumes a Rollout that has been paused at a canary step, in the same way as 'kubectl argo rollouts promote'
+func (r *KeptnWorkloadVersionReconciler) promoteRollout(ctx context.Context, rollout *argov1alpha1.Rollout) error {
+	if rollout.Spec.Paused {
+		patch := client.MergeFrom(rollout.DeepCopy())
+		rollout.Spec.Paused = false
+		if err := r.Client.Patch(ctx, rollout, patch); err != nil {
+			return err
+		}
+	}
+	patch := client.MergeFrom(rollout.DeepCopy())
+	rollout.Status.PauseConditions = nil
+	return r.Client.Status().Patch(ctx, rollout, patch)
+}
+
+// abortRollout aborts a Rollout, in the same way as 'kubectl argo rollouts abort'
+func (r *KeptnWorkloadVersionReconciler) abortRollout(ctx context.Context, rollout *argov1alpha1.Rollout) error {
+	patch := client.MergeFrom(rollout.DeepCopy())
+	rollout.Status.Abort = true
+	return r.Client.Status().Patch(ctx, rollout, patch)
+}
+
+func isPausedAtCanaryStep(rollout *argov1alpha1.Rollout) bool {
+	if rollout.Status.CurrentStepIndex == nil {
+		return false
+	}
+	for _, condition := range rollout.Status.PauseConditions {
+		if condition.Reason == argov1alpha1.PauseReasonCanaryPauseStep {
+			return true
+		}
+	}
+	return false
+}
+
+// getCanaryStepStatus returns the status of the canary step with the given index, adding it to the CanaryStatus if needed
+func getCanaryStepStatus(canaryStatus *apilifecycle.CanaryStatus, stepIndex int32) *apilifecycle.CanaryStepStatus {
+	for i := range canaryStatus.Steps {
+		if canaryStatus.Steps[i].StepIndex == stepIndex {
+			return &canaryStatus.Steps[i]
+		}
+	}
+	canaryStatus.Steps = append(canaryStatus.Steps, apilifecycle.CanaryStepStatus{
+		StepIndex: stepIndex,
+		Status:    apicommon.StatePending,
+	})
+	return &canaryStatus.Steps[len(canaryStatus.Steps)-1]
+}
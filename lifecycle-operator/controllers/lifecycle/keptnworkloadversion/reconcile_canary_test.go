@@ -0,0 +1,256 @@
+package keptnworkloadversion
+
+import (
+	"context"
+	"testing"
+
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_CanaryStepCreatesEvaluations(t *testing.T) {
+	rollout := makeCanaryRollout(1, true)
+	replicaSet, workloadVersion := makeCanaryWorkloadVersion(rollout)
+	evaluationDefinition := &apilifecycle.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "canary-check", Namespace: "default"},
+	}
+
+	r, _ := setupCanaryReconciler(rollout, replicaSet, workloadVersion, evaluationDefinition)
+
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateProgressing, keptnState)
+
+	canaryStatus := workloadVersion.Status.CanaryStatus
+	require.NotNil(t, canaryStatus)
+	require.Equal(t, int32(1), *canaryStatus.CurrentStepIndex)
+	require.Equal(t, int32(4), canaryStatus.StepCount)
+	require.Len(t, canaryStatus.Steps, 1)
+	require.Equal(t, int32(1), canaryStatus.Steps[0].StepIndex)
+	require.Equal(t, apicommon.StatePending, canaryStatus.Steps[0].Status)
+	require.Len(t, canaryStatus.Steps[0].EvaluationStatus, 1)
+	require.Equal(t, "canary-check", canaryStatus.Steps[0].EvaluationStatus[0].DefinitionName)
+
+	evaluation := &apilifecycle.KeptnEvaluation{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: canaryStatus.Steps[0].EvaluationStatus[0].Name, Namespace: "default"}, evaluation)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.CanaryEvaluationCheckType, evaluation.Spec.Type)
+	require.Equal(t, "canary-check", evaluation.Spec.EvaluationDefinition)
+
+	// the rollout stays paused while the evaluation is running
+	updatedRollout := &argov1alpha1.Rollout{}
+	require.Nil(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(rollout), updatedRollout))
+	require.Len(t, updatedRollout.Status.PauseConditions, 1)
+	require.False(t, updatedRollout.Status.Abort)
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_CanaryStepPromotesRollout(t *testing.T) {
+	rollout := makeCanaryRollout(1, true)
+	replicaSet, workloadVersion := makeCanaryWorkloadVersion(rollout)
+	evaluation := makeCanaryEvaluation(apicommon.StateSucceeded)
+	workloadVersion.Status.CanaryStatus = makeCanaryStatusWithEvaluation(evaluation.Name)
+
+	r, recorder := setupCanaryReconciler(rollout, replicaSet, workloadVersion, evaluation)
+
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateProgressing, keptnState)
+	require.Equal(t, apicommon.StateSucceeded, workloadVersion.Status.CanaryStatus.Steps[0].Status)
+	require.Equal(t, apicommon.StateSucceeded, workloadVersion.Status.CanaryStatus.Steps[0].EvaluationStatus[0].Status)
+
+	updatedRollout := &argov1alpha1.Rollout{}
+	require.Nil(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(rollout), updatedRollout))
+	require.Empty(t, updatedRollout.Status.PauseConditions)
+	require.False(t, updatedRollout.Spec.Paused)
+	require.False(t, updatedRollout.Status.Abort)
+
+	require.Contains(t, <-recorder.Events, apicommon.PhaseWorkloadCanaryEvaluation.ShortName+apicommon.PhaseStatePromoted)
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_CanaryStepAbortsRollout(t *testing.T) {
+	rollout := makeCanaryRollout(1, false)
+	replicaSet, workloadVersion := makeCanaryWorkloadVersion(rollout)
+	evaluation := makeCanaryEvaluation(apicommon.StateFailed)
+	workloadVersion.Status.CanaryStatus = makeCanaryStatusWithEvaluation(evaluation.Name)
+
+	r, recorder := setupCanaryReconciler(rollout, replicaSet, workloadVersion, evaluation)
+
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateFailed, keptnState)
+	require.Equal(t, apicommon.StateFailed, workloadVersion.Status.CanaryStatus.Steps[0].Status)
+
+	updatedRollout := &argov1alpha1.Rollout{}
+	require.Nil(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(rollout), updatedRollout))
+	require.True(t, updatedRollout.Status.Abort)
+
+	require.Contains(t, <-recorder.Events, apicommon.PhaseWorkloadCanaryEvaluation.ShortName+apicommon.PhaseStateAborted)
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_CanaryStepMissingDefinitionAbortsRollout(t *testing.T) {
+	rollout := makeCanaryRollout(1, true)
+	replicaSet, workloadVersion := makeCanaryWorkloadVersion(rollout)
+
+	r, recorder := setupCanaryReconciler(rollout, replicaSet, workloadVersion)
+
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateFailed, keptnState)
+
+	step := workloadVersion.Status.CanaryStatus.Steps[0]
+	require.Equal(t, apicommon.StateFailed, step.Status)
+	require.Len(t, step.EvaluationStatus, 1)
+	require.Equal(t, apicommon.StateFailed, step.EvaluationStatus[0].Status)
+	require.Empty(t, step.EvaluationStatus[0].Name)
+
+	updatedRollout := &argov1alpha1.Rollout{}
+	require.Nil(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(rollout), updatedRollout))
+	require.True(t, updatedRollout.Status.Abort)
+
+	require.Contains(t, <-recorder.Events, apicommon.PhaseCreateEvaluation.ShortName+apicommon.PhaseStateNotFound)
+	require.Contains(t, <-recorder.Events, apicommon.PhaseWorkloadCanaryEvaluation.ShortName+apicommon.PhaseStateAborted)
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_CanaryRolloutCompleted(t *testing.T) {
+	rollout := makeCanaryRollout(4, false)
+	rollout.Status.PauseConditions = nil
+	rollout.Status.Phase = argov1alpha1.RolloutPhaseHealthy
+	replicaSet, workloadVersion := makeCanaryWorkloadVersion(rollout)
+	workloadVersion.Status.CanaryStatus = makeCanaryStatusWithEvaluation("my-evaluation")
+	workloadVersion.Status.CanaryStatus.Steps[0].Status = apicommon.StateSucceeded
+
+	r, _ := setupCanaryReconciler(rollout, replicaSet, workloadVersion)
+
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateSucceeded, keptnState)
+	require.Equal(t, int32(4), *workloadVersion.Status.CanaryStatus.CurrentStepIndex)
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_CanaryOfOtherRevisionIgnored(t *testing.T) {
+	rollout := makeCanaryRollout(1, true)
+	replicaSet, workloadVersion := makeCanaryWorkloadVersion(rollout)
+	replicaSet.Labels[argov1alpha1.DefaultRolloutUniqueLabelKey] = "old-hash"
+
+	r, _ := setupCanaryReconciler(rollout, replicaSet, workloadVersion)
+
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateProgressing, keptnState)
+	require.Nil(t, workloadVersion.Status.CanaryStatus)
+}
+
+func setupCanaryReconciler(objs ...client.Object) (*KeptnWorkloadVersionReconciler, *record.FakeRecorder) {
+	recorder := record.NewFakeRecorder(100)
+	return &KeptnWorkloadVersionReconciler{
+		Client:      testcommon.NewTestClient(objs...),
+		Scheme:      scheme.Scheme,
+		EventSender: eventsender.NewK8sSender(recorder),
+		Log:         ctrl.Log.WithName("test-canary"),
+		Config:      config.Instance(),
+	}, recorder
+}
+
+func makeCanaryRollout(currentStepIndex int32, paused bool) *argov1alpha1.Rollout {
+	weight := int32(20)
+	rollout := &argov1alpha1.Rollout{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-rollout",
+			Namespace: "default",
+			UID:       "my-rollout",
+		},
+		Spec: argov1alpha1.RolloutSpec{
+			Paused: paused,
+			Strategy: argov1alpha1.RolloutStrategy{
+				Canary: &argov1alpha1.CanaryStrategy{
+					Steps: []argov1alpha1.CanaryStep{
+						{SetWeight: &weight},
+						{Pause: &argov1alpha1.RolloutPause{}},
+						{SetWeight: &weight},
+						{Pause: &argov1alpha1.RolloutPause{}},
+					},
+				},
+			},
+		},
+		Status: argov1alpha1.RolloutStatus{
+			CurrentPodHash:   "new-hash",
+			CurrentStepIndex: &currentStepIndex,
+			Replicas:         2,
+			UpdatedReplicas:  1,
+			Phase:            argov1alpha1.RolloutPhasePaused,
+			PauseConditions: []argov1alpha1.PauseCondition{
+				{
+					Reason:    argov1alpha1.PauseReasonCanaryPauseStep,
+					StartTime: metav1.Now(),
+				},
+			},
+		},
+	}
+	if currentStepIndex == int32(len(rollout.Spec.Strategy.Canary.Steps)) {
+		rollout.Status.UpdatedReplicas = rollout.Status.Replicas
+	}
+	return rollout
+}
+
+func makeCanaryWorkloadVersion(rollout *argov1alpha1.Rollout) (*appsv1.ReplicaSet, *apilifecycle.KeptnWorkloadVersion) {
+	rep := int32(1)
+	isController := true
+	replicaSet := makeReplicaSet("my-rollout-new-hash", "default", &rep, 1)
+	replicaSet.Labels = map[string]string{
+		argov1alpha1.DefaultRolloutUniqueLabelKey: "new-hash",
+	}
+	replicaSet.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: "argoproj.io/v1alpha1",
+			Kind:       "Rollout",
+			Name:       rollout.Name,
+			UID:        rollout.UID,
+			Controller: &isController,
+		},
+	}
+	workloadVersion := makeWorkloadVersionWithRef(replicaSet.ObjectMeta, "ReplicaSet")
+	workloadVersion.Spec.CanaryEvaluations = []string{"canary-check"}
+	return replicaSet, workloadVersion
+}
+
+func makeCanaryEvaluation(state apicommon.KeptnState) *apilifecycle.KeptnEvaluation {
+	return &apilifecycle.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: "default",
+		},
+		Status: apilifecycle.KeptnEvaluationStatus{
+			OverallStatus: state,
+		},
+	}
+}
+
+func makeCanaryStatusWithEvaluation(evaluationName string) *apilifecycle.CanaryStatus {
+	return &apilifecycle.CanaryStatus{
+		Steps: []apilifecycle.CanaryStepStatus{
+			{
+				StepIndex: 1,
+				Status:    apicommon.StateProgressing,
+				EvaluationStatus: []apilifecycle.ItemStatus{
+					{
+						DefinitionName: "canary-check",
+						Name:           evaluationName,
+						Status:         apicommon.StateProgressing,
+					},
+				},
+			},
+		},
+	}
+}
@@ -1,42 +1,55 @@
 package keptnworkloadversion
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
 	apilifecycle "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
 )
 
+var knativeRevisionGVK = schema.GroupVersionKind{Group: "serving.knative.dev", Version: "v1", Kind: "Revision"}
+
 func (r *KeptnWorkloadVersionReconciler) reconcileDeployment(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion) (apicommon.KeptnState, error) {
 	var isRunning bool
 	var err error
 
 	if r.isDeploymentTimedOut(workloadVersion) {
-		workloadVersion.Status.DeploymentStatus = apicommon.StateFailed
-		err = r.Client.Status().Update(ctx, workloadVersion)
-		if err != nil {
-			return apicommon.StateUnknown, err
-		}
-		r.EventSender.Emit(apicommon.PhaseWorkloadDeployment, "Warning", workloadVersion, apicommon.PhaseStateFinished, "has reached timeout", workloadVersion.GetVersion())
-		return workloadVersion.Status.DeploymentStatus, nil
+		return r.failDeployment(ctx, workloadVersion, "has reached timeout")
 	}
 
 	switch workloadVersion.Spec.ResourceReference.Kind {
 	case "ReplicaSet":
-		isRunning, err = r.isReplicaSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
+		isRunning, err = r.isReplicaSetRunning(ctx, workloadVersion)
 	case "StatefulSet":
 		isRunning, err = r.isStatefulSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
 	case "DaemonSet":
 		isRunning, err = r.isDaemonSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
+	case "Job":
+		isRunning, err = r.isJobCompleted(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
 	default:
-		isRunning, err = false, controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+		isRunning, err = r.isCustomWorkloadReady(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
 	}
 
+	if errors.Is(err, controllererrors.ErrWorkloadResourceFailed) {
+		return r.failDeployment(ctx, workloadVersion, err.Error())
+	}
 	if err != nil {
 		return apicommon.StateUnknown, err
 	}
@@ -46,8 +59,13 @@ func (r *KeptnWorkloadVersionReconciler) reconcileDeployment(ctx context.Context
 		workloadVersion.Status.DeploymentStatus = apicommon.StateProgressing
 	}
 
-	if isRunning {
+	if workloadVersion.IsCanaryFailed() {
+		workloadVersion.Status.DeploymentStatus = apicommon.StateFailed
+	} else if isRunning {
 		workloadVersion.Status.DeploymentStatus = apicommon.StateSucceeded
+		if workloadVersion.Status.DeployedTemplate == nil {
+			r.recordDeployedTemplate(ctx, workloadVersion)
+		}
 	}
 
 	err = r.Client.Status().Update(ctx, workloadVersion)
@@ -57,6 +75,15 @@ func (r *KeptnWorkloadVersionReconciler) reconcileDeployment(ctx context.Context
 	return workloadVersion.Status.DeploymentStatus, nil
 }
 
+func (r *KeptnWorkloadVersionReconciler) failDeployment(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion, message string) (apicommon.KeptnState, error) {
+	workloadVersion.Status.DeploymentStatus = apicommon.StateFailed
+	if err := r.Client.Status().Update(ctx, workloadVersion); err != nil {
+		return apicommon.StateUnknown, err
+	}
+	r.EventSender.Emit(apicommon.PhaseWorkloadDeployment, "Warning", workloadVersion, apicommon.PhaseStateFinished, message, workloadVersion.GetVersion())
+	return workloadVersion.Status.DeploymentStatus, nil
+}
+
 func (r *KeptnWorkloadVersionReconciler) isDeploymentTimedOut(workloadVersion *apilifecycle.KeptnWorkloadVersion) bool {
 	if !workloadVersion.IsDeploymentStartTimeSet() {
 		return false
@@ -67,19 +94,27 @@ func (r *KeptnWorkloadVersionReconciler) isDeploymentTimedOut(workloadVersion *a
 	return currentTime.After(deploymentDeadline)
 }
 
-func (r *KeptnWorkloadVersionReconciler) isReplicaSetRunning(ctx context.Context, resource apilifecycle.ResourceReference, namespace string) (bool, error) {
+func (r *KeptnWorkloadVersionReconciler) isReplicaSetRunning(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion) (bool, error) {
 	rep := appsv1.ReplicaSet{}
-	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, &rep)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: workloadVersion.Spec.ResourceReference.Name, Namespace: workloadVersion.Namespace}, &rep)
 	if err != nil {
 		return false, err
 	}
 
 	for _, ownerRef := range rep.OwnerReferences {
 		if ownerRef.Kind == "Rollout" {
-			return r.isRolloutRunning(ctx, apilifecycle.ResourceReference{Name: ownerRef.Name, UID: ownerRef.UID}, namespace)
+			if len(workloadVersion.Spec.CanaryEvaluations) > 0 {
+				return r.isCanaryRolloutRunning(ctx, workloadVersion, &rep, ownerRef.Name)
+			}
+			return r.isRolloutRunning(ctx, apilifecycle.ResourceReference{Name: ownerRef.Name, UID: ownerRef.UID}, workloadVersion.Namespace)
 		}
 	}
 
+	// the ReplicaSets of Knative Services are scaled by Knative, so the readiness of their Revision is checked instead
+	if revisionName, ok := rep.Labels[apicommon.KnativeRevisionLabel]; ok {
+		return r.isKnativeRevisionReady(ctx, revisionName, workloadVersion.Namespace)
+	}
+
 	return *rep.Spec.Replicas == rep.Status.AvailableReplicas, nil
 }
 
@@ -101,6 +136,72 @@ func (r *KeptnWorkloadVersionReconciler) isStatefulSetRunning(ctx context.Contex
 	return *sts.Spec.Replicas == sts.Status.AvailableReplicas, nil
 }
 
+// isJobCompleted returns whether the Job has completed successfully, and an error wrapping ErrWorkloadResourceFailed if it has failed
+func (r *KeptnWorkloadVersionReconciler) isJobCompleted(ctx context.Context, resource apilifecycle.ResourceReference, namespace string) (bool, error) {
+	job := &batchv1.Job{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, job)
+	if err != nil {
+		return false, err
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("%w: Job %s failed: %s", controllererrors.ErrWorkloadResourceFailed, job.Name, condition.Message)
+		}
+	}
+	return false, nil
+}
+
+func (r *KeptnWorkloadVersionReconciler) isKnativeRevisionReady(ctx context.Context, revisionName string, namespace string) (bool, error) {
+	revision := &unstructured.Unstructured{}
+	revision.SetGroupVersionKind(knativeRevisionGVK)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: revisionName, Namespace: namespace}, revision)
+	if err != nil {
+		return false, err
+	}
+	conditions, _, err := unstructured.NestedSlice(revision.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Ready" {
+			return condition["status"] == string(corev1.ConditionTrue), nil
+		}
+	}
+	return false, nil
+}
+
+// isCustomWorkloadReady evaluates the ReadyJSONPath of the CustomWorkloadKind configured for the API version and kind of the resource
+func (r *KeptnWorkloadVersionReconciler) isCustomWorkloadReady(ctx context.Context, resource apilifecycle.ResourceReference, namespace string) (bool, error) {
+	customKind, ok := config.GetCustomWorkloadKind(r.Config, resource.APIVersion, resource.Kind)
+	if !ok {
+		return false, controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	}
+
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(customKind.GroupVersionKind())
+	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, workload)
+	if err != nil {
+		return false, err
+	}
+
+	jp := jsonpath.New("ready").AllowMissingKeys(true)
+	if err := jp.Parse(customKind.ReadyJSONPath); err != nil {
+		return false, fmt.Errorf("could not parse JSONPath %s of %s: %w", customKind.ReadyJSONPath, customKind.Kind, err)
+	}
+	buf := &bytes.Buffer{}
+	if err := jp.Execute(buf, workload.Object); err != nil {
+		return false, err
+	}
+	return buf.String() == customKind.ReadyValue, nil
+}
+
 func (r *KeptnWorkloadVersionReconciler) isRolloutRunning(ctx context.Context, resource apilifecycle.ResourceReference, namespace string) (bool, error) {
 	rollout := argov1alpha1.Rollout{}
 	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, &rollout)
@@ -109,3 +210,57 @@ func (r *KeptnWorkloadVersionReconciler) isRolloutRunning(ctx context.Context, r
 	}
 	return rollout.Status.Replicas == rollout.Status.UpdatedReplicas && rollout.Status.Phase == argov1alpha1.RolloutPhaseHealthy, nil
 }
+
+// recordDeployedTemplate stores the pod template of the workload resource in the status of the KeptnWorkloadVersion,
+// so that it can be restored when a later version of the KeptnApp is rolled back
+func (r *KeptnWorkloadVersionReconciler) recordDeployedTemplate(ctx context.Context, workloadVersion *apilifecycle.KeptnWorkloadVersion) {
+	owner, template, err := r.getDeployedTemplate(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
+	if err != nil {
+		r.Log.Error(err, "could not retrieve pod template of KeptnWorkloadVersion", "workloadVersion", workloadVersion.Name)
+		return
+	}
+
+	raw, err := json.Marshal(template)
+	if err != nil {
+		r.Log.Error(err, "could not marshal pod template of KeptnWorkloadVersion", "workloadVersion", workloadVersion.Name)
+		return
+	}
+
+	workloadVersion.Status.DeployedTemplate = &apilifecycle.DeployedTemplate{
+		Owner:    owner,
+		Template: runtime.RawExtension{Raw: raw},
+	}
+}
+
+func (r *KeptnWorkloadVersionReconciler) getDeployedTemplate(ctx context.Context, resource apilifecycle.ResourceReference, namespace string) (apilifecycle.ResourceReference, *corev1.PodTemplateSpec, error) {
+	switch resource.Kind {
+	case "ReplicaSet":
+		rep := &appsv1.ReplicaSet{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, rep); err != nil {
+			return apilifecycle.ResourceReference{}, nil, err
+		}
+		ownerRef := metav1.GetControllerOf(rep)
+		if ownerRef == nil || (ownerRef.Kind != "Deployment" && ownerRef.Kind != "Rollout") {
+			return apilifecycle.ResourceReference{}, nil, fmt.Errorf("%w: ReplicaSet %s is not owned by a Deployment or Rollout", controllererrors.ErrUnsupportedWorkloadVersionResourceReference, rep.Name)
+		}
+		// the pod template hash labels are added by the Deployment and Rollout controllers and must not be restored
+		template := rep.Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		delete(template.Labels, argov1alpha1.DefaultRolloutUniqueLabelKey)
+		return apilifecycle.ResourceReference{UID: ownerRef.UID, Kind: ownerRef.Kind, Name: ownerRef.Name}, template, nil
+	case "StatefulSet":
+		sts := &appsv1.StatefulSet{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, sts); err != nil {
+			return apilifecycle.ResourceReference{}, nil, err
+		}
+		return apilifecycle.ResourceReference{UID: sts.UID, Kind: resource.Kind, Name: sts.Name}, sts.Spec.Template.DeepCopy(), nil
+	case "DaemonSet":
+		ds := &appsv1.DaemonSet{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, ds); err != nil {
+			return apilifecycle.ResourceReference{}, nil, err
+		}
+		return apilifecycle.ResourceReference{UID: ds.UID, Kind: resource.Kind, Name: ds.Name}, ds.Spec.Template.DeepCopy(), nil
+	default:
+		return apilifecycle.ResourceReference{}, nil, controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	}
+}
@@ -79,6 +79,7 @@ func (r *KeptnConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	r.config.SetBlockDeployment(cfg.Spec.BlockDeployment)
 	r.config.SetObservabilityTimeout(cfg.Spec.ObservabilityTimeout)
 	r.config.SetRestApiEnabled(cfg.Spec.RestApiEnabled)
+	r.config.SetCustomWorkloadKinds(cfg.Spec.CustomWorkloadKinds)
 	result, err := r.reconcileOtelCollectorUrl(cfg)
 	if err != nil {
 		return result, err
@@ -250,6 +250,37 @@ func TestKeptnConfigReconciler_Reconcile(t *testing.T) {
 	}
 }
 
+func TestKeptnConfigReconciler_Reconcile_CustomWorkloadKinds(t *testing.T) {
+	customWorkloadKinds := []optionsv1alpha1.CustomWorkloadKind{
+		{
+			Group:         "apps.kruise.io",
+			Version:       "v1alpha1",
+			Kind:          "CloneSet",
+			ReadyJSONPath: `{.status.conditions[?(@.type=="Ready")].status}`,
+			ReadyValue:    "True",
+		},
+	}
+	cfg := &optionsv1alpha1.KeptnConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config1",
+			Namespace: "keptn-system",
+		},
+		Spec: optionsv1alpha1.KeptnConfigSpec{
+			CustomWorkloadKinds: customWorkloadKinds,
+		},
+	}
+
+	reconciler := setupReconciler(cfg)
+	var gotKinds []optionsv1alpha1.CustomWorkloadKind
+	reconciler.config.(*fakeconfig.MockConfig).SetCustomWorkloadKindsFunc = func(kinds []optionsv1alpha1.CustomWorkloadKind) {
+		gotKinds = kinds
+	}
+
+	_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "keptn-system", Name: "config1"}})
+	require.Nil(t, err)
+	require.Equal(t, customWorkloadKinds, gotKinds)
+}
+
 func TestKeptnConfigReconciler_initConfig(t *testing.T) {
 	type fields struct {
 		Client          client.Client
@@ -390,6 +421,7 @@ func setupReconciler(withConfig *optionsv1alpha1.KeptnConfig) *KeptnConfigReconc
 		SetBlockDeploymentFunc:        func(value bool) {},
 		SetObservabilityTimeoutFunc:   func(timeout metav1.Duration) {},
 		SetRestApiEnabledFunc:         func(value bool) {},
+		SetCustomWorkloadKindsFunc:    func(kinds []optionsv1alpha1.CustomWorkloadKind) {},
 	}
 	return r
 }
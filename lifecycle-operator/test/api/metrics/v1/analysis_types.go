@@ -0,0 +1,82 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnalysisSpec defines the desired state of Analysis
+type AnalysisSpec struct {
+	// Timeframe specifies the range for the corresponding query in the AnalysisValueTemplate
+	Timeframe `json:"timeframe"`
+	// Args corresponds to a map of key/value pairs that can be used to substitute placeholders in the AnalysisValueTemplate query
+	// +optional
+	Args map[string]string `json:"args,omitempty"`
+	// AnalysisDefinition refers to the AnalysisDefinition, a CRD that stores the AnalysisValuesTemplates
+	AnalysisDefinition ObjectReference `json:"analysisDefinition"`
+}
+
+type Timeframe struct {
+	// From is the time of start for the query. This field follows RFC3339 time format
+	// +optional
+	From metav1.Time `json:"from,omitempty"`
+	// To is the time of end for the query. This field follows RFC3339 time format
+	// +optional
+	To metav1.Time `json:"to,omitempty"`
+	// Recent describes a recent timeframe using a duration string
+	// +optional
+	Recent metav1.Duration `json:"recent,omitempty"`
+}
+
+// ObjectReference represents a reference to an object
+type ObjectReference struct {
+	// Name defines the name of the referenced object
+	Name string `json:"name"`
+	// Namespace defines the namespace of the referenced object
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AnalysisStatus stores the status of the overall analysis returns also pass or warnings
+type AnalysisStatus struct {
+	// Timeframe describes the time frame which is evaluated by the Analysis
+	Timeframe Timeframe `json:"timeframe"`
+	// Raw contains the raw result of the SLO computation
+	// +optional
+	Raw string `json:"raw,omitempty"`
+	// Pass returns whether the SLO is satisfied
+	// +optional
+	Pass bool `json:"pass,omitempty"`
+	// Warning returns whether the analysis returned a warning
+	// +optional
+	Warning bool `json:"warning,omitempty"`
+	// State describes the current state of the Analysis (Pending/Progressing/Completed)
+	State string `json:"state"`
+}
+
+// +kubebuilder:object:root=true
+
+// Analysis is the Schema for the analyses API
+type Analysis struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec AnalysisSpec `json:"spec,omitempty"`
+	// +optional
+	Status AnalysisStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AnalysisList contains a list of Analysis resources
+type AnalysisList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Analysis `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Analysis{}, &AnalysisList{})
+}
@@ -34,6 +34,22 @@ type KeptnMetricStatus struct {
 	// IntervalResults contain a slice of all the interval results
 	// +optional
 	IntervalResults []IntervalResult `json:"intervalResults,omitempty"`
+	// Results contain the value of each series with its labels, if the query returned more than one series
+	// +optional
+	Results []MetricResult `json:"results,omitempty"`
+	// Conditions represent the latest observations of the metric.
+	// The Anomalous condition is True if the latest value has been detected as anomaly.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// MetricResult represents the value of a single series of a query result
+type MetricResult struct {
+	// Labels represent the label set identifying the series
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Value represents the resulting value of the series
+	Value string `json:"value"`
 }
 
 // ProviderRef represents the provider object
@@ -73,6 +89,8 @@ type IntervalResult struct {
 	ErrMsg string `json:"errMsg,omitempty"`
 }
 
+// +kubebuilder:object:root=true
+
 // KeptnMetric is the Schema for the keptnmetrics API
 type KeptnMetric struct {
 	metav1.TypeMeta `json:",inline"`
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceLevelObjectiveSpec defines the desired state of ServiceLevelObjective
+type ServiceLevelObjectiveSpec struct {
+	// FetchIntervalSeconds represents the update frequency in seconds that is used to update the status
+	// +optional
+	FetchIntervalSeconds uint `json:"fetchIntervalSeconds,omitempty"`
+}
+
+// ServiceLevelObjectiveStatus defines the observed state of ServiceLevelObjective
+type ServiceLevelObjectiveStatus struct {
+	// SLI is the percentage of good events within the window
+	// +optional
+	SLI string `json:"sli,omitempty"`
+	// ErrorBudgetRemaining is the percentage of the error budget of the window which has not been consumed yet
+	// +optional
+	ErrorBudgetRemaining string `json:"errorBudgetRemaining,omitempty"`
+	// Exhausted indicates whether the error budget of the window has been consumed completely
+	// +optional
+	Exhausted bool `json:"exhausted,omitempty"`
+	// LastUpdated represents the time when the status data was last updated
+	// +optional
+	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
+	// ErrMsg represents the error details when the queries could not be evaluated
+	// +optional
+	ErrMsg string `json:"errMsg,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ServiceLevelObjective is the Schema for the servicelevelobjectives API
+type ServiceLevelObjective struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ServiceLevelObjectiveSpec `json:"spec,omitempty"`
+	// +optional
+	Status ServiceLevelObjectiveStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceLevelObjectiveList contains a list of ServiceLevelObjective resources
+type ServiceLevelObjectiveList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceLevelObjective `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServiceLevelObjective{}, &ServiceLevelObjectiveList{})
+}
@@ -21,9 +21,109 @@ limitations under the License.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Analysis) DeepCopyInto(out *Analysis) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Analysis.
+func (in *Analysis) DeepCopy() *Analysis {
+	if in == nil {
+		return nil
+	}
+	out := new(Analysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Analysis) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnalysisList) DeepCopyInto(out *AnalysisList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Analysis, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalysisList.
+func (in *AnalysisList) DeepCopy() *AnalysisList {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalysisList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AnalysisList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnalysisSpec) DeepCopyInto(out *AnalysisSpec) {
+	*out = *in
+	in.Timeframe.DeepCopyInto(&out.Timeframe)
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.AnalysisDefinition = in.AnalysisDefinition
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalysisSpec.
+func (in *AnalysisSpec) DeepCopy() *AnalysisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalysisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnalysisStatus) DeepCopyInto(out *AnalysisStatus) {
+	*out = *in
+	in.Timeframe.DeepCopyInto(&out.Timeframe)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalysisStatus.
+func (in *AnalysisStatus) DeepCopy() *AnalysisStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalysisStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntervalResult) DeepCopyInto(out *IntervalResult) {
 	*out = *in
@@ -141,6 +241,20 @@ func (in *KeptnMetricStatus) DeepCopyInto(out *KeptnMetricStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]MetricResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnMetricStatus.
@@ -153,6 +267,43 @@ func (in *KeptnMetricStatus) DeepCopy() *KeptnMetricStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricResult) DeepCopyInto(out *MetricResult) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricResult.
+func (in *MetricResult) DeepCopy() *MetricResult {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderRef) DeepCopyInto(out *ProviderRef) {
 	*out = *in
@@ -182,3 +333,111 @@ func (in *RangeSpec) DeepCopy() *RangeSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceLevelObjective) DeepCopyInto(out *ServiceLevelObjective) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjective.
+func (in *ServiceLevelObjective) DeepCopy() *ServiceLevelObjective {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceLevelObjective)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceLevelObjective) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceLevelObjectiveList) DeepCopyInto(out *ServiceLevelObjectiveList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServiceLevelObjective, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjectiveList.
+func (in *ServiceLevelObjectiveList) DeepCopy() *ServiceLevelObjectiveList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceLevelObjectiveList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceLevelObjectiveList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceLevelObjectiveSpec) DeepCopyInto(out *ServiceLevelObjectiveSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjectiveSpec.
+func (in *ServiceLevelObjectiveSpec) DeepCopy() *ServiceLevelObjectiveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceLevelObjectiveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceLevelObjectiveStatus) DeepCopyInto(out *ServiceLevelObjectiveStatus) {
+	*out = *in
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjectiveStatus.
+func (in *ServiceLevelObjectiveStatus) DeepCopy() *ServiceLevelObjectiveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceLevelObjectiveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Timeframe) DeepCopyInto(out *Timeframe) {
+	*out = *in
+	in.From.DeepCopyInto(&out.From)
+	in.To.DeepCopyInto(&out.To)
+	out.Recent = in.Recent
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Timeframe.
+func (in *Timeframe) DeepCopy() *Timeframe {
+	if in == nil {
+		return nil
+	}
+	out := new(Timeframe)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -7,6 +7,7 @@ import (
 
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
 	operatorcommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -63,7 +64,7 @@ func GetOwnerReference(resource *metav1.ObjectMeta) metav1.OwnerReference {
 	reference := metav1.OwnerReference{}
 	if len(resource.OwnerReferences) != 0 {
 		for _, owner := range resource.OwnerReferences {
-			if apicommon.IsOwnerSupported(owner) {
+			if isOwnerSupported(owner) {
 				reference.UID = owner.UID
 				reference.Kind = owner.Kind
 				reference.Name = owner.Name
@@ -74,6 +75,15 @@ func GetOwnerReference(resource *metav1.ObjectMeta) metav1.OwnerReference {
 	return reference
 }
 
+// isOwnerSupported returns whether the owner is natively supported by Keptn or configured as CustomWorkloadKind in the KeptnConfig
+func isOwnerSupported(owner metav1.OwnerReference) bool {
+	if apicommon.IsOwnerSupported(owner) {
+		return true
+	}
+	_, ok := config.GetCustomWorkloadKind(config.Instance(), owner.APIVersion, owner.Kind)
+	return ok
+}
+
 func setMapKey(myMap map[string]string, key, value string) {
 	if myMap == nil {
 		return
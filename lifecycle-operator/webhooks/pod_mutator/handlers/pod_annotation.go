@@ -7,9 +7,13 @@ import (
 	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
 	"github.com/go-logr/logr"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -21,15 +25,23 @@ type PodAnnotationHandler struct {
 }
 
 func (p *PodAnnotationHandler) IsAnnotated(ctx context.Context, req *admission.Request, pod *corev1.Pod) bool {
+	ownerJob := p.fetchOwnerJob(ctx, req, pod)
+	if ownerJob != nil && isKeptnTaskJob(ownerJob) {
+		// the pods of KeptnTasks carry the annotations of the workload they are executed for,
+		// but must not be treated as part of it
+		return false
+	}
 	podIsAnnotated := isPodAnnotated(pod)
 	if !podIsAnnotated {
 		p.Log.Info("Pod is not annotated, check for parent annotations...")
-		podIsAnnotated = p.copyAnnotationsIfParentAnnotated(ctx, req, pod)
+		podIsAnnotated = p.copyAnnotationsIfParentAnnotated(ctx, req, pod, ownerJob)
 	}
 	return podIsAnnotated
 }
 
-func (p *PodAnnotationHandler) copyAnnotationsIfParentAnnotated(ctx context.Context, req *admission.Request, pod *corev1.Pod) bool {
+// copyAnnotationsIfParentAnnotated copies the annotations of the parent of the pod to the pod.
+// The Job owning the pod is passed in by the caller, as it has already been fetched to check whether the pod belongs to a KeptnTask.
+func (p *PodAnnotationHandler) copyAnnotationsIfParentAnnotated(ctx context.Context, req *admission.Request, pod *corev1.Pod, ownerJob *batchv1.Job) bool {
 	podOwner := GetOwnerReference(&pod.ObjectMeta)
 	if podOwner.UID == "" {
 		return false
@@ -47,6 +59,10 @@ func (p *PodAnnotationHandler) copyAnnotationsIfParentAnnotated(ctx context.Cont
 			return false
 		}
 
+		if customWorkloadMetaData, ok := p.fetchCustomWorkloadParent(ctx, rsOwner, req.Namespace); ok {
+			return copyResourceLabelsIfPresent(customWorkloadMetaData, pod)
+		}
+
 		if rsOwner.Kind == "Rollout" {
 			ro := &argov1alpha1.Rollout{}
 			objectContainerMetaData := p.fetchParent(ctx, types.NamespacedName{Name: rsOwner.Name, Namespace: req.Namespace}, ro)
@@ -54,8 +70,27 @@ func (p *PodAnnotationHandler) copyAnnotationsIfParentAnnotated(ctx context.Cont
 		}
 		dp := &appsv1.Deployment{}
 		objectContainerMetaData := p.fetchParent(ctx, types.NamespacedName{Name: rsOwner.Name, Namespace: req.Namespace}, dp)
+		if serviceName, ok := dp.Labels[apicommon.KnativeServiceLabel]; ok {
+			// the Deployments of Knative Services are managed by Knative, so the annotations are taken from the Service
+			objectContainerMetaData = p.fetchParent(ctx, types.NamespacedName{Name: serviceName, Namespace: req.Namespace}, newKnativeService())
+		}
 		return copyResourceLabelsIfPresent(objectContainerMetaData, pod)
 
+	case "Job":
+		if ownerJob == nil || isKeptnTaskJob(ownerJob) {
+			return false
+		}
+		objectContainerMetaData := &metav1.ObjectMeta{
+			Labels:      ownerJob.GetLabels(),
+			Annotations: ownerJob.GetAnnotations(),
+		}
+
+		jobOwner := GetOwnerReference(&ownerJob.ObjectMeta)
+		if jobOwner.Kind == "CronJob" {
+			cj := &batchv1.CronJob{}
+			objectContainerMetaData = p.fetchParent(ctx, types.NamespacedName{Name: jobOwner.Name, Namespace: req.Namespace}, cj)
+		}
+		return copyResourceLabelsIfPresent(objectContainerMetaData, pod)
 	case "StatefulSet":
 		sts := &appsv1.StatefulSet{}
 		objectContainerMetaData := p.fetchParent(ctx, types.NamespacedName{Name: podOwner.Name, Namespace: req.Namespace}, sts)
@@ -65,10 +100,49 @@ func (p *PodAnnotationHandler) copyAnnotationsIfParentAnnotated(ctx context.Cont
 		objectContainerMetaData := p.fetchParent(ctx, types.NamespacedName{Name: podOwner.Name, Namespace: req.Namespace}, ds)
 		return copyResourceLabelsIfPresent(objectContainerMetaData, pod)
 	default:
-		return false
+		objectContainerMetaData, ok := p.fetchCustomWorkloadParent(ctx, podOwner, req.Namespace)
+		if !ok {
+			return false
+		}
+		return copyResourceLabelsIfPresent(objectContainerMetaData, pod)
 	}
 }
 
+// fetchCustomWorkloadParent fetches the metadata of the given owner if its kind is configured as CustomWorkloadKind in the KeptnConfig
+func (p *PodAnnotationHandler) fetchCustomWorkloadParent(ctx context.Context, owner metav1.OwnerReference, namespace string) (*metav1.ObjectMeta, bool) {
+	customKind, ok := config.GetCustomWorkloadKind(config.Instance(), owner.APIVersion, owner.Kind)
+	if !ok {
+		return nil, false
+	}
+	parent := &unstructured.Unstructured{}
+	parent.SetGroupVersionKind(customKind.GroupVersionKind())
+	return p.fetchParent(ctx, types.NamespacedName{Name: owner.Name, Namespace: namespace}, parent), true
+}
+
+// fetchOwnerJob returns the Job owning the pod, or nil if the pod is not owned by a Job or it cannot be fetched
+func (p *PodAnnotationHandler) fetchOwnerJob(ctx context.Context, req *admission.Request, pod *corev1.Pod) *batchv1.Job {
+	podOwner := GetOwnerReference(&pod.ObjectMeta)
+	if podOwner.Kind != "Job" {
+		return nil
+	}
+	job := &batchv1.Job{}
+	if p.fetchParent(ctx, types.NamespacedName{Name: podOwner.Name, Namespace: req.Namespace}, job) == nil {
+		return nil
+	}
+	return job
+}
+
+func isKeptnTaskJob(job *batchv1.Job) bool {
+	_, ok := job.Annotations[apicommon.TaskNameAnnotation]
+	return ok
+}
+
+func newKnativeService() *unstructured.Unstructured {
+	service := &unstructured.Unstructured{}
+	service.SetGroupVersionKind(schema.GroupVersionKind{Group: "serving.knative.dev", Version: "v1", Kind: "Service"})
+	return service
+}
+
 func (p *PodAnnotationHandler) fetchParent(ctx context.Context, name types.NamespacedName, objectContainer client.Object) *metav1.ObjectMeta {
 	if err := p.Client.Get(ctx, name, objectContainer); err != nil {
 		p.Log.Info("Could not find pod parent")
@@ -84,9 +158,9 @@ func copyResourceLabelsIfPresent(sourceResource *metav1.ObjectMeta, targetPod *c
 	if sourceResource == nil {
 		return false
 	}
-	var workloadName, appName, version, preDeploymentChecks, postDeploymentChecks, preEvaluationChecks, postEvaluationChecks string
+	var workloadName, appName, version, preDeploymentChecks, postDeploymentChecks, preEvaluationChecks, postEvaluationChecks, canaryEvaluationChecks string
 	var gotWorkloadName, gotVersion bool
-	initEmptyAnnotations(&targetPod.ObjectMeta, 7)
+	initEmptyAnnotations(&targetPod.ObjectMeta, 8)
 
 	workloadName, gotWorkloadName = GetLabelOrAnnotation(sourceResource, apicommon.WorkloadAnnotation, apicommon.K8sRecommendedWorkloadAnnotations)
 	appName, _ = GetLabelOrAnnotation(sourceResource, apicommon.AppAnnotation, apicommon.K8sRecommendedAppAnnotations)
@@ -95,6 +169,7 @@ func copyResourceLabelsIfPresent(sourceResource *metav1.ObjectMeta, targetPod *c
 	postDeploymentChecks, _ = GetLabelOrAnnotation(sourceResource, apicommon.PostDeploymentTaskAnnotation, "")
 	preEvaluationChecks, _ = GetLabelOrAnnotation(sourceResource, apicommon.PreDeploymentEvaluationAnnotation, "")
 	postEvaluationChecks, _ = GetLabelOrAnnotation(sourceResource, apicommon.PostDeploymentEvaluationAnnotation, "")
+	canaryEvaluationChecks, _ = GetLabelOrAnnotation(sourceResource, apicommon.CanaryEvaluationAnnotation, "")
 	containerName, _ := GetLabelOrAnnotation(sourceResource, apicommon.ContainerNameAnnotation, "")
 	metadata, _ := GetLabelOrAnnotation(sourceResource, apicommon.MetadataAnnotation, "")
 
@@ -117,6 +192,7 @@ func copyResourceLabelsIfPresent(sourceResource *metav1.ObjectMeta, targetPod *c
 		setMapKey(targetPod.Annotations, apicommon.PostDeploymentTaskAnnotation, postDeploymentChecks)
 		setMapKey(targetPod.Annotations, apicommon.PreDeploymentEvaluationAnnotation, preEvaluationChecks)
 		setMapKey(targetPod.Annotations, apicommon.PostDeploymentEvaluationAnnotation, postEvaluationChecks)
+		setMapKey(targetPod.Annotations, apicommon.CanaryEvaluationAnnotation, canaryEvaluationChecks)
 		setMapKey(targetPod.Annotations, apicommon.MetadataAnnotation, metadata)
 
 		return true
@@ -7,15 +7,21 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/testr"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1/common"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
 	"github.com/stretchr/testify/require"
 	admissionv1 "k8s.io/api/admission/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -100,16 +106,83 @@ func TestCopyAnnotationsIfParentAnnotated(t *testing.T) {
 		},
 	}
 
-	fakeClient := testcommon.NewTestClient(rsWithDpOwner, rsWithNoOwner, testDp, testSts, testDs)
+	testCj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cron-job",
+			UID:       "this-is-the-cron-job-uid",
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				apicommon.WorkloadAnnotation: workloadName,
+			},
+		},
+	}
+	jobWithCjOwner := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cron-job-1234",
+			UID:       "this-is-the-job-with-cj-owner",
+			Namespace: testNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind: "CronJob",
+					Name: testCj.Name,
+					UID:  testCj.UID,
+				},
+			},
+		},
+	}
+	taskJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keptn-task-job",
+			UID:       "this-is-the-task-job",
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				apicommon.WorkloadAnnotation: workloadName,
+				apicommon.TaskNameAnnotation: "my-task",
+			},
+		},
+	}
+	knativeDp := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service-00001-deployment",
+			UID:       "this-is-the-knative-deployment-uid",
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				apicommon.KnativeServiceLabel: "my-service",
+			},
+		},
+	}
+	rsWithKnativeOwner := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service-00001-deployment-1234",
+			UID:       "this-is-the-replicaset-with-knative-owner",
+			Namespace: testNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind: "Deployment",
+					Name: knativeDp.Name,
+					UID:  knativeDp.UID,
+				},
+			},
+		},
+	}
+	knativeService := newKnativeService()
+	knativeService.SetName("my-service")
+	knativeService.SetNamespace(testNamespace)
+	knativeService.SetAnnotations(map[string]string{
+		apicommon.WorkloadAnnotation: workloadName,
+	})
+
+	fakeClient := testcommon.NewTestClient(rsWithDpOwner, rsWithNoOwner, testDp, testSts, testDs, testCj, jobWithCjOwner, taskJob, knativeDp, rsWithKnativeOwner, knativeService)
 
 	type fields struct {
 		Client client.Client
 		Log    logr.Logger
 	}
 	type args struct {
-		ctx context.Context
-		req *admission.Request
-		pod *corev1.Pod
+		ctx      context.Context
+		req      *admission.Request
+		pod      *corev1.Pod
+		ownerJob *batchv1.Job
 	}
 	tests := []struct {
 		name   string
@@ -222,6 +295,92 @@ func TestCopyAnnotationsIfParentAnnotated(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "Test fetching of job owner of pod and cronjob owner of job",
+			fields: fields{
+				Log:    testr.New(t),
+				Client: fakeClient,
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Namespace: testNamespace,
+					},
+				},
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: uid,
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								Name: jobWithCjOwner.Name,
+								UID:  jobWithCjOwner.UID,
+								Kind: "Job",
+							},
+						},
+					},
+				},
+				ownerJob: jobWithCjOwner,
+			},
+			want: true,
+		},
+		{
+			name: "Test that annotations of the job of a KeptnTask are not copied",
+			fields: fields{
+				Log:    testr.New(t),
+				Client: fakeClient,
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Namespace: testNamespace,
+					},
+				},
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: uid,
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								Name: taskJob.Name,
+								UID:  taskJob.UID,
+								Kind: "Job",
+							},
+						},
+					},
+				},
+				ownerJob: taskJob,
+			},
+			want: false,
+		},
+		{
+			name: "Test fetching of knative service owning the deployment of the replicaset of pod",
+			fields: fields{
+				Log:    testr.New(t),
+				Client: fakeClient,
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Namespace: testNamespace,
+					},
+				},
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: uid,
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								Name: rsWithKnativeOwner.Name,
+								UID:  rsWithKnativeOwner.UID,
+								Kind: "ReplicaSet",
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
 		{
 			name: "Test that method returns without doing anything when we get a pod with replicaset without owner",
 			fields: fields{
@@ -257,7 +416,7 @@ func TestCopyAnnotationsIfParentAnnotated(t *testing.T) {
 				Client: tt.fields.Client,
 				Log:    tt.fields.Log,
 			}
-			got := a.copyAnnotationsIfParentAnnotated(tt.args.ctx, tt.args.req, tt.args.pod)
+			got := a.copyAnnotationsIfParentAnnotated(tt.args.ctx, tt.args.req, tt.args.pod, tt.args.ownerJob)
 			if got != tt.want {
 				t.Errorf("copyAnnotationsIfParentAnnotated() got = %v, want %v", got, tt.want)
 			}
@@ -265,6 +424,109 @@ func TestCopyAnnotationsIfParentAnnotated(t *testing.T) {
 	}
 }
 
+func TestCopyAnnotationsIfParentAnnotated_CustomWorkloadKind(t *testing.T) {
+	testNamespace := "test-namespace"
+	cloneSetKind := optionsv1alpha1.CustomWorkloadKind{
+		Group:         "apps.kruise.io",
+		Version:       "v1alpha1",
+		Kind:          "CloneSet",
+		ReadyJSONPath: `{.status.conditions[?(@.type=="Ready")].status}`,
+	}
+	config.Instance().SetCustomWorkloadKinds([]optionsv1alpha1.CustomWorkloadKind{cloneSetKind})
+	t.Cleanup(func() {
+		config.Instance().SetCustomWorkloadKinds(nil)
+	})
+
+	cloneSet := &unstructured.Unstructured{}
+	cloneSet.SetGroupVersionKind(cloneSetKind.GroupVersionKind())
+	cloneSet.SetName("my-cloneset")
+	cloneSet.SetNamespace(testNamespace)
+	cloneSet.SetUID("this-is-the-cloneset-uid")
+	cloneSet.SetAnnotations(map[string]string{
+		apicommon.WorkloadAnnotation: workloadName,
+		apicommon.VersionAnnotation:  version,
+	})
+	rsWithCloneSetOwner := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cloneset-rs",
+			UID:       "this-is-the-replicaset-with-cloneset-owner",
+			Namespace: testNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps.kruise.io/v1alpha1",
+					Kind:       "CloneSet",
+					Name:       cloneSet.GetName(),
+					UID:        cloneSet.GetUID(),
+				},
+			},
+		},
+	}
+
+	fakeClient := testcommon.NewTestClient(cloneSet, rsWithCloneSetOwner)
+
+	tests := []struct {
+		name  string
+		owner metav1.OwnerReference
+		want  bool
+	}{
+		{
+			name: "pod owned by custom workload kind",
+			owner: metav1.OwnerReference{
+				APIVersion: "apps.kruise.io/v1alpha1",
+				Kind:       "CloneSet",
+				Name:       cloneSet.GetName(),
+				UID:        cloneSet.GetUID(),
+			},
+			want: true,
+		},
+		{
+			name: "pod owned by replicaset of custom workload kind",
+			owner: metav1.OwnerReference{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       rsWithCloneSetOwner.Name,
+				UID:        rsWithCloneSetOwner.UID,
+			},
+			want: true,
+		},
+		{
+			name: "pod owned by kind of other API version",
+			owner: metav1.OwnerReference{
+				APIVersion: "apps.kruise.io/v1beta1",
+				Kind:       "CloneSet",
+				Name:       cloneSet.GetName(),
+				UID:        cloneSet.GetUID(),
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &PodAnnotationHandler{
+				Client: fakeClient,
+				Log:    testr.New(t),
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:             uid,
+					OwnerReferences: []metav1.OwnerReference{tt.owner},
+				},
+			}
+			req := &admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Namespace: testNamespace,
+				},
+			}
+			got := a.copyAnnotationsIfParentAnnotated(context.TODO(), req, pod, nil)
+			require.Equal(t, tt.want, got)
+			if tt.want {
+				require.Equal(t, workloadName, pod.Annotations[apicommon.WorkloadAnnotation])
+				require.Equal(t, version, pod.Annotations[apicommon.VersionAnnotation])
+			}
+		})
+	}
+}
+
 func TestIsAnnotated(t *testing.T) {
 	testNamespace := "test-namespace"
 	rsUidWithDpOwner := types.UID("this-is-the-replicaset-with-dp-owner")
@@ -416,6 +678,71 @@ func TestIsAnnotated(t *testing.T) {
 	}
 }
 
+func TestIsAnnotated_FetchesOwnerJobOnce(t *testing.T) {
+	testNamespace := "test-namespace"
+	testCj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cron-job",
+			UID:       "this-is-the-cron-job-uid",
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				apicommon.WorkloadAnnotation: workloadName,
+			},
+		},
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cron-job-1234",
+			UID:       "this-is-the-job-with-cj-owner",
+			Namespace: testNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind: "CronJob",
+					Name: testCj.Name,
+					UID:  testCj.UID,
+				},
+			},
+		},
+	}
+
+	jobGets := 0
+	testcommon.SetupSchemes()
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(testCj, job).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*batchv1.Job); ok {
+				jobGets++
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+
+	a := &PodAnnotationHandler{
+		Client: fakeClient,
+		Log:    testr.New(t),
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID: "this-is-the-pod-uid",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Name: job.Name,
+					UID:  job.UID,
+					Kind: "Job",
+				},
+			},
+		},
+	}
+	req := &admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Namespace: testNamespace,
+		},
+	}
+
+	require.True(t, a.IsAnnotated(context.TODO(), req, pod))
+	require.Equal(t, workloadName, pod.Annotations[apicommon.WorkloadAnnotation])
+	require.Equal(t, 1, jobGets)
+}
+
 func TestCopyResourceLabelsIfPresent(t *testing.T) {
 
 	type args struct {
@@ -83,6 +83,7 @@ func generateWorkload(ctx context.Context, pod *corev1.Pod, namespace string) *a
 	postDeploymentTasks := getValuesForAnnotations(&pod.ObjectMeta, apicommon.PostDeploymentTaskAnnotation)
 	preDeploymentEvaluation := getValuesForAnnotations(&pod.ObjectMeta, apicommon.PreDeploymentEvaluationAnnotation)
 	postDeploymentEvaluation := getValuesForAnnotations(&pod.ObjectMeta, apicommon.PostDeploymentEvaluationAnnotation)
+	canaryEvaluation := getValuesForAnnotations(&pod.ObjectMeta, apicommon.CanaryEvaluationAnnotation)
 	applicationName := getAppName(&pod.ObjectMeta)
 	// create TraceContext
 	// follow up with a Keptn propagator that JSON-encoded the OTel map into our own key
@@ -103,11 +104,12 @@ func generateWorkload(ctx context.Context, pod *corev1.Pod, namespace string) *a
 		Spec: apilifecycle.KeptnWorkloadSpec{
 			AppName:                   applicationName,
 			Version:                   version,
-			ResourceReference:         apilifecycle.ResourceReference{UID: ownerRef.UID, Kind: ownerRef.Kind, Name: ownerRef.Name},
+			ResourceReference:         apilifecycle.ResourceReference{UID: ownerRef.UID, Kind: ownerRef.Kind, Name: ownerRef.Name, APIVersion: ownerRef.APIVersion},
 			PreDeploymentTasks:        preDeploymentTasks,
 			PostDeploymentTasks:       postDeploymentTasks,
 			PreDeploymentEvaluations:  preDeploymentEvaluation,
 			PostDeploymentEvaluations: postDeploymentEvaluation,
+			CanaryEvaluations:         canaryEvaluation,
 			Metadata:                  parseWorkloadMetadata(getValuesForAnnotations(&pod.ObjectMeta, apicommon.MetadataAnnotation)),
 		},
 	}
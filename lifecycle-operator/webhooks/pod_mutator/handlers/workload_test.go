@@ -79,9 +79,10 @@ func TestHandle(t *testing.T) {
 				"bar": "foo",
 			},
 			ResourceReference: apilifecycle.ResourceReference{
-				UID:  "owner-uid",
-				Kind: "Deployment",
-				Name: "deployment-1",
+				UID:        "owner-uid",
+				Kind:       "Deployment",
+				Name:       "deployment-1",
+				APIVersion: "apps/v1",
 			},
 		},
 	}
@@ -248,7 +249,7 @@ func TestGenerateWorkload(t *testing.T) {
 				Spec: apilifecycle.KeptnWorkloadSpec{
 					AppName:                   "my-app",
 					Version:                   "v1",
-					ResourceReference:         apilifecycle.ResourceReference{UID: "owner-uid", Kind: "Deployment", Name: "deployment-1"},
+					ResourceReference:         apilifecycle.ResourceReference{UID: "owner-uid", Kind: "Deployment", Name: "deployment-1", APIVersion: "apps/v1"},
 					PreDeploymentTasks:        []string{"task1", "task2"},
 					PostDeploymentTasks:       []string{"task3", "task4"},
 					PreDeploymentEvaluations:  []string{"eval1", "eval2"},
@@ -274,9 +275,10 @@ func TestGenerateWorkload(t *testing.T) {
 					}},
 				Spec: apilifecycle.KeptnWorkloadSpec{
 					ResourceReference: apilifecycle.ResourceReference{
-						UID:  "owner-uid",
-						Kind: "Deployment",
-						Name: "deployment-1",
+						UID:        "owner-uid",
+						Kind:       "Deployment",
+						Name:       "deployment-1",
+						APIVersion: "apps/v1",
 					},
 					Metadata: map[string]string{},
 				},
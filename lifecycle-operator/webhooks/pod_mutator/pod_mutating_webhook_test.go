@@ -105,9 +105,9 @@ func TestPodMutatingWebhookHandleUnsupportedOwner(t *testing.T) {
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				{
-					APIVersion: "batchv1",
-					Kind:       "Job",
-					Name:       "my-job",
+					APIVersion: "example.com/v1",
+					Kind:       "CustomWorkload",
+					Name:       "my-custom-workload",
 					UID:        "1234",
 				},
 			},
@@ -229,9 +229,10 @@ func TestPodMutatingWebhookHandleSingleService(t *testing.T) {
 		AppName: kacr.Spec.AppName,
 		Version: "0.1",
 		ResourceReference: apilifecycle.ResourceReference{
-			UID:  "1234",
-			Kind: "Deployment",
-			Name: testDeployment,
+			UID:        "1234",
+			Kind:       "Deployment",
+			Name:       testDeployment,
+			APIVersion: "v1",
 		},
 	}, workload.Spec)
 }
@@ -380,9 +381,10 @@ func TestPodMutatingWebhookHandleSchedulingGates(t *testing.T) {
 		AppName: kacr.Spec.AppName,
 		Version: "0.1",
 		ResourceReference: apilifecycle.ResourceReference{
-			UID:  "1234",
-			Kind: "Deployment",
-			Name: testDeployment,
+			UID:        "1234",
+			Kind:       "Deployment",
+			Name:       testDeployment,
+			APIVersion: "v1",
 		},
 	}, workload.Spec)
 }
@@ -479,9 +481,10 @@ func TestPodMutatingWebhookHandleSingleServiceAppCreationRequestAlreadyPresent(t
 		AppName: kacr.Spec.AppName,
 		Version: "0.1",
 		ResourceReference: apilifecycle.ResourceReference{
-			UID:  "1234",
-			Kind: "Deployment",
-			Name: testDeployment,
+			UID:        "1234",
+			Kind:       "Deployment",
+			Name:       testDeployment,
+			APIVersion: "v1",
 		},
 	}, workload.Spec)
 }
@@ -535,9 +538,10 @@ func TestPodMutatingWebhookHandleMultiService(t *testing.T) {
 		AppName: kacr.Spec.AppName,
 		Version: "v0.1",
 		ResourceReference: apilifecycle.ResourceReference{
-			UID:  "1234",
-			Kind: "Deployment",
-			Name: testDeployment,
+			UID:        "1234",
+			Kind:       "Deployment",
+			Name:       testDeployment,
+			APIVersion: "v1",
 		},
 	}, workload.Spec)
 }
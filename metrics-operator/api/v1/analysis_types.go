@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	"maps"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,6 +34,41 @@ type AnalysisSpec struct {
 	Args map[string]string `json:"args,omitempty"`
 	// AnalysisDefinition refers to the AnalysisDefinition, a CRD that stores the AnalysisValuesTemplates
 	AnalysisDefinition ObjectReference `json:"analysisDefinition"`
+	// Schedule runs the Analysis repeatedly instead of only once. Each run evaluates the 'recent' timeframe
+	// preceding the start of the run, and the results of the most recent runs are kept in the status.
+	// +optional
+	Schedule *AnalysisSchedule `json:"schedule,omitempty"`
+}
+
+const defaultHistoryLimit = 10
+
+// AnalysisSchedule defines how often a recurring Analysis is run
+type AnalysisSchedule struct {
+	// Interval is the time between the start of two consecutive runs of the Analysis, e.g. '5m'.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	Interval metav1.Duration `json:"interval"`
+	// HistoryLimit is the number of past runs whose results are kept in the status of the Analysis.
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=100
+	// +optional
+	HistoryLimit int `json:"historyLimit,omitempty"`
+}
+
+// AnalysisRunResult stores the result of a single run of a scheduled Analysis
+type AnalysisRunResult struct {
+	// Timeframe describes the time frame which was evaluated by the run
+	Timeframe Timeframe `json:"timeframe"`
+	// Pass returns whether the SLO was satisfied
+	// +optional
+	Pass bool `json:"pass,omitempty"`
+	// Warning returns whether the run returned a warning
+	// +optional
+	Warning bool `json:"warning,omitempty"`
+	// Score is the percentage of the maximum score achieved by the run
+	// +optional
+	Score string `json:"score,omitempty"`
 }
 
 // ProviderResult stores reference of already collected provider query associated to its objective template
@@ -49,6 +85,18 @@ type ProviderResult struct {
 	// ErrMsg stores any possible error at retrieval time
 	// +optional
 	ErrMsg string `json:"errMsg,omitempty"`
+	// BaselineQuery represents the executed query for the baseline of the objective
+	// +optional
+	BaselineQuery string `json:"baselineQuery,omitempty"`
+	// BaselineValue is the value the provider returned for the baseline of the objective
+	// +optional
+	BaselineValue string `json:"baselineValue,omitempty"`
+	// Values are the series of values the provider returned, if the objective defines a statistical test
+	// +optional
+	Values []string `json:"values,omitempty"`
+	// BaselineValues are the series of values the provider returned for the baseline, if the objective defines a statistical test
+	// +optional
+	BaselineValues []string `json:"baselineValues,omitempty"`
 }
 
 type Timeframe struct {
@@ -85,6 +133,12 @@ type AnalysisStatus struct {
 	// StoredValues contains all analysis values that have already been retrieved successfully
 	// +optional
 	StoredValues map[string]ProviderResult `json:"storedValues,omitempty"`
+	// NextRunTime is the time at which the next run of a scheduled Analysis starts
+	// +optional
+	NextRunTime metav1.Time `json:"nextRunTime,omitempty"`
+	// History contains the results of the most recent runs of a scheduled Analysis, starting with the latest run
+	// +optional
+	History []AnalysisRunResult `json:"history,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -135,6 +189,30 @@ func (a *Analysis) GetTo() time.Time {
 	return a.Status.Timeframe.GetTo()
 }
 
+// GetBaselineTimeframe returns the timeframe for which the baseline of an objective is retrieved.
+// The timeframe is either the one defined in the baseline, or the timeframe of the Analysis shifted by the offset
+// of the baseline.
+func (a *Analysis) GetBaselineTimeframe(baseline *Baseline) Timeframe {
+	from, to := a.GetFrom(), a.GetTo()
+	if baseline.Timeframe != nil {
+		from, to = baseline.Timeframe.GetFrom(), baseline.Timeframe.GetTo()
+	} else if baseline.Offset.Duration > 0 {
+		from, to = from.Add(-baseline.Offset.Duration), to.Add(-baseline.Offset.Duration)
+	}
+	return Timeframe{
+		From: metav1.Time{Time: from},
+		To:   metav1.Time{Time: to},
+	}
+}
+
+// GetBaselineArgs returns the args of the Analysis, overridden by the args of the baseline
+func (a *Analysis) GetBaselineArgs(baseline *Baseline) map[string]string {
+	args := make(map[string]string, len(a.Spec.Args)+len(baseline.Args))
+	maps.Copy(args, a.Spec.Args)
+	maps.Copy(args, baseline.Args)
+	return args
+}
+
 func (a *Analysis) EnsureTimeframeIsSet() {
 	// make sure the correct time frame is set in the status - once an Analysis with a duration string specifying the
 	// time frame is triggered, the time frame derived from that duration should stay the same and not shift over the course
@@ -149,6 +227,39 @@ func (a *Analysis) EnsureTimeframeIsSet() {
 	}
 }
 
+// IsScheduled returns whether the Analysis is run repeatedly
+func (a *Analysis) IsScheduled() bool {
+	return a.Spec.Schedule != nil
+}
+
+// ResetForNextRun clears the state of the previous run of a scheduled Analysis, so that the timeframe of the next
+// run is derived from the current time. The results of the previous run stay available until the next run completes.
+func (a *Analysis) ResetForNextRun() {
+	a.Status.Timeframe = Timeframe{}
+	a.Status.State = StatePending
+	a.Status.StoredValues = nil
+}
+
+// AddRunResult adds the result of a run to the history of a scheduled Analysis, dropping the oldest results
+// exceeding the history limit, and schedules the next run
+func (a *Analysis) AddRunResult(result AnalysisRunResult) {
+	a.Status.History = append([]AnalysisRunResult{result}, a.Status.History...)
+	if limit := a.Spec.Schedule.GetHistoryLimit(); len(a.Status.History) > limit {
+		a.Status.History = a.Status.History[:limit]
+	}
+	a.Status.NextRunTime = metav1.Time{
+		Time: a.Status.Timeframe.To.Add(a.Spec.Schedule.Interval.Duration),
+	}
+}
+
+// GetHistoryLimit returns the number of past runs kept in the status of the Analysis
+func (s *AnalysisSchedule) GetHistoryLimit() int {
+	if s.HistoryLimit <= 0 {
+		return defaultHistoryLimit
+	}
+	return s.HistoryLimit
+}
+
 func (t *Timeframe) GetFrom() time.Time {
 	if t.Recent.Duration > 0 {
 		return time.Now().UTC().Add(-t.Recent.Duration)
@@ -78,3 +78,118 @@ func TestAnalysis_SetAndRetrieveTime(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalysis_GetBaselineTimeframe(t *testing.T) {
+	from := time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	a := &Analysis{
+		Status: AnalysisStatus{
+			Timeframe: Timeframe{
+				From: v1.Time{Time: from},
+				To:   v1.Time{Time: to},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		baseline Baseline
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		{
+			name:     "timeframe of the analysis",
+			baseline: Baseline{},
+			wantFrom: from,
+			wantTo:   to,
+		},
+		{
+			name: "offset",
+			baseline: Baseline{
+				Offset: v1.Duration{Duration: 7 * 24 * time.Hour},
+			},
+			wantFrom: from.Add(-7 * 24 * time.Hour),
+			wantTo:   to.Add(-7 * 24 * time.Hour),
+		},
+		{
+			name: "timeframe",
+			baseline: Baseline{
+				Timeframe: &Timeframe{
+					From: v1.Time{Time: from.Add(-time.Hour)},
+					To:   v1.Time{Time: from},
+				},
+			},
+			wantFrom: from.Add(-time.Hour),
+			wantTo:   from,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeframe := a.GetBaselineTimeframe(&tt.baseline)
+			require.Equal(t, tt.wantFrom, timeframe.GetFrom())
+			require.Equal(t, tt.wantTo, timeframe.GetTo())
+		})
+	}
+}
+
+func TestAnalysis_GetBaselineArgs(t *testing.T) {
+	a := &Analysis{
+		Spec: AnalysisSpec{
+			Args: map[string]string{
+				"version":  "canary",
+				"workload": "podtato-head",
+			},
+		},
+	}
+
+	args := a.GetBaselineArgs(&Baseline{
+		Args: map[string]string{
+			"version": "stable",
+		},
+	})
+
+	require.Equal(t, map[string]string{"version": "stable", "workload": "podtato-head"}, args)
+	require.Equal(t, "canary", a.Spec.Args["version"])
+}
+
+func TestAnalysis_AddRunResult(t *testing.T) {
+	to := time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC)
+	a := &Analysis{
+		Spec: AnalysisSpec{
+			Schedule: &AnalysisSchedule{
+				Interval:     v1.Duration{Duration: 5 * time.Minute},
+				HistoryLimit: 2,
+			},
+		},
+		Status: AnalysisStatus{
+			Timeframe: Timeframe{
+				From: v1.Time{Time: to.Add(-10 * time.Minute)},
+				To:   v1.Time{Time: to},
+			},
+			State: StateCompleted,
+		},
+	}
+	require.True(t, a.IsScheduled())
+
+	a.AddRunResult(AnalysisRunResult{Score: "1"})
+	a.AddRunResult(AnalysisRunResult{Score: "2"})
+	a.AddRunResult(AnalysisRunResult{Score: "3"})
+
+	require.Len(t, a.Status.History, 2)
+	require.Equal(t, "3", a.Status.History[0].Score)
+	require.Equal(t, "2", a.Status.History[1].Score)
+	require.Equal(t, to.Add(5*time.Minute), a.Status.NextRunTime.Time)
+
+	a.Status.StoredValues = map[string]ProviderResult{"key": {}}
+	a.ResetForNextRun()
+	require.True(t, a.Status.State.IsPending())
+	require.True(t, a.Status.Timeframe.From.IsZero())
+	require.True(t, a.Status.Timeframe.To.IsZero())
+	require.Nil(t, a.Status.StoredValues)
+	require.Len(t, a.Status.History, 2)
+}
+
+func TestAnalysisSchedule_GetHistoryLimit(t *testing.T) {
+	require.Equal(t, defaultHistoryLimit, (&AnalysisSchedule{}).GetHistoryLimit())
+	require.Equal(t, 3, (&AnalysisSchedule{HistoryLimit: 3}).GetHistoryLimit())
+}
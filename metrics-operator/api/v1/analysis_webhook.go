@@ -47,6 +47,10 @@ func (a *Analysis) ValidateCreate() (admission.Warnings, error) {
 		return admission.Warnings{}, err
 	}
 
+	if err := a.validateSchedule(); err != nil {
+		return admission.Warnings{}, err
+	}
+
 	return admission.Warnings{}, nil
 }
 
@@ -58,6 +62,10 @@ func (a *Analysis) ValidateUpdate(old runtime.Object) (admission.Warnings, error
 		return admission.Warnings{}, err
 	}
 
+	if err := a.validateSchedule(); err != nil {
+		return admission.Warnings{}, err
+	}
+
 	return admission.Warnings{}, nil
 }
 
@@ -69,29 +77,56 @@ func (a *Analysis) ValidateDelete() (admission.Warnings, error) {
 }
 
 func (a *Analysis) validateTimeframe() error {
+	return a.Spec.Timeframe.validate(field.NewPath("spec").Child("timeframe"))
+}
+
+func (a *Analysis) validateSchedule() error {
+	if a.Spec.Schedule == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("schedule")
+	if a.Spec.Schedule.Interval.Duration <= 0 {
+		return field.Invalid(
+			path.Child("interval"),
+			a.Spec.Schedule.Interval,
+			errors.New("the interval of a scheduled Analysis must be greater than 0").Error(),
+		)
+	}
+	// every run evaluates the timeframe preceding its start, so a fixed timeframe can not be used
+	if a.Spec.Timeframe.Recent.Duration == 0 {
+		return field.Invalid(
+			path,
+			*a.Spec.Schedule,
+			errors.New("a scheduled Analysis requires the field 'recent' to be set in the timeframe").Error(),
+		)
+	}
+	return nil
+}
+
+func (t *Timeframe) validate(path *field.Path) error {
 	// if 'Recent'  is set, this must be the only field
-	if a.Spec.Timeframe.Recent.Duration != 0 {
-		if !a.Spec.Timeframe.From.IsZero() || !a.Spec.Timeframe.To.IsZero() {
+	if t.Recent.Duration != 0 {
+		if !t.From.IsZero() || !t.To.IsZero() {
 			return field.Invalid(
-				field.NewPath("spec").Child("timeframe"),
-				a.Spec.Timeframe,
+				path,
+				*t,
 				errors.New("the field 'recent' can not be used in conjunction with 'from'/'to'").Error(),
 			)
 		}
 		return nil
 	}
 	// if 'Recent' is not set, both 'From' and 'To' must be set
-	if a.Spec.Timeframe.From.IsZero() || a.Spec.Timeframe.To.IsZero() {
+	if t.From.IsZero() || t.To.IsZero() {
 		return field.Invalid(
-			field.NewPath("spec").Child("timeframe"),
-			a.Spec.Timeframe,
+			path,
+			*t,
 			errors.New("either 'recent' or both 'from' and 'to'  must be set").Error(),
 		)
 	}
-	if !a.Spec.Timeframe.To.After(a.Spec.Timeframe.From.Time) {
+	if !t.To.After(t.From.Time) {
 		return field.Invalid(
-			field.NewPath("spec").Child("timeframe"),
-			a.Spec.Timeframe,
+			path,
+			*t,
 			errors.New("value of 'to' must be a timestamp later than 'from'").Error(),
 		)
 	}
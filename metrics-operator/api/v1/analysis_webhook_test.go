@@ -134,6 +134,65 @@ func TestAnalysis_Validation(t *testing.T) {
 			want:    admission.Warnings{},
 			wantErr: true,
 		},
+		{
+			name: "valid scheduled Analysis",
+			fields: fields{
+				Spec: AnalysisSpec{
+					Timeframe: Timeframe{
+						Recent: v1.Duration{
+							Duration: 10 * time.Minute,
+						},
+					},
+					Schedule: &AnalysisSchedule{
+						Interval: v1.Duration{
+							Duration: 5 * time.Minute,
+						},
+					},
+				},
+			},
+			verb:    "create",
+			want:    admission.Warnings{},
+			wantErr: false,
+		},
+		{
+			name: "invalid scheduled Analysis without interval",
+			fields: fields{
+				Spec: AnalysisSpec{
+					Timeframe: Timeframe{
+						Recent: v1.Duration{
+							Duration: 10 * time.Minute,
+						},
+					},
+					Schedule: &AnalysisSchedule{},
+				},
+			},
+			verb:    "create",
+			want:    admission.Warnings{},
+			wantErr: true,
+		},
+		{
+			name: "invalid scheduled Analysis with from/to timestamps",
+			fields: fields{
+				Spec: AnalysisSpec{
+					Timeframe: Timeframe{
+						From: v1.Time{
+							Time: time.Now(),
+						},
+						To: v1.Time{
+							Time: time.Now().Add(1 * time.Second),
+						},
+					},
+					Schedule: &AnalysisSchedule{
+						Interval: v1.Duration{
+							Duration: 5 * time.Minute,
+						},
+					},
+				},
+			},
+			verb:    "create",
+			want:    admission.Warnings{},
+			wantErr: true,
+		},
 		// UPDATE
 		{
 			name: "valid Analysis with from/to timestamps - update",
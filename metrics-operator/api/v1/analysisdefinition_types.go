@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -57,6 +59,93 @@ type Objective struct {
 	// +kubebuilder:default:=false
 	// +optional
 	KeyObjective bool `json:"keyObjective,omitempty" yaml:"keyObjective,omitempty"`
+	// Baseline defines a baseline the value of the objective is compared with.
+	// If set, the Target is evaluated on the difference between the value and the baseline value
+	// instead of the value itself.
+	// +optional
+	Baseline *Baseline `json:"baseline,omitempty" yaml:"baseline,omitempty"`
+	// StatisticalTest compares the series of values of the objective with the series of values of the baseline
+	// using a Mann-Whitney U test, instead of comparing single values with the Target.
+	// Requires the Baseline to be set.
+	// +optional
+	StatisticalTest *StatisticalTest `json:"statisticalTest,omitempty" yaml:"statisticalTest,omitempty"`
+}
+
+// TestDirection defines which difference between the series of values and the baseline series fails an objective
+type TestDirection string
+
+const (
+	// TestDirectionIncrease fails the objective if the values are significantly greater than the baseline values
+	TestDirectionIncrease TestDirection = "increase"
+	// TestDirectionDecrease fails the objective if the values are significantly smaller than the baseline values
+	TestDirectionDecrease TestDirection = "decrease"
+	// TestDirectionEither fails the objective if the values significantly differ from the baseline values
+	TestDirectionEither TestDirection = "either"
+)
+
+const defaultTestConfidence = 0.95
+
+// StatisticalTest defines how the series of values of an objective is compared with the baseline series
+type StatisticalTest struct {
+	// Step defines the resolution of the series of values retrieved for the objective and the baseline.
+	// +kubebuilder:default:="1m"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Step metav1.Duration `json:"step,omitempty" yaml:"step,omitempty"`
+	// Confidence defines the confidence level at which a difference between the series fails the objective,
+	// e.g. '0.95' fails the objective if the p-value of the test is below 0.05.
+	// +optional
+	Confidence *resource.Quantity `json:"confidence,omitempty" yaml:"confidence,omitempty"`
+	// WarningConfidence defines the confidence level at which a difference between the series results in a warning.
+	// Must be lower than Confidence. If not set, the objective either passes or fails.
+	// +optional
+	WarningConfidence *resource.Quantity `json:"warningConfidence,omitempty" yaml:"warningConfidence,omitempty"`
+	// Direction defines which difference between the series fails the objective. Accepted values are 'increase',
+	// 'decrease' and 'either'. E.g. 'increase' fails the objective if the values are significantly greater than
+	// the baseline values, which is suited for response times or error rates.
+	// +kubebuilder:validation:Enum:=increase;decrease;either
+	// +kubebuilder:default:=increase
+	// +optional
+	Direction TestDirection `json:"direction,omitempty" yaml:"direction,omitempty"`
+}
+
+// DeltaType defines how the value of an objective is compared with its baseline value
+type DeltaType string
+
+const (
+	// DeltaAbsolute compares the difference between the value and the baseline value, i.e. value - baseline
+	DeltaAbsolute DeltaType = "absolute"
+	// DeltaPercentage compares the difference between the value and the baseline value in percent of the baseline value,
+	// i.e. (value - baseline) / baseline * 100
+	DeltaPercentage DeltaType = "percentage"
+)
+
+// Baseline defines how the baseline value of an objective is retrieved and compared with the value of the objective.
+// The baseline value is retrieved with the same AnalysisValueTemplate as the value of the objective.
+type Baseline struct {
+	// Offset shifts the timeframe of the Analysis into the past to obtain the timeframe of the baseline,
+	// e.g. setting this to '168h' compares the value with the value of the same timeframe one week earlier.
+	// Can not be used in conjunction with 'timeframe'.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Offset metav1.Duration `json:"offset,omitempty" yaml:"offset,omitempty"`
+	// Timeframe specifies the timeframe of the baseline, using either 'recent' or 'from' and 'to'.
+	// If neither 'offset' nor 'timeframe' is set, the baseline is retrieved for the timeframe of the Analysis.
+	// +optional
+	Timeframe *Timeframe `json:"timeframe,omitempty" yaml:"timeframe,omitempty"`
+	// Args corresponds to a map of key/value pairs which override the args of the Analysis when the query
+	// for the baseline is generated, e.g. setting 'version: stable' compares a canary with the stable version.
+	// +optional
+	Args map[string]string `json:"args,omitempty" yaml:"args,omitempty"`
+	// Delta defines how the value is compared with the baseline value. Accepted values are 'absolute',
+	// which compares the difference between the value and the baseline value, and 'percentage', which
+	// compares the difference in percent of the baseline value.
+	// +kubebuilder:validation:Enum:=absolute;percentage
+	// +kubebuilder:default:=absolute
+	// +optional
+	Delta DeltaType `json:"delta,omitempty" yaml:"delta,omitempty"`
 }
 
 // Target defines the failure and warning criteria
@@ -142,3 +231,44 @@ func init() {
 func (o *OperatorValue) GetFloatValue() float64 {
 	return o.FixedValue.AsApproximateFloat64()
 }
+
+// GetDelta returns how the value is compared with the baseline value, defaulting to an absolute difference
+func (b *Baseline) GetDelta() DeltaType {
+	if b.Delta == "" {
+		return DeltaAbsolute
+	}
+	return b.Delta
+}
+
+// GetConfidence returns the confidence level at which a difference between the series fails the objective
+func (s *StatisticalTest) GetConfidence() float64 {
+	if s.Confidence == nil {
+		return defaultTestConfidence
+	}
+	return s.Confidence.AsApproximateFloat64()
+}
+
+// GetWarningConfidence returns the confidence level at which a difference between the series results in a warning,
+// or 0 if no warning is defined
+func (s *StatisticalTest) GetWarningConfidence() float64 {
+	if s.WarningConfidence == nil {
+		return 0
+	}
+	return s.WarningConfidence.AsApproximateFloat64()
+}
+
+// GetDirection returns which difference between the series fails the objective
+func (s *StatisticalTest) GetDirection() TestDirection {
+	if s.Direction == "" {
+		return TestDirectionIncrease
+	}
+	return s.Direction
+}
+
+// GetStep returns the resolution of the series of values, defaulting to one minute
+func (s *StatisticalTest) GetStep() time.Duration {
+	if s.Step.Duration <= 0 {
+		return time.Minute
+	}
+	return s.Step.Duration
+}
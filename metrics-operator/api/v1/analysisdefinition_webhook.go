@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -83,6 +84,44 @@ func (o *Objective) validate() error {
 	if err := o.Target.validate(); err != nil {
 		return err
 	}
+	if o.Baseline != nil {
+		if err := o.Baseline.validate(); err != nil {
+			return err
+		}
+	}
+	if o.StatisticalTest != nil {
+		if o.Baseline == nil {
+			return fmt.Errorf("StatisticalTest: a baseline must be set")
+		}
+		return o.StatisticalTest.validate()
+	}
+	return nil
+}
+
+func (s *StatisticalTest) validate() error {
+	confidence := s.GetConfidence()
+	if confidence <= 0 || confidence >= 1 {
+		return fmt.Errorf("StatisticalTest: confidence must be between 0 and 1")
+	}
+	if s.WarningConfidence != nil {
+		warningConfidence := s.GetWarningConfidence()
+		if warningConfidence <= 0 || warningConfidence >= confidence {
+			return fmt.Errorf("StatisticalTest: warning confidence must be between 0 and the confidence")
+		}
+	}
+	return nil
+}
+
+func (b *Baseline) validate() error {
+	if b.Timeframe != nil {
+		if b.Offset.Duration != 0 {
+			return fmt.Errorf("Baseline: the field 'offset' can not be used in conjunction with 'timeframe'")
+		}
+		return b.Timeframe.validate(field.NewPath("baseline").Child("timeframe"))
+	}
+	if b.Offset.Duration == 0 && len(b.Args) == 0 {
+		return fmt.Errorf("Baseline: at least one of 'offset', 'timeframe' or 'args' must be set")
+	}
 	return nil
 }
 
@@ -3,9 +3,11 @@ package v1
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestOperator_validate(t *testing.T) {
@@ -206,6 +208,87 @@ func TestObjective_validate(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "baseline with offset",
+			obj: Objective{
+				Baseline: &Baseline{
+					Offset: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "baseline with args",
+			obj: Objective{
+				Baseline: &Baseline{
+					Args: map[string]string{"version": "stable"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "empty baseline",
+			obj: Objective{
+				Baseline: &Baseline{},
+			},
+			wantErr: fmt.Errorf("Baseline: at least one of 'offset', 'timeframe' or 'args' must be set"),
+		},
+		{
+			name: "baseline with offset and timeframe",
+			obj: Objective{
+				Baseline: &Baseline{
+					Offset: metav1.Duration{Duration: time.Hour},
+					Timeframe: &Timeframe{
+						Recent: metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			wantErr: fmt.Errorf("Baseline: the field 'offset' can not be used in conjunction with 'timeframe'"),
+		},
+		{
+			name: "statistical test with baseline",
+			obj: Objective{
+				Baseline: &Baseline{
+					Args: map[string]string{"version": "stable"},
+				},
+				StatisticalTest: &StatisticalTest{
+					Confidence:        ptr(resource.MustParse("0.99")),
+					WarningConfidence: ptr(resource.MustParse("0.9")),
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "statistical test without baseline",
+			obj: Objective{
+				StatisticalTest: &StatisticalTest{},
+			},
+			wantErr: fmt.Errorf("StatisticalTest: a baseline must be set"),
+		},
+		{
+			name: "statistical test with invalid confidence",
+			obj: Objective{
+				Baseline: &Baseline{
+					Args: map[string]string{"version": "stable"},
+				},
+				StatisticalTest: &StatisticalTest{
+					Confidence: ptr(resource.MustParse("95")),
+				},
+			},
+			wantErr: fmt.Errorf("StatisticalTest: confidence must be between 0 and 1"),
+		},
+		{
+			name: "statistical test with warning confidence higher than confidence",
+			obj: Objective{
+				Baseline: &Baseline{
+					Args: map[string]string{"version": "stable"},
+				},
+				StatisticalTest: &StatisticalTest{
+					WarningConfidence: ptr(resource.MustParse("0.99")),
+				},
+			},
+			wantErr: fmt.Errorf("StatisticalTest: warning confidence must be between 0 and the confidence"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -215,6 +298,25 @@ func TestObjective_validate(t *testing.T) {
 	}
 }
 
+func TestBaseline_validateTimeframe(t *testing.T) {
+	now := time.Now()
+	valid := Baseline{
+		Timeframe: &Timeframe{
+			From: metav1.Time{Time: now.Add(-time.Hour)},
+			To:   metav1.Time{Time: now},
+		},
+	}
+	require.Nil(t, valid.validate())
+
+	invalid := Baseline{
+		Timeframe: &Timeframe{
+			From: metav1.Time{Time: now},
+			To:   metav1.Time{Time: now.Add(-time.Hour)},
+		},
+	}
+	require.ErrorContains(t, invalid.validate(), "value of 'to' must be a timestamp later than 'from'")
+}
+
 func TestAnalysisDefinition_validateCreateUpdate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -390,3 +492,7 @@ func TestTarget_validate(t *testing.T) {
 		})
 	}
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetInputKey returns the namespaced name of the KeptnMetric referenced by the input of the composite metric
+func (s *KeptnMetric) GetInputKey(input CompositeInput) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: input.KeptnMetricRef.GetNamespace(s.Namespace),
+		Name:      input.KeptnMetricRef.Name,
+	}
+}
+
+// FindCompositeCycle follows the inputs of the composite metric and returns the path of KeptnMetrics
+// leading back to the metric, or nil if the metric does not depend on itself.
+// Inputs that do not exist (yet) are ignored.
+func FindCompositeCycle(ctx context.Context, reader client.Reader, metric *KeptnMetric) ([]string, error) {
+	start := types.NamespacedName{Namespace: metric.Namespace, Name: metric.Name}
+	visited := map[types.NamespacedName]bool{start: true}
+	return findCompositeCycle(ctx, reader, start, metric, []string{start.String()}, visited)
+}
+
+func findCompositeCycle(ctx context.Context, reader client.Reader, start types.NamespacedName, current *KeptnMetric, path []string, visited map[types.NamespacedName]bool) ([]string, error) {
+	if !current.IsComposite() {
+		return nil, nil
+	}
+	for _, input := range current.Spec.Composite.Inputs {
+		key := current.GetInputKey(input)
+		if key == start {
+			return append(path, key.String()), nil
+		}
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		next := &KeptnMetric{}
+		if err := reader.Get(ctx, key, next); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		cycle, err := findCompositeCycle(ctx, reader, start, next, append(path, key.String()), visited)
+		if err != nil || cycle != nil {
+			return cycle, err
+		}
+	}
+	return nil, nil
+}
@@ -17,20 +17,124 @@ limitations under the License.
 package v1
 
 import (
+	"strconv"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// DefaultSmoothingFactor is the smoothing factor used by the ewma aggregation if none is set
+const DefaultSmoothingFactor = 0.5
+
+// AnomalousConditionType is the type of the condition of a KeptnMetric
+// which is True if the latest value has been detected as anomaly
+const AnomalousConditionType = "Anomalous"
+
+const (
+	// AnomalyDetectionMethodZScore flags values that deviate from the mean of the history
+	// by more than the threshold times the standard deviation
+	AnomalyDetectionMethodZScore = "zscore"
+	// AnomalyDetectionMethodSeasonal flags values that deviate from the values recorded
+	// at the same time of previous seasons by more than the threshold times their standard deviation
+	AnomalyDetectionMethodSeasonal = "seasonal"
+)
+
+const (
+	// DefaultMaxHistoryEntries is the number of past values kept in the history if spec.history.maxEntries is not set
+	DefaultMaxHistoryEntries = 100
+	// DefaultMinSamples is the minimum size of the baseline of the zscore method if spec.history.anomalyDetection.minSamples is not set
+	DefaultMinSamples = 10
+	// DefaultSeasonalMinSamples is the minimum size of the baseline of the seasonal method if spec.history.anomalyDetection.minSamples is not set
+	DefaultSeasonalMinSamples = 3
+)
+
 // KeptnMetricSpec defines the desired state of KeptnMetric
+// +kubebuilder:validation:XValidation:rule="has(self.composite) || (has(self.provider) && size(self.provider.name) > 0 && has(self.query) && size(self.query) > 0)",message="provider and query are required unless composite is set"
 type KeptnMetricSpec struct {
-	// Provider represents the provider object
-	Provider ProviderRef `json:"provider"`
+	// Provider represents the provider object.
+	// Provider and Query are required unless Composite is set.
+	// +optional
+	Provider ProviderRef `json:"provider,omitempty"`
 	// Query represents the query to be run
-	Query string `json:"query"`
+	// +optional
+	Query string `json:"query,omitempty"`
 	// FetchIntervalSeconds represents the update frequency in seconds that is used to update the metric
 	FetchIntervalSeconds uint `json:"fetchIntervalSeconds"`
 	// Range represents the time range for which data is to be queried
 	// +optional
 	Range *RangeSpec `json:"range,omitempty"`
+	// History defines whether past values of the metric are kept in the status
+	// and whether they are used to detect anomalies
+	// +optional
+	History *HistorySpec `json:"history,omitempty"`
+	// Composite defines the value of the metric as an arithmetic expression over the values of other KeptnMetrics.
+	// If set, the metric is computed from its inputs instead of querying a provider.
+	// +optional
+	Composite *CompositeSpec `json:"composite,omitempty"`
+}
+
+// CompositeSpec defines how the value of a composite KeptnMetric is computed
+type CompositeSpec struct {
+	// Expression is the arithmetic expression computing the value of the metric, e.g. 'errors / requests * 100'.
+	// It supports numbers, the names of the inputs, the operators +, -, *, / and parentheses.
+	Expression string `json:"expression"`
+	// Inputs define the KeptnMetrics whose values are used in the expression
+	// +kubebuilder:validation:MinItems:=1
+	Inputs []CompositeInput `json:"inputs"`
+}
+
+// CompositeInput represents a KeptnMetric whose value is used in the expression of a composite KeptnMetric
+type CompositeInput struct {
+	// Name is the name under which the value of the KeptnMetric is used in the expression
+	// +kubebuilder:validation:Pattern:=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Name string `json:"name"`
+	// KeptnMetricRef references the KeptnMetric.
+	// If no namespace is set, the namespace of the composite KeptnMetric is used.
+	KeptnMetricRef ObjectReference `json:"keptnMetricRef"`
+}
+
+// HistorySpec defines how past values of a KeptnMetric are recorded
+type HistorySpec struct {
+	// MaxEntries indicates the upper limit of how many past values are stored in the status of a KeptnMetric
+	// +kubebuilder:default:=100
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=1000
+	// +optional
+	MaxEntries uint `json:"maxEntries,omitempty"`
+	// Interval defines the minimum time between two entries of the history.
+	// If not set, every value of the metric is recorded.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// AnomalyDetection defines how the latest value is compared to the history to detect anomalies
+	// +optional
+	AnomalyDetection *AnomalyDetectionSpec `json:"anomalyDetection,omitempty"`
+}
+
+// AnomalyDetectionSpec defines how anomalies of a KeptnMetric are detected
+type AnomalyDetectionSpec struct {
+	// Method defines the detection method. With zscore, the latest value is compared to all values of the history.
+	// With seasonal, it is only compared to the values recorded at the same time of previous seasons.
+	// +kubebuilder:validation:Enum:=zscore;seasonal
+	// +kubebuilder:default:=zscore
+	// +optional
+	Method string `json:"method,omitempty"`
+	// Threshold defines by how many standard deviations a value must deviate from the mean of its baseline
+	// to be flagged as anomaly
+	// +kubebuilder:default:="3"
+	// +optional
+	Threshold string `json:"threshold,omitempty"`
+	// MinSamples defines how many values the baseline must contain before anomalies are detected.
+	// Defaults to 10 for the zscore method and to 3 for the seasonal method.
+	// +kubebuilder:validation:Minimum:=2
+	// +optional
+	MinSamples uint `json:"minSamples,omitempty"`
+	// Season defines the length of a season for the seasonal method, e.g. 24h for daily patterns
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Season metav1.Duration `json:"season,omitempty"`
 }
 
 // KeptnMetricStatus defines the observed state of KeptnMetric
@@ -50,6 +154,49 @@ type KeptnMetricStatus struct {
 	// IntervalResults contain a slice of all the interval results
 	// +optional
 	IntervalResults []IntervalResult `json:"intervalResults,omitempty"`
+	// Results contain the value of each series with its labels, if the query returned more than one series
+	// +optional
+	Results []MetricResult `json:"results,omitempty"`
+	// RollingWindow contains the values of the range that are merged with new values on each evaluation,
+	// if the range is a rolling window
+	// +optional
+	RollingWindow *RollingWindow `json:"rollingWindow,omitempty"`
+	// History contains the past values of the metric, oldest first, if spec.history is set
+	// +optional
+	History []HistoryEntry `json:"history,omitempty"`
+	// Conditions represent the latest observations of the metric.
+	// The Anomalous condition is True if the latest value has been detected as anomaly.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// HistoryEntry represents a past value of a KeptnMetric
+type HistoryEntry struct {
+	// Value represents the value of the metric
+	Value string `json:"value"`
+	// Timestamp represents the time when the value was recorded
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// RollingWindow represents the values of a range that are kept between evaluations
+type RollingWindow struct {
+	// Values contain the values of each step of the range, oldest first
+	// +optional
+	Values []string `json:"values,omitempty"`
+	// End represents the time of the most recent value of the window
+	End metav1.Time `json:"end"`
+	// Generation represents the generation of the KeptnMetric the values were queried for
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+}
+
+// MetricResult represents the value of a single series of a query result
+type MetricResult struct {
+	// Labels represent the label set identifying the series
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Value represents the resulting value of the series
+	Value string `json:"value"`
 }
 
 // ProviderRef represents the provider object
@@ -67,16 +214,46 @@ type RangeSpec struct {
 	// Step represents the query resolution step width for the data query
 	// +optional
 	Step string `json:"step,omitempty"`
-	// Aggregation defines the type of aggregation function to be applied on the data. Accepted values: p90, p95, p99, max, min, avg, median
-	// +kubebuilder:validation:Enum:=p90;p95;p99;max;min;avg;median
+	// Aggregation defines the type of aggregation function to be applied on the data. Accepted values: max, min, avg, median,
+	// sum, count, stddev, rate, increase, last, ewma and any percentile in the form pNN (e.g. p50, p99.9)
+	// +kubebuilder:validation:Pattern:=`^(max|min|avg|median|sum|count|stddev|rate|increase|last|ewma|p[0-9]+(\.[0-9]+)?)$`
 	// +optional
 	Aggregation string `json:"aggregation,omitempty"`
+	// SmoothingFactor represents the weight of the most recent value when using the ewma aggregation.
+	// Accepted values are in the range (0, 1]
+	// +kubebuilder:default:="0.5"
+	// +optional
+	SmoothingFactor string `json:"smoothingFactor,omitempty"`
+	// RollingWindow indicates whether the values of the range are kept in the status and only the values
+	// of the time passed since the last evaluation are queried, instead of querying the whole interval every time
+	// +optional
+	RollingWindow bool `json:"rollingWindow,omitempty"`
 	// StoredResults indicates the upper limit of how many past results should be stored in the status of a KeptnMetric
 	// +kubebuilder:validation:Maximum:=255
 	// +optional
 	StoredResults uint `json:"storedResults,omitempty"`
 }
 
+// GetPercentile returns the percentile of the aggregation, if it is of the form pNN
+func (r RangeSpec) GetPercentile() (float64, bool) {
+	if !strings.HasPrefix(r.Aggregation, "p") {
+		return 0, false
+	}
+	perc, err := strconv.ParseFloat(strings.TrimPrefix(r.Aggregation, "p"), 64)
+	if err != nil {
+		return 0, false
+	}
+	return perc, true
+}
+
+// GetSmoothingFactor returns the smoothing factor used by the ewma aggregation
+func (r RangeSpec) GetSmoothingFactor() (float64, error) {
+	if r.SmoothingFactor == "" {
+		return DefaultSmoothingFactor, nil
+	}
+	return strconv.ParseFloat(r.SmoothingFactor, 64)
+}
+
 type IntervalResult struct {
 	// Value represents the resulting value
 	Value string `json:"value"`
@@ -97,6 +274,7 @@ type IntervalResult struct {
 // +kubebuilder:printcolumn:name="Value",type=string,JSONPath=`.status.value`
 // +kubebuilder:printcolumn:name="Step",type=string,JSONPath=`.spec.range.step`
 // +kubebuilder:printcolumn:name="Aggregation",type=string,JSONPath=`.spec.range.aggregation`
+// +kubebuilder:printcolumn:name="Anomalous",type=string,JSONPath=`.status.conditions[?(@.type=="Anomalous")].status`
 // +kubebuilder:storageversion
 
 // KeptnMetric is the Schema for the keptnmetrics API
@@ -125,6 +303,11 @@ func init() {
 	SchemeBuilder.Register(&KeptnMetric{}, &KeptnMetricList{})
 }
 
+// IsComposite returns true if the value of the KeptnMetric is computed from other KeptnMetrics
+func (s *KeptnMetric) IsComposite() bool {
+	return s.Spec.Composite != nil
+}
+
 func (s *KeptnMetric) IsStatusSet() bool {
-	return s.Status.Value != ""
+	return s.Status.Value != "" || len(s.Status.Results) > 0
 }
@@ -36,6 +36,18 @@ func TestKeptnMetric_IsStatusSet(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "we have the results of multiple series",
+			fields: fields{
+				Status: KeptnMetricStatus{
+					Results: []MetricResult{
+						{Labels: map[string]string{"pod": "pod-1"}, Value: "1.0"},
+						{Labels: map[string]string{"pod": "pod-2"}, Value: "2.0"},
+					},
+				},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
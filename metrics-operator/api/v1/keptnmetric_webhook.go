@@ -17,54 +17,82 @@ limitations under the License.
 package v1
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/pkg/expression"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+// MaxRollingWindowSize is the maximum number of steps kept in the status of a KeptnMetric using a rolling window
+const MaxRollingWindowSize = 1000
+
 // log is for logging in this package.
 var keptnmetriclog = logf.Log.WithName("keptnmetric-resource")
 
 func (r *KeptnMetric) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(&KeptnMetricValidator{Reader: mgr.GetClient()}).
 		Complete()
 }
 
 //+kubebuilder:webhook:path=/validate-metrics-keptn-sh-v1-keptnmetric,mutating=false,failurePolicy=fail,sideEffects=None,groups=metrics.keptn.sh,resources=keptnmetrics,verbs=create;update,versions=v1,name=vkeptnmetric.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Validator = &KeptnMetric{}
+// +kubebuilder:object:generate=false
+
+// KeptnMetricValidator validates KeptnMetrics.
+// The Reader is used to look up the inputs of composite KeptnMetrics, the check for cycles is skipped without it.
+type KeptnMetricValidator struct {
+	Reader client.Reader
+}
+
+var _ webhook.CustomValidator = &KeptnMetricValidator{}
 
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (r *KeptnMetric) ValidateCreate() (admission.Warnings, error) {
-	keptnmetriclog.Info("validate create", "name", r.Name)
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *KeptnMetricValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	metric, ok := obj.(*KeptnMetric)
+	if !ok {
+		return []string{}, fmt.Errorf("expected a KeptnMetric but got %T", obj)
+	}
+	keptnmetriclog.Info("validate create", "name", metric.Name)
 
-	return []string{}, r.validateKeptnMetric()
+	return []string{}, metric.validateKeptnMetric(ctx, v.Reader)
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *KeptnMetric) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
-	keptnmetriclog.Info("validate update", "name", r.Name)
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *KeptnMetricValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	metric, ok := newObj.(*KeptnMetric)
+	if !ok {
+		return []string{}, fmt.Errorf("expected a KeptnMetric but got %T", newObj)
+	}
+	keptnmetriclog.Info("validate update", "name", metric.Name)
 
-	return []string{}, r.validateKeptnMetric()
+	return []string{}, metric.validateKeptnMetric(ctx, v.Reader)
 }
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
-func (r *KeptnMetric) ValidateDelete() (admission.Warnings, error) {
-	keptnmetriclog.Info("validate delete", "name", r.Name)
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *KeptnMetricValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	if metric, ok := obj.(*KeptnMetric); ok {
+		keptnmetriclog.Info("validate delete", "name", metric.Name)
+	}
 
 	return []string{}, nil
 }
 
-func (s *KeptnMetric) validateKeptnMetric() error {
+func (s *KeptnMetric) validateKeptnMetric(ctx context.Context, reader client.Reader) error {
 	var allErrs field.ErrorList // defined as a list to allow returning multiple validation errors
 	var err *field.Error
 	if err = s.validateRangeInterval(); err != nil {
@@ -76,6 +104,18 @@ func (s *KeptnMetric) validateKeptnMetric() error {
 	if err = s.validateAggregation(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err = s.validateSmoothingFactor(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err = s.validateRollingWindow(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err = s.validateAnomalyDetection(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err = s.validateComposite(ctx, reader); err != nil {
+		allErrs = append(allErrs, err)
+	}
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -131,5 +171,176 @@ func (s *KeptnMetric) validateAggregation() *field.Error {
 			errors.New("Forbidden! Step interval is required for the aggregation to work").Error(),
 		)
 	}
+	if s.Spec.Range.Aggregation != "" && !isValidAggregation(*s.Spec.Range) {
+		return field.Invalid(
+			field.NewPath("spec").Child("range").Child("aggregation"),
+			s.Spec.Range.Aggregation,
+			errors.New("Forbidden! The aggregation is not supported. Please use one of max, min, avg, median, sum, count, stddev, rate, increase, last, ewma or a percentile in the form pNN, e.g. p50").Error(),
+		)
+	}
+	return nil
+}
+
+func (s *KeptnMetric) validateSmoothingFactor() *field.Error {
+	if s.Spec.Range == nil || s.Spec.Range.SmoothingFactor == "" {
+		return nil
+	}
+	factor, err := s.Spec.Range.GetSmoothingFactor()
+	if err != nil || factor <= 0 || factor > 1 {
+		return field.Invalid(
+			field.NewPath("spec").Child("range").Child("smoothingFactor"),
+			s.Spec.Range.SmoothingFactor,
+			errors.New("Forbidden! The smoothing factor must be a number greater than 0 and lower than or equal to 1").Error(),
+		)
+	}
+	return nil
+}
+
+func (s *KeptnMetric) validateRollingWindow() *field.Error {
+	if s.Spec.Range == nil || !s.Spec.Range.RollingWindow {
+		return nil
+	}
+	if s.Spec.Range.Step == "" {
+		return field.Required(
+			field.NewPath("spec").Child("range").Child("step"),
+			errors.New("Forbidden! Step interval is required for the rolling window to work").Error(),
+		)
+	}
+	interval, err := time.ParseDuration(s.Spec.Range.Interval)
+	if err != nil {
+		// reported by the interval validation
+		return nil
+	}
+	step, err := time.ParseDuration(s.Spec.Range.Step)
+	if err != nil || step <= 0 {
+		// reported by the step validation
+		return nil
+	}
+	if interval/step > MaxRollingWindowSize {
+		return field.Invalid(
+			field.NewPath("spec").Child("range").Child("rollingWindow"),
+			s.Spec.Range.RollingWindow,
+			fmt.Sprintf("Forbidden! The rolling window cannot contain more than %d steps. Please increase the step or decrease the interval", MaxRollingWindowSize),
+		)
+	}
+	return nil
+}
+
+func (s *KeptnMetric) validateAnomalyDetection() *field.Error {
+	if s.Spec.History == nil || s.Spec.History.AnomalyDetection == nil {
+		return nil
+	}
+	detection := s.Spec.History.AnomalyDetection
+	if detection.Threshold != "" {
+		threshold, err := strconv.ParseFloat(detection.Threshold, 64)
+		if err != nil || threshold <= 0 {
+			return field.Invalid(
+				field.NewPath("spec").Child("history").Child("anomalyDetection").Child("threshold"),
+				detection.Threshold,
+				errors.New("Forbidden! The threshold must be a number greater than 0").Error(),
+			)
+		}
+	}
+	if detection.Method == AnomalyDetectionMethodSeasonal && detection.Season.Duration <= 0 {
+		return field.Required(
+			field.NewPath("spec").Child("history").Child("anomalyDetection").Child("season"),
+			errors.New("Forbidden! Season is required for the seasonal anomaly detection").Error(),
+		)
+	}
+	if detection.Method == AnomalyDetectionMethodSeasonal {
+		return s.validateHistoryCapacity()
+	}
 	return nil
 }
+
+// validateHistoryCapacity checks that the history can span enough seasons to collect the minimum number of samples
+// of the seasonal anomaly detection, as entries are at least the interval of the history or the fetch interval apart
+func (s *KeptnMetric) validateHistoryCapacity() *field.Error {
+	history := s.Spec.History
+	minSamples := history.AnomalyDetection.MinSamples
+	if minSamples == 0 {
+		minSamples = DefaultSeasonalMinSamples
+	}
+	maxEntries := history.MaxEntries
+	if maxEntries == 0 {
+		maxEntries = DefaultMaxHistoryEntries
+	}
+	spacing := time.Duration(s.Spec.FetchIntervalSeconds) * time.Second
+	if history.Interval.Duration > spacing {
+		spacing = history.Interval.Duration
+	}
+
+	required := time.Duration(minSamples) * history.AnomalyDetection.Season.Duration
+	if time.Duration(maxEntries)*spacing < required {
+		return field.Invalid(
+			field.NewPath("spec").Child("history").Child("maxEntries"),
+			maxEntries,
+			fmt.Sprintf("Forbidden! The history must span at least %s to collect %d samples of the seasonal anomaly detection. Please increase maxEntries or the interval", required, minSamples),
+		)
+	}
+	return nil
+}
+
+func (s *KeptnMetric) validateComposite(ctx context.Context, reader client.Reader) *field.Error {
+	if !s.IsComposite() {
+		return nil
+	}
+	compositePath := field.NewPath("spec").Child("composite")
+	if s.Spec.Range != nil {
+		return field.Forbidden(
+			field.NewPath("spec").Child("range"),
+			errors.New("Forbidden! Range cannot be used for composite metrics").Error(),
+		)
+	}
+
+	expr, err := expression.Parse(s.Spec.Composite.Expression)
+	if err != nil {
+		return field.Invalid(
+			compositePath.Child("expression"),
+			s.Spec.Composite.Expression,
+			fmt.Sprintf("Forbidden! The expression cannot be parsed: %s", err.Error()),
+		)
+	}
+
+	inputs := map[string]bool{}
+	for i, input := range s.Spec.Composite.Inputs {
+		if inputs[input.Name] {
+			return field.Duplicate(compositePath.Child("inputs").Index(i).Child("name"), input.Name)
+		}
+		inputs[input.Name] = true
+	}
+	for _, variable := range expr.Variables() {
+		if !inputs[variable] {
+			return field.Invalid(
+				compositePath.Child("expression"),
+				s.Spec.Composite.Expression,
+				fmt.Sprintf("Forbidden! The expression uses %s, which is not defined in the inputs", variable),
+			)
+		}
+	}
+
+	if reader == nil {
+		return nil
+	}
+	cycle, err := FindCompositeCycle(ctx, reader, s)
+	if err != nil {
+		return field.InternalError(compositePath.Child("inputs"), err)
+	}
+	if cycle != nil {
+		return field.Invalid(
+			compositePath.Child("inputs"),
+			strings.Join(cycle, " -> "),
+			errors.New("Forbidden! The composite metric depends on itself").Error(),
+		)
+	}
+	return nil
+}
+
+func isValidAggregation(r RangeSpec) bool {
+	switch r.Aggregation {
+	case "max", "min", "avg", "median", "sum", "count", "stddev", "rate", "increase", "last", "ewma":
+		return true
+	}
+	perc, ok := r.GetPercentile()
+	return ok && perc > 0 && perc <= 100
+}
@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -9,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestKeptnMetric_validateRangeInterval(t *testing.T) {
@@ -127,11 +130,11 @@ func TestKeptnMetric_validateRangeInterval(t *testing.T) {
 			var err error
 			switch tt.verb {
 			case "create":
-				_, err = s.ValidateCreate()
+				_, err = (&KeptnMetricValidator{}).ValidateCreate(context.TODO(), s)
 			case "update":
-				_, err = s.ValidateUpdate(tt.oldSpec)
+				_, err = (&KeptnMetricValidator{}).ValidateUpdate(context.TODO(), tt.oldSpec, s)
 			case "delete":
-				_, err = s.ValidateDelete()
+				_, err = (&KeptnMetricValidator{}).ValidateDelete(context.TODO(), s)
 			}
 			if tt.want == nil {
 				require.Nil(t, err)
@@ -474,11 +477,11 @@ func TestKeptnMetric_validateRangeStep(t *testing.T) {
 			var err error
 			switch tt.verb {
 			case "create":
-				_, err = s.ValidateCreate()
+				_, err = (&KeptnMetricValidator{}).ValidateCreate(context.TODO(), s)
 			case "update":
-				_, err = s.ValidateUpdate(tt.oldSpec)
+				_, err = (&KeptnMetricValidator{}).ValidateUpdate(context.TODO(), tt.oldSpec, s)
 			case "delete":
-				_, err = s.ValidateDelete()
+				_, err = (&KeptnMetricValidator{}).ValidateDelete(context.TODO(), s)
 			}
 			if tt.want == nil {
 				require.Nil(t, err)
@@ -488,3 +491,326 @@ func TestKeptnMetric_validateRangeStep(t *testing.T) {
 		})
 	}
 }
+
+func TestKeptnMetric_validateAggregationFunctions(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		Range *RangeSpec
+		want  error
+	}{
+		{
+			name:  "create-with-percentile",
+			Range: &RangeSpec{Interval: "5m", Step: "1m", Aggregation: "p99.9"},
+		},
+		{
+			name:  "create-with-ewma-and-smoothing-factor",
+			Range: &RangeSpec{Interval: "5m", Step: "1m", Aggregation: "ewma", SmoothingFactor: "0.3"},
+		},
+		{
+			name:  "create-with-rolling-window",
+			Range: &RangeSpec{Interval: "5m", Step: "1m", Aggregation: "rate", RollingWindow: true},
+		},
+		{
+			name:  "create-with-unsupported-aggregation",
+			Range: &RangeSpec{Interval: "5m", Step: "1m", Aggregation: "mode"},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "metrics.keptn.sh", Kind: "KeptnMetric"},
+				"create-with-unsupported-aggregation",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("range").Child("aggregation"),
+						"mode",
+						"Forbidden! The aggregation is not supported. Please use one of max, min, avg, median, sum, count, stddev, rate, increase, last, ewma or a percentile in the form pNN, e.g. p50",
+					),
+				},
+			),
+		},
+		{
+			name:  "create-with-percentile-out-of-range",
+			Range: &RangeSpec{Interval: "5m", Step: "1m", Aggregation: "p101"},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "metrics.keptn.sh", Kind: "KeptnMetric"},
+				"create-with-percentile-out-of-range",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("range").Child("aggregation"),
+						"p101",
+						"Forbidden! The aggregation is not supported. Please use one of max, min, avg, median, sum, count, stddev, rate, increase, last, ewma or a percentile in the form pNN, e.g. p50",
+					),
+				},
+			),
+		},
+		{
+			name:  "create-with-wrong-smoothing-factor",
+			Range: &RangeSpec{Interval: "5m", Step: "1m", Aggregation: "ewma", SmoothingFactor: "1.5"},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "metrics.keptn.sh", Kind: "KeptnMetric"},
+				"create-with-wrong-smoothing-factor",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("range").Child("smoothingFactor"),
+						"1.5",
+						"Forbidden! The smoothing factor must be a number greater than 0 and lower than or equal to 1",
+					),
+				},
+			),
+		},
+		{
+			name:  "create-with-rolling-window-without-step",
+			Range: &RangeSpec{Interval: "5m", RollingWindow: true},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "metrics.keptn.sh", Kind: "KeptnMetric"},
+				"create-with-rolling-window-without-step",
+				field.ErrorList{
+					field.Required(
+						field.NewPath("spec").Child("range").Child("step"),
+						"Forbidden! Step interval is required for the rolling window to work",
+					),
+				},
+			),
+		},
+		{
+			name:  "create-with-too-large-rolling-window",
+			Range: &RangeSpec{Interval: "24h", Step: "1s", Aggregation: "avg", RollingWindow: true},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "metrics.keptn.sh", Kind: "KeptnMetric"},
+				"create-with-too-large-rolling-window",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("range").Child("rollingWindow"),
+						true,
+						"Forbidden! The rolling window cannot contain more than 1000 steps. Please increase the step or decrease the interval",
+					),
+				},
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &KeptnMetric{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
+				Spec:       KeptnMetricSpec{Range: tt.Range},
+			}
+			_, err := (&KeptnMetricValidator{}).ValidateCreate(context.TODO(), s)
+			if tt.want == nil {
+				require.Nil(t, err)
+			} else {
+				require.Equal(t, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestKeptnMetric_validateAnomalyDetection(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		History *HistorySpec
+		want    error
+	}{
+		{
+			name:    "create-with-history-without-anomaly-detection",
+			History: &HistorySpec{MaxEntries: 10},
+		},
+		{
+			name: "create-with-zscore",
+			History: &HistorySpec{
+				AnomalyDetection: &AnomalyDetectionSpec{Method: AnomalyDetectionMethodZScore, Threshold: "2.5"},
+			},
+		},
+		{
+			name: "create-with-seasonal",
+			History: &HistorySpec{
+				Interval:         metav1.Duration{Duration: time.Hour},
+				AnomalyDetection: &AnomalyDetectionSpec{Method: AnomalyDetectionMethodSeasonal, Season: metav1.Duration{Duration: 24 * time.Hour}},
+			},
+		},
+		{
+			name: "create-with-seasonal-exceeding-history",
+			History: &HistorySpec{
+				MaxEntries:       48,
+				Interval:         metav1.Duration{Duration: time.Hour},
+				AnomalyDetection: &AnomalyDetectionSpec{Method: AnomalyDetectionMethodSeasonal, Season: metav1.Duration{Duration: 24 * time.Hour}},
+			},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "metrics.keptn.sh", Kind: "KeptnMetric"},
+				"create-with-seasonal-exceeding-history",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("history").Child("maxEntries"),
+						uint(48),
+						"Forbidden! The history must span at least 72h0m0s to collect 3 samples of the seasonal anomaly detection. Please increase maxEntries or the interval",
+					),
+				},
+			),
+		},
+		{
+			name: "create-with-wrong-threshold",
+			History: &HistorySpec{
+				AnomalyDetection: &AnomalyDetectionSpec{Method: AnomalyDetectionMethodZScore, Threshold: "-1"},
+			},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "metrics.keptn.sh", Kind: "KeptnMetric"},
+				"create-with-wrong-threshold",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("history").Child("anomalyDetection").Child("threshold"),
+						"-1",
+						"Forbidden! The threshold must be a number greater than 0",
+					),
+				},
+			),
+		},
+		{
+			name: "create-with-seasonal-without-season",
+			History: &HistorySpec{
+				AnomalyDetection: &AnomalyDetectionSpec{Method: AnomalyDetectionMethodSeasonal},
+			},
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "metrics.keptn.sh", Kind: "KeptnMetric"},
+				"create-with-seasonal-without-season",
+				field.ErrorList{
+					field.Required(
+						field.NewPath("spec").Child("history").Child("anomalyDetection").Child("season"),
+						"Forbidden! Season is required for the seasonal anomaly detection",
+					),
+				},
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &KeptnMetric{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
+				Spec:       KeptnMetricSpec{History: tt.History},
+			}
+			_, err := (&KeptnMetricValidator{}).ValidateCreate(context.TODO(), s)
+			if tt.want == nil {
+				require.Nil(t, err)
+			} else {
+				require.Equal(t, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestKeptnMetric_validateComposite(t *testing.T) {
+	newComposite := func(name string, expression string, inputs ...string) *KeptnMetric {
+		metric := &KeptnMetric{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: KeptnMetricSpec{
+				Composite: &CompositeSpec{Expression: expression},
+			},
+		}
+		for _, input := range inputs {
+			metric.Spec.Composite.Inputs = append(metric.Spec.Composite.Inputs, CompositeInput{
+				Name:           input,
+				KeptnMetricRef: ObjectReference{Name: input},
+			})
+		}
+		return metric
+	}
+
+	scheme := runtime.NewScheme()
+	require.Nil(t, AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&KeptnMetric{
+			ObjectMeta: metav1.ObjectMeta{Name: "requests", Namespace: "default"},
+			Spec:       KeptnMetricSpec{Provider: ProviderRef{Name: "prometheus"}, Query: "requests"},
+		},
+		newComposite("errors", "ratio * requests", "ratio", "requests"),
+	).Build()
+
+	tests := []struct {
+		name   string
+		metric *KeptnMetric
+		want   *field.Error
+	}{
+		{
+			name:   "indirect-cycle",
+			metric: newComposite("ratio", "errors / requests * 100", "errors", "requests"),
+			want: field.Invalid(
+				field.NewPath("spec").Child("composite").Child("inputs"),
+				"default/ratio -> default/errors -> default/ratio",
+				"Forbidden! The composite metric depends on itself",
+			),
+		},
+		{
+			name:   "valid",
+			metric: newComposite("availability", "100 - errors / requests * 100", "errors", "requests"),
+		},
+		{
+			name:   "self-reference",
+			metric: newComposite("requests2", "requests2 * 2", "requests2"),
+			want: field.Invalid(
+				field.NewPath("spec").Child("composite").Child("inputs"),
+				"default/requests2 -> default/requests2",
+				"Forbidden! The composite metric depends on itself",
+			),
+		},
+		{
+			name:   "undefined-variable",
+			metric: newComposite("ratio", "errors / total", "errors"),
+			want: field.Invalid(
+				field.NewPath("spec").Child("composite").Child("expression"),
+				"errors / total",
+				"Forbidden! The expression uses total, which is not defined in the inputs",
+			),
+		},
+		{
+			name:   "duplicate-input",
+			metric: newComposite("ratio", "errors / 2", "errors", "errors"),
+			want:   field.Duplicate(field.NewPath("spec").Child("composite").Child("inputs").Index(1).Child("name"), "errors"),
+		},
+		{
+			name:   "invalid-expression",
+			metric: newComposite("ratio", "errors %", "errors"),
+			want: field.Invalid(
+				field.NewPath("spec").Child("composite").Child("expression"),
+				"errors %",
+				"Forbidden! The expression cannot be parsed: invalid expression: 1:9: expected operand, found 'EOF'",
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.metric.validateComposite(context.TODO(), reader))
+		})
+	}
+}
+
+func TestKeptnMetricValidator_UsesReader(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.Nil(t, AddToScheme(scheme))
+	validator := &KeptnMetricValidator{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&KeptnMetric{
+				ObjectMeta: metav1.ObjectMeta{Name: "errors", Namespace: "default"},
+				Spec: KeptnMetricSpec{Composite: &CompositeSpec{
+					Expression: "ratio * 2",
+					Inputs:     []CompositeInput{{Name: "ratio", KeptnMetricRef: ObjectReference{Name: "ratio"}}},
+				}},
+			},
+		).Build(),
+	}
+	metric := &KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "ratio", Namespace: "default"},
+		Spec: KeptnMetricSpec{Composite: &CompositeSpec{
+			Expression: "errors / 2",
+			Inputs:     []CompositeInput{{Name: "errors", KeptnMetricRef: ObjectReference{Name: "errors"}}},
+		}},
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), metric)
+	require.ErrorContains(t, err, "default/ratio -> default/errors -> default/ratio")
+
+	_, err = validator.ValidateUpdate(context.TODO(), metric, metric)
+	require.ErrorContains(t, err, "default/ratio -> default/errors -> default/ratio")
+
+	_, err = (&KeptnMetricValidator{}).ValidateCreate(context.TODO(), metric)
+	require.Nil(t, err)
+
+	_, err = validator.ValidateCreate(context.TODO(), &Analysis{})
+	require.NotNil(t, err)
+}
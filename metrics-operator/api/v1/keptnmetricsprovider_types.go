@@ -26,8 +26,8 @@ import (
 // KeptnMetricsProviderSpec defines the desired state of KeptnMetricsProvider
 type KeptnMetricsProviderSpec struct {
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Pattern:=cortex|datadog|dql|dynatrace|prometheus|thanos|elastic
-	// Type represents the provider type. This can be one of cortex, datadog, dql, dynatrace, prometheus, elastic or thanos.
+	// +kubebuilder:validation:Pattern:=cortex|datadog|dql|dynatrace|prometheus|thanos|elastic|http|loki|tempo|influxdb|plugin
+	// Type represents the provider type. This can be one of cortex, datadog, dql, dynatrace, prometheus, elastic, http, loki, tempo, influxdb, plugin or thanos.
 	Type string `json:"type"`
 	// TargetServer defines URL (including port and protocol) at which the metrics provider is reachable.
 	TargetServer string `json:"targetServer"`
@@ -39,12 +39,104 @@ type KeptnMetricsProviderSpec struct {
 	// +kubebuilder:default:=false
 	// +optional
 	InsecureSkipTlsVerify bool `json:"insecureSkipTlsVerify,omitempty"`
+	// HTTP defines how metric values are retrieved by providers of type http,
+	// which query an arbitrary HTTP endpoint returning JSON.
+	// +optional
+	HTTP *HTTPProviderSpec `json:"http,omitempty"`
+	// Plugin defines the external plugin to which providers of type plugin delegate the retrieval of metric values.
+	// +optional
+	Plugin *PluginProviderSpec `json:"plugin,omitempty"`
+	// Resilience defines how queries to the provider are limited, retried and suspended while the provider is failing.
+	// If not set, queries are neither limited nor retried.
+	// +optional
+	Resilience *ResilienceSpec `json:"resilience,omitempty"`
+}
+
+// PluginProviderSpec defines the external plugin a provider delegates to
+type PluginProviderSpec struct {
+	// Endpoint defines the URL (including port and protocol) at which the plugin is reachable,
+	// e.g. 'http://my-plugin.my-namespace.svc.cluster.local:8080'.
+	Endpoint string `json:"endpoint"`
+	// Name is a human-readable name of the plugin, used for logging.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Trusted allows the value of the SecretKeyRef to be sent to the plugin although its endpoint does not use https,
+	// e.g. if the plugin runs within the cluster.
+	// +optional
+	Trusted bool `json:"trusted,omitempty"`
+}
+
+// HTTPProviderSpec defines how metric values are retrieved from an HTTP endpoint returning JSON
+type HTTPProviderSpec struct {
+	// URLTemplate is a Go template used to build the URL of the request.
+	// The template can access .TargetServer, .Query, .From and .To (time.Time), and .Step (time.Duration),
+	// e.g. '{{.TargetServer}}/sli?name={{urlquery .Query}}&from={{.From.Unix}}&to={{.To.Unix}}'.
+	// If not set, the query, start and end of the time range are passed as 'query', 'from' and 'to' parameters to the TargetServer.
+	// +optional
+	URLTemplate string `json:"urlTemplate,omitempty"`
+	// AuthHeader is the name of the HTTP header in which the value of SecretKeyRef is sent.
+	// +kubebuilder:default:="Authorization"
+	// +optional
+	AuthHeader string `json:"authHeader,omitempty"`
+	// ValuePath is a JSONPath expression evaluated on the response to retrieve the value of the metric,
+	// e.g. '{.data.value}'. More info on JSONPath - https://kubernetes.io/docs/reference/kubectl/jsonpath/
+	ValuePath string `json:"valuePath"`
+	// SeriesPath is a JSONPath expression evaluated on the response to retrieve the values of the metric
+	// within the time range of a KeptnMetric with a step, e.g. '{.data.points[*].value}'.
+	// +optional
+	SeriesPath string `json:"seriesPath,omitempty"`
+}
+
+// ResilienceSpec defines how queries to a provider are limited, retried and suspended while the provider is failing
+type ResilienceSpec struct {
+	// MaxConcurrentQueries limits the number of queries executed concurrently against the provider.
+	// If set to 0, the number of concurrent queries is not limited.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	MaxConcurrentQueries int `json:"maxConcurrentQueries,omitempty"`
+	// MaxRetries is the number of times a request is retried if the provider responds with
+	// the status code 429, 502, 503 or 504.
+	// +kubebuilder:default:=3
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// InitialBackoff is the time waited before the first retry of a request, which is doubled with every further retry.
+	// A Retry-After header sent by the provider takes precedence.
+	// +kubebuilder:default:="1s"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	InitialBackoff metav1.Duration `json:"initialBackoff,omitempty"`
+	// FailureThreshold is the number of consecutive queries which found the provider unavailable
+	// after which the circuit breaker opens and further queries are rejected.
+	// If set to 0, the circuit breaker is disabled.
+	// +kubebuilder:default:=5
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// OpenDuration is the time for which the circuit breaker stays open
+	// before a single query is let through to probe whether the provider has recovered.
+	// +kubebuilder:default:="1m"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	OpenDuration metav1.Duration `json:"openDuration,omitempty"`
+}
+
+// KeptnMetricsProviderStatus defines the observed state of KeptnMetricsProvider
+type KeptnMetricsProviderStatus struct {
+	// Conditions represent the availability of the provider.
+	// The Available condition is False while the circuit breaker of the provider is open.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=keptnmetricsproviders,shortName=kmp
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Available",type=string,JSONPath=`.status.conditions[?(@.type=="Available")].status`
 
 // KeptnMetricsProvider is the Schema for the keptnmetricsproviders API
 type KeptnMetricsProvider struct {
@@ -54,9 +146,8 @@ type KeptnMetricsProvider struct {
 
 	// +optional
 	Spec KeptnMetricsProviderSpec `json:"spec,omitempty"`
-	// unused field
 	// +optional
-	Status string `json:"status,omitempty"`
+	Status KeptnMetricsProviderStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
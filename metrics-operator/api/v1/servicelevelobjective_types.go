@@ -0,0 +1,153 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultSLOWindow               = 30 * 24 * time.Hour
+	defaultSLOFetchIntervalSeconds = 60
+)
+
+var defaultBurnRateWindows = []metav1.Duration{{Duration: time.Hour}, {Duration: 6 * time.Hour}}
+
+// ServiceLevelObjectiveSpec defines the desired state of ServiceLevelObjective
+type ServiceLevelObjectiveSpec struct {
+	// Provider refers to the KeptnMetricsProvider which is used to run the queries of the ServiceLevelObjective
+	Provider ProviderRef `json:"provider"`
+	// GoodQuery is the query returning the number of good events, e.g. successful requests, within a window.
+	// The placeholder '{{.window}}' is replaced with the duration of the window,
+	// e.g. 'sum(increase(http_requests_total{code!~"5.."}[{{.window}}]))'.
+	GoodQuery string `json:"goodQuery"`
+	// TotalQuery is the query returning the total number of events within a window.
+	// The placeholder '{{.window}}' is replaced with the duration of the window,
+	// e.g. 'sum(increase(http_requests_total[{{.window}}]))'.
+	TotalQuery string `json:"totalQuery"`
+	// Target is the percentage of good events that must be reached within the Window, e.g. '99.9'.
+	Target resource.Quantity `json:"target"`
+	// Window is the rolling time window over which the compliance with the Target and the error budget are computed.
+	// +kubebuilder:default:="720h"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Window metav1.Duration `json:"window,omitempty"`
+	// BurnRateWindows are the time windows for which the rate at which the error budget is consumed is computed.
+	// If not set, the burn rates for the last hour and the last six hours are computed.
+	// +optional
+	BurnRateWindows []metav1.Duration `json:"burnRateWindows,omitempty"`
+	// FetchIntervalSeconds represents the update frequency in seconds that is used to update the status
+	// +kubebuilder:default:=60
+	// +optional
+	FetchIntervalSeconds uint `json:"fetchIntervalSeconds,omitempty"`
+}
+
+// ServiceLevelObjectiveStatus defines the observed state of ServiceLevelObjective
+type ServiceLevelObjectiveStatus struct {
+	// SLI is the percentage of good events within the Window
+	// +optional
+	SLI string `json:"sli,omitempty"`
+	// ErrorBudgetRemaining is the percentage of the error budget of the Window which has not been consumed yet.
+	// A negative value indicates that the error budget has been exceeded.
+	// +optional
+	ErrorBudgetRemaining string `json:"errorBudgetRemaining,omitempty"`
+	// Exhausted indicates whether the error budget of the Window has been consumed completely
+	// +optional
+	Exhausted bool `json:"exhausted,omitempty"`
+	// BurnRates contains the rates at which the error budget is consumed within the BurnRateWindows
+	// +optional
+	BurnRates []BurnRate `json:"burnRates,omitempty"`
+	// LastUpdated represents the time when the status data was last updated
+	// +optional
+	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
+	// ErrMsg represents the error details when the queries could not be evaluated
+	// +optional
+	ErrMsg string `json:"errMsg,omitempty"`
+}
+
+// BurnRate stores the rate at which the error budget is consumed within a time window
+type BurnRate struct {
+	// Window is the time window for which the burn rate has been computed
+	Window metav1.Duration `json:"window"`
+	// Value is the ratio between the error rate within the window and the error rate allowed by the Target.
+	// A value of 1 consumes the error budget exactly by the end of the Window, higher values exhaust it earlier.
+	Value string `json:"value"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=servicelevelobjectives,shortName=slo
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider.name`
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target`
+// +kubebuilder:printcolumn:name="SLI",type=string,JSONPath=`.status.sli`
+// +kubebuilder:printcolumn:name="Budget Remaining",type=string,JSONPath=`.status.errorBudgetRemaining`
+// +kubebuilder:printcolumn:name="Exhausted",type=boolean,JSONPath=`.status.exhausted`
+// +kubebuilder:storageversion
+
+// ServiceLevelObjective is the Schema for the servicelevelobjectives API
+type ServiceLevelObjective struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ServiceLevelObjectiveSpec `json:"spec,omitempty"`
+	// +optional
+	Status ServiceLevelObjectiveStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceLevelObjectiveList contains a list of ServiceLevelObjective resources
+type ServiceLevelObjectiveList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceLevelObjective `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServiceLevelObjective{}, &ServiceLevelObjectiveList{})
+}
+
+// GetWindow returns the rolling time window of the ServiceLevelObjective, defaulting to 30 days
+func (s *ServiceLevelObjective) GetWindow() time.Duration {
+	if s.Spec.Window.Duration <= 0 {
+		return defaultSLOWindow
+	}
+	return s.Spec.Window.Duration
+}
+
+// GetBurnRateWindows returns the time windows for which burn rates are computed
+func (s *ServiceLevelObjective) GetBurnRateWindows() []metav1.Duration {
+	if len(s.Spec.BurnRateWindows) == 0 {
+		return defaultBurnRateWindows
+	}
+	return s.Spec.BurnRateWindows
+}
+
+// GetFetchInterval returns the interval in which the status of the ServiceLevelObjective is updated
+func (s *ServiceLevelObjective) GetFetchInterval() time.Duration {
+	if s.Spec.FetchIntervalSeconds == 0 {
+		return defaultSLOFetchIntervalSeconds * time.Second
+	}
+	return time.Duration(s.Spec.FetchIntervalSeconds) * time.Second
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"text/template"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var servicelevelobjectivelog = logf.Log.WithName("servicelevelobjective-webhook")
+
+func (r *ServiceLevelObjective) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-metrics-keptn-sh-v1-servicelevelobjective,mutating=false,failurePolicy=fail,sideEffects=None,groups=metrics.keptn.sh,resources=servicelevelobjectives,verbs=create;update,versions=v1,name=vservicelevelobjective.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ServiceLevelObjective{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ServiceLevelObjective) ValidateCreate() (admission.Warnings, error) {
+	servicelevelobjectivelog.Info("validate create", "name", r.Name)
+
+	return []string{}, r.validateServiceLevelObjective()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ServiceLevelObjective) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	servicelevelobjectivelog.Info("validate update", "name", r.Name)
+
+	return []string{}, r.validateServiceLevelObjective()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ServiceLevelObjective) ValidateDelete() (admission.Warnings, error) {
+	servicelevelobjectivelog.Info("validate delete", "name", r.Name)
+
+	return []string{}, nil
+}
+
+func (r *ServiceLevelObjective) validateServiceLevelObjective() error {
+	var allErrs field.ErrorList // defined as a list to allow returning multiple validation errors
+	if err := r.validateTarget(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := validateSLOQuery(field.NewPath("spec").Child("goodQuery"), r.Spec.GoodQuery); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := validateSLOQuery(field.NewPath("spec").Child("totalQuery"), r.Spec.TotalQuery); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	allErrs = append(allErrs, r.validateWindows()...)
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "metrics.keptn.sh", Kind: "ServiceLevelObjective"},
+		r.Name,
+		allErrs)
+}
+
+func (r *ServiceLevelObjective) validateTarget() *field.Error {
+	target := r.Spec.Target.AsApproximateFloat64()
+	if target <= 0 || target >= 100 {
+		return field.Invalid(
+			field.NewPath("spec").Child("target"),
+			r.Spec.Target.String(),
+			errors.New("Forbidden! The target must be a percentage greater than 0 and lower than 100").Error(),
+		)
+	}
+	return nil
+}
+
+func validateSLOQuery(path *field.Path, query string) *field.Error {
+	if query == "" {
+		return field.Required(path, "Forbidden! The query must not be empty")
+	}
+	if _, err := template.New("").Parse(query); err != nil {
+		return field.Invalid(
+			path,
+			query,
+			errors.Errorf("Forbidden! The query is no valid template: %s", err.Error()).Error(),
+		)
+	}
+	return nil
+}
+
+func (r *ServiceLevelObjective) validateWindows() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.Window.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec").Child("window"),
+			r.Spec.Window.Duration.String(),
+			errors.New("Forbidden! The window must be a positive duration").Error(),
+		))
+	}
+	for i, window := range r.Spec.BurnRateWindows {
+		if window.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(
+				field.NewPath("spec").Child("burnRateWindows").Index(i),
+				window.Duration.String(),
+				errors.New("Forbidden! The burn rate window must be a positive duration").Error(),
+			))
+		}
+	}
+	return allErrs
+}
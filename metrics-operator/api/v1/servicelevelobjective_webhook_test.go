@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestServiceLevelObjective_validateServiceLevelObjective(t *testing.T) {
+	validSpec := func() ServiceLevelObjectiveSpec {
+		return ServiceLevelObjectiveSpec{
+			Provider:   ProviderRef{Name: "prometheus"},
+			GoodQuery:  `sum(increase(http_requests_total{code!~"5.."}[{{.window}}]))`,
+			TotalQuery: "sum(increase(http_requests_total[{{.window}}]))",
+			Target:     resource.MustParse("99.9"),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		spec    func() ServiceLevelObjectiveSpec
+		wantErr field.ErrorList
+	}{
+		{
+			name: "valid",
+			spec: validSpec,
+		},
+		{
+			name: "target of 100",
+			spec: func() ServiceLevelObjectiveSpec {
+				s := validSpec()
+				s.Target = resource.MustParse("100")
+				return s
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("target"), "100", "Forbidden! The target must be a percentage greater than 0 and lower than 100"),
+			},
+		},
+		{
+			name: "negative target",
+			spec: func() ServiceLevelObjectiveSpec {
+				s := validSpec()
+				s.Target = resource.MustParse("-1")
+				return s
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("target"), "-1", "Forbidden! The target must be a percentage greater than 0 and lower than 100"),
+			},
+		},
+		{
+			name: "empty queries",
+			spec: func() ServiceLevelObjectiveSpec {
+				s := validSpec()
+				s.GoodQuery = ""
+				s.TotalQuery = ""
+				return s
+			},
+			wantErr: field.ErrorList{
+				field.Required(field.NewPath("spec").Child("goodQuery"), "Forbidden! The query must not be empty"),
+				field.Required(field.NewPath("spec").Child("totalQuery"), "Forbidden! The query must not be empty"),
+			},
+		},
+		{
+			name: "invalid query template",
+			spec: func() ServiceLevelObjectiveSpec {
+				s := validSpec()
+				s.TotalQuery = "sum(increase(http_requests_total[{{.window]))"
+				return s
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec").Child("totalQuery"),
+					"sum(increase(http_requests_total[{{.window]))",
+					`Forbidden! The query is no valid template: template: :1: bad character U+005D ']'`,
+				),
+			},
+		},
+		{
+			name: "invalid windows",
+			spec: func() ServiceLevelObjectiveSpec {
+				s := validSpec()
+				s.Window = metav1.Duration{Duration: -time.Hour}
+				s.BurnRateWindows = []metav1.Duration{{Duration: time.Hour}, {Duration: 0}}
+				return s
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("window"), "-1h0m0s", "Forbidden! The window must be a positive duration"),
+				field.Invalid(field.NewPath("spec").Child("burnRateWindows").Index(1), "0s", "Forbidden! The burn rate window must be a positive duration"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slo := &ServiceLevelObjective{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-slo"},
+				Spec:       tt.spec(),
+			}
+
+			_, err := slo.ValidateCreate()
+			if tt.wantErr == nil {
+				require.Nil(t, err)
+				return
+			}
+			require.Equal(t, apierrors.NewInvalid(
+				schema.GroupKind{Group: "metrics.keptn.sh", Kind: "ServiceLevelObjective"},
+				"my-slo",
+				tt.wantErr,
+			), err)
+
+			_, err = slo.ValidateUpdate(&ServiceLevelObjective{})
+			require.NotNil(t, err)
+		})
+	}
+}
@@ -21,6 +21,7 @@ limitations under the License.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -164,6 +165,38 @@ func (in *AnalysisList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnalysisRunResult) DeepCopyInto(out *AnalysisRunResult) {
+	*out = *in
+	in.Timeframe.DeepCopyInto(&out.Timeframe)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalysisRunResult.
+func (in *AnalysisRunResult) DeepCopy() *AnalysisRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalysisRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnalysisSchedule) DeepCopyInto(out *AnalysisSchedule) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalysisSchedule.
+func (in *AnalysisSchedule) DeepCopy() *AnalysisSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalysisSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AnalysisSpec) DeepCopyInto(out *AnalysisSpec) {
 	*out = *in
@@ -176,6 +209,11 @@ func (in *AnalysisSpec) DeepCopyInto(out *AnalysisSpec) {
 		}
 	}
 	out.AnalysisDefinition = in.AnalysisDefinition
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(AnalysisSchedule)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalysisSpec.
@@ -196,7 +234,15 @@ func (in *AnalysisStatus) DeepCopyInto(out *AnalysisStatus) {
 		in, out := &in.StoredValues, &out.StoredValues
 		*out = make(map[string]ProviderResult, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	in.NextRunTime.DeepCopyInto(&out.NextRunTime)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]AnalysisRunResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
@@ -285,6 +331,154 @@ func (in *AnalysisValueTemplateSpec) DeepCopy() *AnalysisValueTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnomalyDetectionSpec) DeepCopyInto(out *AnomalyDetectionSpec) {
+	*out = *in
+	out.Season = in.Season
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnomalyDetectionSpec.
+func (in *AnomalyDetectionSpec) DeepCopy() *AnomalyDetectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AnomalyDetectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Baseline) DeepCopyInto(out *Baseline) {
+	*out = *in
+	out.Offset = in.Offset
+	if in.Timeframe != nil {
+		in, out := &in.Timeframe, &out.Timeframe
+		*out = new(Timeframe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Baseline.
+func (in *Baseline) DeepCopy() *Baseline {
+	if in == nil {
+		return nil
+	}
+	out := new(Baseline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurnRate) DeepCopyInto(out *BurnRate) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurnRate.
+func (in *BurnRate) DeepCopy() *BurnRate {
+	if in == nil {
+		return nil
+	}
+	out := new(BurnRate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeInput) DeepCopyInto(out *CompositeInput) {
+	*out = *in
+	out.KeptnMetricRef = in.KeptnMetricRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeInput.
+func (in *CompositeInput) DeepCopy() *CompositeInput {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeSpec) DeepCopyInto(out *CompositeSpec) {
+	*out = *in
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]CompositeInput, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeSpec.
+func (in *CompositeSpec) DeepCopy() *CompositeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProviderSpec) DeepCopyInto(out *HTTPProviderSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProviderSpec.
+func (in *HTTPProviderSpec) DeepCopy() *HTTPProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HistoryEntry) DeepCopyInto(out *HistoryEntry) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HistoryEntry.
+func (in *HistoryEntry) DeepCopy() *HistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(HistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HistorySpec) DeepCopyInto(out *HistorySpec) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.AnomalyDetection != nil {
+		in, out := &in.AnomalyDetection, &out.AnomalyDetection
+		*out = new(AnomalyDetectionSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HistorySpec.
+func (in *HistorySpec) DeepCopy() *HistorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HistorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntervalResult) DeepCopyInto(out *IntervalResult) {
 	*out = *in
@@ -374,6 +568,16 @@ func (in *KeptnMetricSpec) DeepCopyInto(out *KeptnMetricSpec) {
 		*out = new(RangeSpec)
 		**out = **in
 	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(HistorySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Composite != nil {
+		in, out := &in.Composite, &out.Composite
+		*out = new(CompositeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnMetricSpec.
@@ -402,6 +606,32 @@ func (in *KeptnMetricStatus) DeepCopyInto(out *KeptnMetricStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]MetricResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RollingWindow != nil {
+		in, out := &in.RollingWindow, &out.RollingWindow
+		*out = new(RollingWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]HistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnMetricStatus.
@@ -420,6 +650,7 @@ func (in *KeptnMetricsProvider) DeepCopyInto(out *KeptnMetricsProvider) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnMetricsProvider.
@@ -476,6 +707,21 @@ func (in *KeptnMetricsProviderList) DeepCopyObject() runtime.Object {
 func (in *KeptnMetricsProviderSpec) DeepCopyInto(out *KeptnMetricsProviderSpec) {
 	*out = *in
 	in.SecretKeyRef.DeepCopyInto(&out.SecretKeyRef)
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPProviderSpec)
+		**out = **in
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginProviderSpec)
+		**out = **in
+	}
+	if in.Resilience != nil {
+		in, out := &in.Resilience, &out.Resilience
+		*out = new(ResilienceSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnMetricsProviderSpec.
@@ -488,6 +734,50 @@ func (in *KeptnMetricsProviderSpec) DeepCopy() *KeptnMetricsProviderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnMetricsProviderStatus) DeepCopyInto(out *KeptnMetricsProviderStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnMetricsProviderStatus.
+func (in *KeptnMetricsProviderStatus) DeepCopy() *KeptnMetricsProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnMetricsProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricResult) DeepCopyInto(out *MetricResult) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricResult.
+func (in *MetricResult) DeepCopy() *MetricResult {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
@@ -508,6 +798,16 @@ func (in *Objective) DeepCopyInto(out *Objective) {
 	*out = *in
 	out.AnalysisValueTemplateRef = in.AnalysisValueTemplateRef
 	in.Target.DeepCopyInto(&out.Target)
+	if in.Baseline != nil {
+		in, out := &in.Baseline, &out.Baseline
+		*out = new(Baseline)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StatisticalTest != nil {
+		in, out := &in.StatisticalTest, &out.StatisticalTest
+		*out = new(StatisticalTest)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Objective.
@@ -586,6 +886,21 @@ func (in *OperatorValue) DeepCopy() *OperatorValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginProviderSpec) DeepCopyInto(out *PluginProviderSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginProviderSpec.
+func (in *PluginProviderSpec) DeepCopy() *PluginProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderRef) DeepCopyInto(out *ProviderRef) {
 	*out = *in
@@ -605,6 +920,16 @@ func (in *ProviderRef) DeepCopy() *ProviderRef {
 func (in *ProviderResult) DeepCopyInto(out *ProviderResult) {
 	*out = *in
 	out.Objective = in.Objective
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BaselineValues != nil {
+		in, out := &in.BaselineValues, &out.BaselineValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderResult.
@@ -649,6 +974,173 @@ func (in *RangeValue) DeepCopy() *RangeValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResilienceSpec) DeepCopyInto(out *ResilienceSpec) {
+	*out = *in
+	out.InitialBackoff = in.InitialBackoff
+	out.OpenDuration = in.OpenDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResilienceSpec.
+func (in *ResilienceSpec) DeepCopy() *ResilienceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResilienceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingWindow) DeepCopyInto(out *RollingWindow) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingWindow.
+func (in *RollingWindow) DeepCopy() *RollingWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceLevelObjective) DeepCopyInto(out *ServiceLevelObjective) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjective.
+func (in *ServiceLevelObjective) DeepCopy() *ServiceLevelObjective {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceLevelObjective)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceLevelObjective) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceLevelObjectiveList) DeepCopyInto(out *ServiceLevelObjectiveList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServiceLevelObjective, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjectiveList.
+func (in *ServiceLevelObjectiveList) DeepCopy() *ServiceLevelObjectiveList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceLevelObjectiveList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceLevelObjectiveList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceLevelObjectiveSpec) DeepCopyInto(out *ServiceLevelObjectiveSpec) {
+	*out = *in
+	out.Provider = in.Provider
+	out.Target = in.Target.DeepCopy()
+	out.Window = in.Window
+	if in.BurnRateWindows != nil {
+		in, out := &in.BurnRateWindows, &out.BurnRateWindows
+		*out = make([]metav1.Duration, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjectiveSpec.
+func (in *ServiceLevelObjectiveSpec) DeepCopy() *ServiceLevelObjectiveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceLevelObjectiveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceLevelObjectiveStatus) DeepCopyInto(out *ServiceLevelObjectiveStatus) {
+	*out = *in
+	if in.BurnRates != nil {
+		in, out := &in.BurnRates, &out.BurnRates
+		*out = make([]BurnRate, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjectiveStatus.
+func (in *ServiceLevelObjectiveStatus) DeepCopy() *ServiceLevelObjectiveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceLevelObjectiveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatisticalTest) DeepCopyInto(out *StatisticalTest) {
+	*out = *in
+	out.Step = in.Step
+	if in.Confidence != nil {
+		in, out := &in.Confidence, &out.Confidence
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.WarningConfidence != nil {
+		in, out := &in.WarningConfidence, &out.WarningConfidence
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatisticalTest.
+func (in *StatisticalTest) DeepCopy() *StatisticalTest {
+	if in == nil {
+		return nil
+	}
+	out := new(StatisticalTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Target) DeepCopyInto(out *Target) {
 	*out = *in
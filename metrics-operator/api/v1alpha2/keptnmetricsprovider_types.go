@@ -28,6 +28,15 @@ type KeptnMetricsProviderSpec struct {
 	SecretKeyRef corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
 }
 
+// KeptnMetricsProviderStatus defines the observed state of KeptnMetricsProvider.
+// It is shared by all versions of KeptnMetricsProvider, as they are served from the same stored object.
+type KeptnMetricsProviderStatus struct {
+	// Conditions represent the availability of the provider.
+	// The Available condition is False while the circuit breaker of the provider is open.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=keptnmetricsproviders,shortName=kmp
@@ -40,9 +49,8 @@ type KeptnMetricsProvider struct {
 
 	// +optional
 	Spec KeptnMetricsProviderSpec `json:"spec,omitempty"`
-	// unused field
 	// +optional
-	Status string `json:"status,omitempty"`
+	Status KeptnMetricsProviderStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
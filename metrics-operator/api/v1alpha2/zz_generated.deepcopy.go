@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -126,6 +127,7 @@ func (in *KeptnMetricsProvider) DeepCopyInto(out *KeptnMetricsProvider) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnMetricsProvider.
@@ -194,6 +196,28 @@ func (in *KeptnMetricsProviderSpec) DeepCopy() *KeptnMetricsProviderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnMetricsProviderStatus) DeepCopyInto(out *KeptnMetricsProviderStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnMetricsProviderStatus.
+func (in *KeptnMetricsProviderStatus) DeepCopy() *KeptnMetricsProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnMetricsProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderRef) DeepCopyInto(out *ProviderRef) {
 	*out = *in
@@ -10,7 +10,6 @@ import (
 	"k8s.io/component-base/logs"
 	"k8s.io/klog/v2"
 	basecmd "sigs.k8s.io/custom-metrics-apiserver/pkg/cmd"
-	"sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
 )
 
 const (
@@ -29,7 +28,8 @@ type MetricsAdapter struct {
 	KeptnNamespace string
 }
 
-// RunAdapter starts the Keptn Metrics adapter to provide KeptnMetrics via the Kubernetes Custom Metrics API.
+// RunAdapter starts the Keptn Metrics adapter to provide KeptnMetrics via the Kubernetes Custom Metrics API
+// and the Kubernetes External Metrics API.
 // Runs until the given context is done.
 func (a *MetricsAdapter) RunAdapter(ctx context.Context) {
 
@@ -57,6 +57,7 @@ func (a *MetricsAdapter) RunAdapter(ctx context.Context) {
 	prov := cmd.makeProviderOrDie(ctx)
 
 	cmd.WithCustomMetrics(prov)
+	cmd.WithExternalMetrics(prov)
 
 	if err := cmd.Run(ctx.Done()); err != nil {
 		klog.Fatalf("Could not run custom metrics adapter: %v", err)
@@ -64,7 +65,7 @@ func (a *MetricsAdapter) RunAdapter(ctx context.Context) {
 	klog.Info("Finishing Keptn Metrics Adapter")
 }
 
-func (a *MetricsAdapter) makeProviderOrDie(ctx context.Context) provider.CustomMetricsProvider {
+func (a *MetricsAdapter) makeProviderOrDie(ctx context.Context) kmprovider.MetricsProvider {
 	client, err := a.DynamicClient()
 	if err != nil {
 		klog.Fatalf("unable to construct dynamic client: %v", err)
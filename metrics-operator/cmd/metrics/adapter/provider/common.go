@@ -5,7 +5,10 @@ import "github.com/pkg/errors"
 const (
 	metricsGroup        = "metrics.keptn.sh"
 	metricsResource     = "keptnmetrics"
+	sloResource         = "servicelevelobjectives"
 	defaultMetricsValue = "0.0"
 )
 
 var ErrMetricNotFound = errors.New("no metric value found")
+
+var ErrMultipleSeries = errors.New("selector matches multiple series of the metric")
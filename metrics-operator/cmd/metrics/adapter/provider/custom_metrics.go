@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"k8s.io/apimachinery/pkg/labels"
@@ -16,24 +17,46 @@ type metricKey string
 type CustomMetricValue struct {
 	Value  custom_metrics.MetricValue
 	Labels map[string]string
+	// SeriesLabels identify the series of a multi-dimensional KeptnMetric the value belongs to
+	SeriesLabels map[string]string
 }
 
 type CustomMetricsCache struct {
 	mtx     sync.RWMutex
 	metrics map[metricKey]CustomMetricValue
+	// resource is the resource the cached metrics are described by, defaults to keptnmetrics
+	resource string
 }
 
-// Update adds a new metricValue for the given metricName to the cache. If an item has already been present for the provided
-// metricName, the previous value will be replaced.
+// Update adds a new metricValue for the given metricName and series labels to the cache. If an item has already been present for the provided
+// metricName and series labels, the previous value will be replaced.
 func (cm *CustomMetricsCache) Update(metricName string, metricValue CustomMetricValue) {
 	cm.mtx.Lock()
 	defer cm.mtx.Unlock()
+	cm.update(metricName, metricValue)
+}
+
+// ReplaceObject replaces all values that are described by the object with the given name with the given values,
+// which are grouped by their metricName. Readers never observe the object without values in between.
+func (cm *CustomMetricsCache) ReplaceObject(objectName types.NamespacedName, metricValues map[string][]CustomMetricValue) {
+	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
+	cm.deleteObject(objectName)
+	for metricName, values := range metricValues {
+		for _, value := range values {
+			cm.update(metricName, value)
+		}
+	}
+}
+
+// update adds the metricValue to the cache, the caller must hold the lock
+func (cm *CustomMetricsCache) update(metricName string, metricValue CustomMetricValue) {
 	if cm.metrics == nil {
 		cm.metrics = map[metricKey]CustomMetricValue{}
 	}
 	metricNamespace := metricValue.Value.DescribedObject.Namespace
 
-	metricKey := getMetricKey(metricName, metricNamespace)
+	metricKey := getSeriesKey(getMetricKey(metricName, metricNamespace), metricValue.SeriesLabels)
 	cm.metrics[metricKey] = metricValue
 }
 
@@ -45,17 +68,38 @@ func (cm *CustomMetricsCache) Delete(metricName types.NamespacedName) {
 	delete(cm.metrics, getMetricKey(metricName.Name, metricName.Namespace))
 }
 
+// DeleteObject will delete all values that are described by the object with the given name
+func (cm *CustomMetricsCache) DeleteObject(objectName types.NamespacedName) {
+	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
+	cm.deleteObject(objectName)
+}
+
+// deleteObject deletes all values that are described by the object with the given name, the caller must hold the lock
+func (cm *CustomMetricsCache) deleteObject(objectName types.NamespacedName) {
+	for key, value := range cm.metrics {
+		describedObject := value.Value.DescribedObject
+		if describedObject.Name == objectName.Name && describedObject.Namespace == objectName.Namespace {
+			delete(cm.metrics, key)
+		}
+	}
+}
+
 // List returns a slice of provider.CustomMetricInfo objects containing all the available metrics
-// that are currently present in the cache
+// that are currently present in the cache. Metrics with multiple series are listed once.
 func (cm *CustomMetricsCache) List() []provider.CustomMetricInfo {
 	cm.mtx.RLock()
 	defer cm.mtx.RUnlock()
-	res := make([]provider.CustomMetricInfo, len(cm.metrics))
+	res := []provider.CustomMetricInfo{}
 
-	i := 0
-	for metricInfo := range cm.metrics {
-		res[i] = generateCustomMetricInfo(cm.metrics[metricInfo].Value.Metric.Name)
-		i++
+	listed := map[string]bool{}
+	for _, metricValue := range cm.metrics {
+		name := metricValue.Value.Metric.Name
+		if listed[name] {
+			continue
+		}
+		listed[name] = true
+		res = append(res, generateCustomMetricInfo(cm.getResource(), name))
 	}
 	return res
 }
@@ -68,23 +112,61 @@ func (cm *CustomMetricsCache) ListByLabelSelector(selector labels.Selector) []pr
 	res := []provider.CustomMetricInfo{}
 	for _, metricValue := range cm.metrics {
 		if selector.Matches(labels.Set(metricValue.Labels)) {
-			res = append(res, generateCustomMetricInfo(metricValue.Value.Metric.Name))
+			res = append(res, generateCustomMetricInfo(cm.getResource(), metricValue.Value.Metric.Name))
 		}
 	}
 	return res
 }
 
-// Get returns the metric value for the given metric name
+// Get returns the metric value for the given metric name.
+// For a metric with multiple series, the value of the first series ordered by their labels is returned.
 func (cm *CustomMetricsCache) Get(metricName types.NamespacedName) (*CustomMetricValue, error) {
 	cm.mtx.RLock()
 	defer cm.mtx.RUnlock()
-	metric, ok := cm.metrics[getMetricKey(metricName.Name, metricName.Namespace)]
-	if !ok {
+	key := getMetricKey(metricName.Name, metricName.Namespace)
+	if metric, ok := cm.metrics[key]; ok {
+		return &metric, nil
+	}
+
+	var firstSeriesKey metricKey
+	for seriesKey := range cm.metrics {
+		if strings.HasPrefix(string(seriesKey), string(key)+"{") && (firstSeriesKey == "" || seriesKey < firstSeriesKey) {
+			firstSeriesKey = seriesKey
+		}
+	}
+	if firstSeriesKey == "" {
 		return nil, ErrMetricNotFound
 	}
+	metric := cm.metrics[firstSeriesKey]
 	return &metric, nil
 }
 
+// GetBySeriesSelector returns the value of the series of the given metric whose labels match the given selector
+func (cm *CustomMetricsCache) GetBySeriesSelector(metricName types.NamespacedName, selector labels.Selector) (*CustomMetricValue, error) {
+	cm.mtx.RLock()
+	defer cm.mtx.RUnlock()
+
+	var res *CustomMetricValue
+	for _, value := range cm.metrics {
+		describedObject := value.Value.DescribedObject
+		if describedObject.Name != metricName.Name || describedObject.Namespace != metricName.Namespace {
+			continue
+		}
+		if !selector.Matches(labels.Set(value.SeriesLabels)) {
+			continue
+		}
+		if res != nil {
+			return nil, fmt.Errorf("%w: %s", ErrMultipleSeries, selector.String())
+		}
+		v := value
+		res = &v
+	}
+	if res == nil {
+		return nil, ErrMetricNotFound
+	}
+	return res, nil
+}
+
 // GetValuesByLabel returns a slice of CustomMetricValue objects containing the values of all
 // available metrics that match with the given label
 func (cm *CustomMetricsCache) GetValuesByLabel(selector labels.Selector) []CustomMetricValue {
@@ -100,17 +182,50 @@ func (cm *CustomMetricsCache) GetValuesByLabel(selector labels.Selector) []Custo
 	return res
 }
 
-func generateCustomMetricInfo(name string) provider.CustomMetricInfo {
+// GetValuesByName returns a slice of CustomMetricValue objects containing the values of all metrics with the given name
+// in the given namespace, whose labels, including the labels of their series, match with the given selector
+func (cm *CustomMetricsCache) GetValuesByName(metricName, namespace string, selector labels.Selector) []CustomMetricValue {
+	cm.mtx.RLock()
+	defer cm.mtx.RUnlock()
+
+	res := []CustomMetricValue{}
+	for _, value := range cm.metrics {
+		if value.Value.Metric.Name != metricName || value.Value.DescribedObject.Namespace != namespace {
+			continue
+		}
+		if selector.Matches(labels.Merge(value.Labels, value.SeriesLabels)) {
+			res = append(res, value)
+		}
+	}
+	return res
+}
+
+func (cm *CustomMetricsCache) getResource() string {
+	if cm.resource == "" {
+		return metricsResource
+	}
+	return cm.resource
+}
+
+func generateCustomMetricInfo(resource, name string) provider.CustomMetricInfo {
 	return provider.CustomMetricInfo{
 		GroupResource: schema.GroupResource{
 			Group:    metricsGroup,
-			Resource: metricsResource,
+			Resource: resource,
 		},
 		Metric:     name,
 		Namespaced: true,
 	}
 }
 
+// getSeriesKey returns the key of the series with the given labels of the metric with the given key
+func getSeriesKey(key metricKey, seriesLabels map[string]string) metricKey {
+	if len(seriesLabels) == 0 {
+		return key
+	}
+	return metricKey(fmt.Sprintf("%s{%s}", key, labels.Set(seriesLabels).String()))
+}
+
 func getMetricKey(metricName, metricNamespace string) metricKey {
 	if metricNamespace == "" {
 		metricNamespace = "default"
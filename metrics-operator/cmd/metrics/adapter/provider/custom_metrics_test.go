@@ -32,6 +32,54 @@ func TestCustomMetrics_Delete(t *testing.T) {
 	require.Empty(t, cm.metrics)
 }
 
+func TestCustomMetrics_DeleteObject(t *testing.T) {
+	cm := CustomMetricsCache{
+		metrics: map[metricKey]CustomMetricValue{
+			"my-namespace-my-slo/sli": {
+				Value: custom_metrics.MetricValue{
+					Metric: custom_metrics.MetricIdentifier{
+						Name: "sli",
+					},
+					DescribedObject: custom_metrics.ObjectReference{
+						Name:      "my-slo",
+						Namespace: "my-namespace",
+					},
+				},
+			},
+			"my-namespace-my-slo/error_budget_remaining": {
+				Value: custom_metrics.MetricValue{
+					Metric: custom_metrics.MetricIdentifier{
+						Name: "error_budget_remaining",
+					},
+					DescribedObject: custom_metrics.ObjectReference{
+						Name:      "my-slo",
+						Namespace: "my-namespace",
+					},
+				},
+			},
+			"my-namespace-other-slo/sli": {
+				Value: custom_metrics.MetricValue{
+					Metric: custom_metrics.MetricIdentifier{
+						Name: "sli",
+					},
+					DescribedObject: custom_metrics.ObjectReference{
+						Name:      "other-slo",
+						Namespace: "my-namespace",
+					},
+				},
+			},
+		},
+	}
+
+	cm.DeleteObject(types.NamespacedName{
+		Namespace: "my-namespace",
+		Name:      "my-slo",
+	})
+
+	require.Len(t, cm.metrics, 1)
+	require.Contains(t, cm.metrics, metricKey("my-namespace-other-slo/sli"))
+}
+
 func TestCustomMetrics_DeleteWrongKey(t *testing.T) {
 	cm := CustomMetricsCache{
 		metrics: map[metricKey]CustomMetricValue{
@@ -242,3 +290,104 @@ func TestCustomMetrics_Update(t *testing.T) {
 	require.Equal(t, "my-metric", get.Value.Metric.Name)
 	require.Equal(t, *q, get.Value.Value)
 }
+
+func TestCustomMetrics_GetBySeriesSelector(t *testing.T) {
+	cm := CustomMetricsCache{}
+
+	for _, pod := range []string{"pod-1", "pod-2"} {
+		cm.Update("my-metric", CustomMetricValue{
+			Value: custom_metrics.MetricValue{
+				Metric: custom_metrics.MetricIdentifier{
+					Name: "my-metric",
+				},
+				DescribedObject: custom_metrics.ObjectReference{
+					Name:      "my-metric",
+					Namespace: "my-namespace",
+				},
+			},
+			SeriesLabels: map[string]string{"pod": pod, "app": "frontend"},
+		})
+	}
+
+	require.Len(t, cm.metrics, 2)
+	require.Len(t, cm.List(), 1)
+
+	name := types.NamespacedName{Namespace: "my-namespace", Name: "my-metric"}
+
+	get, err := cm.GetBySeriesSelector(name, labels.Set{"pod": "pod-2"}.AsSelector())
+
+	require.Nil(t, err)
+	require.Equal(t, "pod-2", get.SeriesLabels["pod"])
+
+	_, err = cm.GetBySeriesSelector(name, labels.Set{"app": "frontend"}.AsSelector())
+
+	require.ErrorIs(t, err, ErrMultipleSeries)
+
+	_, err = cm.GetBySeriesSelector(name, labels.Set{"pod": "pod-3"}.AsSelector())
+
+	require.ErrorIs(t, err, ErrMetricNotFound)
+
+	// without a selector, the first series is returned
+	get, err = cm.Get(name)
+
+	require.Nil(t, err)
+	require.Equal(t, "pod-1", get.SeriesLabels["pod"])
+
+	_, err = cm.Get(types.NamespacedName{Namespace: "my-namespace", Name: "my-metric-2"})
+
+	require.ErrorIs(t, err, ErrMetricNotFound)
+}
+
+func TestCustomMetrics_ReplaceObject(t *testing.T) {
+	cm := CustomMetricsCache{}
+	name := types.NamespacedName{Namespace: "my-namespace", Name: "my-metric"}
+	newValue := func(value int64, pod string) CustomMetricValue {
+		return CustomMetricValue{
+			Value: custom_metrics.MetricValue{
+				Metric: custom_metrics.MetricIdentifier{
+					Name: "my-metric",
+				},
+				DescribedObject: custom_metrics.ObjectReference{
+					Name:      name.Name,
+					Namespace: name.Namespace,
+				},
+				Value: *resource.NewQuantity(value, resource.DecimalSI),
+			},
+			SeriesLabels: map[string]string{"pod": pod},
+		}
+	}
+
+	cm.ReplaceObject(name, map[string][]CustomMetricValue{"my-metric": {newValue(1, "pod-1"), newValue(2, "pod-2")}})
+	require.Len(t, cm.metrics, 2)
+
+	// series which are not part of the new values are removed
+	cm.ReplaceObject(name, map[string][]CustomMetricValue{"my-metric": {newValue(3, "pod-1")}})
+	require.Len(t, cm.metrics, 1)
+
+	get, err := cm.Get(name)
+	require.Nil(t, err)
+	require.Equal(t, int64(3), get.Value.Value.Value())
+
+	// concurrent readers always see a value while it is replaced
+	done := make(chan struct{})
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if _, err := cm.Get(name); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+	for i := int64(0); i < 1000; i++ {
+		cm.ReplaceObject(name, map[string][]CustomMetricValue{"my-metric": {newValue(i, "pod-1")}})
+	}
+	close(done)
+	require.Nil(t, <-errs)
+}
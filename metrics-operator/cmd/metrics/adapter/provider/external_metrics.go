@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
+)
+
+// GetExternalMetric retrieves the values of all KeptnMetrics with the name of the requested metric in the given namespace.
+// The metric selector is matched against the labels of the KeptnMetrics and the labels of their series.
+// Used for requests such as e.g. /apis/external.metrics.k8s.io/v1beta1/namespaces/my-namespace/keptnmetric-sample?labelSelector=<key>%3D<value>
+func (p *keptnMetricsProvider) GetExternalMetric(ctx context.Context, namespace string, metricSelector labels.Selector, info provider.ExternalMetricInfo) (*external_metrics.ExternalMetricValueList, error) {
+	klog.InfoS("GetExternalMetric()", "namespace", namespace, "metricSelector", metricSelector, "info", info, "context", ctx)
+	if metricSelector == nil {
+		metricSelector = labels.Everything()
+	}
+
+	metricValues := p.cache.GetValuesByName(info.Metric, namespace, metricSelector)
+
+	res := make([]external_metrics.ExternalMetricValue, len(metricValues))
+	for i, metricValue := range metricValues {
+		res[i] = external_metrics.ExternalMetricValue{
+			MetricName:   info.Metric,
+			MetricLabels: labels.Merge(metricValue.Labels, metricValue.SeriesLabels),
+			Timestamp:    metricValue.Value.Timestamp,
+			Value:        metricValue.Value.Value,
+		}
+	}
+
+	return &external_metrics.ExternalMetricValueList{
+		Items: res,
+	}, nil
+}
+
+// ListAllExternalMetrics lists the names of all available KeptnMetrics
+func (p *keptnMetricsProvider) ListAllExternalMetrics() []provider.ExternalMetricInfo {
+	metricInfos := p.cache.List()
+	res := make([]provider.ExternalMetricInfo, len(metricInfos))
+	for i, metricInfo := range metricInfos {
+		res[i] = provider.ExternalMetricInfo{Metric: metricInfo.Metric}
+	}
+	return res
+}
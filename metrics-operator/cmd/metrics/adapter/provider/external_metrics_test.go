@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	provider2 "sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
+)
+
+func TestProvider_GetExternalMetric(t *testing.T) {
+	p := &keptnMetricsProvider{
+		logger: logr.Discard(),
+	}
+
+	queueDepth := getSampleKeptnMetric("queue-depth", map[string]interface{}{"queue": "orders"})
+	queueDepth["status"] = map[string]interface{}{
+		"value": "12",
+	}
+	requests := getSampleKeptnMetric("requests", map[string]interface{}{"app": "frontend"})
+	requests["status"] = map[string]interface{}{
+		"results": []interface{}{
+			map[string]interface{}{"labels": map[string]interface{}{"pod": "pod-1"}, "value": "1"},
+			map[string]interface{}{"labels": map[string]interface{}{"pod": "pod-2"}, "value": "2"},
+		},
+	}
+	for _, metric := range []map[string]interface{}{queueDepth, requests} {
+		km := &unstructured.Unstructured{}
+		km.SetUnstructuredContent(metric)
+		p.updateMetric(km)
+	}
+
+	metricInfos := p.ListAllExternalMetrics()
+
+	require.ElementsMatch(t, []provider2.ExternalMetricInfo{{Metric: "queue-depth"}, {Metric: "requests"}}, metricInfos)
+
+	metrics, err := p.GetExternalMetric(context.TODO(), KeptnNamespace, labels.Everything(), provider2.ExternalMetricInfo{Metric: "queue-depth"})
+
+	require.Nil(t, err)
+	require.Len(t, metrics.Items, 1)
+	require.Equal(t, "queue-depth", metrics.Items[0].MetricName)
+	require.Equal(t, map[string]string{"queue": "orders"}, metrics.Items[0].MetricLabels)
+	require.Equal(t, int64(12), metrics.Items[0].Value.Value())
+
+	// the selector is matched against the labels of the KeptnMetric
+	metrics, err = p.GetExternalMetric(context.TODO(), KeptnNamespace, labels.Set{"queue": "payments"}.AsSelector(), provider2.ExternalMetricInfo{Metric: "queue-depth"})
+
+	require.Nil(t, err)
+	require.Empty(t, metrics.Items)
+
+	// each series of a multi-dimensional KeptnMetric is a separate value
+	metrics, err = p.GetExternalMetric(context.TODO(), KeptnNamespace, labels.Set{"app": "frontend"}.AsSelector(), provider2.ExternalMetricInfo{Metric: "requests"})
+
+	require.Nil(t, err)
+	require.Len(t, metrics.Items, 2)
+
+	// the selector is matched against the labels of the series
+	metrics, err = p.GetExternalMetric(context.TODO(), KeptnNamespace, labels.Set{"app": "frontend", "pod": "pod-2"}.AsSelector(), provider2.ExternalMetricInfo{Metric: "requests"})
+
+	require.Nil(t, err)
+	require.Len(t, metrics.Items, 1)
+	require.Equal(t, map[string]string{"app": "frontend", "pod": "pod-2"}, metrics.Items[0].MetricLabels)
+	require.Equal(t, int64(2), metrics.Items[0].Value.Value())
+
+	// metrics of other namespaces are not provided
+	metrics, err = p.GetExternalMetric(context.TODO(), "other-namespace", nil, provider2.ExternalMetricInfo{Metric: "queue-depth"})
+
+	require.Nil(t, err)
+	require.Empty(t, metrics.Items)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/slo"
 	"github.com/pkg/errors"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -27,6 +28,20 @@ import (
 
 var keptnMetricGroupVersionResource = schema.GroupVersionResource{Group: "metrics.keptn.sh", Version: "v1", Resource: "keptnmetrics"}
 
+var serviceLevelObjectiveGroupVersionResource = schema.GroupVersionResource{Group: "metrics.keptn.sh", Version: "v1", Resource: sloResource}
+
+const (
+	sloMetricSLI                  = "sli"
+	sloMetricErrorBudgetRemaining = "error_budget_remaining"
+	sloMetricBurnRatePrefix       = "burn_rate_"
+)
+
+// MetricsProvider provides KeptnMetrics via the Custom Metrics API and the External Metrics API
+type MetricsProvider interface {
+	provider.CustomMetricsProvider
+	provider.ExternalMetricsProvider
+}
+
 var providerInstance *keptnMetricsProvider
 
 var providerOnce sync.Once
@@ -40,11 +55,13 @@ type keptnMetricsProvider struct {
 	// cache is being populated via the updates received by the provider's dynamic informer
 	// this way, we avoid sending a request to the Kubernetes API each time a custom metric value should be retrieved
 	cache CustomMetricsCache
+	// sloCache contains the SLI, remaining error budget and burn rates of each ServiceLevelObjective
+	sloCache CustomMetricsCache
 }
 
 // NewProvider creates and starts a new keptnMetricsProvider. The provider will run until the given context is cancelled.
 // the client passed to this function will be used to set up a dynamic informer that listens for KeptnMetric CRDs and provides metric values that reflect their states.
-func NewProvider(ctx context.Context, client dynamic.Interface, namespace string) provider.CustomMetricsProvider {
+func NewProvider(ctx context.Context, client dynamic.Interface, namespace string) MetricsProvider {
 	providerOnce.Do(func() {
 		scheme := runtime.NewScheme()
 
@@ -54,6 +71,10 @@ func NewProvider(ctx context.Context, client dynamic.Interface, namespace string
 			cache: CustomMetricsCache{
 				metrics: map[metricKey]CustomMetricValue{},
 			},
+			sloCache: CustomMetricsCache{
+				metrics:  map[metricKey]CustomMetricValue{},
+				resource: sloResource,
+			},
 			logger:         ctrl.Log.WithName("provider"),
 			KeptnNamespace: namespace,
 		}
@@ -68,14 +89,23 @@ func NewProvider(ctx context.Context, client dynamic.Interface, namespace string
 
 // ListAllMetrics lists all available metrics
 func (p *keptnMetricsProvider) ListAllMetrics() []provider.CustomMetricInfo {
-	return p.cache.List()
+	return append(p.cache.List(), p.sloCache.List()...)
 }
 
 // GetMetricByName retrieves a metric based on its name.
 // Used for requests such as e.g. /apis/custom.metrics.k8s.io/v1beta2/namespaces/keptn-lifecycle-toolkit/keptnmetrics.metrics.sh/keptnmetric-sample/keptnmetric-sample
 func (p *keptnMetricsProvider) GetMetricByName(ctx context.Context, name types.NamespacedName, info provider.CustomMetricInfo, metricSelector labels.Selector) (*custom_metrics.MetricValue, error) {
 	klog.InfoS("GetMetricByName()", "name", name, "metricSelector", metricSelector, "context", ctx)
-	val, err := p.cache.Get(name)
+	var val *CustomMetricValue
+	var err error
+	if info.GroupResource.Resource == sloResource {
+		val, err = p.sloCache.Get(types.NamespacedName{Namespace: name.Namespace, Name: getSLOMetricName(name.Name, info.Metric)})
+	} else if metricSelector != nil && !metricSelector.Empty() {
+		// select a single series of a multi-dimensional KeptnMetric
+		val, err = p.cache.GetBySeriesSelector(name, metricSelector)
+	} else {
+		val, err = p.cache.Get(name)
+	}
 	if err != nil {
 		if errors.Is(err, ErrMetricNotFound) {
 			return nil, provider.NewMetricNotFoundForSelectorError(info.GroupResource, info.Metric, name.Name, metricSelector)
@@ -92,16 +122,29 @@ func (p *keptnMetricsProvider) GetMetricByName(ctx context.Context, name types.N
 
 // GetMetricBySelector retrieves a list of metrics based on the given selectors.
 // Used for requests such as e.g. /apis/custom.metrics.k8s.io/v1beta2/namespaces/keptn-lifecycle-toolkit/keptnmetrics.metrics.sh/*/*?labelSelector=<key>%3D<value>
-func (p *keptnMetricsProvider) GetMetricBySelector(ctx context.Context, _ string, selector labels.Selector, _ provider.CustomMetricInfo, metricSelector labels.Selector) (*custom_metrics.MetricValueList, error) {
+func (p *keptnMetricsProvider) GetMetricBySelector(ctx context.Context, _ string, selector labels.Selector, info provider.CustomMetricInfo, metricSelector labels.Selector) (*custom_metrics.MetricValueList, error) {
 	klog.InfoS("GetMetricBySelector()", "selector", selector, "metricSelector", metricSelector, "context", ctx)
 
-	metricValues := p.cache.GetValuesByLabel(selector)
+	if info.GroupResource.Resource == sloResource {
+		// only return the requested metric of each ServiceLevelObjective
+		res := []custom_metrics.MetricValue{}
+		for _, metricValue := range p.sloCache.GetValuesByLabel(selector) {
+			if metricValue.Value.Metric.Name == info.Metric {
+				res = append(res, metricValue.Value)
+			}
+		}
+		return &custom_metrics.MetricValueList{
+			Items: res,
+		}, nil
+	}
 
-	res := make([]custom_metrics.MetricValue, len(metricValues))
-	i := 0
-	for _, metricValue := range metricValues {
-		res[i] = metricValue.Value
-		i++
+	res := []custom_metrics.MetricValue{}
+	for _, metricValue := range p.cache.GetValuesByLabel(selector) {
+		// only return the series of multi-dimensional KeptnMetrics matching the metric selector
+		if metricSelector != nil && !metricSelector.Empty() && !metricSelector.Matches(labels.Set(metricValue.SeriesLabels)) {
+			continue
+		}
+		res = append(res, metricValue.Value)
 	}
 
 	return &custom_metrics.MetricValueList{
@@ -127,7 +170,7 @@ func (p *keptnMetricsProvider) watchMetrics(ctx context.Context) error {
 			klog.InfoS("DeleteFunc", "obj", obj)
 			unstructuredKeptnMetric := obj.(*unstructured.Unstructured)
 
-			p.cache.Delete(types.NamespacedName{
+			p.cache.DeleteObject(types.NamespacedName{
 				Namespace: unstructuredKeptnMetric.GetNamespace(),
 				Name:      unstructuredKeptnMetric.GetName(),
 			})
@@ -136,19 +179,79 @@ func (p *keptnMetricsProvider) watchMetrics(ctx context.Context) error {
 	if _, err := informer.AddEventHandler(handlers); err != nil {
 		return err
 	}
+
+	sloInformer := factory.ForResource(serviceLevelObjectiveGroupVersionResource).Informer()
+
+	sloHandlers := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			klog.InfoS("AddFunc", "obj", obj)
+			p.updateSLOMetrics(obj)
+		},
+		UpdateFunc: func(oldObj, obj interface{}) {
+			klog.InfoS("UpdateFunc", "obj", obj)
+			p.updateSLOMetrics(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			klog.InfoS("DeleteFunc", "obj", obj)
+			unstructuredSLO := obj.(*unstructured.Unstructured)
+
+			p.sloCache.DeleteObject(types.NamespacedName{
+				Namespace: unstructuredSLO.GetNamespace(),
+				Name:      unstructuredSLO.GetName(),
+			})
+		},
+	}
+	if _, err := sloInformer.AddEventHandler(sloHandlers); err != nil {
+		return err
+	}
+
 	go func() {
 		informer.Run(ctx.Done())
 	}()
+	go func() {
+		sloInformer.Run(ctx.Done())
+	}()
 	return nil
 }
 
+// updateMetric replaces the values of a KeptnMetric with the values of its status.
+// A multi-dimensional KeptnMetric provides one value per series, identified by the labels of the series.
 func (p *keptnMetricsProvider) updateMetric(obj interface{}) {
 	unstructuredKeptnMetric := obj.(*unstructured.Unstructured)
-	value, found, err := unstructured.NestedString(unstructuredKeptnMetric.UnstructuredContent(), "status", "value")
+	content := unstructuredKeptnMetric.UnstructuredContent()
+	value, found, err := unstructured.NestedString(content, "status", "value")
+	if err != nil {
+		p.logger.Error(err, "Could not parse metric", "name", unstructuredKeptnMetric.GetName())
+		return
+	}
+	results, _, err := unstructured.NestedSlice(content, "status", "results")
 	if err != nil {
 		p.logger.Error(err, "Could not parse metric", "name", unstructuredKeptnMetric.GetName())
 		return
 	}
+
+	name := types.NamespacedName{Namespace: unstructuredKeptnMetric.GetNamespace(), Name: unstructuredKeptnMetric.GetName()}
+	if len(results) > 0 {
+		// replace the previous values, so that series which are not part of the result anymore are not provided anymore
+		values := []CustomMetricValue{}
+		for _, r := range results {
+			result, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			seriesValue, _, _ := unstructured.NestedString(result, "value")
+			seriesLabels, _, _ := unstructured.NestedStringMap(result, "labels")
+			metricValue, err := resource.ParseQuantity(seriesValue)
+			if err != nil {
+				klog.ErrorS(err, "Could not parse metric", "name", unstructuredKeptnMetric.GetName(), "labels", seriesLabels)
+				continue
+			}
+			values = append(values, newKeptnMetricValue(unstructuredKeptnMetric, metricValue, seriesLabels))
+		}
+		p.cache.ReplaceObject(name, map[string][]CustomMetricValue{name.Name: values})
+		return
+	}
+
 	if !found {
 		// set the value to defaultMetricsValue, and add the metric to the list of available metrics
 		value = defaultMetricsValue
@@ -160,23 +263,107 @@ func (p *keptnMetricsProvider) updateMetric(obj interface{}) {
 		klog.ErrorS(err, "Could not parse metric", "name", unstructuredKeptnMetric.GetName())
 		return
 	}
-	metricObj := CustomMetricValue{
+	p.cache.ReplaceObject(name, map[string][]CustomMetricValue{name.Name: {newKeptnMetricValue(unstructuredKeptnMetric, metricValue, nil)}})
+}
+
+// newKeptnMetricValue returns the value of a KeptnMetric, or of one of its series if seriesLabels are given
+func newKeptnMetricValue(keptnMetric *unstructured.Unstructured, value resource.Quantity, seriesLabels map[string]string) CustomMetricValue {
+	selector := keptnMetric.GetLabels()
+	if len(seriesLabels) > 0 {
+		selector = seriesLabels
+	}
+	return CustomMetricValue{
 		Value: custom_metrics.MetricValue{
 			Metric: custom_metrics.MetricIdentifier{
-				Name:     unstructuredKeptnMetric.GetName(),
-				Selector: &metav1.LabelSelector{MatchLabels: unstructuredKeptnMetric.GetLabels()},
+				Name:     keptnMetric.GetName(),
+				Selector: &metav1.LabelSelector{MatchLabels: selector},
 			},
 			Timestamp: metav1.Time{Time: time.Now().UTC()},
-			Value:     metricValue,
+			Value:     value,
 			DescribedObject: custom_metrics.ObjectReference{
 				APIVersion: keptnMetricGroupVersionResource.Group + "/" + keptnMetricGroupVersionResource.Version,
 				Kind:       "KeptnMetric",
-				Name:       unstructuredKeptnMetric.GetName(),
-				Namespace:  unstructuredKeptnMetric.GetNamespace(),
+				Name:       keptnMetric.GetName(),
+				Namespace:  keptnMetric.GetNamespace(),
 			},
 		},
-		Labels: unstructuredKeptnMetric.GetLabels(),
+		Labels:       keptnMetric.GetLabels(),
+		SeriesLabels: seriesLabels,
+	}
+}
+
+// updateSLOMetrics replaces the metrics of a ServiceLevelObjective with the values of its status.
+// Each ServiceLevelObjective provides the metrics 'sli', 'error_budget_remaining' and 'burn_rate_<window>'.
+func (p *keptnMetricsProvider) updateSLOMetrics(obj interface{}) {
+	unstructuredSLO := obj.(*unstructured.Unstructured)
+	content := unstructuredSLO.UnstructuredContent()
+
+	values := map[string]string{}
+	for metricName, field := range map[string]string{
+		sloMetricSLI:                  "sli",
+		sloMetricErrorBudgetRemaining: "errorBudgetRemaining",
+	} {
+		value, found, err := unstructured.NestedString(content, "status", field)
+		if err != nil {
+			p.logger.Error(err, "Could not parse ServiceLevelObjective", "name", unstructuredSLO.GetName())
+			return
+		}
+		if found {
+			values[metricName] = value
+		}
+	}
+
+	burnRates, _, err := unstructured.NestedSlice(content, "status", "burnRates")
+	if err != nil {
+		p.logger.Error(err, "Could not parse ServiceLevelObjective", "name", unstructuredSLO.GetName())
+		return
 	}
+	for _, b := range burnRates {
+		burnRate, ok := b.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		window, _, _ := unstructured.NestedString(burnRate, "window")
+		value, _, _ := unstructured.NestedString(burnRate, "value")
+		duration, err := time.ParseDuration(window)
+		if err != nil {
+			klog.ErrorS(err, "Could not parse burn rate window", "name", unstructuredSLO.GetName())
+			continue
+		}
+		values[sloMetricBurnRatePrefix+slo.FormatWindow(duration)] = value
+	}
+
+	name := types.NamespacedName{Namespace: unstructuredSLO.GetNamespace(), Name: unstructuredSLO.GetName()}
+	// replace the previous values, so that metrics of burn rate windows which have been removed are not provided anymore
+	metricValues := map[string][]CustomMetricValue{}
+	for metricName, value := range values {
+		metricValue, err := resource.ParseQuantity(value)
+		if err != nil {
+			klog.ErrorS(err, "Could not parse metric", "name", unstructuredSLO.GetName(), "metric", metricName)
+			continue
+		}
+		metricValues[getSLOMetricName(name.Name, metricName)] = []CustomMetricValue{{
+			Value: custom_metrics.MetricValue{
+				Metric: custom_metrics.MetricIdentifier{
+					Name:     metricName,
+					Selector: &metav1.LabelSelector{MatchLabels: unstructuredSLO.GetLabels()},
+				},
+				Timestamp: metav1.Time{Time: time.Now().UTC()},
+				Value:     metricValue,
+				DescribedObject: custom_metrics.ObjectReference{
+					APIVersion: serviceLevelObjectiveGroupVersionResource.Group + "/" + serviceLevelObjectiveGroupVersionResource.Version,
+					Kind:       "ServiceLevelObjective",
+					Name:       name.Name,
+					Namespace:  name.Namespace,
+				},
+			},
+			Labels: unstructuredSLO.GetLabels(),
+		}}
+	}
+	p.sloCache.ReplaceObject(name, metricValues)
+}
 
-	p.cache.Update(unstructuredKeptnMetric.GetName(), metricObj)
+// getSLOMetricName returns the key of a metric of a ServiceLevelObjective in the cache
+func getSLOMetricName(sloName, metricName string) string {
+	return sloName + "/" + metricName
 }
@@ -5,11 +5,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic/fake"
 	provider2 "sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
@@ -24,7 +26,10 @@ func TestProvider(t *testing.T) {
 	km.SetUnstructuredContent(metricObj1)
 
 	scheme := runtime.NewScheme()
-	fakeClient := fake.NewSimpleDynamicClient(scheme, km)
+	fakeClient := fake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		keptnMetricGroupVersionResource:           "KeptnMetricList",
+		serviceLevelObjectiveGroupVersionResource: "ServiceLevelObjectiveList",
+	}, km)
 
 	provider := NewProvider(context.TODO(), fakeClient, KeptnNamespace)
 
@@ -122,6 +127,150 @@ func TestProvider(t *testing.T) {
 	}, 10*time.Second, 100*time.Millisecond)
 }
 
+func TestProvider_ServiceLevelObjectiveMetrics(t *testing.T) {
+	p := &keptnMetricsProvider{
+		logger: logr.Discard(),
+		sloCache: CustomMetricsCache{
+			resource: sloResource,
+		},
+	}
+
+	slo := &unstructured.Unstructured{}
+	slo.SetUnstructuredContent(getSampleServiceLevelObjective("my-slo", []interface{}{
+		map[string]interface{}{"window": "1h0m0s", "value": "2.5"},
+		map[string]interface{}{"window": "6h0m0s", "value": "0.5"},
+	}))
+	p.updateSLOMetrics(slo)
+
+	require.Len(t, p.ListAllMetrics(), 4)
+
+	info := provider2.CustomMetricInfo{
+		GroupResource: schema.GroupResource{Group: metricsGroup, Resource: sloResource},
+		Metric:        "error_budget_remaining",
+		Namespaced:    true,
+	}
+	metricValue, err := p.GetMetricByName(context.TODO(), types.NamespacedName{
+		Namespace: KeptnNamespace,
+		Name:      "my-slo",
+	}, info, nil)
+
+	require.Nil(t, err)
+	require.Equal(t, "ServiceLevelObjective", metricValue.DescribedObject.Kind)
+	require.Equal(t, int64(42), metricValue.Value.Value())
+
+	info.Metric = "burn_rate_1h"
+	metrics, err := p.GetMetricBySelector(context.TODO(), KeptnNamespace, labels.Everything(), info, nil)
+
+	require.Nil(t, err)
+	require.Len(t, metrics.Items, 1)
+	require.Equal(t, "2500m", metrics.Items[0].Value.String())
+
+	// removing a burn rate window should remove its metric
+	slo.SetUnstructuredContent(getSampleServiceLevelObjective("my-slo", []interface{}{
+		map[string]interface{}{"window": "6h0m0s", "value": "0.5"},
+	}))
+	p.updateSLOMetrics(slo)
+
+	metrics, err = p.GetMetricBySelector(context.TODO(), KeptnNamespace, labels.Everything(), info, nil)
+
+	require.Nil(t, err)
+	require.Empty(t, metrics.Items)
+	require.Len(t, p.ListAllMetrics(), 3)
+}
+
+func TestProvider_MultiDimensionalMetric(t *testing.T) {
+	p := &keptnMetricsProvider{
+		logger: logr.Discard(),
+	}
+
+	metricObj := getSampleKeptnMetric("my-metric", map[string]interface{}{"app": "frontend"})
+	metricObj["status"] = map[string]interface{}{
+		"results": []interface{}{
+			map[string]interface{}{"labels": map[string]interface{}{"pod": "pod-1"}, "value": "1"},
+			map[string]interface{}{"labels": map[string]interface{}{"pod": "pod-2"}, "value": "2"},
+		},
+	}
+	km := &unstructured.Unstructured{}
+	km.SetUnstructuredContent(metricObj)
+	p.updateMetric(km)
+
+	require.Len(t, p.ListAllMetrics(), 1)
+
+	name := types.NamespacedName{Namespace: KeptnNamespace, Name: "my-metric"}
+
+	// select a single series via the metric selector
+	metricValue, err := p.GetMetricByName(context.TODO(), name, provider2.CustomMetricInfo{}, labels.Set{"pod": "pod-2"}.AsSelector())
+
+	require.Nil(t, err)
+	require.Equal(t, int64(2), metricValue.Value.Value())
+	require.Equal(t, map[string]string{"pod": "pod-2"}, metricValue.Metric.Selector.MatchLabels)
+
+	// without a metric selector, the first series is returned
+	metricValue, err = p.GetMetricByName(context.TODO(), name, provider2.CustomMetricInfo{}, labels.Everything())
+
+	require.Nil(t, err)
+	require.Equal(t, int64(1), metricValue.Value.Value())
+	require.Equal(t, map[string]string{"pod": "pod-1"}, metricValue.Metric.Selector.MatchLabels)
+
+	metricValue, err = p.GetMetricByName(context.TODO(), name, provider2.CustomMetricInfo{}, nil)
+
+	require.Nil(t, err)
+	require.Equal(t, int64(1), metricValue.Value.Value())
+
+	metrics, err := p.GetMetricBySelector(context.TODO(), KeptnNamespace, labels.Set{"app": "frontend"}.AsSelector(), provider2.CustomMetricInfo{}, labels.Everything())
+
+	require.Nil(t, err)
+	require.Len(t, metrics.Items, 2)
+
+	metrics, err = p.GetMetricBySelector(context.TODO(), KeptnNamespace, labels.Set{"app": "frontend"}.AsSelector(), provider2.CustomMetricInfo{}, labels.Set{"pod": "pod-1"}.AsSelector())
+
+	require.Nil(t, err)
+	require.Len(t, metrics.Items, 1)
+	require.Equal(t, int64(1), metrics.Items[0].Value.Value())
+
+	// a series which is not part of the result anymore should be removed
+	metricObj["status"] = map[string]interface{}{
+		"results": []interface{}{
+			map[string]interface{}{"labels": map[string]interface{}{"pod": "pod-1"}, "value": "3"},
+		},
+	}
+	km.SetUnstructuredContent(metricObj)
+	p.updateMetric(km)
+
+	_, err = p.GetMetricByName(context.TODO(), name, provider2.CustomMetricInfo{}, labels.Set{"pod": "pod-2"}.AsSelector())
+
+	require.NotNil(t, err)
+
+	// a single value replaces the series
+	metricObj["status"] = map[string]interface{}{
+		"value": "5",
+	}
+	km.SetUnstructuredContent(metricObj)
+	p.updateMetric(km)
+
+	metricValue, err = p.GetMetricByName(context.TODO(), name, provider2.CustomMetricInfo{}, nil)
+
+	require.Nil(t, err)
+	require.Equal(t, int64(5), metricValue.Value.Value())
+	require.Len(t, p.cache.metrics, 1)
+}
+
+func getSampleServiceLevelObjective(name string, burnRates []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "metrics.keptn.sh/v1",
+		"kind":       "ServiceLevelObjective",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": KeptnNamespace,
+		},
+		"status": map[string]interface{}{
+			"sli":                  "99.95",
+			"errorBudgetRemaining": "42",
+			"burnRates":            burnRates,
+		},
+	}
+}
+
 func getSampleKeptnMetric(metricName string, labels map[string]interface{}) map[string]interface{} {
 	return map[string]interface{}{
 		"apiVersion": "metrics.keptn.sh/v1",
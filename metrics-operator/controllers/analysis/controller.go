@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -52,6 +53,7 @@ type AnalysisReconciler struct {
 //+kubebuilder:rbac:groups=metrics.keptn.sh,resources=analyses/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=metrics.keptn.sh,resources=analyses/finalizers,verbs=update
 // +kubebuilder:rbac:groups=metrics.keptn.sh,resources=keptnmetricsproviders,verbs=get;list;watch;
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=keptnmetricsproviders/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=metrics.keptn.sh,resources=analysisdefinitions,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=metrics.keptn.sh,resources=analysisvaluetemplates,verbs=get;list;watch;
 
@@ -76,7 +78,14 @@ func (a *AnalysisReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	if analysis.Status.State.IsCompleted() {
-		return ctrl.Result{}, nil
+		if !analysis.IsScheduled() {
+			return ctrl.Result{}, nil
+		}
+		if wait := time.Until(analysis.Status.NextRunTime.Time); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		a.Log.Info("Starting next run of scheduled Analysis", "requestInfo", requestInfo)
+		analysis.ResetForNextRun()
 	}
 
 	analysis.EnsureTimeframeIsSet()
@@ -115,6 +124,9 @@ func (a *AnalysisReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, err
 	}
 
+	if analysis.IsScheduled() {
+		return ctrl.Result{RequeueAfter: time.Until(analysis.Status.NextRunTime.Time)}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -131,6 +143,14 @@ func (a *AnalysisReconciler) evaluateObjectives(res map[string]metricsapi.Provid
 	analysis.Status.State = metricsapi.StateCompleted
 	// if evaluation was successful remove the stored values
 	analysis.Status.StoredValues = nil
+	if analysis.IsScheduled() {
+		analysis.AddRunResult(metricsapi.AnalysisRunResult{
+			Timeframe: analysis.Status.Timeframe,
+			Pass:      eval.Pass,
+			Warning:   eval.Warning,
+			Score:     strconv.FormatFloat(eval.GetAchievedPercentage(), 'f', 2, 64),
+		})
+	}
 	go a.reportAnalysisResult(eval, *analysis)
 }
 
@@ -194,7 +214,7 @@ func extractMissingObjectives(objectives []metricsapi.Objective, status map[stri
 	var todo []metricsapi.Objective
 	done := make(map[string]metricsapi.ProviderResult, len(status))
 	for _, obj := range objectives {
-		key := common.ComputeKey(obj.AnalysisValueTemplateRef)
+		key := common.ComputeObjectiveKey(&obj)
 		if value, ok := status[key]; ok {
 			if value.ErrMsg != "" {
 				todo = append(todo, obj)
@@ -418,6 +418,82 @@ func TestAnalysisReconciler_AnalysisTimeframeIsDerivedFromDurationString(t *test
 
 }
 
+func TestAnalysisReconciler_ScheduledAnalysis(t *testing.T) {
+	analysis, analysisDef, template, _ := getTestCRDs()
+	analysis.Spec.Timeframe = metricsapi.Timeframe{Recent: metav1.Duration{Duration: 10 * time.Minute}}
+	analysis.Spec.Schedule = &metricsapi.AnalysisSchedule{
+		Interval:     metav1.Duration{Duration: 5 * time.Minute},
+		HistoryLimit: 2,
+	}
+
+	mockFactory := func(ctx context.Context, analysisMoqParam *metricsapi.Analysis, obj []metricsapi.Objective, numWorkers int, c client.Client, log logr.Logger, namespace string) (context.Context, IAnalysisPool) {
+		mymock := fake.IAnalysisPoolMock{
+			DispatchAndCollectFunc: func(ctx context.Context) (map[string]metricsapi.ProviderResult, error) {
+				return map[string]metricsapi.ProviderResult{}, nil
+			},
+		}
+		return ctx, &mymock
+	}
+
+	evaluator := &fakeEvaluator.IAnalysisEvaluatorMock{
+		EvaluateFunc: func(values map[string]metricsapi.ProviderResult, ad *metricsapi.AnalysisDefinition) metricstypes.AnalysisResult {
+			return metricstypes.AnalysisResult{Pass: true, TotalScore: 1, MaximumScore: 2}
+		}}
+	fclient := fake2.NewClient(&analysis, &analysisDef, &template)
+	a := &AnalysisReconciler{
+		Client:                fclient,
+		Scheme:                fclient.Scheme(),
+		Log:                   testr.New(t),
+		MaxWorkers:            2,
+		NewWorkersPoolFactory: mockFactory,
+		IAnalysisEvaluator:    evaluator,
+	}
+
+	req := controllerruntime.Request{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-analysis"},
+	}
+
+	// the first run is evaluated right away and the next run is scheduled
+	got, err := a.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	require.InDelta(t, 5*time.Minute, got.RequeueAfter, float64(time.Minute))
+
+	resAnalysis := metricsapi.Analysis{}
+	require.Nil(t, fclient.Get(context.TODO(), req.NamespacedName, &resAnalysis))
+	require.Equal(t, metricsapi.StateCompleted, resAnalysis.Status.State)
+	require.Len(t, resAnalysis.Status.History, 1)
+	require.Equal(t, "50.00", resAnalysis.Status.History[0].Score)
+	require.True(t, resAnalysis.Status.History[0].Pass)
+	require.Equal(t, resAnalysis.Status.Timeframe, resAnalysis.Status.History[0].Timeframe)
+	require.Equal(t, resAnalysis.Status.Timeframe.To.Add(5*time.Minute), resAnalysis.Status.NextRunTime.Time)
+
+	// before the next run is due, the Analysis is not evaluated again
+	got, err = a.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	require.Greater(t, got.RequeueAfter, time.Duration(0))
+	require.Len(t, evaluator.EvaluateCalls(), 1)
+
+	// once the next run is due, a new timeframe is evaluated and the history is bounded by the limit
+	for i := 0; i < 2; i++ {
+		require.Nil(t, fclient.Get(context.TODO(), req.NamespacedName, &resAnalysis))
+		resAnalysis.Status.NextRunTime = metav1.Time{Time: time.Now().Add(-time.Second)}
+		resAnalysis.Status.Timeframe = metricsapi.Timeframe{
+			From: metav1.Time{Time: time.Now().Add(-time.Hour)},
+			To:   metav1.Time{Time: time.Now().Add(-50 * time.Minute)},
+		}
+		require.Nil(t, fclient.Status().Update(context.TODO(), &resAnalysis))
+
+		_, err = a.Reconcile(context.TODO(), req)
+		require.Nil(t, err)
+	}
+
+	require.Nil(t, fclient.Get(context.TODO(), req.NamespacedName, &resAnalysis))
+	require.Len(t, evaluator.EvaluateCalls(), 3)
+	require.Len(t, resAnalysis.Status.History, 2)
+	require.WithinDuration(t, time.Now(), resAnalysis.Status.Timeframe.To.Time, time.Minute)
+	require.WithinDuration(t, time.Now(), resAnalysis.Status.History[0].Timeframe.To.Time, time.Minute)
+}
+
 func getTestCRDs() (metricsapi.Analysis, metricsapi.AnalysisDefinition, metricsapi.AnalysisValueTemplate, metricsapi.KeptnMetricsProvider) {
 	currentTime := time.Now().Round(time.Minute)
 	analysis := metricsapi.Analysis{
@@ -1,6 +1,8 @@
 package analysis
 
 import (
+	"fmt"
+
 	"github.com/go-logr/logr"
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
 	metricstypes "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/analysis/types"
@@ -19,7 +21,7 @@ type ObjectivesEvaluator struct {
 	providers.ProviderFactory
 	client.Client
 	log     logr.Logger
-	results chan metricsapi.ProviderResult
+	results chan metricstypes.ProviderResult
 	cancel  context.CancelFunc
 }
 
@@ -31,20 +33,67 @@ func (oe ObjectivesEvaluator) Evaluate(ctx context.Context, metricsProvider *met
 		return
 	}
 	for o := range obj {
-		value := ""
-		var strErr string
-		value, err = provider.FetchAnalysisValue(ctx, o.Query, *oe.Analysis, o.Provider)
+		var result metricsapi.ProviderResult
+		if o.Objective.StatisticalTest != nil {
+			result = oe.evaluateSeries(ctx, provider, o)
+		} else {
+			result = oe.evaluateValue(ctx, provider, o)
+		}
+		oe.log.Info("provider", "id:", metricsProvider.Spec.Type, "finished job:", o.Objective.AnalysisValueTemplateRef.Name, "result:", result)
+		oe.results <- metricstypes.ProviderResult{Objective: o.Objective, Result: result}
+	}
+}
 
+// evaluateValue retrieves the value of the objective and, if the objective defines a baseline, the baseline value
+func (oe ObjectivesEvaluator) evaluateValue(ctx context.Context, provider providers.KeptnSLIProvider, o metricstypes.ProviderRequest) metricsapi.ProviderResult {
+	result := metricsapi.ProviderResult{
+		Objective: o.Objective.AnalysisValueTemplateRef,
+		Query:     o.Query,
+	}
+	value, err := provider.FetchAnalysisValue(ctx, o.Query, *oe.Analysis, o.Provider)
+	result.Value = value
+	if err != nil {
+		result.ErrMsg = err.Error()
+		return result
+	}
+	if o.Baseline != nil {
+		result.BaselineQuery = o.Baseline.Query
+		result.BaselineValue, err = provider.FetchAnalysisValue(ctx, o.Baseline.Query, oe.baselineAnalysis(o), o.Provider)
 		if err != nil {
-			strErr = err.Error()
+			result.ErrMsg = fmt.Sprintf("could not retrieve baseline value: %s", err.Error())
 		}
-		result := metricsapi.ProviderResult{
-			Objective: o.Objective.AnalysisValueTemplateRef,
-			Query:     o.Query,
-			Value:     value,
-			ErrMsg:    strErr,
-		}
-		oe.log.Info("provider", "id:", metricsProvider.Spec.Type, "finished job:", o.Objective.AnalysisValueTemplateRef.Name, "result:", result)
-		oe.results <- result
 	}
+	return result
+}
+
+// evaluateSeries retrieves the series of values of the objective and the baseline for a statistical test
+func (oe ObjectivesEvaluator) evaluateSeries(ctx context.Context, provider providers.KeptnSLIProvider, o metricstypes.ProviderRequest) metricsapi.ProviderResult {
+	result := metricsapi.ProviderResult{
+		Objective: o.Objective.AnalysisValueTemplateRef,
+		Query:     o.Query,
+	}
+	if o.Baseline == nil {
+		result.ErrMsg = "a baseline is required for the statistical test"
+		return result
+	}
+	step := o.Objective.StatisticalTest.GetStep()
+	values, err := providers.FetchAnalysisSeries(ctx, provider, o.Query, *oe.Analysis, o.Provider, step)
+	result.Values = values
+	if err != nil {
+		result.ErrMsg = err.Error()
+		return result
+	}
+	result.BaselineQuery = o.Baseline.Query
+	result.BaselineValues, err = providers.FetchAnalysisSeries(ctx, provider, o.Baseline.Query, oe.baselineAnalysis(o), o.Provider, step)
+	if err != nil {
+		result.ErrMsg = fmt.Sprintf("could not retrieve baseline values: %s", err.Error())
+	}
+	return result
+}
+
+// baselineAnalysis returns a copy of the Analysis with the timeframe of the baseline
+func (oe ObjectivesEvaluator) baselineAnalysis(o metricstypes.ProviderRequest) metricsapi.Analysis {
+	baselineAnalysis := oe.Analysis.DeepCopy()
+	baselineAnalysis.Status.Timeframe = o.Baseline.Timeframe
+	return *baselineAnalysis
 }
@@ -13,10 +13,14 @@ import (
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers"
 	fake2 "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/fake"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestEvaluate(t *testing.T) {
+	baselineFrom := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	baselineTo := time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC)
+
 	// Define test cases
 	testCases := []struct {
 		name            string
@@ -81,6 +85,81 @@ func TestEvaluate(t *testing.T) {
 			},
 			expectedError: "something bad",
 		},
+		{
+			name: "SuccessfulEvaluationWithBaseline",
+			mockProvider: &fake2.KeptnSLIProviderMock{
+				FetchAnalysisValueFunc: func(ctx context.Context, query string, spec metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+					if query == "baseline_query" && spec.GetFrom().Equal(baselineFrom) && spec.GetTo().Equal(baselineTo) {
+						return "8", nil
+					}
+					return "10", nil
+				},
+			},
+			providerRequest: metricstypes.ProviderRequest{
+				Objective: metricsapi.Objective{
+					AnalysisValueTemplateRef: metricsapi.ObjectReference{
+						Name:      "mytemp",
+						Namespace: "default",
+					},
+				},
+				Query:    "query_fake_metric",
+				Provider: &metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{Type: "prometheus"}},
+				Baseline: &metricstypes.BaselineRequest{
+					Query: "baseline_query",
+					Timeframe: metricsapi.Timeframe{
+						From: metav1.NewTime(baselineFrom),
+						To:   metav1.NewTime(baselineTo),
+					},
+				},
+			},
+			expectedResult: metricsapi.ProviderResult{
+				Objective: metricsapi.ObjectReference{
+					Name:      "mytemp",
+					Namespace: "default",
+				},
+				Query:         "query_fake_metric",
+				Value:         "10",
+				BaselineQuery: "baseline_query",
+				BaselineValue: "8",
+				ErrMsg:        "",
+			},
+			expectedError: "",
+		},
+		{
+			name: "FailedBaselineEvaluation",
+			mockProvider: &fake2.KeptnSLIProviderMock{
+				FetchAnalysisValueFunc: func(ctx context.Context, query string, spec metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+					if query == "baseline_query" {
+						return "", fmt.Errorf("something bad")
+					}
+					return "10", nil
+				},
+			},
+			providerRequest: metricstypes.ProviderRequest{
+				Objective: metricsapi.Objective{
+					AnalysisValueTemplateRef: metricsapi.ObjectReference{
+						Name:      "mytemp",
+						Namespace: "default",
+					},
+				},
+				Query:    "query_fake_metric",
+				Provider: &metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{Type: "prometheus"}},
+				Baseline: &metricstypes.BaselineRequest{
+					Query: "baseline_query",
+				},
+			},
+			expectedResult: metricsapi.ProviderResult{
+				Objective: metricsapi.ObjectReference{
+					Name:      "mytemp",
+					Namespace: "default",
+				},
+				Query:         "query_fake_metric",
+				Value:         "10",
+				BaselineQuery: "baseline_query",
+				ErrMsg:        "could not retrieve baseline value: something bad",
+			},
+			expectedError: "could not retrieve baseline value: something bad",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -102,7 +181,7 @@ func TestEvaluate(t *testing.T) {
 						},
 					},
 				},
-				results: make(chan metricsapi.ProviderResult, 1),
+				results: make(chan metricstypes.ProviderResult, 1),
 			}
 
 			ctx := context.TODO()
@@ -114,10 +193,122 @@ func TestEvaluate(t *testing.T) {
 			}()
 			objectivesEvaluator.Evaluate(ctx, tc.providerRequest.Provider, objChan)
 			close(objectivesEvaluator.results)
-			result := <-objectivesEvaluator.results
+			result := (<-objectivesEvaluator.results).Result
 
 			require.Equal(t, tc.expectedResult, result)
 			require.Equal(t, tc.expectedError, result.ErrMsg)
 		})
 	}
 }
+
+type seriesProviderMock struct {
+	*fake2.KeptnSLIProviderMock
+	FetchAnalysisSeriesFunc func(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider, step time.Duration) ([]string, error)
+}
+
+func (m *seriesProviderMock) FetchAnalysisSeries(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider, step time.Duration) ([]string, error) {
+	return m.FetchAnalysisSeriesFunc(ctx, query, analysis, provider, step)
+}
+
+func TestEvaluate_StatisticalTest(t *testing.T) {
+	baselineFrom := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	baselineTo := time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	request := metricstypes.ProviderRequest{
+		Objective: metricsapi.Objective{
+			AnalysisValueTemplateRef: metricsapi.ObjectReference{
+				Name:      "mytemp",
+				Namespace: "default",
+			},
+			StatisticalTest: &metricsapi.StatisticalTest{
+				Step: metav1.Duration{Duration: 30 * time.Second},
+			},
+		},
+		Query:    "query_fake_metric",
+		Provider: &metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{Type: "prometheus"}},
+		Baseline: &metricstypes.BaselineRequest{
+			Query: "baseline_query",
+			Timeframe: metricsapi.Timeframe{
+				From: metav1.NewTime(baselineFrom),
+				To:   metav1.NewTime(baselineTo),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		mockProvider   providers.KeptnSLIProvider
+		expectedResult metricsapi.ProviderResult
+	}{
+		{
+			name: "SuccessfulEvaluation",
+			mockProvider: &seriesProviderMock{
+				KeptnSLIProviderMock: &fake2.KeptnSLIProviderMock{},
+				FetchAnalysisSeriesFunc: func(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider, step time.Duration) ([]string, error) {
+					require.Equal(t, 30*time.Second, step)
+					if query == "baseline_query" && analysis.GetFrom().Equal(baselineFrom) && analysis.GetTo().Equal(baselineTo) {
+						return []string{"1", "2", "3"}, nil
+					}
+					return []string{"4", "5", "6"}, nil
+				},
+			},
+			expectedResult: metricsapi.ProviderResult{
+				Objective:      request.Objective.AnalysisValueTemplateRef,
+				Query:          "query_fake_metric",
+				Values:         []string{"4", "5", "6"},
+				BaselineQuery:  "baseline_query",
+				BaselineValues: []string{"1", "2", "3"},
+			},
+		},
+		{
+			name: "FailedBaselineEvaluation",
+			mockProvider: &seriesProviderMock{
+				KeptnSLIProviderMock: &fake2.KeptnSLIProviderMock{},
+				FetchAnalysisSeriesFunc: func(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider, step time.Duration) ([]string, error) {
+					if query == "baseline_query" {
+						return nil, fmt.Errorf("something bad")
+					}
+					return []string{"4", "5", "6"}, nil
+				},
+			},
+			expectedResult: metricsapi.ProviderResult{
+				Objective:     request.Objective.AnalysisValueTemplateRef,
+				Query:         "query_fake_metric",
+				Values:        []string{"4", "5", "6"},
+				BaselineQuery: "baseline_query",
+				ErrMsg:        "could not retrieve baseline values: something bad",
+			},
+		},
+		{
+			name:         "UnsupportedProvider",
+			mockProvider: &fake2.KeptnSLIProviderMock{},
+			expectedResult: metricsapi.ProviderResult{
+				Objective: request.Objective.AnalysisValueTemplateRef,
+				Query:     "query_fake_metric",
+				ErrMsg:    "provider does not support retrieving a series of values for an Analysis: prometheus",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			objectivesEvaluator := ObjectivesEvaluator{
+				Client: fake.NewClient(),
+				log:    logr.Discard(),
+				ProviderFactory: func(metricsProvider *metricsapi.KeptnMetricsProvider, log logr.Logger, client client.Client) (providers.KeptnSLIProvider, error) {
+					return tc.mockProvider, nil
+				},
+				Analysis: &metricsapi.Analysis{},
+				results:  make(chan metricstypes.ProviderResult, 1),
+			}
+
+			objChan := make(chan metricstypes.ProviderRequest, 1)
+			objChan <- request
+			close(objChan)
+			objectivesEvaluator.Evaluate(context.TODO(), request.Provider, objChan)
+			close(objectivesEvaluator.results)
+
+			require.Equal(t, tc.expectedResult, (<-objectivesEvaluator.results).Result)
+		})
+	}
+}
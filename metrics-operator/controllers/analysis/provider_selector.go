@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"text/template"
 
 	"github.com/go-logr/logr"
@@ -19,7 +20,7 @@ import (
 type IProvidersPool interface {
 	StartProviders(ctx context.Context, numJobs int)
 	DispatchToProviders(ctx context.Context, id int)
-	GetResult(ctx context.Context) (*metricsapi.ProviderResult, error)
+	GetResult(ctx context.Context) (*metricstypes.ProviderResult, error)
 	StopProviders()
 }
 
@@ -30,25 +31,23 @@ type ProvidersPool struct {
 	Namespace  string
 	Objectives map[int][]metricsapi.Objective
 	*metricsapi.Analysis
-	results   chan metricsapi.ProviderResult
-	providers map[string]chan metricstypes.ProviderRequest
-	cancel    context.CancelFunc
+	results      chan metricstypes.ProviderResult
+	providers    map[string]chan metricstypes.ProviderRequest
+	providersMtx sync.Mutex
+	numJobs      int
+	cancel       context.CancelFunc
 }
 
-func (ps ProvidersPool) StartProviders(ctx context.Context, numJobs int) {
-	for _, provider := range providers.SupportedProviders {
-		channel := make(chan metricstypes.ProviderRequest, numJobs)
-		ps.providers[provider] = channel
-		metricsProvider := &metricsapi.KeptnMetricsProvider{
-			Spec: metricsapi.KeptnMetricsProviderSpec{
-				Type: provider,
-			},
-		}
-		go ps.Evaluate(ctx, metricsProvider, channel)
-	}
+// StartProviders prepares the pool for the given number of jobs.
+// The providers themselves are registered when the first job for them is dispatched,
+// so that a provider is started for each KeptnMetricsProvider referenced by the objectives.
+func (ps *ProvidersPool) StartProviders(ctx context.Context, numJobs int) {
+	ps.providersMtx.Lock()
+	defer ps.providersMtx.Unlock()
+	ps.numJobs = numJobs
 }
 
-func (ps ProvidersPool) DispatchToProviders(ctx context.Context, id int) {
+func (ps *ProvidersPool) DispatchToProviders(ctx context.Context, id int) {
 
 	for _, j := range ps.Objectives[id] {
 		select {
@@ -67,7 +66,7 @@ func (ps ProvidersPool) DispatchToProviders(ctx context.Context, id int) {
 
 			if err != nil {
 				ps.log.Error(err, "Failed to get AnalysisValueTemplate")
-				ps.results <- metricsapi.ProviderResult{Objective: j.AnalysisValueTemplateRef, ErrMsg: err.Error()}
+				ps.sendError(j, err)
 				continue
 			}
 
@@ -83,41 +82,65 @@ func (ps ProvidersPool) DispatchToProviders(ctx context.Context, id int) {
 
 			if err != nil {
 				ps.log.Error(err, "Failed to get KeptnMetricsProvider")
-				ps.results <- metricsapi.ProviderResult{Objective: j.AnalysisValueTemplateRef, ErrMsg: err.Error()}
+				ps.sendError(j, err)
 				continue
 			}
 
 			ps.log.Info("found KeptnMetricsProvider, preparing query", "workerID:", id, "AnalysisValueTemplate:", templ.Name, "KeptnMetricsProvider:", templ.Spec.Provider.Name, "ProviderType:", providerRef.Spec.Type, "query:", templ.Spec.Query)
 
-			if !ps.isProviderTypeRegistered(providerRef.Spec.Type) {
-				ps.results <- metricsapi.ProviderResult{Objective: j.AnalysisValueTemplateRef, ErrMsg: fmt.Sprintf("unsupported provider: %s", providerRef.Spec.Type)}
+			providerChan, err := ps.registerProvider(ctx, providerRef)
+			if err != nil {
+				ps.sendError(j, err)
 				continue
 			}
 
 			templatedQuery, err := generateQuery(templ.Spec.Query, ps.Analysis.Spec.Args)
 			if err != nil {
 				ps.log.Error(err, "Failed to substitute args in AnalysisValueTemplate")
-				ps.results <- metricsapi.ProviderResult{Objective: j.AnalysisValueTemplateRef, ErrMsg: err.Error()}
+				ps.sendError(j, err)
 				continue
 			}
+			var baseline *metricstypes.BaselineRequest
+			if j.Baseline != nil {
+				baselineQuery, err := generateQuery(templ.Spec.Query, ps.Analysis.GetBaselineArgs(j.Baseline))
+				if err != nil {
+					ps.log.Error(err, "Failed to substitute baseline args in AnalysisValueTemplate")
+					ps.sendError(j, err)
+					continue
+				}
+				baseline = &metricstypes.BaselineRequest{
+					Query:     baselineQuery,
+					Timeframe: ps.Analysis.GetBaselineTimeframe(j.Baseline),
+				}
+			}
 			//send job to provider solver
-			ps.providers[providerRef.Spec.Type] <- metricstypes.ProviderRequest{
+			providerChan <- metricstypes.ProviderRequest{
 				Objective: j,
 				Query:     templatedQuery,
 				Provider:  providerRef,
+				Baseline:  baseline,
 			}
 		}
 	}
 }
 
-func (ps ProvidersPool) StopProviders() {
+func (ps *ProvidersPool) sendError(objective metricsapi.Objective, err error) {
+	ps.results <- metricstypes.ProviderResult{
+		Objective: objective,
+		Result:    metricsapi.ProviderResult{Objective: objective.AnalysisValueTemplateRef, ErrMsg: err.Error()},
+	}
+}
+
+func (ps *ProvidersPool) StopProviders() {
+	ps.providersMtx.Lock()
+	defer ps.providersMtx.Unlock()
 	for _, ch := range ps.providers {
 		close(ch)
 	}
 	close(ps.results)
 }
 
-func (ps ProvidersPool) GetResult(ctx context.Context) (*metricsapi.ProviderResult, error) {
+func (ps *ProvidersPool) GetResult(ctx context.Context) (*metricstypes.ProviderResult, error) {
 	select {
 	case <-ctx.Done():
 		return nil, errors.New("context has been cancelled")
@@ -126,13 +149,29 @@ func (ps ProvidersPool) GetResult(ctx context.Context) (*metricsapi.ProviderResu
 	}
 }
 
-func (ps ProvidersPool) isProviderTypeRegistered(providerType string) bool {
-	for p := range ps.providers {
-		if p == providerType {
-			return true
-		}
+// registerProvider returns the channel of the provider serving the KeptnMetricsProvider,
+// starting the provider if it has not been registered yet
+func (ps *ProvidersPool) registerProvider(ctx context.Context, metricsProvider *metricsapi.KeptnMetricsProvider) (chan metricstypes.ProviderRequest, error) {
+	ps.providersMtx.Lock()
+	defer ps.providersMtx.Unlock()
+
+	key := providers.GetProviderKey(metricsProvider)
+	if ps.isProviderTypeRegistered(key) {
+		return ps.providers[key], nil
 	}
-	return false
+	if !providers.IsProviderSupported(metricsProvider) {
+		return nil, fmt.Errorf("unsupported provider: %s", metricsProvider.Spec.Type)
+	}
+	ps.log.Info("registering provider", "provider", key)
+	channel := make(chan metricstypes.ProviderRequest, ps.numJobs)
+	ps.providers[key] = channel
+	go ps.Evaluate(ctx, metricsProvider.DeepCopy(), channel)
+	return channel, nil
+}
+
+func (ps *ProvidersPool) isProviderTypeRegistered(providerType string) bool {
+	_, ok := ps.providers[providerType]
+	return ok
 }
 
 func generateQuery(query string, selectors map[string]string) (string, error) {
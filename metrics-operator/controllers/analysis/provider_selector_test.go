@@ -147,6 +147,14 @@ func TestProvidersPool(t *testing.T) {
 		},
 	}
 
+	analysisDefBaseline := *analysisDef.DeepCopy()
+	analysisDefBaseline.Spec.Objectives[0].Baseline = &metricsapi.Baseline{
+		Offset: metav1.Duration{Duration: time.Hour},
+		Args: map[string]string{
+			"good": "baseline",
+		},
+	}
+
 	provider.Spec.Type = "mock-provider"
 	provider2.Spec.Type = "mock-provider"
 
@@ -155,6 +163,7 @@ func TestProvidersPool(t *testing.T) {
 		expectedErr    string
 		mockClient     client.Client
 		analysisDef    metricsapi.AnalysisDefinition
+		providerKey    string
 		providerResult *metricstypes.ProviderRequest
 	}{
 
@@ -180,6 +189,7 @@ func TestProvidersPool(t *testing.T) {
 			name:        "Success",
 			mockClient:  fake2.NewClient(&analysis, &analysisDef, &template, &provider),
 			analysisDef: analysisDef,
+			providerKey: "default/my-provider",
 			providerResult: &metricstypes.ProviderRequest{
 				Query: "this is a good query.",
 			},
@@ -188,14 +198,29 @@ func TestProvidersPool(t *testing.T) {
 			name:        "Success - provider in same namespace",
 			mockClient:  fake2.NewClient(&analysis, &analysisDef, &template2, &provider2),
 			analysisDef: analysisDef,
+			providerKey: "default2/my-provider",
 			providerResult: &metricstypes.ProviderRequest{
 				Query: "this is a good query.",
 			},
 		},
+		{
+			name:        "Success - objective with baseline",
+			mockClient:  fake2.NewClient(&analysis, &analysisDefBaseline, &template, &provider),
+			analysisDef: analysisDefBaseline,
+			providerKey: "default/my-provider",
+			providerResult: &metricstypes.ProviderRequest{
+				Query: "this is a good query.",
+				Baseline: &metricstypes.BaselineRequest{
+					Query:     "this is a baseline query.",
+					Timeframe: analysis.GetBaselineTimeframe(analysisDefBaseline.Spec.Objectives[0].Baseline),
+				},
+			},
+		},
 		{
 			name:        "Success - analysisValueTemplate in same namespace",
 			mockClient:  fake2.NewClient(&analysis, &analysisDef2, &template3, &provider),
 			analysisDef: analysisDef2,
+			providerKey: "default/my-provider",
 			providerResult: &metricstypes.ProviderRequest{
 				Query: "this is a good query.",
 			},
@@ -207,7 +232,7 @@ func TestProvidersPool(t *testing.T) {
 			// Create a mock context for testing
 			ctx, cancel := context.WithCancel(context.TODO())
 
-			resultChan := make(chan metricsapi.ProviderResult, 1)
+			resultChan := make(chan metricstypes.ProviderResult, 1)
 
 			// Create a mock IObjectivesEvaluator and Logger for testing
 			mockEvaluator := &fake.IObjectivesEvaluatorMock{}
@@ -221,12 +246,13 @@ func TestProvidersPool(t *testing.T) {
 				Objectives: map[int][]metricsapi.Objective{
 					1: tc.analysisDef.Spec.Objectives,
 				},
-				Analysis: &analysis,
-				results:  resultChan,
-				cancel:   cancel,
-				providers: map[string]chan metricstypes.ProviderRequest{
-					"mock-provider": providerChan,
-				},
+				Analysis:  &analysis,
+				results:   resultChan,
+				cancel:    cancel,
+				providers: map[string]chan metricstypes.ProviderRequest{},
+			}
+			if tc.providerKey != "" {
+				pool.providers[tc.providerKey] = providerChan
 			}
 
 			// Call DispatchToProviders with the test context and example ID
@@ -235,9 +261,10 @@ func TestProvidersPool(t *testing.T) {
 			if tc.expectedErr == "" {
 				res := <-providerChan
 				require.Equal(t, tc.providerResult.Query, res.Query)
+				require.Equal(t, tc.providerResult.Baseline, res.Baseline)
 			} else {
 				res := <-resultChan
-				require.Contains(t, res.ErrMsg, tc.expectedErr)
+				require.Contains(t, res.Result.ErrMsg, tc.expectedErr)
 			}
 			pool.StopProviders()
 		})
@@ -248,7 +275,7 @@ func TestProvidersPool_StartProviders(t *testing.T) {
 
 	numJobs := 7
 	ctx, cancel := context.WithCancel(context.Background())
-	resChan := make(chan metricsapi.ProviderResult)
+	resChan := make(chan metricstypes.ProviderResult)
 	// Create a mock IObjectivesEvaluator, Client, and Logger for testing
 	mockEvaluator := &fake.IObjectivesEvaluatorMock{
 		EvaluateFunc: func(ctx context.Context, metricsProvider *metricsapi.KeptnMetricsProvider, obj chan metricstypes.ProviderRequest) {
@@ -258,6 +285,7 @@ func TestProvidersPool_StartProviders(t *testing.T) {
 	// Create a ProvidersPool instance with the mock objects
 	pool := ProvidersPool{
 		IObjectivesEvaluator: mockEvaluator,
+		log:                  logr.Discard(),
 		Namespace:            "test-namespace",
 		Objectives:           make(map[int][]metricsapi.Objective),
 		Analysis:             &metricsapi.Analysis{},
@@ -269,12 +297,44 @@ func TestProvidersPool_StartProviders(t *testing.T) {
 	// Call StartProviders with the test context and example numJobs
 	pool.StartProviders(ctx, numJobs)
 
+	// providers are only registered once a job is dispatched to them
+	require.Empty(t, pool.providers)
+
+	newProvider := func(name string, spec metricsapi.KeptnMetricsProviderSpec) *metricsapi.KeptnMetricsProvider {
+		return &metricsapi.KeptnMetricsProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+			Spec:       spec,
+		}
+	}
+
+	_, err := pool.registerProvider(ctx, newProvider("prometheus-a", metricsapi.KeptnMetricsProviderSpec{Type: "prometheus", TargetServer: "http://prometheus-a:9090"}))
+	require.Nil(t, err)
+	_, err = pool.registerProvider(ctx, newProvider("prometheus-a", metricsapi.KeptnMetricsProviderSpec{Type: "prometheus", TargetServer: "http://prometheus-a:9090"}))
+	require.Nil(t, err)
+	_, err = pool.registerProvider(ctx, newProvider("foo", metricsapi.KeptnMetricsProviderSpec{Type: "foo"}))
+	require.ErrorContains(t, err, "unsupported provider: foo")
+
+	// each KeptnMetricsProvider is registered separately, even if it has the same type as another one
+	_, err = pool.registerProvider(ctx, newProvider("prometheus-b", metricsapi.KeptnMetricsProviderSpec{Type: "prometheus", TargetServer: "http://prometheus-b:9090"}))
+	require.Nil(t, err)
+	for _, name := range []string{"plugin-a", "plugin-b"} {
+		_, err = pool.registerProvider(ctx, newProvider(name, metricsapi.KeptnMetricsProviderSpec{
+			Type:   "plugin",
+			Plugin: &metricsapi.PluginProviderSpec{Endpoint: "http://" + name + ":8080"},
+		}))
+		require.Nil(t, err)
+	}
+
 	// Wait for a short time to allow the goroutines to start
 	time.Sleep(time.Millisecond * 100)
 
 	// Assert the expected number of workers (goroutines) were started
-	require.Equal(t, 7, len(pool.providers))
-	require.Equal(t, numJobs, cap(pool.providers["prometheus"]))
+	require.Equal(t, 4, len(pool.providers))
+	require.Len(t, mockEvaluator.EvaluateCalls(), 4)
+	require.Equal(t, numJobs, cap(pool.providers["test-namespace/prometheus-a"]))
+	require.Contains(t, pool.providers, "test-namespace/prometheus-b")
+	require.Contains(t, pool.providers, "test-namespace/plugin-a")
+	require.Contains(t, pool.providers, "test-namespace/plugin-b")
 	// Stop the providers after testing
 	pool.StopProviders()
 
@@ -28,19 +28,19 @@ func NewWorkersPool(ctx context.Context, analysis *metricsapi.Analysis, objectiv
 		numWorkers = numJobs
 	}
 	childCtx, cancel := context.WithTimeout(ctx, workerPoolTimeout)
-	providerChans := make(map[string]chan metricstypes.ProviderRequest, len(providers.SupportedProviders))
+	providerChans := make(map[string]chan metricstypes.ProviderRequest)
 
 	assigner := TaskAssigner{tasks: objectives, numWorkers: numWorkers}
-	results := make(chan metricsapi.ProviderResult, numJobs)
+	results := make(chan metricstypes.ProviderResult, numJobs)
 	evaluator := ObjectivesEvaluator{
-		ProviderFactory: providers.NewProvider,
+		ProviderFactory: providers.NewCachedProvider,
 		log:             log,
 		Client:          c,
 		Analysis:        analysis,
 		results:         results,
 		cancel:          cancel,
 	}
-	retriever := ProvidersPool{
+	retriever := &ProvidersPool{
 		Client:               c,
 		log:                  log,
 		Analysis:             analysis,
@@ -87,9 +87,9 @@ func (aw WorkersPool) CollectAnalysisResults(ctx context.Context) (map[string]me
 			if err2 != nil {
 				err = err2
 			} else {
-				results[analysis.ComputeKey(res.Objective)] = *res
-				if res.ErrMsg != "" {
-					err = errors.New(res.ErrMsg)
+				results[analysis.ComputeObjectiveKey(&res.Objective)] = res.Result
+				if res.Result.ErrMsg != "" {
+					err = errors.New(res.Result.ErrMsg)
 				}
 			}
 		}
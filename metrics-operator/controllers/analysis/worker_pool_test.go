@@ -7,6 +7,8 @@ import (
 
 	"github.com/go-logr/logr/testr"
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/analysis"
+	metricstypes "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/analysis/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -45,9 +47,9 @@ func TestNewWorkerPool(t *testing.T) {
 
 func TestWorkersPool_CollectAnalysisResults(t *testing.T) {
 	// Create a fake WorkersPool instance for testing
-	resChan := make(chan metricsapi.ProviderResult, 2)
+	resChan := make(chan metricstypes.ProviderResult, 2)
 	fakePool := WorkersPool{
-		IProvidersPool: ProvidersPool{
+		IProvidersPool: &ProvidersPool{
 			results: resChan,
 		},
 		numJobs: 2,
@@ -68,8 +70,8 @@ func TestWorkersPool_CollectAnalysisResults(t *testing.T) {
 	// Create and send mock results to the results channel
 	go func() {
 		time.Sleep(time.Second)
-		resChan <- res1
-		resChan <- res2
+		resChan <- metricstypes.ProviderResult{Objective: metricsapi.Objective{AnalysisValueTemplateRef: res1.Objective}, Result: res1}
+		resChan <- metricstypes.ProviderResult{Objective: metricsapi.Objective{AnalysisValueTemplateRef: res2.Objective}, Result: res2}
 	}()
 
 	// Collect the results
@@ -81,11 +83,41 @@ func TestWorkersPool_CollectAnalysisResults(t *testing.T) {
 	require.Equal(t, res2, results["t2"])
 }
 
+func TestWorkersPool_CollectAnalysisResultsSameTemplate(t *testing.T) {
+	resChan := make(chan metricstypes.ProviderResult, 2)
+	fakePool := WorkersPool{
+		IProvidersPool: &ProvidersPool{
+			results: resChan,
+		},
+		numJobs: 2,
+	}
+
+	template := metricsapi.ObjectReference{Name: "t1"}
+	plain := metricsapi.Objective{AnalysisValueTemplateRef: template}
+	withBaseline := metricsapi.Objective{
+		AnalysisValueTemplateRef: template,
+		Baseline:                 &metricsapi.Baseline{Args: map[string]string{"version": "stable"}},
+	}
+	res1 := metricsapi.ProviderResult{Objective: template, Value: "10"}
+	res2 := metricsapi.ProviderResult{Objective: template, Value: "11", BaselineValue: "9"}
+
+	resChan <- metricstypes.ProviderResult{Objective: plain, Result: res1}
+	resChan <- metricstypes.ProviderResult{Objective: withBaseline, Result: res2}
+
+	results, err := fakePool.CollectAnalysisResults(context.TODO())
+
+	// the objectives use the same template, but the result of one must not overwrite the other
+	require.Nil(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, res1, results["t1"])
+	require.Equal(t, res2, results[analysis.ComputeObjectiveKey(&withBaseline)])
+}
+
 func TestWorkersPool_CollectAnalysisResultsWithError(t *testing.T) {
 	// Create a fake WorkersPool instance for testing
-	resChan := make(chan metricsapi.ProviderResult, 2)
+	resChan := make(chan metricstypes.ProviderResult, 2)
 	fakePool := WorkersPool{
-		IProvidersPool: ProvidersPool{
+		IProvidersPool: &ProvidersPool{
 			results: resChan,
 		},
 		numJobs: 2,
@@ -106,8 +138,8 @@ func TestWorkersPool_CollectAnalysisResultsWithError(t *testing.T) {
 	// Create and send mock results to the results channel
 	go func() {
 		time.Sleep(time.Second)
-		resChan <- res1
-		resChan <- res2
+		resChan <- metricstypes.ProviderResult{Objective: metricsapi.Objective{AnalysisValueTemplateRef: res1.Objective}, Result: res1}
+		resChan <- metricstypes.ProviderResult{Objective: metricsapi.Objective{AnalysisValueTemplateRef: res2.Objective}, Result: res2}
 	}()
 
 	// Collect the results
@@ -121,9 +153,9 @@ func TestWorkersPool_CollectAnalysisResultsWithError(t *testing.T) {
 
 func TestWorkersPool_CollectAnalysisResultsTimeout(t *testing.T) {
 	// Create a fake WorkersPool instance for testing
-	resChan := make(chan metricsapi.ProviderResult, 2)
+	resChan := make(chan metricstypes.ProviderResult, 2)
 	fakePool := WorkersPool{
-		IProvidersPool: ProvidersPool{
+		IProvidersPool: &ProvidersPool{
 			results: resChan,
 		},
 		numJobs: 2,
@@ -143,9 +175,9 @@ func TestWorkersPool_CollectAnalysisResultsTimeout(t *testing.T) {
 
 func TestWorkersPool_CollectAnalysisResultsNoJob(t *testing.T) {
 	// Create a fake WorkersPool instance for testing
-	resChan := make(chan metricsapi.ProviderResult, 1)
+	resChan := make(chan metricstypes.ProviderResult, 1)
 	fakePool := WorkersPool{
-		IProvidersPool: ProvidersPool{
+		IProvidersPool: &ProvidersPool{
 			results: resChan,
 		},
 		numJobs: 0,
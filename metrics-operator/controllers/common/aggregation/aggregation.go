@@ -3,6 +3,7 @@ package aggregation
 import (
 	"math"
 	"sort"
+	"time"
 )
 
 func CalculateMax(values []float64) float64 {
@@ -64,12 +65,84 @@ func CalculateAverage(values []float64) float64 {
 	return 0.0
 }
 
+// CalculatePercentile returns the value below which the given percentage of the sorted values falls
 func CalculatePercentile(values sort.Float64Slice, perc float64) float64 {
 	if len(values) == 0 {
 		return 0.0
 	}
 	// Calculate the index for the requested percentile
 	i := math.Ceil(float64(len(values)) * perc / 100)
+	if i < 1 {
+		i = 1
+	}
 
 	return values[int(i-1)]
 }
+
+func CalculateSum(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
+
+func CalculateCount(values []float64) float64 {
+	return float64(len(values))
+}
+
+// CalculateStdDev returns the population standard deviation of the values
+func CalculateStdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	avg := CalculateAverage(values)
+	variance := 0.0
+	for _, value := range values {
+		variance += (value - avg) * (value - avg)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
+
+func CalculateLast(values []float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	return values[len(values)-1]
+}
+
+// CalculateIncrease returns the increase of a counter over the values.
+// A value lower than its predecessor is treated as a counter reset.
+func CalculateIncrease(values []float64) float64 {
+	increase := 0.0
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[i-1] {
+			// the counter has been reset
+			increase += values[i]
+		} else {
+			increase += values[i] - values[i-1]
+		}
+	}
+	return increase
+}
+
+// CalculateRate returns the per-second increase of a counter over the values, which are the given step apart
+func CalculateRate(values []float64, step time.Duration) float64 {
+	if len(values) < 2 || step <= 0 {
+		return 0.0
+	}
+	return CalculateIncrease(values) / (float64(len(values)-1) * step.Seconds())
+}
+
+// CalculateEWMA returns the exponentially weighted moving average of the values,
+// where the smoothing factor is the weight of the most recent value
+func CalculateEWMA(values []float64, smoothingFactor float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	ewma := values[0]
+	for _, value := range values[1:] {
+		ewma = smoothingFactor*value + (1-smoothingFactor)*ewma
+	}
+	return ewma
+}
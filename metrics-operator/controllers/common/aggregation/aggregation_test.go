@@ -0,0 +1,110 @@
+package aggregation
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregations(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		calc   func([]float64) float64
+		want   float64
+	}{
+		{name: "max", values: []float64{3, 7, 1}, calc: CalculateMax, want: 7},
+		{name: "max of negative values", values: []float64{-3, -7, -1}, calc: CalculateMax, want: -1},
+		{name: "max of no values", values: []float64{}, calc: CalculateMax, want: 0},
+		{name: "min", values: []float64{3, 7, 1}, calc: CalculateMin, want: 1},
+		{name: "min of no values", values: []float64{}, calc: CalculateMin, want: 0},
+		{name: "median of odd count", values: []float64{5, 1, 3}, calc: CalculateMedian, want: 3},
+		{name: "median of even count", values: []float64{4, 1, 3, 2}, calc: CalculateMedian, want: 2.5},
+		{name: "median of no values", values: []float64{}, calc: CalculateMedian, want: 0},
+		{name: "average", values: []float64{1, 2, 3, 6}, calc: CalculateAverage, want: 3},
+		{name: "average of no values", values: []float64{}, calc: CalculateAverage, want: 0},
+		{name: "sum", values: []float64{1, 2, 3.5}, calc: CalculateSum, want: 6.5},
+		{name: "sum of no values", values: []float64{}, calc: CalculateSum, want: 0},
+		{name: "count", values: []float64{1, 2, 3}, calc: CalculateCount, want: 3},
+		{name: "count of no values", values: []float64{}, calc: CalculateCount, want: 0},
+		{name: "stddev", values: []float64{2, 4, 4, 4, 5, 5, 7, 9}, calc: CalculateStdDev, want: 2},
+		{name: "stddev of no values", values: []float64{}, calc: CalculateStdDev, want: 0},
+		{name: "last", values: []float64{1, 5, 2}, calc: CalculateLast, want: 2},
+		{name: "last of no values", values: []float64{}, calc: CalculateLast, want: 0},
+		{name: "increase", values: []float64{1, 3, 6}, calc: CalculateIncrease, want: 5},
+		{name: "increase with counter reset", values: []float64{5, 8, 2, 4}, calc: CalculateIncrease, want: 7},
+		{name: "increase of a single value", values: []float64{5}, calc: CalculateIncrease, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.want, tt.calc(tt.values), 1e-9)
+		})
+	}
+}
+
+func TestCalculateMedian_DoesNotModifyValues(t *testing.T) {
+	values := []float64{3, 1, 2}
+	CalculateMedian(values)
+	require.Equal(t, []float64{3, 1, 2}, values)
+}
+
+func TestCalculatePercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		values sort.Float64Slice
+		perc   float64
+		want   float64
+	}{
+		{name: "p90", values: sort.Float64Slice{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, perc: 90, want: 9},
+		{name: "p95", values: sort.Float64Slice{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, perc: 95, want: 10},
+		{name: "p50", values: sort.Float64Slice{1, 2, 3, 4}, perc: 50, want: 2},
+		{name: "p0 returns the smallest value", values: sort.Float64Slice{1, 2, 3}, perc: 0, want: 1},
+		{name: "p100 returns the largest value", values: sort.Float64Slice{1, 2, 3}, perc: 100, want: 3},
+		{name: "no values", values: sort.Float64Slice{}, perc: 90, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, CalculatePercentile(tt.values, tt.perc))
+		})
+	}
+}
+
+func TestCalculateRate(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		step   time.Duration
+		want   float64
+	}{
+		{name: "rate per second", values: []float64{0, 60, 120}, step: time.Minute, want: 1},
+		{name: "rate with counter reset", values: []float64{0, 60, 30}, step: time.Minute, want: 0.75},
+		{name: "single value", values: []float64{10}, step: time.Minute, want: 0},
+		{name: "no step", values: []float64{0, 60}, step: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.want, CalculateRate(tt.values, tt.step), 1e-9)
+		})
+	}
+}
+
+func TestCalculateEWMA(t *testing.T) {
+	tests := []struct {
+		name            string
+		values          []float64
+		smoothingFactor float64
+		want            float64
+	}{
+		{name: "half smoothing", values: []float64{10, 20, 30}, smoothingFactor: 0.5, want: 22.5},
+		{name: "full smoothing returns the last value", values: []float64{10, 20, 30}, smoothingFactor: 1, want: 30},
+		{name: "no smoothing returns the first value", values: []float64{10, 20, 30}, smoothingFactor: 0, want: 10},
+		{name: "no values", values: []float64{}, smoothingFactor: 0.5, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.want, CalculateEWMA(tt.values, tt.smoothingFactor), 1e-9)
+		})
+	}
+}
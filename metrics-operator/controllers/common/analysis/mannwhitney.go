@@ -0,0 +1,111 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+)
+
+// minSampleSize is the minimum number of values each series needs for the Mann-Whitney U test
+const minSampleSize = 3
+
+// MannWhitneyResult contains the outcome of a Mann-Whitney U test
+type MannWhitneyResult struct {
+	// U is the U statistic of the sample compared with the baseline
+	U float64
+	// PValue is the probability of observing a difference at least as extreme as the one found,
+	// assuming both series come from the same distribution
+	PValue float64
+}
+
+// MannWhitneyU performs a Mann-Whitney U test checking whether the sample differs from the baseline in the given direction.
+// The p-value is computed using the normal approximation with tie and continuity correction.
+func MannWhitneyU(sample, baseline []float64, direction metricsapi.TestDirection) (MannWhitneyResult, error) {
+	n1, n2 := len(sample), len(baseline)
+	if n1 < minSampleSize || n2 < minSampleSize {
+		return MannWhitneyResult{}, fmt.Errorf("at least %d values are required in each series, got %d and %d", minSampleSize, n1, n2)
+	}
+
+	rankSum, tieCorrection := rankSample(sample, baseline)
+	u := rankSum - float64(n1*(n1+1))/2
+
+	n := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	if variance <= 0 {
+		// all values are equal
+		return MannWhitneyResult{U: u, PValue: 1}, nil
+	}
+	sigma := math.Sqrt(variance)
+
+	var p float64
+	switch direction {
+	case metricsapi.TestDirectionIncrease:
+		p = 1 - normalCDF((u-mean-0.5)/sigma)
+	case metricsapi.TestDirectionDecrease:
+		p = normalCDF((u - mean + 0.5) / sigma)
+	case metricsapi.TestDirectionEither:
+		p = 2 * (1 - normalCDF((math.Abs(u-mean)-0.5)/sigma))
+	default:
+		return MannWhitneyResult{}, fmt.Errorf("unsupported test direction: %s", direction)
+	}
+
+	return MannWhitneyResult{U: u, PValue: math.Min(math.Max(p, 0), 1)}, nil
+}
+
+// rankSample ranks the values of both series together, assigning the average rank to ties,
+// and returns the sum of the ranks of the sample and the tie correction term sum(t^3 - t)
+func rankSample(sample, baseline []float64) (float64, float64) {
+	type rankedValue struct {
+		value    float64
+		inSample bool
+	}
+	values := make([]rankedValue, 0, len(sample)+len(baseline))
+	for _, v := range sample {
+		values = append(values, rankedValue{value: v, inSample: true})
+	}
+	for _, v := range baseline {
+		values = append(values, rankedValue{value: v})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].value < values[j].value
+	})
+
+	rankSum, tieCorrection := 0.0, 0.0
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j].value == values[i].value {
+			j++
+		}
+		// values i..j-1 are tied and share the average of the ranks i+1..j
+		rank := float64(i+1+j) / 2
+		for k := i; k < j; k++ {
+			if values[k].inSample {
+				rankSum += rank
+			}
+		}
+		t := float64(j - i)
+		tieCorrection += t*t*t - t
+		i = j
+	}
+	return rankSum, tieCorrection
+}
+
+func normalCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
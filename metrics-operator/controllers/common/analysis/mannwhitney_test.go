@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"testing"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name      string
+		sample    []float64
+		baseline  []float64
+		direction metricsapi.TestDirection
+		wantU     float64
+		wantP     float64
+		wantErr   string
+	}{
+		{
+			name:      "sample smaller than baseline, decrease",
+			sample:    []float64{1, 2, 3},
+			baseline:  []float64{4, 5, 6},
+			direction: metricsapi.TestDirectionDecrease,
+			wantU:     0,
+			wantP:     0.040428,
+		},
+		{
+			name:      "sample smaller than baseline, increase",
+			sample:    []float64{1, 2, 3},
+			baseline:  []float64{4, 5, 6},
+			direction: metricsapi.TestDirectionIncrease,
+			wantU:     0,
+			wantP:     0.985452,
+		},
+		{
+			name:      "sample smaller than baseline, either",
+			sample:    []float64{1, 2, 3},
+			baseline:  []float64{4, 5, 6},
+			direction: metricsapi.TestDirectionEither,
+			wantU:     0,
+			wantP:     0.080856,
+		},
+		{
+			name:      "sample greater than baseline, increase",
+			sample:    []float64{4, 5, 6},
+			baseline:  []float64{1, 2, 3},
+			direction: metricsapi.TestDirectionIncrease,
+			wantU:     9,
+			wantP:     0.040428,
+		},
+		{
+			name:      "ties, either",
+			sample:    []float64{1, 2, 2, 3},
+			baseline:  []float64{2, 3, 3, 4},
+			direction: metricsapi.TestDirectionEither,
+			wantU:     3,
+			wantP:     0.172034,
+		},
+		{
+			name:      "ties, decrease",
+			sample:    []float64{3, 2, 1, 2},
+			baseline:  []float64{4, 3, 2, 3},
+			direction: metricsapi.TestDirectionDecrease,
+			wantU:     3,
+			wantP:     0.086017,
+		},
+		{
+			name:      "all values equal",
+			sample:    []float64{1, 1, 1},
+			baseline:  []float64{1, 1, 1},
+			direction: metricsapi.TestDirectionEither,
+			wantU:     4.5,
+			wantP:     1,
+		},
+		{
+			name:      "not enough values",
+			sample:    []float64{1, 2},
+			baseline:  []float64{1, 2, 3},
+			direction: metricsapi.TestDirectionEither,
+			wantErr:   "at least 3 values are required in each series, got 2 and 3",
+		},
+		{
+			name:      "unsupported direction",
+			sample:    []float64{1, 2, 3},
+			baseline:  []float64{1, 2, 4},
+			direction: "sideways",
+			wantErr:   "unsupported test direction: sideways",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MannWhitneyU(tt.sample, tt.baseline, tt.direction)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantU, result.U)
+			require.InDelta(t, tt.wantP, result.PValue, 0.000001)
+		})
+	}
+}
+
+func TestMedian(t *testing.T) {
+	require.Equal(t, 0.0, median(nil))
+	require.Equal(t, 2.0, median([]float64{3, 1, 2}))
+	require.Equal(t, 2.5, median([]float64{4, 1, 3, 2}))
+}
@@ -1,7 +1,10 @@
 package analysis
 
 import (
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"strconv"
 
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
@@ -25,8 +28,13 @@ func (oe *ObjectiveEvaluator) Evaluate(values map[string]metricsapi.ProviderResu
 		Objective: *obj,
 	}
 
+	// if a statistical test is defined, the series of values are compared instead of single values
+	if obj.StatisticalTest != nil {
+		return evaluateStatisticalTest(values, obj, result)
+	}
+
 	// get the value
-	floatVal, query, err := getResultFromMap(values, ComputeKey(obj.AnalysisValueTemplateRef))
+	floatVal, query, err := getResultFromMap(values, ComputeObjectiveKey(obj))
 	result.Query = query
 	if err != nil {
 		result.Error = err
@@ -34,6 +42,18 @@ func (oe *ObjectiveEvaluator) Evaluate(values map[string]metricsapi.ProviderResu
 	}
 
 	result.Value = floatVal
+
+	// if a baseline is defined, the target is evaluated on the delta between the value and the baseline value
+	if obj.Baseline != nil {
+		baseline, err := getBaselineResult(values[ComputeObjectiveKey(obj)], floatVal, obj.Baseline)
+		result.Baseline = baseline
+		if err != nil {
+			result.Error = err
+			return result
+		}
+		floatVal = baseline.Delta
+	}
+
 	result.Result = oe.TargetEvaluator.Evaluate(floatVal, &obj.Target)
 
 	// if target passed, we return the full score
@@ -64,9 +84,125 @@ func getResultFromMap(values map[string]metricsapi.ProviderResult, key string) (
 	return floatVal, val.Query, nil
 }
 
+func getBaselineResult(val metricsapi.ProviderResult, floatVal float64, baseline *metricsapi.Baseline) (*types.BaselineResult, error) {
+	baselineVal, err := strconv.ParseFloat(val.BaselineValue, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse baseline value: %w", err)
+	}
+
+	result := &types.BaselineResult{
+		Value: baselineVal,
+		Query: val.BaselineQuery,
+	}
+	result.Delta, err = computeDelta(floatVal, baselineVal, baseline.GetDelta())
+	return result, err
+}
+
+func computeDelta(value, baseline float64, deltaType metricsapi.DeltaType) (float64, error) {
+	delta := value - baseline
+	if deltaType == metricsapi.DeltaPercentage {
+		if baseline == 0 {
+			return delta, fmt.Errorf("percentage delta can not be computed for a baseline value of 0")
+		}
+		delta = delta / math.Abs(baseline) * 100
+	}
+	return delta, nil
+}
+
+// evaluateStatisticalTest scores the objective by comparing its series of values with the baseline series
+// using a Mann-Whitney U test. The value and baseline value of the result are the medians of the series.
+func evaluateStatisticalTest(values map[string]metricsapi.ProviderResult, obj *metricsapi.Objective, result types.ObjectiveResult) types.ObjectiveResult {
+	key := ComputeObjectiveKey(obj)
+	val, ok := values[key]
+	if !ok {
+		result.Error = fmt.Errorf("required value '%s' not available", key)
+		return result
+	}
+	result.Query = val.Query
+
+	sample, err := parseSeries(val.Values)
+	if err != nil {
+		result.Error = fmt.Errorf("could not parse values: %w", err)
+		return result
+	}
+	baseline, err := parseSeries(val.BaselineValues)
+	if err != nil {
+		result.Error = fmt.Errorf("could not parse baseline values: %w", err)
+		return result
+	}
+
+	result.Value = median(sample)
+	result.Baseline = &types.BaselineResult{
+		Value: median(baseline),
+		Query: val.BaselineQuery,
+	}
+	// the delta between the medians is informational only, the objective is scored by the test
+	result.Baseline.Delta, _ = computeDelta(result.Value, result.Baseline.Value, obj.Baseline.GetDelta())
+
+	test := obj.StatisticalTest
+	testResult, err := MannWhitneyU(sample, baseline, test.GetDirection())
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	result.StatisticalTest = &types.StatisticalTestResult{
+		U:                  testResult.U,
+		PValue:             testResult.PValue,
+		Direction:          test.GetDirection(),
+		Confidence:         test.GetConfidence(),
+		SampleSize:         len(sample),
+		BaselineSampleSize: len(baseline),
+	}
+
+	switch {
+	case testResult.PValue < 1-test.GetConfidence():
+		// the difference is significant, the objective fails
+	case test.GetWarningConfidence() > 0 && testResult.PValue < 1-test.GetWarningConfidence():
+		result.Result.Warning = true
+		result.Score = float64(obj.Weight) / 2
+	default:
+		result.Result.Pass = true
+		result.Score = float64(obj.Weight)
+	}
+	return result
+}
+
+func parseSeries(values []string) ([]float64, error) {
+	series := make([]float64, 0, len(values))
+	for _, v := range values {
+		floatVal, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, err
+		}
+		// providers return NaN for missing data points, which can not be ranked
+		if math.IsNaN(floatVal) {
+			continue
+		}
+		series = append(series, floatVal)
+	}
+	return series, nil
+}
+
 func ComputeKey(obj metricsapi.ObjectReference) string {
 	if !obj.IsNamespaceSet() {
 		return obj.Name
 	}
 	return obj.Name + "-" + obj.Namespace
 }
+
+// ComputeObjectiveKey returns the key of the values retrieved for the objective.
+// Objectives comparing with a baseline or using a statistical test retrieve different values than
+// other objectives using the same AnalysisValueTemplate, so their baseline and test are part of the key.
+func ComputeObjectiveKey(obj *metricsapi.Objective) string {
+	key := ComputeKey(obj.AnalysisValueTemplateRef)
+	if obj.Baseline == nil && obj.StatisticalTest == nil {
+		return key
+	}
+	spec, _ := json.Marshal(struct {
+		Baseline        *metricsapi.Baseline        `json:"baseline,omitempty"`
+		StatisticalTest *metricsapi.StatisticalTest `json:"statisticalTest,omitempty"`
+	}{obj.Baseline, obj.StatisticalTest})
+	h := fnv.New32a()
+	_, _ = h.Write(spec)
+	return fmt.Sprintf("%s-%x", key, h.Sum32())
+}
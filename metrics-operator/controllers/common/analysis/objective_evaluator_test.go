@@ -8,6 +8,7 @@ import (
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/analysis/fake"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/analysis/types"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestObjectiveEvaluator_Evaluate(t *testing.T) {
@@ -152,6 +153,92 @@ func TestObjectiveEvaluator_Evaluate(t *testing.T) {
 	}
 }
 
+func TestObjectiveEvaluator_EvaluateWithBaseline(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         metricsapi.ProviderResult
+		baseline      metricsapi.Baseline
+		wantValue     float64
+		wantEvaluated float64
+		wantBaseline  *types.BaselineResult
+		wantErr       string
+	}{
+		{
+			name:          "absolute delta",
+			value:         metricsapi.ProviderResult{Value: "12", BaselineValue: "8", BaselineQuery: "bbbbbbbb"},
+			baseline:      metricsapi.Baseline{Delta: metricsapi.DeltaAbsolute},
+			wantValue:     12.0,
+			wantEvaluated: 4.0,
+			wantBaseline:  &types.BaselineResult{Value: 8.0, Query: "bbbbbbbb", Delta: 4.0},
+		},
+		{
+			name:          "absolute delta is the default",
+			value:         metricsapi.ProviderResult{Value: "6", BaselineValue: "8", BaselineQuery: "bbbbbbbb"},
+			baseline:      metricsapi.Baseline{},
+			wantValue:     6.0,
+			wantEvaluated: -2.0,
+			wantBaseline:  &types.BaselineResult{Value: 8.0, Query: "bbbbbbbb", Delta: -2.0},
+		},
+		{
+			name:          "percentage delta",
+			value:         metricsapi.ProviderResult{Value: "12", BaselineValue: "8", BaselineQuery: "bbbbbbbb"},
+			baseline:      metricsapi.Baseline{Delta: metricsapi.DeltaPercentage},
+			wantValue:     12.0,
+			wantEvaluated: 50.0,
+			wantBaseline:  &types.BaselineResult{Value: 8.0, Query: "bbbbbbbb", Delta: 50.0},
+		},
+		{
+			name:          "percentage delta of negative baseline",
+			value:         metricsapi.ProviderResult{Value: "-6", BaselineValue: "-8", BaselineQuery: "bbbbbbbb"},
+			baseline:      metricsapi.Baseline{Delta: metricsapi.DeltaPercentage},
+			wantValue:     -6.0,
+			wantEvaluated: 25.0,
+			wantBaseline:  &types.BaselineResult{Value: -8.0, Query: "bbbbbbbb", Delta: 25.0},
+		},
+		{
+			name:         "percentage delta of zero baseline",
+			value:        metricsapi.ProviderResult{Value: "12", BaselineValue: "0", BaselineQuery: "bbbbbbbb"},
+			baseline:     metricsapi.Baseline{Delta: metricsapi.DeltaPercentage},
+			wantBaseline: &types.BaselineResult{Value: 0.0, Query: "bbbbbbbb", Delta: 12.0},
+			wantErr:      "percentage delta can not be computed for a baseline value of 0",
+		},
+		{
+			name:     "missing baseline value",
+			value:    metricsapi.ProviderResult{Value: "12"},
+			baseline: metricsapi.Baseline{},
+			wantErr:  "could not parse baseline value: strconv.ParseFloat: parsing \"\": invalid syntax",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetEvaluator := &fake.ITargetEvaluatorMock{
+				EvaluateFunc: func(val float64, target *metricsapi.Target) types.TargetResult {
+					return types.TargetResult{Pass: true}
+				},
+			}
+			oe := NewObjectiveEvaluator(targetEvaluator)
+			obj := metricsapi.Objective{
+				AnalysisValueTemplateRef: metricsapi.ObjectReference{Name: "name"},
+				Weight:                   1,
+				Baseline:                 &tt.baseline,
+			}
+
+			result := oe.Evaluate(map[string]metricsapi.ProviderResult{ComputeObjectiveKey(&obj): tt.value}, &obj)
+
+			require.Equal(t, tt.wantBaseline, result.Baseline)
+			if tt.wantErr != "" {
+				require.EqualError(t, result.Error, tt.wantErr)
+				require.Empty(t, targetEvaluator.EvaluateCalls())
+				return
+			}
+			require.Nil(t, result.Error)
+			require.Equal(t, tt.wantValue, result.Value)
+			require.Len(t, targetEvaluator.EvaluateCalls(), 1)
+			require.Equal(t, tt.wantEvaluated, targetEvaluator.EvaluateCalls()[0].Val)
+		})
+	}
+}
+
 func TestGetValueFromMap(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -216,3 +303,182 @@ func TestComputeKey(t *testing.T) {
 
 	require.Equal(t, "key-namespace", ComputeKey(obj))
 }
+
+func TestComputeObjectiveKey(t *testing.T) {
+	template := metricsapi.ObjectReference{Name: "key", Namespace: "namespace"}
+	plain := metricsapi.Objective{AnalysisValueTemplateRef: template}
+	withBaseline := metricsapi.Objective{
+		AnalysisValueTemplateRef: template,
+		Baseline:                 &metricsapi.Baseline{Args: map[string]string{"version": "stable"}},
+	}
+	withOtherBaseline := metricsapi.Objective{
+		AnalysisValueTemplateRef: template,
+		Baseline:                 &metricsapi.Baseline{Args: map[string]string{"version": "canary"}},
+	}
+	withStatisticalTest := metricsapi.Objective{
+		AnalysisValueTemplateRef: template,
+		Baseline:                 withBaseline.Baseline,
+		StatisticalTest:          &metricsapi.StatisticalTest{},
+	}
+
+	// the key of objectives without baseline is the key of their template
+	require.Equal(t, "key-namespace", ComputeObjectiveKey(&plain))
+
+	keys := map[string]bool{}
+	for _, obj := range []metricsapi.Objective{plain, withBaseline, withOtherBaseline, withStatisticalTest} {
+		keys[ComputeObjectiveKey(&obj)] = true
+	}
+	require.Len(t, keys, 4)
+
+	sameBaseline := withBaseline
+	sameBaseline.Weight = 5
+	require.Equal(t, ComputeObjectiveKey(&withBaseline), ComputeObjectiveKey(&sameBaseline))
+}
+
+func TestObjectiveEvaluator_EvaluateObjectivesWithSameTemplate(t *testing.T) {
+	template := metricsapi.ObjectReference{Name: "name"}
+	plain := metricsapi.Objective{
+		AnalysisValueTemplateRef: template,
+		Target: metricsapi.Target{
+			Failure: &metricsapi.Operator{GreaterThan: &metricsapi.OperatorValue{FixedValue: *resource.NewQuantity(15, resource.DecimalSI)}},
+		},
+		Weight: 1,
+	}
+	withBaseline := metricsapi.Objective{
+		AnalysisValueTemplateRef: template,
+		Target: metricsapi.Target{
+			Failure: &metricsapi.Operator{GreaterThan: &metricsapi.OperatorValue{FixedValue: *resource.NewQuantity(1, resource.DecimalSI)}},
+		},
+		Baseline: &metricsapi.Baseline{Args: map[string]string{"version": "stable"}},
+		Weight:   2,
+	}
+	values := map[string]metricsapi.ProviderResult{
+		ComputeObjectiveKey(&plain):        {Objective: template, Value: "10"},
+		ComputeObjectiveKey(&withBaseline): {Objective: template, Value: "12", BaselineValue: "8"},
+	}
+
+	targetEvaluator := NewTargetEvaluator(&OperatorEvaluator{})
+	oe := NewObjectiveEvaluator(&targetEvaluator)
+
+	// the value of 10 passes the plain objective
+	result := oe.Evaluate(values, &plain)
+	require.Nil(t, result.Error)
+	require.Equal(t, 10.0, result.Value)
+	require.Equal(t, 1.0, result.Score)
+
+	// the delta of 4 to the baseline fails the other objective
+	result = oe.Evaluate(values, &withBaseline)
+	require.Nil(t, result.Error)
+	require.Equal(t, 12.0, result.Value)
+	require.Equal(t, 4.0, result.Baseline.Delta)
+	require.Equal(t, 0.0, result.Score)
+}
+
+func TestObjectiveEvaluator_EvaluateWithStatisticalTest(t *testing.T) {
+	warningConfidence := resource.MustParse("0.9")
+	tests := []struct {
+		name        string
+		value       metricsapi.ProviderResult
+		test        metricsapi.StatisticalTest
+		wantPass    bool
+		wantWarning bool
+		wantScore   float64
+		wantErr     string
+	}{
+		{
+			name: "no significant difference",
+			value: metricsapi.ProviderResult{
+				Values:         []string{"1", "3", "5", "7"},
+				BaselineValues: []string{"2", "4", "6", "8"},
+			},
+			test:      metricsapi.StatisticalTest{},
+			wantPass:  true,
+			wantScore: 2.0,
+		},
+		{
+			name: "significant increase",
+			value: metricsapi.ProviderResult{
+				Values:         []string{"10", "11", "12", "13", "14"},
+				BaselineValues: []string{"1", "2", "3", "4", "5"},
+			},
+			test:      metricsapi.StatisticalTest{Direction: metricsapi.TestDirectionIncrease},
+			wantScore: 0.0,
+		},
+		{
+			name: "significant decrease does not fail an increase test",
+			value: metricsapi.ProviderResult{
+				Values:         []string{"1", "2", "3", "4", "5"},
+				BaselineValues: []string{"10", "11", "12", "13", "14"},
+			},
+			test:      metricsapi.StatisticalTest{Direction: metricsapi.TestDirectionIncrease},
+			wantPass:  true,
+			wantScore: 2.0,
+		},
+		{
+			name: "warning",
+			value: metricsapi.ProviderResult{
+				Values:         []string{"3", "5", "6", "7"},
+				BaselineValues: []string{"1", "2", "4", "5"},
+			},
+			test:        metricsapi.StatisticalTest{WarningConfidence: &warningConfidence},
+			wantWarning: true,
+			wantScore:   1.0,
+		},
+		{
+			name: "NaN values are skipped",
+			value: metricsapi.ProviderResult{
+				Values:         []string{"1", "NaN", "3", "5"},
+				BaselineValues: []string{"2", "4", "6"},
+			},
+			test:      metricsapi.StatisticalTest{},
+			wantPass:  true,
+			wantScore: 2.0,
+		},
+		{
+			name: "invalid value",
+			value: metricsapi.ProviderResult{
+				Values:         []string{"1", "abc", "3"},
+				BaselineValues: []string{"2", "4", "6"},
+			},
+			test:    metricsapi.StatisticalTest{},
+			wantErr: "could not parse values: strconv.ParseFloat: parsing \"abc\": invalid syntax",
+		},
+		{
+			name: "not enough values",
+			value: metricsapi.ProviderResult{
+				Values:         []string{"1"},
+				BaselineValues: []string{"2", "4", "6"},
+			},
+			test:    metricsapi.StatisticalTest{},
+			wantErr: "at least 3 values are required in each series, got 1 and 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetEvaluator := &fake.ITargetEvaluatorMock{}
+			oe := NewObjectiveEvaluator(targetEvaluator)
+			obj := metricsapi.Objective{
+				AnalysisValueTemplateRef: metricsapi.ObjectReference{Name: "name"},
+				Weight:                   2,
+				Baseline:                 &metricsapi.Baseline{Args: map[string]string{"version": "stable"}},
+				StatisticalTest:          &tt.test,
+			}
+
+			result := oe.Evaluate(map[string]metricsapi.ProviderResult{ComputeObjectiveKey(&obj): tt.value}, &obj)
+
+			require.Empty(t, targetEvaluator.EvaluateCalls())
+			if tt.wantErr != "" {
+				require.EqualError(t, result.Error, tt.wantErr)
+				require.True(t, result.IsFail())
+				return
+			}
+			require.Nil(t, result.Error)
+			require.Equal(t, tt.wantPass, result.IsPass())
+			require.Equal(t, tt.wantWarning, result.IsWarn())
+			require.Equal(t, tt.wantScore, result.Score)
+			require.NotNil(t, result.StatisticalTest)
+			require.NotNil(t, result.Baseline)
+			require.Equal(t, tt.test.GetDirection(), result.StatisticalTest.Direction)
+		})
+	}
+}
@@ -8,6 +8,19 @@ type ProviderRequest struct {
 	Objective metricsapi.Objective
 	Query     string
 	Provider  *metricsapi.KeptnMetricsProvider
+	Baseline  *BaselineRequest
+}
+
+// ProviderResult contains the values retrieved for an objective
+type ProviderResult struct {
+	Objective metricsapi.Objective
+	Result    metricsapi.ProviderResult
+}
+
+// BaselineRequest contains the query and timeframe for retrieving the baseline value of an objective
+type BaselineRequest struct {
+	Query     string
+	Timeframe metricsapi.Timeframe
 }
 
 type TargetResult struct {
@@ -37,6 +50,28 @@ type ObjectiveResult struct {
 	Query     string               `json:"query"`
 	Score     float64              `json:"score"`
 	Error     error                `json:"error,omitempty"`
+	Baseline  *BaselineResult      `json:"baseline,omitempty"`
+	// StatisticalTest contains the outcome of the statistical test, if the objective defines one
+	StatisticalTest *StatisticalTestResult `json:"statisticalTest,omitempty"`
+}
+
+// StatisticalTestResult contains the outcome of the Mann-Whitney U test comparing the series of values of an objective
+// with the baseline series
+type StatisticalTestResult struct {
+	U                  float64                  `json:"u"`
+	PValue             float64                  `json:"pValue"`
+	Direction          metricsapi.TestDirection `json:"direction"`
+	Confidence         float64                  `json:"confidence"`
+	SampleSize         int                      `json:"sampleSize"`
+	BaselineSampleSize int                      `json:"baselineSampleSize"`
+}
+
+// BaselineResult contains the baseline value of an objective and the delta
+// between the value and the baseline value on which the target has been evaluated
+type BaselineResult struct {
+	Value float64 `json:"value"`
+	Query string  `json:"query"`
+	Delta float64 `json:"delta"`
 }
 
 func (o *ObjectiveResult) IsFail() bool {
@@ -0,0 +1,129 @@
+package anomaly
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/aggregation"
+)
+
+const defaultThreshold = 3.0
+
+var ErrInsufficientSamples = errors.New("not enough samples in the history")
+
+// Result represents the outcome of the anomaly detection for a single value
+type Result struct {
+	// Anomalous is true if the value deviates from the baseline by more than the threshold
+	Anomalous bool
+	// Score is the number of standard deviations by which the value deviates from the mean of the baseline
+	Score float64
+	Mean  float64
+	// StdDev is the population standard deviation of the baseline
+	StdDev  float64
+	Samples int
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("z-score %.2f (mean %g, standard deviation %g, %d samples)", r.Score, r.Mean, r.StdDev, r.Samples)
+}
+
+// Detect checks whether the value recorded at the given time is an anomaly compared to the history.
+// For the seasonal method, only the entries of the history which were recorded at the same time of previous
+// seasons are part of the baseline, where an entry may be off by the given tolerance.
+func Detect(spec metricsapi.AnomalyDetectionSpec, history []metricsapi.HistoryEntry, value float64, now time.Time, tolerance time.Duration) (Result, error) {
+	threshold, err := getThreshold(spec)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var baseline []float64
+	minSamples := int(spec.MinSamples)
+	if spec.Method == metricsapi.AnomalyDetectionMethodSeasonal {
+		if spec.Season.Duration <= 0 {
+			return Result{}, fmt.Errorf("the seasonal method requires a season")
+		}
+		baseline = getSeasonalBaseline(history, now, spec.Season.Duration, tolerance)
+		if minSamples == 0 {
+			minSamples = metricsapi.DefaultSeasonalMinSamples
+		}
+	} else {
+		baseline = getValues(history)
+		if minSamples == 0 {
+			minSamples = metricsapi.DefaultMinSamples
+		}
+	}
+
+	if len(baseline) < minSamples {
+		return Result{Samples: len(baseline)}, fmt.Errorf("%w: %d of %d", ErrInsufficientSamples, len(baseline), minSamples)
+	}
+
+	result := Result{
+		Mean:    aggregation.CalculateAverage(baseline),
+		StdDev:  aggregation.CalculateStdDev(baseline),
+		Samples: len(baseline),
+	}
+	switch {
+	case result.StdDev > 0:
+		result.Score = math.Abs(value-result.Mean) / result.StdDev
+	case value != result.Mean:
+		// any deviation from a baseline without variance is an anomaly
+		result.Score = math.Inf(1)
+	}
+	result.Anomalous = result.Score > threshold
+	return result, nil
+}
+
+func getThreshold(spec metricsapi.AnomalyDetectionSpec) (float64, error) {
+	if spec.Threshold == "" {
+		return defaultThreshold, nil
+	}
+	threshold, err := strconv.ParseFloat(spec.Threshold, 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse threshold %s: %w", spec.Threshold, err)
+	}
+	return threshold, nil
+}
+
+func getValues(history []metricsapi.HistoryEntry) []float64 {
+	values := make([]float64, 0, len(history))
+	for _, entry := range history {
+		if value, err := strconv.ParseFloat(entry.Value, 64); err == nil {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getSeasonalBaseline returns for each previous season the value of the entry recorded closest
+// to the same time of the season, if it is within the tolerance
+func getSeasonalBaseline(history []metricsapi.HistoryEntry, now time.Time, season time.Duration, tolerance time.Duration) []float64 {
+	var baseline []float64
+	if len(history) == 0 {
+		return baseline
+	}
+	oldest := history[0].Timestamp.Time
+	for target := now.Add(-season); !target.Before(oldest.Add(-tolerance)); target = target.Add(-season) {
+		closest := -1
+		var closestDiff time.Duration
+		for i, entry := range history {
+			diff := entry.Timestamp.Sub(target)
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff <= tolerance && (closest < 0 || diff < closestDiff) {
+				closest, closestDiff = i, diff
+			}
+		}
+		if closest < 0 {
+			continue
+		}
+		if value, err := strconv.ParseFloat(history[closest].Value, 64); err == nil {
+			baseline = append(baseline, value)
+		}
+	}
+	return baseline
+}
@@ -0,0 +1,125 @@
+package anomaly
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newHistory(start time.Time, step time.Duration, values ...float64) []metricsapi.HistoryEntry {
+	history := make([]metricsapi.HistoryEntry, 0, len(values))
+	for i, value := range values {
+		history = append(history, metricsapi.HistoryEntry{
+			Value:     strconv.FormatFloat(value, 'f', -1, 64),
+			Timestamp: metav1.Time{Time: start.Add(time.Duration(i) * step)},
+		})
+	}
+	return history
+}
+
+func TestDetect_ZScore(t *testing.T) {
+	now := time.Now()
+	history := newHistory(now.Add(-10*time.Minute), time.Minute, 2, 4, 4, 4, 5, 5, 7, 9)
+
+	tests := []struct {
+		name          string
+		spec          metricsapi.AnomalyDetectionSpec
+		history       []metricsapi.HistoryEntry
+		value         float64
+		wantAnomalous bool
+		wantScore     float64
+		wantErr       error
+	}{
+		{
+			name:          "value within threshold",
+			spec:          metricsapi.AnomalyDetectionSpec{Method: metricsapi.AnomalyDetectionMethodZScore, MinSamples: 8},
+			history:       history,
+			value:         7,
+			wantAnomalous: false,
+			wantScore:     1,
+		},
+		{
+			name:          "value above default threshold",
+			spec:          metricsapi.AnomalyDetectionSpec{Method: metricsapi.AnomalyDetectionMethodZScore, MinSamples: 8},
+			history:       history,
+			value:         15,
+			wantAnomalous: true,
+			wantScore:     5,
+		},
+		{
+			name:          "value above custom threshold",
+			spec:          metricsapi.AnomalyDetectionSpec{Method: metricsapi.AnomalyDetectionMethodZScore, Threshold: "0.5", MinSamples: 8},
+			history:       history,
+			value:         7,
+			wantAnomalous: true,
+			wantScore:     1,
+		},
+		{
+			name:    "not enough samples",
+			spec:    metricsapi.AnomalyDetectionSpec{Method: metricsapi.AnomalyDetectionMethodZScore},
+			history: history,
+			value:   15,
+			wantErr: ErrInsufficientSamples,
+		},
+		{
+			name:          "deviation from constant baseline",
+			spec:          metricsapi.AnomalyDetectionSpec{MinSamples: 3},
+			history:       newHistory(now.Add(-3*time.Minute), time.Minute, 1, 1, 1),
+			value:         2,
+			wantAnomalous: true,
+			wantScore:     math.Inf(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Detect(tt.spec, tt.history, tt.value, now, time.Minute)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantAnomalous, result.Anomalous)
+			require.Equal(t, tt.wantScore, result.Score)
+		})
+	}
+}
+
+func TestDetect_Seasonal(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	// one entry per hour over the last four days, with a peak of 100 at noon and 10 otherwise
+	var history []metricsapi.HistoryEntry
+	for ts := now.Add(-96 * time.Hour); ts.Before(now); ts = ts.Add(time.Hour) {
+		value := "10"
+		if ts.Hour() == 12 {
+			value = "100"
+		}
+		history = append(history, metricsapi.HistoryEntry{Value: value, Timestamp: metav1.Time{Time: ts.Add(time.Minute)}})
+	}
+	spec := metricsapi.AnomalyDetectionSpec{
+		Method: metricsapi.AnomalyDetectionMethodSeasonal,
+		Season: metav1.Duration{Duration: 24 * time.Hour},
+	}
+
+	result, err := Detect(spec, history, 100, now, 5*time.Minute)
+	require.Nil(t, err)
+	require.False(t, result.Anomalous)
+	require.Equal(t, 4, result.Samples)
+
+	result, err = Detect(spec, history, 10, now, 5*time.Minute)
+	require.Nil(t, err)
+	require.True(t, result.Anomalous)
+
+	// the same peak is an anomaly compared to the whole history
+	spec.Method = metricsapi.AnomalyDetectionMethodZScore
+	result, err = Detect(spec, history, 100, now, 5*time.Minute)
+	require.Nil(t, err)
+	require.True(t, result.Anomalous)
+
+	_, err = Detect(metricsapi.AnomalyDetectionSpec{Method: metricsapi.AnomalyDetectionMethodSeasonal}, history, 100, now, 5*time.Minute)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,242 @@
+package providers
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const DefaultQueryCacheTTL = 10 * time.Second
+
+// queryCacheFetchTimeout limits the duration of a query that is shared between several callers
+const queryCacheFetchTimeout = 30 * time.Second
+
+const queryCacheRequestsMetricName = "keptn_provider_query_cache_requests_total"
+
+const (
+	cacheResultHit       = "hit"
+	cacheResultMiss      = "miss"
+	cacheResultCoalesced = "coalesced"
+)
+
+// use singleton pattern here to share the cached results between all controllers
+// and to avoid registering the same metrics on Prometheus multiple times
+var queryCacheInstance *QueryCache
+var queryCacheOnce sync.Once
+
+// GetQueryCache returns the query cache shared by all providers created with NewCachedProvider.
+// The logger is only used when the cache is created by the first call.
+func GetQueryCache(log logr.Logger) *QueryCache {
+	queryCacheOnce.Do(func() {
+		queryCacheInstance = NewQueryCache(DefaultQueryCacheTTL, prometheus.DefaultRegisterer, log)
+	})
+	return queryCacheInstance
+}
+
+// QueryCache stores the results of queries for a limited time and coalesces
+// concurrent executions of the same query into a single request to the provider
+type QueryCache struct {
+	ttl      time.Duration
+	entries  map[string]cacheEntry
+	mtx      sync.Mutex
+	group    singleflight.Group
+	requests *prometheus.CounterVec
+	now      func() time.Time
+}
+
+type cacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func NewQueryCache(ttl time.Duration, registerer prometheus.Registerer, log logr.Logger) *QueryCache {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: queryCacheRequestsMetricName,
+		Help: "Number of queries requested from the provider query cache, by provider type and whether the result was a cache hit, miss, or coalesced with a concurrent request",
+	}, []string{"provider_type", "result"})
+	if err := registerer.Register(requests); err != nil {
+		log.Error(err, "Could not register provider query cache requests as Prometheus metric")
+	}
+	return &QueryCache{
+		ttl:      ttl,
+		entries:  map[string]cacheEntry{},
+		requests: requests,
+		now:      time.Now,
+	}
+}
+
+// SetTTL sets the duration for which query results are cached. A TTL of 0 disables the cache.
+func (c *QueryCache) SetTTL(ttl time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.ttl = ttl
+	c.entries = map[string]cacheEntry{}
+}
+
+// Get returns the cached value for the key, or executes fetch to retrieve it.
+// Concurrent calls for the same key share a single execution of fetch. Errors are not cached.
+// As the execution is shared, fetch does not receive the context of the caller, but a context
+// with its own timeout, which is not cancelled when the first caller gives up.
+func (c *QueryCache) Get(ctx context.Context, providerType string, key string, fetch func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	c.mtx.Lock()
+	ttl := c.ttl
+	if ttl <= 0 {
+		c.mtx.Unlock()
+		return fetch(ctx)
+	}
+	if entry, ok := c.entries[key]; ok {
+		if c.now().Before(entry.expiresAt) {
+			c.mtx.Unlock()
+			c.requests.WithLabelValues(providerType, cacheResultHit).Inc()
+			return entry.value, nil
+		}
+		delete(c.entries, key)
+	}
+	c.mtx.Unlock()
+
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), queryCacheFetchTimeout)
+		defer cancel()
+		value, err := fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+		c.evictExpired()
+		c.entries[key] = cacheEntry{value: value, expiresAt: c.now().Add(ttl)}
+		return value, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Shared {
+			c.requests.WithLabelValues(providerType, cacheResultCoalesced).Inc()
+		} else {
+			c.requests.WithLabelValues(providerType, cacheResultMiss).Inc()
+		}
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// evictExpired removes all expired entries, the caller must hold the lock
+func (c *QueryCache) evictExpired() {
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// NewCachedProvider is a ProviderFactory which creates the provider using NewProvider,
+// applies the resilience settings of the KeptnMetricsProvider to its queries
+// and serves its results from the shared query cache
+func NewCachedProvider(provider *metricsapi.KeptnMetricsProvider, log logr.Logger, k8sClient client.Client) (KeptnSLIProvider, error) {
+	p, err := NewProvider(provider, log, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	return &cachedProvider{
+		KeptnSLIProvider: &resilientProvider{KeptnSLIProvider: p, k8sClient: k8sClient, log: log},
+		cache:            GetQueryCache(log),
+	}, nil
+}
+
+// cachedProvider serves the results of a KeptnSLIProvider from a QueryCache.
+// Entries are keyed by the provider, the rendered query and its timeframe.
+type cachedProvider struct {
+	KeptnSLIProvider
+	cache *QueryCache
+}
+
+type queryResult struct {
+	value   string
+	values  []string
+	results []metricsapi.MetricResult
+	raw     []byte
+}
+
+func (c *cachedProvider) FetchAnalysisValue(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+	key := cacheKey("analysis", *provider, query, analysis.GetFrom().UTC().Format(time.RFC3339Nano), analysis.GetTo().UTC().Format(time.RFC3339Nano))
+	res, err := c.cache.Get(ctx, provider.Spec.Type, key, func(ctx context.Context) (interface{}, error) {
+		value, err := c.KeptnSLIProvider.FetchAnalysisValue(ctx, query, analysis, provider)
+		return queryResult{value: value}, err
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.(queryResult).value, nil
+}
+
+func (c *cachedProvider) EvaluateQuery(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+	key := cacheKey("metric", provider, metric.Spec.Query, rangeKey(metric.Spec.Range)...)
+	res, err := c.cache.Get(ctx, provider.Spec.Type, key, func(ctx context.Context) (interface{}, error) {
+		value, raw, err := c.KeptnSLIProvider.EvaluateQuery(ctx, metric, provider)
+		return queryResult{value: value, raw: raw}, err
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return res.(queryResult).value, res.(queryResult).raw, nil
+}
+
+func (c *cachedProvider) EvaluateQueryForStep(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
+	key := cacheKey("step", provider, metric.Spec.Query, rangeKey(metric.Spec.Range)...)
+	res, err := c.cache.Get(ctx, provider.Spec.Type, key, func(ctx context.Context) (interface{}, error) {
+		values, raw, err := c.KeptnSLIProvider.EvaluateQueryForStep(ctx, metric, provider)
+		return queryResult{values: values, raw: raw}, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.(queryResult).values, res.(queryResult).raw, nil
+}
+
+func (c *cachedProvider) FetchAnalysisSeries(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider, step time.Duration) ([]string, error) {
+	key := cacheKey("series", *provider, query, analysis.GetFrom().UTC().Format(time.RFC3339Nano), analysis.GetTo().UTC().Format(time.RFC3339Nano), step.String())
+	res, err := c.cache.Get(ctx, provider.Spec.Type, key, func(ctx context.Context) (interface{}, error) {
+		values, err := FetchAnalysisSeries(ctx, c.KeptnSLIProvider, query, analysis, provider, step)
+		return queryResult{values: values}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(queryResult).values, nil
+}
+
+func (c *cachedProvider) EvaluateQueryResults(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]metricsapi.MetricResult, []byte, error) {
+	if !supportsQueryResults(c.KeptnSLIProvider) {
+		return EvaluateQueryResults(ctx, c.KeptnSLIProvider, metric, provider)
+	}
+	key := cacheKey("results", provider, metric.Spec.Query, rangeKey(metric.Spec.Range)...)
+	res, err := c.cache.Get(ctx, provider.Spec.Type, key, func(ctx context.Context) (interface{}, error) {
+		results, raw, err := EvaluateQueryResults(ctx, c.KeptnSLIProvider, metric, provider)
+		return queryResult{results: results, raw: raw}, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.(queryResult).results, res.(queryResult).raw, nil
+}
+
+func cacheKey(kind string, provider metricsapi.KeptnMetricsProvider, query string, timeframe ...string) string {
+	parts := []string{kind, provider.Namespace, provider.Name, provider.Spec.Type, provider.Spec.TargetServer, query}
+	return strings.Join(append(parts, timeframe...), "\x00")
+}
+
+// rangeKey returns the parts of the RangeSpec which determine the time range, the step and the aggregation of a query
+func rangeKey(r *metricsapi.RangeSpec) []string {
+	if r == nil {
+		return nil
+	}
+	return []string{"interval=" + r.Interval, "step=" + r.Step, "aggregation=" + r.Aggregation, "smoothingFactor=" + r.SmoothingFactor}
+}
@@ -0,0 +1,302 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/fake"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestQueryCache_Get(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewQueryCache(time.Minute, prometheus.NewRegistry(), logr.Discard())
+	cache.now = func() time.Time { return now }
+
+	calls := 0
+	fetch := func(context.Context) (interface{}, error) {
+		calls++
+		return calls, nil
+	}
+
+	value, err := cache.Get(context.TODO(), "prometheus", "key", fetch)
+	require.Nil(t, err)
+	require.Equal(t, 1, value)
+
+	// served from the cache
+	value, err = cache.Get(context.TODO(), "prometheus", "key", fetch)
+	require.Nil(t, err)
+	require.Equal(t, 1, value)
+
+	// different key
+	value, err = cache.Get(context.TODO(), "prometheus", "other-key", fetch)
+	require.Nil(t, err)
+	require.Equal(t, 2, value)
+
+	// expired
+	now = now.Add(2 * time.Minute)
+	value, err = cache.Get(context.TODO(), "prometheus", "key", fetch)
+	require.Nil(t, err)
+	require.Equal(t, 3, value)
+	require.Len(t, cache.entries, 1)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(cache.requests.WithLabelValues("prometheus", cacheResultHit)))
+	require.Equal(t, float64(3), testutil.ToFloat64(cache.requests.WithLabelValues("prometheus", cacheResultMiss)))
+}
+
+func TestQueryCache_GetErrorNotCached(t *testing.T) {
+	cache := NewQueryCache(time.Minute, prometheus.NewRegistry(), logr.Discard())
+
+	_, err := cache.Get(context.TODO(), "prometheus", "key", func(context.Context) (interface{}, error) {
+		return nil, errors.New("unavailable")
+	})
+	require.ErrorContains(t, err, "unavailable")
+
+	value, err := cache.Get(context.TODO(), "prometheus", "key", func(context.Context) (interface{}, error) {
+		return "1", nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, "1", value)
+}
+
+func TestQueryCache_GetDisabled(t *testing.T) {
+	cache := NewQueryCache(time.Minute, prometheus.NewRegistry(), logr.Discard())
+	cache.SetTTL(0)
+
+	calls := 0
+	for i := 0; i < 3; i++ {
+		_, err := cache.Get(context.TODO(), "prometheus", "key", func(context.Context) (interface{}, error) {
+			calls++
+			return calls, nil
+		})
+		require.Nil(t, err)
+	}
+	require.Equal(t, 3, calls)
+	require.Empty(t, cache.entries)
+}
+
+func TestQueryCache_GetCoalescesConcurrentRequests(t *testing.T) {
+	cache := NewQueryCache(time.Minute, prometheus.NewRegistry(), logr.Discard())
+
+	var calls int32
+	release := make(chan struct{})
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cache.Get(context.TODO(), "dynatrace", "key", func(context.Context) (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return "42", nil
+			})
+			require.Nil(t, err)
+			require.Equal(t, "42", value)
+		}()
+	}
+	// wait for the callers to join the in-flight request
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestQueryCache_GetDetachedFromCallerContext(t *testing.T) {
+	cache := NewQueryCache(time.Minute, prometheus.NewRegistry(), logr.Discard())
+
+	release := make(chan struct{})
+	fetchErr := make(chan error, 1)
+	fetch := func(ctx context.Context) (interface{}, error) {
+		<-release
+		fetchErr <- ctx.Err()
+		return "42", nil
+	}
+
+	// the first caller gives up while the query is still running
+	ctx, cancel := context.WithCancel(context.TODO())
+	done := make(chan error, 1)
+	go func() {
+		_, err := cache.Get(ctx, "prometheus", "key", fetch)
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	// the query continues for the other callers
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+	}()
+	value, err := cache.Get(context.TODO(), "prometheus", "key", fetch)
+	require.Nil(t, err)
+	require.Equal(t, "42", value)
+	require.Nil(t, <-fetchErr)
+}
+
+func TestQueryCache_GetFetchTimeout(t *testing.T) {
+	cache := NewQueryCache(time.Minute, prometheus.NewRegistry(), logr.Discard())
+
+	_, err := cache.Get(context.TODO(), "prometheus", "key", func(ctx context.Context) (interface{}, error) {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		require.WithinDuration(t, time.Now().Add(queryCacheFetchTimeout), deadline, time.Second)
+		return "42", nil
+	})
+	require.Nil(t, err)
+}
+
+func TestCachedProvider(t *testing.T) {
+	mock := &fake.KeptnSLIProviderMock{
+		FetchAnalysisValueFunc: func(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+			return "1", nil
+		},
+		EvaluateQueryFunc: func(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+			return "2", []byte("raw"), nil
+		},
+		EvaluateQueryForStepFunc: func(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
+			return []string{"3", "4"}, []byte("raw-step"), nil
+		},
+	}
+	p := &cachedProvider{
+		KeptnSLIProvider: mock,
+		cache:            NewQueryCache(time.Minute, prometheus.NewRegistry(), logr.Discard()),
+	}
+	provider := metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-provider", Namespace: "default"},
+		Spec:       metricsapi.KeptnMetricsProviderSpec{Type: "dynatrace", TargetServer: "http://dynatrace"},
+	}
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	analysis := metricsapi.Analysis{
+		Status: metricsapi.AnalysisStatus{
+			Timeframe: metricsapi.Timeframe{
+				From: metav1.Time{Time: from},
+				To:   metav1.Time{Time: from.Add(time.Hour)},
+			},
+		},
+	}
+	otherAnalysis := *analysis.DeepCopy()
+	otherAnalysis.Status.Timeframe.To = metav1.Time{Time: from.Add(2 * time.Hour)}
+	metric := metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}
+	stepMetric := metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query", Range: &metricsapi.RangeSpec{Interval: "5m", Step: "1m"}}}
+
+	for i := 0; i < 2; i++ {
+		value, err := p.FetchAnalysisValue(context.TODO(), "my-query", analysis, &provider)
+		require.Nil(t, err)
+		require.Equal(t, "1", value)
+
+		value, raw, err := p.EvaluateQuery(context.TODO(), metric, provider)
+		require.Nil(t, err)
+		require.Equal(t, "2", value)
+		require.Equal(t, []byte("raw"), raw)
+
+		values, raw, err := p.EvaluateQueryForStep(context.TODO(), stepMetric, provider)
+		require.Nil(t, err)
+		require.Equal(t, []string{"3", "4"}, values)
+		require.Equal(t, []byte("raw-step"), raw)
+	}
+	require.Len(t, mock.FetchAnalysisValueCalls(), 1)
+	require.Len(t, mock.EvaluateQueryCalls(), 1)
+	require.Len(t, mock.EvaluateQueryForStepCalls(), 1)
+
+	// a different timeframe is not served from the cache
+	_, err := p.FetchAnalysisValue(context.TODO(), "my-query", otherAnalysis, &provider)
+	require.Nil(t, err)
+	require.Len(t, mock.FetchAnalysisValueCalls(), 2)
+
+	// a different range or step is not served from the cache
+	rangeMetric := *stepMetric.DeepCopy()
+	rangeMetric.Spec.Range.Interval = "10m"
+	_, _, err = p.EvaluateQuery(context.TODO(), rangeMetric, provider)
+	require.Nil(t, err)
+	require.Len(t, mock.EvaluateQueryCalls(), 2)
+
+	rangeMetric.Spec.Range.Step = "2m"
+	_, _, err = p.EvaluateQuery(context.TODO(), rangeMetric, provider)
+	require.Nil(t, err)
+	require.Len(t, mock.EvaluateQueryCalls(), 3)
+
+	_, _, err = p.EvaluateQuery(context.TODO(), rangeMetric, provider)
+	require.Nil(t, err)
+	require.Len(t, mock.EvaluateQueryCalls(), 3)
+}
+
+func TestNewCachedProvider(t *testing.T) {
+	p, err := NewCachedProvider(&metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{Type: PrometheusProviderType}}, logr.Discard(), nil)
+	require.Nil(t, err)
+	require.IsType(t, &cachedProvider{}, p)
+	require.Same(t, GetQueryCache(logr.Discard()), p.(*cachedProvider).cache)
+
+	_, err = NewCachedProvider(&metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{Type: "foo"}}, logr.Discard(), nil)
+	require.NotNil(t, err)
+}
+
+type seriesProviderMock struct {
+	*fake.KeptnSLIProviderMock
+	calls int
+}
+
+func (m *seriesProviderMock) FetchAnalysisSeries(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider, step time.Duration) ([]string, error) {
+	m.calls++
+	return []string{"1", "2", step.String()}, nil
+}
+
+func TestCachedProvider_FetchAnalysisSeries(t *testing.T) {
+	provider := &metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-provider", Namespace: "default"},
+		Spec:       metricsapi.KeptnMetricsProviderSpec{Type: "prometheus", TargetServer: "http://prometheus"},
+	}
+	analysis := metricsapi.Analysis{}
+	mock := &seriesProviderMock{KeptnSLIProviderMock: &fake.KeptnSLIProviderMock{}}
+	p := &cachedProvider{
+		KeptnSLIProvider: &resilientProvider{KeptnSLIProvider: mock, log: logr.Discard()},
+		cache:            NewQueryCache(time.Minute, prometheus.NewRegistry(), logr.Discard()),
+	}
+
+	for i := 0; i < 2; i++ {
+		values, err := FetchAnalysisSeries(context.TODO(), p, "my-query", analysis, provider, time.Minute)
+		require.Nil(t, err)
+		require.Equal(t, []string{"1", "2", "1m0s"}, values)
+	}
+	require.Equal(t, 1, mock.calls)
+
+	// a different step is not served from the cache
+	values, err := FetchAnalysisSeries(context.TODO(), p, "my-query", analysis, provider, 30*time.Second)
+	require.Nil(t, err)
+	require.Equal(t, []string{"1", "2", "30s"}, values)
+	require.Equal(t, 2, mock.calls)
+
+	// providers which do not support series return an error
+	p.KeptnSLIProvider = &fake.KeptnSLIProviderMock{}
+	_, err = FetchAnalysisSeries(context.TODO(), p, "other-query", analysis, provider, time.Minute)
+	require.ErrorIs(t, err, ErrSeriesNotSupported)
+}
+
+func TestCachedProvider_EvaluateQueryResultsNotSupported(t *testing.T) {
+	provider := metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "results-test",
+			Namespace: "default",
+		},
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			Type: "fake",
+		},
+	}
+	r := &resilientProvider{KeptnSLIProvider: &fake.KeptnSLIProviderMock{}, log: logr.Discard()}
+	c := &cachedProvider{KeptnSLIProvider: r, cache: NewQueryCache(time.Minute, prometheus.NewRegistry(), logr.Discard())}
+
+	require.False(t, supportsQueryResults(c))
+
+	_, _, err := EvaluateQueryResults(context.TODO(), c, metricsapi.KeptnMetric{}, provider)
+	require.ErrorIs(t, err, ErrResultsNotSupported)
+}
@@ -1,5 +1,12 @@
 package providers
 
+import (
+	"strings"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
 const DynatraceProviderType = "dynatrace"
 const DynatraceDQLProviderType = "dql"
 const PrometheusProviderType = "prometheus"
@@ -7,6 +14,11 @@ const ThanosProviderType = "thanos"
 const CortexProviderType = "cortex"
 const DataDogProviderType = "datadog"
 const ElasticProviderType = "elastic"
+const HTTPProviderType = "http"
+const LokiProviderType = "loki"
+const TempoProviderType = "tempo"
+const InfluxDBProviderType = "influxdb"
+const PluginProviderType = "plugin"
 
 var SupportedProviders = []string{
 	DynatraceProviderType,
@@ -16,4 +28,27 @@ var SupportedProviders = []string{
 	CortexProviderType,
 	ThanosProviderType,
 	ElasticProviderType,
+	HTTPProviderType,
+	LokiProviderType,
+	TempoProviderType,
+	InfluxDBProviderType,
+	PluginProviderType,
+}
+
+// IsProviderSupported returns whether the type of the KeptnMetricsProvider is one of the SupportedProviders
+func IsProviderSupported(provider *metricsapi.KeptnMetricsProvider) bool {
+	providerType := strings.ToLower(provider.Spec.Type)
+	for _, p := range SupportedProviders {
+		if p == providerType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProviderKey returns the key under which the KeptnMetricsProvider is registered.
+// Each KeptnMetricsProvider is registered on its own, as providers of the same type
+// may differ in their target server, credentials or plugin endpoint.
+func GetProviderKey(provider *metricsapi.KeptnMetricsProvider) string {
+	return types.NamespacedName{Namespace: provider.Namespace, Name: provider.Name}.String()
 }
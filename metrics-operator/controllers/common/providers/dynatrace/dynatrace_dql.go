@@ -14,6 +14,7 @@ import (
 	"github.com/go-logr/logr"
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
 	dtclient "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/dynatrace/client"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/resilience"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -275,11 +276,11 @@ func (d *keptnDynatraceDQLProvider) ensureDTClientIsSetUp(ctx context.Context, p
 		}
 		d.dtClient = dtclient.NewAPIClient(*config, dtclient.WithLogger(d.log), dtclient.WithHTTPClient(
 			http.Client{
-				Transport: &http.Transport{
+				Transport: resilience.NewRetryTransport(&http.Transport{
 					TLSClientConfig: &tls.Config{
 						InsecureSkipVerify: d.insecureSkipTlsVerify,
 					},
-				},
+				}),
 			},
 		))
 	}
@@ -12,6 +12,7 @@ import (
 	elastic "github.com/elastic/go-elasticsearch/v8"
 	"github.com/go-logr/logr"
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/resilience"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -30,11 +31,11 @@ func GetElasticClient(provider metricsapi.KeptnMetricsProvider) (*elastic.Client
 	es, err := elastic.NewClient(elastic.Config{
 		Addresses: []string{provider.Spec.TargetServer},
 		APIKey:    provider.Spec.SecretKeyRef.Key,
-		Transport: &http.Transport{
+		Transport: resilience.NewRetryTransport(&http.Transport{
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: provider.Spec.InsecureSkipTlsVerify,
 			},
-		},
+		}),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Elasticsearch client: %w", err)
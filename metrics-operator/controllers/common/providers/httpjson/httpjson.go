@@ -0,0 +1,256 @@
+package httpjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultURLTemplate = "{{.TargetServer}}?query={{urlquery .Query}}&from={{.From.Unix}}&to={{.To.Unix}}{{if .Step}}&step={{.Step.Seconds}}{{end}}"
+const defaultAuthHeader = "Authorization"
+
+var ErrHTTPSpecMissing = errors.New("the http property of the KeptnMetricsProvider is missing")
+var ErrSecretKeyNotDefined = errors.New("the key of the SecretKeyRef property is missing")
+var errNoValues = errors.New("no values in query result")
+
+type KeptnHTTPProvider struct {
+	Log        logr.Logger
+	HttpClient http.Client
+	K8sClient  client.Client
+}
+
+// urlTemplateData contains the values the URLTemplate of a provider can access
+type urlTemplateData struct {
+	TargetServer string
+	Query        string
+	From         time.Time
+	To           time.Time
+	Step         time.Duration
+}
+
+func (h *KeptnHTTPProvider) FetchAnalysisValue(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	res, _, err := h.query(ctx, *provider, urlTemplateData{
+		Query: query,
+		From:  analysis.GetFrom(),
+		To:    analysis.GetTo(),
+	})
+	return res, err
+}
+
+// EvaluateQuery fetches the SLI values from an HTTP endpoint returning JSON
+func (h *KeptnHTTPProvider) EvaluateQuery(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	interval := "5m"
+	if metric.Spec.Range != nil && metric.Spec.Range.Interval != "" {
+		interval = metric.Spec.Range.Interval
+	}
+	intervalDuration, err := time.ParseDuration(interval)
+	if err != nil {
+		return "", nil, err
+	}
+	now := time.Now()
+	return h.query(ctx, provider, urlTemplateData{
+		Query: metric.Spec.Query,
+		From:  now.Add(-intervalDuration),
+		To:    now,
+	})
+}
+
+// EvaluateQueryForStep fetches the SLI values within the range of the metric from an HTTP endpoint returning JSON
+func (h *KeptnHTTPProvider) EvaluateQueryForStep(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	if provider.Spec.HTTP == nil {
+		return nil, nil, ErrHTTPSpecMissing
+	}
+	intervalDuration, err := time.ParseDuration(metric.Spec.Range.Interval)
+	if err != nil {
+		return nil, nil, err
+	}
+	stepDuration, err := time.ParseDuration(metric.Spec.Range.Step)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	b, err := h.executeQuery(ctx, provider, urlTemplateData{
+		Query: metric.Spec.Query,
+		From:  now.Add(-intervalDuration),
+		To:    now,
+		Step:  stepDuration,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seriesPath := provider.Spec.HTTP.SeriesPath
+	if seriesPath == "" {
+		seriesPath = provider.Spec.HTTP.ValuePath
+	}
+	values, err := evaluateJSONPath(b, seriesPath)
+	if err != nil {
+		return nil, b, err
+	}
+	return values, b, nil
+}
+
+func (h *KeptnHTTPProvider) query(ctx context.Context, provider metricsapi.KeptnMetricsProvider, data urlTemplateData) (string, []byte, error) {
+	if provider.Spec.HTTP == nil {
+		return "", nil, ErrHTTPSpecMissing
+	}
+	b, err := h.executeQuery(ctx, provider, data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	values, err := evaluateJSONPath(b, provider.Spec.HTTP.ValuePath)
+	if err != nil {
+		return "", b, err
+	}
+	if len(values) > 1 {
+		return "", b, fmt.Errorf("%s yields %d values instead of a single one", provider.Spec.HTTP.ValuePath, len(values))
+	}
+	return values[0], b, nil
+}
+
+func (h *KeptnHTTPProvider) executeQuery(ctx context.Context, provider metricsapi.KeptnMetricsProvider, data urlTemplateData) ([]byte, error) {
+	data.TargetServer = provider.Spec.TargetServer
+	qURL, err := renderURL(provider.Spec.HTTP.URLTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, qURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if provider.HasSecretDefined() {
+		authValue, err := getAuthSecret(ctx, provider, h.K8sClient)
+		if err != nil {
+			return nil, err
+		}
+		authHeader := provider.Spec.HTTP.AuthHeader
+		if authHeader == "" {
+			authHeader = defaultAuthHeader
+		}
+		req.Header.Set(authHeader, authValue)
+	}
+
+	res, err := h.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			h.Log.Error(err, "could not close request body")
+		}
+	}()
+
+	b, err := prometheus.ReadResponseBody(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("request to %s failed with status code %d: %s", provider.Spec.TargetServer, res.StatusCode, prometheus.TruncateBody(b))
+	}
+	return b, nil
+}
+
+func renderURL(urlTemplate string, data urlTemplateData) (string, error) {
+	if urlTemplate == "" {
+		urlTemplate = defaultURLTemplate
+	}
+	tmpl, err := template.New("url").Parse(urlTemplate)
+	if err != nil {
+		return "", fmt.Errorf("could not parse URL template: %w", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("could not render URL template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// evaluateJSONPath returns the numeric values the JSONPath expression yields for the given JSON document
+func evaluateJSONPath(body []byte, path string) ([]string, error) {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("response is no valid JSON: %w", err)
+	}
+
+	jp := jsonpath.New("value")
+	if err := jp.Parse(path); err != nil {
+		return nil, fmt.Errorf("could not parse JSONPath %s: %w", path, err)
+	}
+	results, err := jp.FindResults(data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []string{}
+	for _, result := range results {
+		for _, value := range result {
+			v, err := formatValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s yields a non-numeric value: %w", path, err)
+			}
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return nil, errNoValues
+	}
+	return values, nil
+}
+
+func formatValue(value reflect.Value) (string, error) {
+	switch v := value.Interface().(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case string:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return "", err
+		}
+		return v, nil
+	default:
+		return "", fmt.Errorf("unexpected value %v", v)
+	}
+}
+
+func getAuthSecret(ctx context.Context, provider metricsapi.KeptnMetricsProvider, k8sClient client.Client) (string, error) {
+	if !provider.HasSecretKeyDefined() {
+		return "", ErrSecretKeyNotDefined
+	}
+	secret := &corev1.Secret{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: provider.Spec.SecretKeyRef.Name, Namespace: provider.Namespace}, secret); err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[provider.Spec.SecretKeyRef.Key]
+	if !ok || len(value) == 0 {
+		return "", fmt.Errorf("secret %s does not contain %s", provider.Spec.SecretKeyRef.Name, provider.Spec.SecretKeyRef.Key)
+	}
+	return string(value), nil
+}
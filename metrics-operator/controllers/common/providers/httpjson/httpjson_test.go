@@ -0,0 +1,280 @@
+package httpjson
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/fake"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/prometheus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const valuePayload = `{"data": {"name": "error-rate", "value": 0.25}}`
+const seriesPayload = `{"data": {"points": [{"ts": 1, "value": 1.5}, {"ts": 2, "value": "2"}, {"ts": 3, "value": 3}]}}`
+
+func TestEvaluateQuery(t *testing.T) {
+	var requestURL string
+	var authHeader string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURL = r.URL.String()
+		authHeader = r.Header.Get("X-Api-Key")
+		_, err := w.Write([]byte(valuePayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"token": []byte("my-token"),
+		},
+	}
+	kh := setupTest(secret)
+
+	provider := metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			Type:         "http",
+			TargetServer: svr.URL,
+			SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+				Key:                  "token",
+			},
+			HTTP: &metricsapi.HTTPProviderSpec{
+				URLTemplate: "{{.TargetServer}}/sli/{{.Query}}?from={{.From.Unix}}&to={{.To.Unix}}",
+				AuthHeader:  "X-Api-Key",
+				ValuePath:   "{.data.value}",
+			},
+		},
+	}
+	metric := metricsapi.KeptnMetric{
+		Spec: metricsapi.KeptnMetricSpec{
+			Query: "error-rate",
+			Range: &metricsapi.RangeSpec{Interval: "10m"},
+		},
+	}
+
+	value, raw, err := kh.EvaluateQuery(context.TODO(), metric, provider)
+	require.Nil(t, err)
+	require.Equal(t, "0.25", value)
+	require.Equal(t, []byte(valuePayload), raw)
+	require.Equal(t, "my-token", authHeader)
+	require.Contains(t, requestURL, "/sli/error-rate?from=")
+}
+
+func TestEvaluateQuery_DefaultURLTemplate(t *testing.T) {
+	var query string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("query")
+		require.NotEmpty(t, r.URL.Query().Get("from"))
+		require.NotEmpty(t, r.URL.Query().Get("to"))
+		require.Empty(t, r.URL.Query().Get("step"))
+		_, err := w.Write([]byte(valuePayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	kh := setupTest()
+	provider := makeProvider(svr.URL, "{.data.value}", "")
+
+	value, _, err := kh.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "rate{code=\"500\"}"}}, provider)
+	require.Nil(t, err)
+	require.Equal(t, "0.25", value)
+	require.Equal(t, "rate{code=\"500\"}", query)
+}
+
+func TestEvaluateQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		statusCode int
+		valuePath  string
+		noHTTPSpec bool
+		wantErr    string
+	}{
+		{
+			name:       "missing http spec",
+			noHTTPSpec: true,
+			wantErr:    ErrHTTPSpecMissing.Error(),
+		},
+		{
+			name:       "error status code",
+			payload:    `{"error": "not found"}`,
+			statusCode: http.StatusNotFound,
+			valuePath:  "{.data.value}",
+			wantErr:    "failed with status code 404",
+		},
+		{
+			name:       "error status code with long body",
+			payload:    `{"error": "` + strings.Repeat("e", 1000) + `"}`,
+			statusCode: http.StatusInternalServerError,
+			valuePath:  "{.data.value}",
+			wantErr:    `failed with status code 500: {"error": "` + strings.Repeat("e", 501) + "...",
+		},
+		{
+			name:       "response too large",
+			payload:    strings.Repeat(" ", prometheus.MaxResponseSize+1),
+			statusCode: http.StatusOK,
+			valuePath:  "{.data.value}",
+			wantErr:    prometheus.ErrResponseTooLarge.Error(),
+		},
+		{
+			name:       "invalid JSON",
+			payload:    `not json`,
+			statusCode: http.StatusOK,
+			valuePath:  "{.data.value}",
+			wantErr:    "response is no valid JSON",
+		},
+		{
+			name:       "value not found",
+			payload:    valuePayload,
+			statusCode: http.StatusOK,
+			valuePath:  "{.data.other}",
+			wantErr:    "is not found",
+		},
+		{
+			name:       "non-numeric value",
+			payload:    valuePayload,
+			statusCode: http.StatusOK,
+			valuePath:  "{.data.name}",
+			wantErr:    "yields a non-numeric value",
+		},
+		{
+			name:       "multiple values",
+			payload:    seriesPayload,
+			statusCode: http.StatusOK,
+			valuePath:  "{.data.points[*].value}",
+			wantErr:    "yields 3 values",
+		},
+		{
+			name:       "invalid JSONPath",
+			payload:    valuePayload,
+			statusCode: http.StatusOK,
+			valuePath:  "{.data[",
+			wantErr:    "could not parse JSONPath",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, err := w.Write([]byte(tt.payload))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			kh := setupTest()
+			provider := makeProvider(svr.URL, tt.valuePath, "")
+			if tt.noHTTPSpec {
+				provider.Spec.HTTP = nil
+			}
+
+			value, _, err := kh.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}, provider)
+			require.ErrorContains(t, err, tt.wantErr)
+			require.Empty(t, value)
+		})
+	}
+}
+
+func TestEvaluateQuery_MissingSecretKey(t *testing.T) {
+	kh := setupTest()
+	provider := makeProvider("http://localhost", "{.data.value}", "")
+	provider.Spec.SecretKeyRef = corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+	}
+
+	_, _, err := kh.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}, provider)
+	require.ErrorIs(t, err, ErrSecretKeyNotDefined)
+}
+
+func TestEvaluateQueryForStep(t *testing.T) {
+	var step string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		step = r.URL.Query().Get("step")
+		_, err := w.Write([]byte(seriesPayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	kh := setupTest()
+	provider := makeProvider(svr.URL, "{.data.value}", "{.data.points[*].value}")
+	metric := metricsapi.KeptnMetric{
+		Spec: metricsapi.KeptnMetricSpec{
+			Query: "my-query",
+			Range: &metricsapi.RangeSpec{
+				Interval: "5m",
+				Step:     "1m",
+			},
+		},
+	}
+
+	values, raw, err := kh.EvaluateQueryForStep(context.TODO(), metric, provider)
+	require.Nil(t, err)
+	require.Equal(t, []string{"1.5", "2", "3"}, values)
+	require.Equal(t, []byte(seriesPayload), raw)
+	require.Equal(t, "60", step)
+}
+
+func TestFetchAnalysisValue(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	var gotFrom, gotTo string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotFrom = r.URL.Query().Get("from")
+		gotTo = r.URL.Query().Get("to")
+		_, err := w.Write([]byte(valuePayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	kh := setupTest()
+	provider := makeProvider(svr.URL, "{.data.value}", "")
+	provider.Spec.HTTP.URLTemplate = `{{.TargetServer}}?query={{urlquery .Query}}&from={{.From.Format "2006-01-02T15:04:05Z07:00"}}&to={{.To.Format "2006-01-02T15:04:05Z07:00"}}`
+	analysis := metricsapi.Analysis{
+		Status: metricsapi.AnalysisStatus{
+			Timeframe: metricsapi.Timeframe{
+				From: metav1.Time{Time: from},
+				To:   metav1.Time{Time: to},
+			},
+		},
+	}
+
+	value, err := kh.FetchAnalysisValue(context.TODO(), "my-query", analysis, &provider)
+	require.Nil(t, err)
+	require.Equal(t, "0.25", value)
+	require.Equal(t, "2023-01-01T00:00:00Z", gotFrom)
+	require.Equal(t, "2023-01-01T01:00:00Z", gotTo)
+}
+
+func makeProvider(targetServer string, valuePath string, seriesPath string) metricsapi.KeptnMetricsProvider {
+	return metricsapi.KeptnMetricsProvider{
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			Type:         "http",
+			TargetServer: targetServer,
+			HTTP: &metricsapi.HTTPProviderSpec{
+				ValuePath:  valuePath,
+				SeriesPath: seriesPath,
+			},
+		},
+	}
+}
+
+func setupTest(objs ...client.Object) KeptnHTTPProvider {
+	return KeptnHTTPProvider{
+		HttpClient: http.Client{},
+		Log:        ctrl.Log.WithName("testytest"),
+		K8sClient:  fake.NewClient(objs...),
+	}
+}
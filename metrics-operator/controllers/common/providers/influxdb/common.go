@@ -0,0 +1,77 @@
+package influxdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/resilience"
+	promapi "github.com/prometheus/client_golang/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const secretKeyToken = "token"
+const secretKeyOrg = "org"
+
+var ErrSecretKeyRefNotDefined = errors.New("the SecretKeyRef property with the InfluxDB API token is missing")
+var ErrInvalidSecretFormat = errors.New("secret key does not contain token and org")
+
+type SecretData struct {
+	Token string
+	Org   string
+}
+
+// RoundTripperRetriever provides the round tripper for requests to the InfluxDB API,
+// which authenticates the requests with the token and organization of the secret of the provider
+type RoundTripperRetriever struct {
+	// Transport is the round tripper the requests are sent with, promapi.DefaultRoundTripper if not set
+	Transport http.RoundTripper
+}
+
+func (r RoundTripperRetriever) GetRoundTripper(ctx context.Context, provider metricsapi.KeptnMetricsProvider, k8sClient client.Client) (http.RoundTripper, error) {
+	secret, err := getInfluxDBSecret(ctx, provider, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	transport := r.Transport
+	if transport == nil {
+		transport = promapi.DefaultRoundTripper
+	}
+	return resilience.NewRetryTransport(&tokenRoundTripper{secret: *secret, next: transport}), nil
+}
+
+// tokenRoundTripper sets the token of the secret in the Authorization header of the request,
+// and the organization of the secret as 'org' query parameter
+type tokenRoundTripper struct {
+	secret SecretData
+	next   http.RoundTripper
+}
+
+func (t *tokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Token "+t.secret.Token)
+	params := req.URL.Query()
+	params.Set("org", t.secret.Org)
+	req.URL.RawQuery = params.Encode()
+	return t.next.RoundTrip(req)
+}
+
+func getInfluxDBSecret(ctx context.Context, provider metricsapi.KeptnMetricsProvider, k8sClient client.Client) (*SecretData, error) {
+	if !provider.HasSecretDefined() {
+		return nil, ErrSecretKeyRefNotDefined
+	}
+	secret := &corev1.Secret{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: provider.Spec.SecretKeyRef.Name, Namespace: provider.Namespace}, secret); err != nil {
+		return nil, err
+	}
+
+	token, ok := secret.Data[secretKeyToken]
+	org, yes := secret.Data[secretKeyOrg]
+	if !ok || !yes {
+		return nil, ErrInvalidSecretFormat
+	}
+	return &SecretData{Token: string(token), Org: string(org)}, nil
+}
@@ -0,0 +1,191 @@
+package influxdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const queryPath = "/api/v2/query"
+const defaultInterval = 5 * time.Minute
+
+var errNoValues = errors.New("no values in query result")
+var errTooManyValues = errors.New("too many values in query result")
+
+type KeptnInfluxDBProvider struct {
+	Log       logr.Logger
+	K8sClient client.Client
+	Getter    prometheus.IRoundTripper
+}
+
+func NewInfluxDBProvider(log logr.Logger, k8sClient client.Client, insecureSkipTlsVerify bool) *KeptnInfluxDBProvider {
+	return &KeptnInfluxDBProvider{
+		Log:       log,
+		K8sClient: k8sClient,
+		Getter: RoundTripperRetriever{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: insecureSkipTlsVerify,
+				},
+			},
+		},
+	}
+}
+
+// FetchAnalysisValue evaluates the Flux query over the timeframe of the Analysis
+func (i *KeptnInfluxDBProvider) FetchAnalysisValue(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	from, to := analysis.GetFrom(), analysis.GetTo()
+	res, _, err := i.querySingleValue(ctx, query, *provider, from, to)
+	return res, err
+}
+
+// EvaluateQuery fetches the SLI values from InfluxDB provider
+func (i *KeptnInfluxDBProvider) EvaluateQuery(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	interval := defaultInterval
+	if metric.Spec.Range != nil && metric.Spec.Range.Interval != "" {
+		var err error
+		interval, err = time.ParseDuration(metric.Spec.Range.Interval)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	queryTime := time.Now().UTC()
+	return i.querySingleValue(ctx, metric.Spec.Query, provider, queryTime.Add(-interval), queryTime)
+}
+
+// EvaluateQueryForStep fetches the SLI values within the range of the metric from InfluxDB provider
+func (i *KeptnInfluxDBProvider) EvaluateQueryForStep(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	interval, err := time.ParseDuration(metric.Spec.Range.Interval)
+	if err != nil {
+		return nil, nil, err
+	}
+	step, err := time.ParseDuration(metric.Spec.Range.Step)
+	if err != nil {
+		return nil, nil, err
+	}
+	queryTime := time.Now().UTC()
+	values, _, err := i.query(ctx, metric.Spec.Query, provider, queryTime.Add(-interval), queryTime, step)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, nil, err
+	}
+	return values, b, nil
+}
+
+func (i *KeptnInfluxDBProvider) querySingleValue(ctx context.Context, query string, provider metricsapi.KeptnMetricsProvider, start time.Time, stop time.Time) (string, []byte, error) {
+	values, b, err := i.query(ctx, query, provider, start, stop, stop.Sub(start))
+	if err != nil {
+		return "", b, err
+	}
+	if len(values) > 1 {
+		return "", b, errTooManyValues
+	}
+	return values[0], b, nil
+}
+
+// query executes the Flux query with the time range and window period made available in the 'v' option,
+// so that it can use v.timeRangeStart, v.timeRangeStop and v.windowPeriod
+func (i *KeptnInfluxDBProvider) query(ctx context.Context, query string, provider metricsapi.KeptnMetricsProvider, start time.Time, stop time.Time, windowPeriod time.Duration) ([]string, []byte, error) {
+	fluxQuery := fmt.Sprintf(
+		"option v = {timeRangeStart: %s, timeRangeStop: %s, windowPeriod: %dms}\n%s",
+		start.UTC().Format(time.RFC3339Nano),
+		stop.UTC().Format(time.RFC3339Nano),
+		windowPeriod.Milliseconds(),
+		query,
+	)
+	i.Log.Info(fmt.Sprintf("Running query: %s", fluxQuery))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.Spec.TargetServer+queryPath, strings.NewReader(fluxQuery))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.flux")
+	req.Header.Set("Accept", "application/csv")
+
+	b, err := prometheus.SendToAPI(i.Log, i.Getter, i.K8sClient, provider, req)
+	if err != nil {
+		return nil, b, err
+	}
+
+	values, err := parseValues(b)
+	if err != nil {
+		return nil, b, err
+	}
+	return values, b, nil
+}
+
+// parseValues returns the values of the '_value' column of the single table contained in the annotated CSV response
+func parseValues(body []byte) ([]string, error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse query result: %w", err)
+	}
+
+	var header map[string]int
+	var table string
+	values := []string{}
+	for _, record := range records {
+		if isHeader(record) {
+			header = make(map[string]int, len(record))
+			for idx, column := range record {
+				header[column] = idx
+			}
+			continue
+		}
+		if header == nil {
+			continue
+		}
+		if idx, ok := header["error"]; ok && idx < len(record) {
+			return nil, fmt.Errorf("influxdb query failed: %s", record[idx])
+		}
+		valueIdx, ok := header["_value"]
+		if !ok || valueIdx >= len(record) {
+			continue
+		}
+		if tableIdx, ok := header["table"]; ok && tableIdx < len(record) {
+			if table != "" && table != record[tableIdx] {
+				return nil, errTooManyValues
+			}
+			table = record[tableIdx]
+		}
+		values = append(values, record[valueIdx])
+	}
+
+	if len(values) == 0 {
+		return nil, errNoValues
+	}
+	return values, nil
+}
+
+// isHeader returns whether the record is the header of a table, which starts with an empty annotation column
+// followed by the 'result' column, or the 'error' column if the query failed
+func isHeader(record []string) bool {
+	return len(record) > 1 && record[0] == "" && (record[1] == "result" || record[1] == "error")
+}
@@ -0,0 +1,245 @@
+package influxdb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const singleValuePayload = `#group,false,false,true,true,false
+#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,double
+#default,_result,,,,
+,result,table,_start,_stop,_value
+,,0,2023-01-01T00:00:00Z,2023-01-01T01:00:00Z,42.5
+
+`
+
+const seriesPayload = `#group,false,false,false,false
+#datatype,string,long,dateTime:RFC3339,double
+#default,_result,,,
+,result,table,_time,_value
+,,0,2023-01-01T00:01:00Z,1
+,,0,2023-01-01T00:02:00Z,2
+,,0,2023-01-01T00:03:00Z,3.5
+
+`
+
+const multipleTablesPayload = `#group,false,false,true,false
+#datatype,string,long,string,double
+#default,_result,,,
+,result,table,host,_value
+,,0,a,1
+,,1,b,2
+
+`
+
+const errorPayload = `#datatype,string,string
+#group,true,true
+#default,,
+,error,reference
+,failed to execute query: undefined identifier foo,
+
+`
+
+func TestEvaluateQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "single value",
+			payload: singleValuePayload,
+			want:    "42.5",
+		},
+		{
+			name:    "too many values",
+			payload: seriesPayload,
+			wantErr: errTooManyValues.Error(),
+		},
+		{
+			name:    "multiple tables",
+			payload: multipleTablesPayload,
+			wantErr: errTooManyValues.Error(),
+		},
+		{
+			name:    "no values",
+			payload: "",
+			wantErr: errNoValues.Error(),
+		},
+		{
+			name:    "query error",
+			payload: errorPayload,
+			wantErr: "undefined identifier foo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, queryPath, r.URL.Path)
+				require.Equal(t, "my-org", r.URL.Query().Get("org"))
+				require.Equal(t, "Token my-token", r.Header.Get("Authorization"))
+				body, err := io.ReadAll(r.Body)
+				require.Nil(t, err)
+				require.Contains(t, string(body), "option v = {timeRangeStart: ")
+				require.Contains(t, string(body), "windowPeriod: 600000ms}")
+				require.Contains(t, string(body), "from(bucket: \"my-bucket\")")
+				_, err = w.Write([]byte(tt.payload))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			provider := setupTest(makeSecret())
+			metric := metricsapi.KeptnMetric{
+				Spec: metricsapi.KeptnMetricSpec{
+					Query: "from(bucket: \"my-bucket\") |> range(start: v.timeRangeStart, stop: v.timeRangeStop) |> mean()",
+					Range: &metricsapi.RangeSpec{Interval: "10m"},
+				},
+			}
+
+			value, _, err := provider.EvaluateQuery(context.TODO(), metric, makeProvider(svr.URL))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestEvaluateQuery_StatusCode(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, err := w.Write([]byte(`{"code":"unauthorized","message":"unauthorized access"}`))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	provider := setupTest(makeSecret())
+
+	_, _, err := provider.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}, makeProvider(svr.URL))
+	require.ErrorContains(t, err, "status code 401")
+}
+
+func TestEvaluateQuery_Secret(t *testing.T) {
+	provider := setupTest(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "influxdb-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			secretKeyToken: []byte("my-token"),
+		},
+	})
+
+	_, _, err := provider.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}, makeProvider("http://localhost"))
+	require.ErrorIs(t, err, ErrInvalidSecretFormat)
+
+	p := makeProvider("http://localhost")
+	p.Spec.SecretKeyRef = corev1.SecretKeySelector{}
+	_, _, err = provider.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}, p)
+	require.ErrorIs(t, err, ErrSecretKeyRefNotDefined)
+}
+
+func TestEvaluateQueryForStep(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		require.Contains(t, string(body), "windowPeriod: 60000ms}")
+		_, err = w.Write([]byte(seriesPayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	provider := setupTest(makeSecret())
+	metric := metricsapi.KeptnMetric{
+		Spec: metricsapi.KeptnMetricSpec{
+			Query: "my-query",
+			Range: &metricsapi.RangeSpec{
+				Interval: "5m",
+				Step:     "1m",
+			},
+		},
+	}
+
+	values, raw, err := provider.EvaluateQueryForStep(context.TODO(), metric, makeProvider(svr.URL))
+	require.Nil(t, err)
+	require.Equal(t, []string{"1", "2", "3.5"}, values)
+	require.Equal(t, []byte(`["1","2","3.5"]`), raw)
+}
+
+func TestFetchAnalysisValue(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		require.Contains(t, string(body), "option v = {timeRangeStart: 2023-01-01T00:00:00Z, timeRangeStop: 2023-01-01T01:00:00Z, windowPeriod: 3600000ms}")
+		_, err = w.Write([]byte(singleValuePayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	provider := setupTest(makeSecret())
+	p := makeProvider(svr.URL)
+	analysis := metricsapi.Analysis{
+		Status: metricsapi.AnalysisStatus{
+			Timeframe: metricsapi.Timeframe{
+				From: metav1.Time{Time: from},
+				To:   metav1.Time{Time: to},
+			},
+		},
+	}
+
+	value, err := provider.FetchAnalysisValue(context.TODO(), "my-query", analysis, &p)
+	require.Nil(t, err)
+	require.Equal(t, "42.5", value)
+}
+
+func makeSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "influxdb-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			secretKeyToken: []byte("my-token"),
+			secretKeyOrg:   []byte("my-org"),
+		},
+	}
+}
+
+func makeProvider(targetServer string) metricsapi.KeptnMetricsProvider {
+	return metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			Type:         "influxdb",
+			TargetServer: targetServer,
+			SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "influxdb-secret"},
+			},
+		},
+	}
+}
+
+func setupTest(objs ...client.Object) KeptnInfluxDBProvider {
+	return KeptnInfluxDBProvider{
+		Log:       ctrl.Log.WithName("testytest"),
+		K8sClient: fake.NewClient(objs...),
+		Getter:    RoundTripperRetriever{},
+	}
+}
@@ -0,0 +1,196 @@
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/prometheus"
+	"github.com/prometheus/common/model"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	queryPath      = "/loki/api/v1/query"
+	queryRangePath = "/loki/api/v1/query_range"
+)
+
+var errNoValues = errors.New("no values in query result")
+var errTooManyValues = errors.New("too many values in query result")
+var errNoMetricQuery = errors.New("query does not return a metric result, only LogQL metric queries are supported")
+
+type KeptnLokiProvider struct {
+	Log       logr.Logger
+	K8sClient client.Client
+	Getter    prometheus.IRoundTripper
+}
+
+type lokiResponse struct {
+	Status string   `json:"status"`
+	Error  string   `json:"error,omitempty"`
+	Data   lokiData `json:"data"`
+}
+
+type lokiData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+func NewLokiProvider(log logr.Logger, k8sClient client.Client) *KeptnLokiProvider {
+	return &KeptnLokiProvider{
+		Log:       log,
+		K8sClient: k8sClient,
+		Getter:    prometheus.RoundTripperRetriever{},
+	}
+}
+
+// FetchAnalysisValue evaluates the LogQL metric query over the timeframe of the Analysis
+func (l *KeptnLokiProvider) FetchAnalysisValue(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	from, to := analysis.GetFrom(), analysis.GetTo()
+	matrix, _, err := l.queryRange(ctx, query, *provider, from, to, to.Sub(from))
+	if err != nil {
+		return "", err
+	}
+	values, err := getSeriesValues(matrix)
+	if err != nil {
+		return "", err
+	}
+	return values[len(values)-1], nil
+}
+
+// EvaluateQuery fetches the SLI values from Loki provider
+func (l *KeptnLokiProvider) EvaluateQuery(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	queryTime := time.Now().UTC()
+	if metric.Spec.Range == nil {
+		params := url.Values{}
+		params.Set("query", metric.Spec.Query)
+		params.Set("time", formatTime(queryTime))
+		res, b, err := l.get(ctx, provider, queryPath, params)
+		if err != nil {
+			return "", nil, err
+		}
+		if res.Data.ResultType != model.ValVector.String() {
+			return "", b, errNoMetricQuery
+		}
+		vector := model.Vector{}
+		if err := json.Unmarshal(res.Data.Result, &vector); err != nil {
+			return "", b, err
+		}
+		if len(vector) == 0 {
+			return "", b, errNoValues
+		} else if len(vector) > 1 {
+			return "", b, errTooManyValues
+		}
+		return vector[0].Value.String(), b, nil
+	}
+
+	interval, err := time.ParseDuration(metric.Spec.Range.Interval)
+	if err != nil {
+		return "", nil, err
+	}
+	matrix, b, err := l.queryRange(ctx, metric.Spec.Query, provider, queryTime.Add(-interval), queryTime, interval)
+	if err != nil {
+		return "", nil, err
+	}
+	values, err := getSeriesValues(matrix)
+	if err != nil {
+		return "", b, err
+	}
+	return values[len(values)-1], b, nil
+}
+
+// EvaluateQueryForStep fetches the SLI values within the range of the metric from Loki provider
+func (l *KeptnLokiProvider) EvaluateQueryForStep(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	interval, err := time.ParseDuration(metric.Spec.Range.Interval)
+	if err != nil {
+		return nil, nil, err
+	}
+	step, err := time.ParseDuration(metric.Spec.Range.Step)
+	if err != nil {
+		return nil, nil, err
+	}
+	queryTime := time.Now().UTC()
+	matrix, _, err := l.queryRange(ctx, metric.Spec.Query, provider, queryTime.Add(-interval), queryTime, step)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := getSeriesValues(matrix)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, nil, err
+	}
+	return values, b, nil
+}
+
+func (l *KeptnLokiProvider) queryRange(ctx context.Context, query string, provider metricsapi.KeptnMetricsProvider, start time.Time, end time.Time, step time.Duration) (model.Matrix, []byte, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", formatTime(start))
+	params.Set("end", formatTime(end))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+	res, b, err := l.get(ctx, provider, queryRangePath, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Data.ResultType != model.ValMatrix.String() {
+		return nil, b, errNoMetricQuery
+	}
+	matrix := model.Matrix{}
+	if err := json.Unmarshal(res.Data.Result, &matrix); err != nil {
+		return nil, b, err
+	}
+	return matrix, b, nil
+}
+
+func (l *KeptnLokiProvider) get(ctx context.Context, provider metricsapi.KeptnMetricsProvider, path string, params url.Values) (*lokiResponse, []byte, error) {
+	b, err := prometheus.GetFromAPI(ctx, l.Log, l.Getter, l.K8sClient, provider, path, params)
+	if err != nil {
+		return nil, b, err
+	}
+
+	result := &lokiResponse{}
+	if err := json.Unmarshal(b, result); err != nil {
+		return nil, b, err
+	}
+	if result.Status != "success" {
+		return nil, b, fmt.Errorf("loki API returned status %s: %s", result.Status, result.Error)
+	}
+	return result, b, nil
+}
+
+// getSeriesValues returns the values of the single series of a matrix
+func getSeriesValues(matrix model.Matrix) ([]string, error) {
+	if len(matrix) == 0 || len(matrix[0].Values) == 0 {
+		return nil, errNoValues
+	} else if len(matrix) > 1 {
+		return nil, errTooManyValues
+	}
+	values := make([]string, len(matrix[0].Values))
+	for i, value := range matrix[0].Values {
+		values[i] = value.Value.String()
+	}
+	return values, nil
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
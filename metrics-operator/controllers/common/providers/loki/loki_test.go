@@ -0,0 +1,195 @@
+package loki
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const vectorPayload = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"app":"podtato"},"value":[1700000000,"12.5"]}]}}`
+const matrixPayload = `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"app":"podtato"},"values":[[1700000000,"1"],[1700000060,"2"],[1700000120,"3"]]}]}}`
+const multipleSeriesPayload = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"app":"a"},"value":[1700000000,"1"]},{"metric":{"app":"b"},"value":[1700000000,"2"]}]}}`
+const streamsPayload = `{"status":"success","data":{"resultType":"streams","result":[{"stream":{"app":"podtato"},"values":[["1700000000000000000","a log line"]]}]}}`
+const emptyPayload = `{"status":"success","data":{"resultType":"vector","result":[]}}`
+const errorPayload = `{"status":"error","error":"parse error"}`
+
+func TestEvaluateQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		rangeSpc *metricsapi.RangeSpec
+		path     string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:    "instant query",
+			payload: vectorPayload,
+			path:    queryPath,
+			want:    "12.5",
+		},
+		{
+			name:     "range query returns last value",
+			payload:  matrixPayload,
+			rangeSpc: &metricsapi.RangeSpec{Interval: "5m"},
+			path:     queryRangePath,
+			want:     "3",
+		},
+		{
+			name:    "log query",
+			payload: streamsPayload,
+			path:    queryPath,
+			wantErr: errNoMetricQuery.Error(),
+		},
+		{
+			name:    "no values",
+			payload: emptyPayload,
+			path:    queryPath,
+			wantErr: errNoValues.Error(),
+		},
+		{
+			name:    "too many values",
+			payload: multipleSeriesPayload,
+			path:    queryPath,
+			wantErr: errTooManyValues.Error(),
+		},
+		{
+			name:    "error status",
+			payload: errorPayload,
+			path:    queryPath,
+			wantErr: "parse error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, tt.path, r.URL.Path)
+				require.Equal(t, `sum(rate({app="podtato"}[5m]))`, r.URL.Query().Get("query"))
+				_, err := w.Write([]byte(tt.payload))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			provider := NewLokiProvider(ctrl.Log.WithName("testytest"), fake.NewClient())
+			metric := metricsapi.KeptnMetric{
+				Spec: metricsapi.KeptnMetricSpec{
+					Query: `sum(rate({app="podtato"}[5m]))`,
+					Range: tt.rangeSpc,
+				},
+			}
+			p := metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{TargetServer: svr.URL}}
+
+			value, _, err := provider.EvaluateQuery(context.TODO(), metric, p)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestEvaluateQuery_StatusCode(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("bad request"))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	provider := NewLokiProvider(ctrl.Log.WithName("testytest"), fake.NewClient())
+	p := metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{TargetServer: svr.URL}}
+
+	_, _, err := provider.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}, p)
+	require.ErrorContains(t, err, "status code 400")
+}
+
+func TestEvaluateQueryForStep(t *testing.T) {
+	var step string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, queryRangePath, r.URL.Path)
+		step = r.URL.Query().Get("step")
+		_, err := w.Write([]byte(matrixPayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	provider := NewLokiProvider(ctrl.Log.WithName("testytest"), fake.NewClient())
+	metric := metricsapi.KeptnMetric{
+		Spec: metricsapi.KeptnMetricSpec{
+			Query: "my-query",
+			Range: &metricsapi.RangeSpec{
+				Interval: "5m",
+				Step:     "1m",
+			},
+		},
+	}
+	p := metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{TargetServer: svr.URL}}
+
+	values, raw, err := provider.EvaluateQueryForStep(context.TODO(), metric, p)
+	require.Nil(t, err)
+	require.Equal(t, []string{"1", "2", "3"}, values)
+	require.Equal(t, []byte(`["1","2","3"]`), raw)
+	require.Equal(t, "60", step)
+}
+
+func TestFetchAnalysisValueWithAuth(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoded := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:password"))
+		if r.Header.Get("Authorization") != encoded {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		require.Equal(t, formatTime(from), r.URL.Query().Get("start"))
+		require.Equal(t, formatTime(to), r.URL.Query().Get("end"))
+		_, err := w.Write([]byte(matrixPayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "loki-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"user":     []byte("user"),
+			"password": []byte("password"),
+		},
+	}
+	provider := NewLokiProvider(ctrl.Log.WithName("testytest"), fake.NewClient(secret))
+	p := &metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			TargetServer: svr.URL,
+			SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "loki-secret"},
+			},
+		},
+	}
+	analysis := metricsapi.Analysis{
+		Status: metricsapi.AnalysisStatus{
+			Timeframe: metricsapi.Timeframe{
+				From: metav1.Time{Time: from},
+				To:   metav1.Time{Time: to},
+			},
+		},
+	}
+
+	value, err := provider.FetchAnalysisValue(context.TODO(), "my-query", analysis, p)
+	require.Nil(t, err)
+	require.Equal(t, "3", value)
+}
@@ -0,0 +1,201 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var ErrPluginSpecMissing = errors.New("the plugin property of the KeptnMetricsProvider is missing")
+var ErrSecretKeyNotDefined = errors.New("the key of the SecretKeyRef property is missing")
+var ErrUntrustedEndpoint = errors.New("the secret of the KeptnMetricsProvider is only sent to plugins using https or marked as trusted")
+
+// RequestTimeout is the maximum duration of a request to a plugin
+const RequestTimeout = 20 * time.Second
+
+// KeptnPluginProvider delegates the evaluation of queries to an external plugin
+type KeptnPluginProvider struct {
+	Log        logr.Logger
+	HttpClient http.Client
+	K8sClient  client.Client
+}
+
+// FetchAnalysisValue lets the plugin evaluate the query over the timeframe of the Analysis
+func (p *KeptnPluginProvider) FetchAnalysisValue(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, RequestTimeout)
+	defer cancel()
+
+	info, err := p.getProviderInfo(ctx, *provider)
+	if err != nil {
+		return "", err
+	}
+	res := FetchAnalysisValueResponse{}
+	err = p.post(ctx, *provider, FetchAnalysisValuePath, FetchAnalysisValueRequest{
+		Provider: *info,
+		Query:    query,
+		From:     analysis.GetFrom(),
+		To:       analysis.GetTo(),
+		Args:     analysis.Spec.Args,
+	}, &res)
+	if err != nil {
+		return "", err
+	}
+	if res.Error != "" {
+		return "", errors.New(res.Error)
+	}
+	return res.Value, nil
+}
+
+// EvaluateQuery lets the plugin evaluate the query of the KeptnMetric
+func (p *KeptnPluginProvider) EvaluateQuery(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, RequestTimeout)
+	defer cancel()
+
+	req, err := p.newEvaluateQueryRequest(ctx, metric, provider)
+	if err != nil {
+		return "", nil, err
+	}
+	res := EvaluateQueryResponse{}
+	if err := p.post(ctx, provider, EvaluateQueryPath, req, &res); err != nil {
+		return "", nil, err
+	}
+	if res.Error != "" {
+		return "", res.Raw, errors.New(res.Error)
+	}
+	return res.Value, res.Raw, nil
+}
+
+// EvaluateQueryForStep lets the plugin evaluate the query of the KeptnMetric within its range
+func (p *KeptnPluginProvider) EvaluateQueryForStep(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, RequestTimeout)
+	defer cancel()
+
+	req, err := p.newEvaluateQueryRequest(ctx, metric, provider)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := EvaluateQueryForStepResponse{}
+	if err := p.post(ctx, provider, EvaluateQueryForStepPath, req, &res); err != nil {
+		return nil, nil, err
+	}
+	if res.Error != "" {
+		return nil, res.Raw, errors.New(res.Error)
+	}
+	return res.Values, res.Raw, nil
+}
+
+func (p *KeptnPluginProvider) newEvaluateQueryRequest(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (*EvaluateQueryRequest, error) {
+	info, err := p.getProviderInfo(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	req := &EvaluateQueryRequest{
+		Provider: *info,
+		Query:    metric.Spec.Query,
+	}
+	if metric.Spec.Range != nil {
+		req.Range = &Range{
+			Interval:    metric.Spec.Range.Interval,
+			Step:        metric.Spec.Range.Step,
+			Aggregation: metric.Spec.Range.Aggregation,
+		}
+	}
+	return req, nil
+}
+
+func (p *KeptnPluginProvider) post(ctx context.Context, provider metricsapi.KeptnMetricsProvider, path string, body interface{}, result interface{}) error {
+	if provider.Spec.Plugin == nil || provider.Spec.Plugin.Endpoint == "" {
+		return ErrPluginSpecMissing
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	pluginURL := strings.TrimSuffix(provider.Spec.Plugin.Endpoint, "/") + path
+	p.Log.Info("Calling provider plugin", "plugin", provider.Spec.Plugin.Name, "url", pluginURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pluginURL, bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			p.Log.Error(err, "could not close request body")
+		}
+	}()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("plugin %s returned status code %d: %s", pluginURL, res.StatusCode, string(resBody))
+	}
+	if err := json.Unmarshal(resBody, result); err != nil {
+		return fmt.Errorf("plugin %s returned an invalid response: %w", pluginURL, err)
+	}
+	return nil
+}
+
+// getProviderInfo collects the information about the KeptnMetricsProvider passed to the plugin,
+// including the value selected by its SecretKeyRef, which is only passed to trusted plugins
+func (p *KeptnPluginProvider) getProviderInfo(ctx context.Context, provider metricsapi.KeptnMetricsProvider) (*ProviderInfo, error) {
+	if provider.Spec.Plugin == nil || provider.Spec.Plugin.Endpoint == "" {
+		return nil, ErrPluginSpecMissing
+	}
+	info := &ProviderInfo{
+		Name:                  provider.Name,
+		Namespace:             provider.Namespace,
+		TargetServer:          provider.Spec.TargetServer,
+		InsecureSkipTlsVerify: provider.Spec.InsecureSkipTlsVerify,
+	}
+	if !provider.HasSecretDefined() {
+		return info, nil
+	}
+	if provider.Spec.SecretKeyRef.Key == "" {
+		return nil, ErrSecretKeyNotDefined
+	}
+	if !isTrusted(*provider.Spec.Plugin) {
+		return nil, fmt.Errorf("%w: %s", ErrUntrustedEndpoint, provider.Spec.Plugin.Endpoint)
+	}
+	secret := &corev1.Secret{}
+	if err := p.K8sClient.Get(ctx, types.NamespacedName{Name: provider.Spec.SecretKeyRef.Name, Namespace: provider.Namespace}, secret); err != nil {
+		return nil, err
+	}
+	value, ok := secret.Data[provider.Spec.SecretKeyRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s does not contain %s", provider.Spec.SecretKeyRef.Name, provider.Spec.SecretKeyRef.Key)
+	}
+	info.Secret = string(value)
+	return info, nil
+}
+
+// isTrusted reports whether secrets may be sent to the plugin
+func isTrusted(plugin metricsapi.PluginProviderSpec) bool {
+	if plugin.Trusted {
+		return true
+	}
+	endpoint, err := url.Parse(plugin.Endpoint)
+	return err == nil && endpoint.Scheme == "https"
+}
@@ -0,0 +1,301 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestEvaluateQuery(t *testing.T) {
+	var got EvaluateQueryRequest
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, EvaluateQueryPath, r.URL.Path)
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&got))
+		writeJSON(t, w, EvaluateQueryResponse{Value: "42", Raw: []byte(`{"value":42}`)})
+	}))
+	defer svr.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"token": []byte("my-token"),
+		},
+	}
+	p := setupTest(secret)
+	provider := makeProvider(svr.URL)
+	provider.Spec.Plugin.Trusted = true
+	provider.Spec.SecretKeyRef = corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+		Key:                  "token",
+	}
+	metric := metricsapi.KeptnMetric{
+		Spec: metricsapi.KeptnMetricSpec{
+			Query: "my-query",
+			Range: &metricsapi.RangeSpec{Interval: "10m", Aggregation: "avg"},
+		},
+	}
+
+	value, raw, err := p.EvaluateQuery(context.TODO(), metric, provider)
+	require.Nil(t, err)
+	require.Equal(t, "42", value)
+	require.Equal(t, []byte(`{"value":42}`), raw)
+	require.Equal(t, EvaluateQueryRequest{
+		Provider: ProviderInfo{
+			Name:         "my-provider",
+			Namespace:    "default",
+			TargetServer: "http://my-backend:9090",
+			Secret:       "my-token",
+		},
+		Query: "my-query",
+		Range: &Range{Interval: "10m", Aggregation: "avg"},
+	}, got)
+}
+
+func TestEvaluateQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		payload    string
+		noPlugin   bool
+		wantErr    string
+	}{
+		{
+			name:     "missing plugin spec",
+			noPlugin: true,
+			wantErr:  ErrPluginSpecMissing.Error(),
+		},
+		{
+			name:       "error status code",
+			statusCode: http.StatusInternalServerError,
+			payload:    "internal error",
+			wantErr:    "returned status code 500: internal error",
+		},
+		{
+			name:       "invalid response",
+			statusCode: http.StatusOK,
+			payload:    "not json",
+			wantErr:    "returned an invalid response",
+		},
+		{
+			name:       "error in response",
+			statusCode: http.StatusOK,
+			payload:    `{"error": "unknown metric my-query"}`,
+			wantErr:    "unknown metric my-query",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, err := w.Write([]byte(tt.payload))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			p := setupTest()
+			provider := makeProvider(svr.URL)
+			if tt.noPlugin {
+				provider.Spec.Plugin = nil
+			}
+
+			value, _, err := p.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}, provider)
+			require.ErrorContains(t, err, tt.wantErr)
+			require.Empty(t, value)
+		})
+	}
+}
+
+func TestEvaluateQuery_MissingSecret(t *testing.T) {
+	p := setupTest()
+	provider := makeProvider("http://localhost")
+	provider.Spec.Plugin.Trusted = true
+	provider.Spec.SecretKeyRef = corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+		Key:                  "token",
+	}
+
+	_, _, err := p.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}, provider)
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestEvaluateQuery_SecretOnlySentToTrustedPlugins(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"token": []byte("my-token"),
+			"other": []byte("other-token"),
+		},
+	}
+	var got EvaluateQueryRequest
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&got))
+		writeJSON(t, w, EvaluateQueryResponse{Value: "42"})
+	})
+	metric := metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}
+	secretKeyRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+		Key:                  "token",
+	}
+
+	t.Run("plain http endpoint is not trusted", func(t *testing.T) {
+		svr := httptest.NewServer(handler)
+		defer svr.Close()
+
+		p := setupTest(secret)
+		provider := makeProvider(svr.URL)
+		provider.Spec.SecretKeyRef = secretKeyRef
+
+		_, _, err := p.EvaluateQuery(context.TODO(), metric, provider)
+		require.ErrorIs(t, err, ErrUntrustedEndpoint)
+	})
+
+	t.Run("https endpoint", func(t *testing.T) {
+		svr := httptest.NewTLSServer(handler)
+		defer svr.Close()
+
+		p := setupTest(secret)
+		p.HttpClient = *svr.Client()
+		provider := makeProvider(svr.URL)
+		provider.Spec.SecretKeyRef = secretKeyRef
+
+		value, _, err := p.EvaluateQuery(context.TODO(), metric, provider)
+		require.Nil(t, err)
+		require.Equal(t, "42", value)
+		// only the selected key of the secret is sent to the plugin
+		require.Equal(t, "my-token", got.Provider.Secret)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		p := setupTest(secret)
+		provider := makeProvider("https://my-plugin")
+		provider.Spec.SecretKeyRef = corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+		}
+
+		_, _, err := p.EvaluateQuery(context.TODO(), metric, provider)
+		require.ErrorIs(t, err, ErrSecretKeyNotDefined)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		p := setupTest(secret)
+		provider := makeProvider("https://my-plugin")
+		provider.Spec.SecretKeyRef = corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+			Key:                  "unknown",
+		}
+
+		_, _, err := p.EvaluateQuery(context.TODO(), metric, provider)
+		require.ErrorContains(t, err, "secret my-secret does not contain unknown")
+	})
+}
+
+func TestEvaluateQueryForStep(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, EvaluateQueryForStepPath, r.URL.Path)
+		got := EvaluateQueryRequest{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&got))
+		require.Equal(t, &Range{Interval: "5m", Step: "1m"}, got.Range)
+		writeJSON(t, w, EvaluateQueryForStepResponse{Values: []string{"1", "2", "3"}, Raw: []byte(`[1,2,3]`)})
+	}))
+	defer svr.Close()
+
+	p := setupTest()
+	metric := metricsapi.KeptnMetric{
+		Spec: metricsapi.KeptnMetricSpec{
+			Query: "my-query",
+			Range: &metricsapi.RangeSpec{
+				Interval: "5m",
+				Step:     "1m",
+			},
+		},
+	}
+
+	values, raw, err := p.EvaluateQueryForStep(context.TODO(), metric, makeProvider(svr.URL))
+	require.Nil(t, err)
+	require.Equal(t, []string{"1", "2", "3"}, values)
+	require.Equal(t, []byte(`[1,2,3]`), raw)
+}
+
+func TestFetchAnalysisValue(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, FetchAnalysisValuePath, r.URL.Path)
+		got := FetchAnalysisValueRequest{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&got))
+		require.Equal(t, "my-query", got.Query)
+		require.True(t, from.Equal(got.From))
+		require.True(t, to.Equal(got.To))
+		require.Equal(t, map[string]string{"workload": "podtato-head"}, got.Args)
+		writeJSON(t, w, FetchAnalysisValueResponse{Value: "0.5"})
+	}))
+	defer svr.Close()
+
+	p := setupTest()
+	provider := makeProvider(svr.URL + "/")
+	analysis := metricsapi.Analysis{
+		Spec: metricsapi.AnalysisSpec{
+			Args: map[string]string{"workload": "podtato-head"},
+		},
+		Status: metricsapi.AnalysisStatus{
+			Timeframe: metricsapi.Timeframe{
+				From: metav1.Time{Time: from},
+				To:   metav1.Time{Time: to},
+			},
+		},
+	}
+
+	value, err := p.FetchAnalysisValue(context.TODO(), "my-query", analysis, &provider)
+	require.Nil(t, err)
+	require.Equal(t, "0.5", value)
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, body interface{}) {
+	b, err := json.Marshal(body)
+	require.Nil(t, err)
+	_, err = w.Write(b)
+	require.Nil(t, err)
+}
+
+func makeProvider(endpoint string) metricsapi.KeptnMetricsProvider {
+	return metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-provider",
+			Namespace: "default",
+		},
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			Type:         "plugin",
+			TargetServer: "http://my-backend:9090",
+			Plugin: &metricsapi.PluginProviderSpec{
+				Endpoint: endpoint,
+				Name:     "my-plugin",
+			},
+		},
+	}
+}
+
+func setupTest(objs ...client.Object) KeptnPluginProvider {
+	return KeptnPluginProvider{
+		HttpClient: http.Client{},
+		Log:        ctrl.Log.WithName("testytest"),
+		K8sClient:  fake.NewClient(objs...),
+	}
+}
@@ -0,0 +1,70 @@
+package plugin
+
+import "time"
+
+// The protocol spoken between the metrics operator and an external provider plugin.
+// A plugin is an HTTP server that accepts POST requests with a JSON body on the paths below
+// and answers with status code 200 and a JSON body.
+// If the query cannot be evaluated, the plugin sets the Error field of the response,
+// which is then reported as the error of the KeptnMetric or Analysis.
+const (
+	// EvaluateQueryPath is the path used to evaluate the query of a KeptnMetric,
+	// with an EvaluateQueryRequest as body and an EvaluateQueryResponse as answer
+	EvaluateQueryPath = "/v1/evaluatequery"
+	// EvaluateQueryForStepPath is the path used to evaluate the query of a KeptnMetric with a step,
+	// with an EvaluateQueryRequest as body and an EvaluateQueryForStepResponse as answer
+	EvaluateQueryForStepPath = "/v1/evaluatequeryforstep"
+	// FetchAnalysisValuePath is the path used to evaluate the query of an AnalysisValueTemplate,
+	// with a FetchAnalysisValueRequest as body and a FetchAnalysisValueResponse as answer
+	FetchAnalysisValuePath = "/v1/fetchanalysisvalue"
+)
+
+// ProviderInfo describes the KeptnMetricsProvider the plugin is invoked for
+type ProviderInfo struct {
+	Name                  string `json:"name"`
+	Namespace             string `json:"namespace"`
+	TargetServer          string `json:"targetServer"`
+	InsecureSkipTlsVerify bool   `json:"insecureSkipTlsVerify,omitempty"`
+	// Secret contains the value selected by the SecretKeyRef of the KeptnMetricsProvider
+	Secret string `json:"secret,omitempty"`
+}
+
+// Range describes the time range of a KeptnMetric
+type Range struct {
+	Interval    string `json:"interval,omitempty"`
+	Step        string `json:"step,omitempty"`
+	Aggregation string `json:"aggregation,omitempty"`
+}
+
+type EvaluateQueryRequest struct {
+	Provider ProviderInfo `json:"provider"`
+	Query    string       `json:"query"`
+	Range    *Range       `json:"range,omitempty"`
+}
+
+type EvaluateQueryResponse struct {
+	Value string `json:"value"`
+	// Raw contains the raw result of the query, which is stored in the status of the KeptnMetric
+	Raw   []byte `json:"raw,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type EvaluateQueryForStepResponse struct {
+	Values []string `json:"values"`
+	// Raw contains the raw result of the query, which is stored in the status of the KeptnMetric
+	Raw   []byte `json:"raw,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type FetchAnalysisValueRequest struct {
+	Provider ProviderInfo      `json:"provider"`
+	Query    string            `json:"query"`
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	Args     map[string]string `json:"args,omitempty"`
+}
+
+type FetchAnalysisValueResponse struct {
+	Value string `json:"value"`
+	Error string `json:"error,omitempty"`
+}
@@ -3,9 +3,14 @@ package prometheus
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 
+	"github.com/go-logr/logr"
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/resilience"
 	promapi "github.com/prometheus/client_golang/api"
 	"github.com/prometheus/common/config"
 	corev1 "k8s.io/api/core/v1"
@@ -16,8 +21,15 @@ import (
 const secretKeyUserName = "user"
 const secretKeyPassword = "password"
 
+// MaxResponseSize is the maximum size in bytes of a response body that is read from the API of a provider
+const MaxResponseSize = 10 << 20
+
+// maxErrorBodyLength is the maximum number of bytes of a response body that are included in an error message
+const maxErrorBodyLength = 512
+
 var ErrSecretKeyRefNotDefined = errors.New("the SecretKeyRef property with the Prometheus API Key is missing")
 var ErrInvalidSecretFormat = errors.New("secret key does not contain user and password")
+var ErrResponseTooLarge = errors.New("response exceeds the maximum size")
 
 type SecretData struct {
 	User     string        `json:"user"`
@@ -36,11 +48,73 @@ func (r RoundTripperRetriever) GetRoundTripper(ctx context.Context, provider met
 	secret, err := getPrometheusSecret(ctx, provider, k8sClient)
 	if err != nil {
 		if errors.Is(err, ErrSecretKeyRefNotDefined) {
-			return promapi.DefaultRoundTripper, nil
+			return resilience.NewRetryTransport(promapi.DefaultRoundTripper), nil
+		}
+		return nil, err
+	}
+	return resilience.NewRetryTransport(config.NewBasicAuthRoundTripper(secret.User, secret.Password, "", "", promapi.DefaultRoundTripper)), nil
+}
+
+// GetFromAPI sends a GET request with the given query parameters to the path of the target server of the provider.
+// See SendToAPI for how the request is authenticated and the response is handled.
+func GetFromAPI(ctx context.Context, log logr.Logger, getter IRoundTripper, k8sClient client.Client, provider metricsapi.KeptnMetricsProvider, path string, params url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.Spec.TargetServer+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Running query: %s?%s", path, params.Encode()))
+	return SendToAPI(log, getter, k8sClient, provider, req)
+}
+
+// SendToAPI sends the request to the API of the provider. The request is authenticated with the round tripper
+// of the given IRoundTripper, which is shared by all providers with a Prometheus compatible authentication.
+// The response body is returned along with an error if the API did not respond with status code 200.
+// Response bodies larger than MaxResponseSize are rejected, and only the beginning of the body is included in the error.
+func SendToAPI(log logr.Logger, getter IRoundTripper, k8sClient client.Client, provider metricsapi.KeptnMetricsProvider, req *http.Request) ([]byte, error) {
+	rt, err := getter.GetRoundTripper(req.Context(), provider, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := http.Client{Transport: rt}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			log.Error(err, "could not close request body")
 		}
+	}()
+
+	b, err := ReadResponseBody(res.Body)
+	if err != nil {
 		return nil, err
 	}
-	return config.NewBasicAuthRoundTripper(secret.User, secret.Password, "", "", promapi.DefaultRoundTripper), nil
+	if res.StatusCode != http.StatusOK {
+		return b, fmt.Errorf("%s API returned status code %d: %s", provider.GetType(), res.StatusCode, TruncateBody(b))
+	}
+	return b, nil
+}
+
+// ReadResponseBody reads the body of a response of a provider, which must not exceed MaxResponseSize
+func ReadResponseBody(body io.Reader) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(body, MaxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > MaxResponseSize {
+		return nil, fmt.Errorf("%w of %d bytes", ErrResponseTooLarge, MaxResponseSize)
+	}
+	return b, nil
+}
+
+// TruncateBody returns the beginning of the response body, to be included in error messages
+func TruncateBody(b []byte) string {
+	if len(b) <= maxErrorBodyLength {
+		return string(b)
+	}
+	return string(b[:maxErrorBodyLength]) + "..."
 }
 
 func getPrometheusSecret(ctx context.Context, provider metricsapi.KeptnMetricsProvider, k8sClient client.Client) (*SecretData, error) {
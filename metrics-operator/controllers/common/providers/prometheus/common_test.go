@@ -4,12 +4,14 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
 
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/fake"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/resilience"
 	promapi "github.com/prometheus/client_golang/api"
 	"github.com/prometheus/common/config"
 	"github.com/stretchr/testify/require"
@@ -17,6 +19,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -140,14 +143,14 @@ func Test_GetRoundtripper(t *testing.T) {
 				},
 			},
 			k8sClient: fake.NewClient(goodsecret),
-			want:      config.NewBasicAuthRoundTripper("myuser", "mytoken", "", "", promapi.DefaultRoundTripper),
+			want:      resilience.NewRetryTransport(config.NewBasicAuthRoundTripper("myuser", "mytoken", "", "", promapi.DefaultRoundTripper)),
 			wantErr:   false,
 		},
 		{
 			name:      "TestSecretNotDefined",
 			provider:  metricsapi.KeptnMetricsProvider{},
 			k8sClient: fake.NewClient(),
-			want:      promapi.DefaultRoundTripper,
+			want:      resilience.NewRetryTransport(promapi.DefaultRoundTripper),
 			wantErr:   false,
 		},
 		{
@@ -191,3 +194,62 @@ func Test_GetRoundtripper(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFromAPI(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/query" {
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte("not found"))
+			require.Nil(t, err)
+			return
+		}
+		require.Equal(t, "my-query", r.URL.Query().Get("query"))
+		_, err := w.Write([]byte(prometheusPayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	provider := metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{Type: "loki", TargetServer: svr.URL}}
+	params := url.Values{}
+	params.Set("query", "my-query")
+
+	b, err := GetFromAPI(context.TODO(), ctrl.Log.WithName("testytest"), RoundTripperRetriever{}, fake.NewClient(), provider, "/api/query", params)
+	require.Nil(t, err)
+	require.Equal(t, []byte(prometheusPayload), b)
+
+	b, err = GetFromAPI(context.TODO(), ctrl.Log.WithName("testytest"), RoundTripperRetriever{}, fake.NewClient(), provider, "/api/unknown", params)
+	require.EqualError(t, err, "loki API returned status code 404: not found")
+	require.Equal(t, []byte("not found"), b)
+
+	provider.Namespace = "default"
+	provider.Spec.SecretKeyRef.Name = "missing-secret"
+	_, err = GetFromAPI(context.TODO(), ctrl.Log.WithName("testytest"), RoundTripperRetriever{}, fake.NewClient(), provider, "/api/query", params)
+	require.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestSendToAPI_ResponseSize(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/error" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write([]byte(strings.Repeat("e", 2*maxErrorBodyLength)))
+			require.Nil(t, err)
+			return
+		}
+		_, err := w.Write([]byte(strings.Repeat("a", MaxResponseSize+1)))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	provider := metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{Type: "loki", TargetServer: svr.URL}}
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, svr.URL+"/api/query", nil)
+	require.Nil(t, err)
+	_, err = SendToAPI(ctrl.Log.WithName("testytest"), RoundTripperRetriever{}, fake.NewClient(), provider, req)
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+
+	req, err = http.NewRequestWithContext(context.TODO(), http.MethodGet, svr.URL+"/api/error", nil)
+	require.Nil(t, err)
+	b, err := SendToAPI(ctrl.Log.WithName("testytest"), RoundTripperRetriever{}, fake.NewClient(), provider, req)
+	require.EqualError(t, err, "loki API returned status code 400: "+strings.Repeat("e", maxErrorBodyLength)+"...")
+	require.Len(t, b, 2*maxErrorBodyLength)
+}
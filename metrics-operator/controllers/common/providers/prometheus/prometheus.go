@@ -69,6 +69,42 @@ func (r *KeptnPrometheusProvider) FetchAnalysisValue(ctx context.Context, query
 	return res, err
 }
 
+// FetchAnalysisSeries fetches the values of the query within the timeframe of the Analysis with the given step
+func (r *KeptnPrometheusProvider) FetchAnalysisSeries(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider, step time.Duration) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	api, err := r.setupApi(ctx, *provider)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Log.Info(fmt.Sprintf(
+		"Running query: /api/v1/query_range?query=%s&start=%d&end=%d&step=%v",
+		query,
+		analysis.GetFrom().Unix(), analysis.GetTo().Unix(),
+		step,
+	))
+	queryRange := prometheus.Range{
+		Start: analysis.GetFrom(),
+		End:   analysis.GetTo(),
+		Step:  step,
+	}
+	result, warnings, err := api.QueryRange(
+		ctx,
+		query,
+		queryRange,
+		[]prometheus.Option{}...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(warnings) != 0 {
+		r.Log.Info(fmt.Sprintf(warningLogString, provider.GetType(), warnings[0]))
+	}
+	res, _, err := getResultForStepMatrix(result)
+	return res, err
+}
+
 // EvaluateQuery fetches the SLI values from prometheus provider
 func (r *KeptnPrometheusProvider) EvaluateQuery(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
 	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
@@ -101,6 +137,32 @@ func (r *KeptnPrometheusProvider) EvaluateQuery(ctx context.Context, metric metr
 	}
 }
 
+// EvaluateQueryResults fetches the value of each series of the query result together with its labels from prometheus provider
+func (r *KeptnPrometheusProvider) EvaluateQueryResults(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]metricsapi.MetricResult, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	api, err := r.setupApi(ctx, provider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result model.Value
+	var warnings prometheus.Warnings
+	if metric.Spec.Range != nil {
+		result, warnings, err = evaluateQueryWithRange(ctx, metric, r, api)
+	} else {
+		result, warnings, err = evaluateQueryWithoutRange(ctx, metric, r, api)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(warnings) != 0 {
+		r.Log.Info(fmt.Sprintf(warningLogString, provider.GetType(), warnings[0]))
+	}
+	return getResultsForSeries(result)
+}
+
 // EvaluateQueryForStep fetches the metric values from prometheus provider
 func (r *KeptnPrometheusProvider) EvaluateQueryForStep(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
 	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
@@ -240,6 +302,64 @@ func getResultForVector(result model.Value) (string, []byte, error) {
 	return value, b, nil
 }
 
+// getResultsForSeries returns the value of each series of a vector, or the first value of each series of a matrix,
+// together with the labels of the series.
+// If there is exactly one series, the raw value has the same format as the one of EvaluateQuery.
+func getResultsForSeries(result model.Value) ([]metricsapi.MetricResult, []byte, error) {
+	results := []metricsapi.MetricResult{}
+	values := []model.SampleValue{}
+	switch res := result.(type) {
+	case model.Vector:
+		for _, sample := range res {
+			results = append(results, metricsapi.MetricResult{
+				Labels: getLabels(sample.Metric),
+				Value:  sample.Value.String(),
+			})
+			values = append(values, sample.Value)
+		}
+	case model.Matrix:
+		for _, series := range res {
+			if len(series.Values) == 0 {
+				continue
+			}
+			results = append(results, metricsapi.MetricResult{
+				Labels: getLabels(series.Metric),
+				Value:  series.Values[0].Value.String(),
+			})
+			values = append(values, series.Values[0].Value)
+		}
+	default:
+		return nil, nil, errCouldNotCast
+	}
+	if len(results) == 0 {
+		return nil, nil, errNoValues
+	}
+
+	var b []byte
+	var err error
+	if len(results) == 1 {
+		b, err = values[0].MarshalJSON()
+	} else {
+		b, err = json.Marshal(results)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, b, nil
+}
+
+// getLabels returns the labels of a series without its metric name
+func getLabels(metric model.Metric) map[string]string {
+	labels := make(map[string]string, len(metric))
+	for name, value := range metric {
+		if name == model.MetricNameLabel {
+			continue
+		}
+		labels[string(name)] = string(value)
+	}
+	return labels
+}
+
 func getResultForStepMatrix(result model.Value) ([]string, []byte, error) {
 	// check if we can cast the result to a matrix
 	resultMatrix, ok := result.(model.Matrix)
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -320,6 +321,106 @@ func Test_resultsForMatrix(t *testing.T) {
 	}
 }
 
+func TestEvaluateQueryResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		hasRange  bool
+		wantPods  []string
+		wantError bool
+	}{
+		{
+			name:     "multiple series with no range",
+			in:       promMultiPointPayloadWithNoRange,
+			wantPods: []string{"kindnet-llt85", "kube-proxy-dlq7m", "node-exporter-dv6nr"},
+		},
+		{
+			name:     "single series with range",
+			in:       promPayloadWithRange,
+			hasRange: true,
+			wantPods: []string{"kindnet-llt85"},
+		},
+		{
+			name:      "empty result",
+			in:        promEmptyDataPayloadWithNoRange,
+			wantError: true,
+		},
+		{
+			name:      "wrong data",
+			in:        "garbage",
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, err := w.Write([]byte(tt.in))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			kpp := KeptnPrometheusProvider{
+				K8sClient: fake.NewClient(),
+				Log:       ctrl.Log.WithName("testytest"),
+				Getter:    RoundTripperRetriever{},
+			}
+			p := metricsapi.KeptnMetricsProvider{
+				Spec: metricsapi.KeptnMetricsProviderSpec{
+					TargetServer: svr.URL,
+				},
+			}
+			obj := metricsapi.KeptnMetric{
+				Spec: metricsapi.KeptnMetricSpec{
+					Query: "my-query",
+				},
+			}
+			if tt.hasRange {
+				obj.Spec.Range = &metricsapi.RangeSpec{Interval: "5m"}
+			}
+
+			results, raw, err := kpp.EvaluateQueryResults(context.TODO(), obj, p)
+			if tt.wantError {
+				require.NotNil(t, err)
+				require.Nil(t, results)
+				return
+			}
+			require.Nil(t, err)
+			require.NotEmpty(t, raw)
+			require.Len(t, results, len(tt.wantPods))
+			for i, pod := range tt.wantPods {
+				require.Equal(t, "1", results[i].Value)
+				require.Equal(t, pod, results[i].Labels["pod"])
+				require.NotContains(t, results[i].Labels, "__name__")
+			}
+		})
+	}
+}
+
+func Test_resultsForSeries(t *testing.T) {
+	results, raw, err := getResultsForSeries(model.Matrix{
+		{Metric: model.Metric{"pod": "pod-1"}, Values: []model.SamplePair{{Value: 1}, {Value: 2}}},
+		{Metric: model.Metric{"pod": "pod-2"}},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []metricsapi.MetricResult{{Labels: map[string]string{"pod": "pod-1"}, Value: "1"}}, results)
+	// a single series keeps the raw format of EvaluateQuery
+	require.Equal(t, `"1"`, string(raw))
+
+	results, raw, err = getResultsForSeries(model.Vector{
+		{Metric: model.Metric{"pod": "pod-1"}, Value: 1},
+		{Metric: model.Metric{"pod": "pod-2"}, Value: 2},
+	})
+	require.Nil(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, `[{"labels":{"pod":"pod-1"},"value":"1"},{"labels":{"pod":"pod-2"},"value":"2"}]`, string(raw))
+
+	_, _, err = getResultsForSeries(model.Matrix{})
+	require.ErrorIs(t, err, errNoValues)
+
+	_, _, err = getResultsForSeries(&model.Scalar{})
+	require.ErrorIs(t, err, errCouldNotCast)
+}
+
 func TestFetchAnalysisValueWithAuth(t *testing.T) {
 
 	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -393,6 +494,44 @@ func TestFetchAnalysisValueWithAuth(t *testing.T) {
 	require.Equal(t, expectedResult, result)
 }
 
+func TestFetchAnalysisSeries(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Nil(t, r.ParseForm())
+		require.Equal(t, "my_query", r.Form.Get("query"))
+		require.Equal(t, "30", r.Form.Get("step"))
+		require.Equal(t, strconv.FormatInt(now.Add(-time.Hour).Unix(), 10), r.Form.Get("start"))
+		require.Equal(t, strconv.FormatInt(now.Unix(), 10), r.Form.Get("end"))
+		_, err := w.Write([]byte(promPayloadWithRangeAndStep))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	provider := KeptnPrometheusProvider{
+		K8sClient: fake.NewClient(),
+		Log:       ctrl.Log.WithName("testytest"),
+		Getter:    RoundTripperRetriever{},
+	}
+	analysis := metricsapi.Analysis{
+		Status: metricsapi.AnalysisStatus{
+			Timeframe: metricsapi.Timeframe{
+				From: metav1.Time{Time: now.Add(-time.Hour)},
+				To:   metav1.Time{Time: now},
+			},
+		},
+	}
+	metricsProvider := &metricsapi.KeptnMetricsProvider{
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			TargetServer: svr.URL,
+		},
+	}
+
+	result, err := provider.FetchAnalysisSeries(context.Background(), "my_query", analysis, metricsProvider, 30*time.Second)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "1", "1", "1", "1"}, result)
+}
+
 func TestKeptnPrometheusProvider_setupApi(t *testing.T) {
 	var b byte = 0x7f
 	tests := []struct {
@@ -3,16 +3,24 @@ package providers
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/datadog"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/dynatrace"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/elastic"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/httpjson"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/influxdb"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/loki"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/plugin"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/prometheus"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/resilience"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/tempo"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -25,6 +33,52 @@ type KeptnSLIProvider interface {
 	FetchAnalysisValue(ctx context.Context, query string, spec metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error)
 }
 
+// KeptnSLISeriesProvider is implemented by providers which can retrieve the series of values of a query
+// within the timeframe of an Analysis, as required by objectives defining a statistical test
+type KeptnSLISeriesProvider interface {
+	FetchAnalysisSeries(ctx context.Context, query string, spec metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider, step time.Duration) ([]string, error)
+}
+
+// KeptnSLIResultsProvider is implemented by providers which can retrieve the value of each series
+// of a query result together with its labels, as required by multi-dimensional KeptnMetrics
+type KeptnSLIResultsProvider interface {
+	EvaluateQueryResults(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]metricsapi.MetricResult, []byte, error)
+}
+
+var ErrSeriesNotSupported = errors.New("provider does not support retrieving a series of values for an Analysis")
+
+var ErrResultsNotSupported = errors.New("provider does not support retrieving the results of multiple series")
+
+// FetchAnalysisSeries retrieves the series of values of the query from the provider, if it implements KeptnSLISeriesProvider
+func FetchAnalysisSeries(ctx context.Context, p KeptnSLIProvider, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider, step time.Duration) ([]string, error) {
+	seriesProvider, ok := p.(KeptnSLISeriesProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSeriesNotSupported, provider.Spec.Type)
+	}
+	return seriesProvider.FetchAnalysisSeries(ctx, query, analysis, provider, step)
+}
+
+// EvaluateQueryResults retrieves the value of each series of the query result from the provider, if it implements KeptnSLIResultsProvider
+func EvaluateQueryResults(ctx context.Context, p KeptnSLIProvider, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]metricsapi.MetricResult, []byte, error) {
+	resultsProvider, ok := p.(KeptnSLIResultsProvider)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrResultsNotSupported, provider.Spec.Type)
+	}
+	return resultsProvider.EvaluateQueryResults(ctx, metric, provider)
+}
+
+// supportsQueryResults reports whether the provider, or the provider wrapped by it, implements KeptnSLIResultsProvider
+func supportsQueryResults(p KeptnSLIProvider) bool {
+	switch w := p.(type) {
+	case *cachedProvider:
+		return supportsQueryResults(w.KeptnSLIProvider)
+	case *resilientProvider:
+		return supportsQueryResults(w.KeptnSLIProvider)
+	}
+	_, ok := p.(KeptnSLIResultsProvider)
+	return ok
+}
+
 type ProviderFactory func(provider *metricsapi.KeptnMetricsProvider, log logr.Logger, k8sClient client.Client) (KeptnSLIProvider, error)
 
 // NewProvider is a factory method that chooses the right implementation of KeptnSLIProvider
@@ -36,11 +90,11 @@ func NewProvider(provider *metricsapi.KeptnMetricsProvider, log logr.Logger, k8s
 	case DynatraceProviderType:
 		return &dynatrace.KeptnDynatraceProvider{
 			HttpClient: http.Client{
-				Transport: &http.Transport{
+				Transport: resilience.NewRetryTransport(&http.Transport{
 					TLSClientConfig: &tls.Config{
 						InsecureSkipVerify: provider.Spec.InsecureSkipTlsVerify,
 					},
-				},
+				}),
 			},
 			Log:       log,
 			K8sClient: k8sClient,
@@ -55,11 +109,11 @@ func NewProvider(provider *metricsapi.KeptnMetricsProvider, log logr.Logger, k8s
 		return &datadog.KeptnDataDogProvider{
 			Log: log,
 			HttpClient: http.Client{
-				Transport: &http.Transport{
+				Transport: resilience.NewRetryTransport(&http.Transport{
 					TLSClientConfig: &tls.Config{
 						InsecureSkipVerify: provider.Spec.InsecureSkipTlsVerify,
 					},
-				},
+				}),
 			},
 			K8sClient: k8sClient,
 		}, nil
@@ -73,6 +127,37 @@ func NewProvider(provider *metricsapi.KeptnMetricsProvider, log logr.Logger, k8s
 			K8sClient: k8sClient,
 			Elastic:   es,
 		}, nil
+	case HTTPProviderType:
+		return &httpjson.KeptnHTTPProvider{
+			Log: log,
+			HttpClient: http.Client{
+				Transport: resilience.NewRetryTransport(&http.Transport{
+					TLSClientConfig: &tls.Config{
+						InsecureSkipVerify: provider.Spec.InsecureSkipTlsVerify,
+					},
+				}),
+			},
+			K8sClient: k8sClient,
+		}, nil
+	case LokiProviderType:
+		return loki.NewLokiProvider(log, k8sClient), nil
+	case TempoProviderType:
+		return tempo.NewTempoProvider(log, k8sClient), nil
+	case InfluxDBProviderType:
+		return influxdb.NewInfluxDBProvider(log, k8sClient, provider.Spec.InsecureSkipTlsVerify), nil
+	case PluginProviderType:
+		return &plugin.KeptnPluginProvider{
+			Log: log,
+			HttpClient: http.Client{
+				Timeout: plugin.RequestTimeout,
+				Transport: resilience.NewRetryTransport(&http.Transport{
+					TLSClientConfig: &tls.Config{
+						InsecureSkipVerify: provider.Spec.InsecureSkipTlsVerify,
+					},
+				}),
+			},
+			K8sClient: k8sClient,
+		}, nil
 	default:
 		return nil, fmt.Errorf("provider %s not supported", provider.Spec.Type)
 	}
@@ -9,8 +9,14 @@ import (
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/datadog"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/dynatrace"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/elastic"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/httpjson"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/influxdb"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/loki"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/plugin"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/prometheus"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/tempo"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestFactory(t *testing.T) {
@@ -82,6 +88,51 @@ func TestFactory(t *testing.T) {
 			provider: &datadog.KeptnDataDogProvider{},
 			err:      false,
 		},
+		{
+			metricsProvider: metricsapi.KeptnMetricsProvider{
+				Spec: metricsapi.KeptnMetricsProviderSpec{
+					Type: HTTPProviderType,
+				},
+			},
+			provider: &httpjson.KeptnHTTPProvider{},
+			err:      false,
+		},
+		{
+			metricsProvider: metricsapi.KeptnMetricsProvider{
+				Spec: metricsapi.KeptnMetricsProviderSpec{
+					Type: LokiProviderType,
+				},
+			},
+			provider: &loki.KeptnLokiProvider{},
+			err:      false,
+		},
+		{
+			metricsProvider: metricsapi.KeptnMetricsProvider{
+				Spec: metricsapi.KeptnMetricsProviderSpec{
+					Type: TempoProviderType,
+				},
+			},
+			provider: &tempo.KeptnTempoProvider{},
+			err:      false,
+		},
+		{
+			metricsProvider: metricsapi.KeptnMetricsProvider{
+				Spec: metricsapi.KeptnMetricsProviderSpec{
+					Type: InfluxDBProviderType,
+				},
+			},
+			provider: &influxdb.KeptnInfluxDBProvider{},
+			err:      false,
+		},
+		{
+			metricsProvider: metricsapi.KeptnMetricsProvider{
+				Spec: metricsapi.KeptnMetricsProviderSpec{
+					Type: PluginProviderType,
+				},
+			},
+			provider: &plugin.KeptnPluginProvider{},
+			err:      false,
+		},
 		{
 			metricsProvider: metricsapi.KeptnMetricsProvider{
 				Spec: metricsapi.KeptnMetricsProviderSpec{
@@ -104,3 +155,16 @@ func TestFactory(t *testing.T) {
 
 	}
 }
+
+func TestIsProviderSupported(t *testing.T) {
+	require.True(t, IsProviderSupported(&metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{Type: "Prometheus"}}))
+	require.True(t, IsProviderSupported(&metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{Type: PluginProviderType}}))
+	require.False(t, IsProviderSupported(&metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{Type: "foo"}}))
+}
+
+func TestGetProviderKey(t *testing.T) {
+	require.Equal(t, "my-namespace/my-provider", GetProviderKey(&metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-provider", Namespace: "my-namespace"},
+		Spec:       metricsapi.KeptnMetricsProviderSpec{Type: "prometheus"},
+	}))
+}
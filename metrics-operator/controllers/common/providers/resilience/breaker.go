@@ -0,0 +1,149 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("provider is degraded, queries are suspended")
+
+type State string
+
+const (
+	StateClosed   State = "Closed"
+	StateOpen     State = "Open"
+	StateHalfOpen State = "HalfOpen"
+)
+
+// CircuitBreaker suspends the queries to a provider after a number of consecutive failures.
+// Once the open duration has passed, a single query is let through to probe whether the provider has recovered.
+type CircuitBreaker struct {
+	mtx       sync.Mutex
+	state     State
+	failures  int
+	openUntil time.Time
+	probing   bool
+	lastError string
+	now       func() time.Time
+}
+
+func NewCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		state: StateClosed,
+		now:   time.Now,
+	}
+}
+
+// Allow returns whether a query may be executed
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	switch cb.state {
+	case StateOpen:
+		if cb.now().Before(cb.openUntil) {
+			return false
+		}
+		cb.state = StateHalfOpen
+		cb.probing = true
+		return true
+	case StateHalfOpen:
+		if cb.probing {
+			return false
+		}
+		cb.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Record records the outcome of an executed query and returns whether the breaker opened or closed because of it.
+// The breaker opens after failureThreshold consecutive failures, or if the probe in half-open state fails,
+// and stays open for openDuration.
+func (cb *CircuitBreaker) Record(failed bool, errMsg string, failureThreshold int, openDuration time.Duration) bool {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	cb.probing = false
+	if !failed {
+		cb.failures = 0
+		if cb.state != StateClosed {
+			cb.state = StateClosed
+			return true
+		}
+		return false
+	}
+
+	cb.failures++
+	cb.lastError = errMsg
+	if cb.state == StateHalfOpen || (cb.state == StateClosed && failureThreshold > 0 && cb.failures >= failureThreshold) {
+		wasOpen := cb.state == StateHalfOpen
+		cb.state = StateOpen
+		cb.openUntil = cb.now().Add(openDuration)
+		return !wasOpen
+	}
+	return false
+}
+
+// Status returns the state of the breaker, the number of consecutive failures, the last error
+// and until when the breaker stays open
+func (cb *CircuitBreaker) Status() (State, int, string, time.Time) {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+	return cb.state, cb.failures, cb.lastError, cb.openUntil
+}
+
+// ProviderState holds the circuit breaker and the concurrency limit of a single provider
+type ProviderState struct {
+	Breaker   *CircuitBreaker
+	mtx       sync.Mutex
+	semaphore chan struct{}
+}
+
+// Acquire waits until less than maxConcurrent queries are executed against the provider
+// and returns a function releasing the acquired slot. If maxConcurrent is 0, the number of queries is not limited.
+func (s *ProviderState) Acquire(ctx context.Context, maxConcurrent int) (func(), error) {
+	if maxConcurrent <= 0 {
+		return func() {}, nil
+	}
+	s.mtx.Lock()
+	if cap(s.semaphore) != maxConcurrent {
+		// queries holding a slot of the previous semaphore release it there
+		s.semaphore = make(chan struct{}, maxConcurrent)
+	}
+	semaphore := s.semaphore
+	s.mtx.Unlock()
+
+	select {
+	case semaphore <- struct{}{}:
+		return func() { <-semaphore }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// use singleton pattern here to share the state of a provider between all controllers
+var states = map[string]*ProviderState{}
+var statesMtx sync.Mutex
+
+// GetProviderState returns the state of the provider with the given key, creating it if needed
+func GetProviderState(key string) *ProviderState {
+	statesMtx.Lock()
+	defer statesMtx.Unlock()
+	if s, ok := states[key]; ok {
+		return s
+	}
+	s := &ProviderState{Breaker: NewCircuitBreaker()}
+	states[key] = s
+	return s
+}
+
+// DeleteProviderState drops the state of the provider with the given key, e.g. once the provider has been deleted
+func DeleteProviderState(key string) {
+	statesMtx.Lock()
+	defer statesMtx.Unlock()
+	delete(states, key)
+}
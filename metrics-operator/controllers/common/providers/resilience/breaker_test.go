@@ -0,0 +1,88 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker()
+	cb.now = func() time.Time { return now }
+
+	// failures below the threshold keep the breaker closed
+	require.True(t, cb.Allow())
+	require.False(t, cb.Record(true, "err1", 2, time.Minute))
+	require.True(t, cb.Allow())
+
+	// a success resets the consecutive failures
+	require.False(t, cb.Record(false, "", 2, time.Minute))
+	require.False(t, cb.Record(true, "err2", 2, time.Minute))
+	require.True(t, cb.Record(true, "err3", 2, time.Minute))
+
+	state, failures, lastErr, openUntil := cb.Status()
+	require.Equal(t, StateOpen, state)
+	require.Equal(t, 2, failures)
+	require.Equal(t, "err3", lastErr)
+	require.Equal(t, now.Add(time.Minute), openUntil)
+	require.False(t, cb.Allow())
+
+	// after the open duration a single probe is let through
+	now = now.Add(time.Minute)
+	require.True(t, cb.Allow())
+	require.False(t, cb.Allow())
+
+	// a failed probe opens the breaker again without reporting a transition
+	require.False(t, cb.Record(true, "err4", 2, time.Minute))
+	state, _, _, _ = cb.Status()
+	require.Equal(t, StateOpen, state)
+	require.False(t, cb.Allow())
+
+	// a successful probe closes the breaker
+	now = now.Add(time.Minute)
+	require.True(t, cb.Allow())
+	require.True(t, cb.Record(false, "", 2, time.Minute))
+	state, failures, _, _ = cb.Status()
+	require.Equal(t, StateClosed, state)
+	require.Equal(t, 0, failures)
+	require.True(t, cb.Allow())
+}
+
+func TestProviderState_Acquire(t *testing.T) {
+	s := GetProviderState("default/acquire-test")
+	require.Same(t, s, GetProviderState("default/acquire-test"))
+
+	release1, err := s.Acquire(context.TODO(), 1)
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.Acquire(ctx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release1()
+	release2, err := s.Acquire(context.TODO(), 1)
+	require.Nil(t, err)
+	release2()
+
+	// no limit
+	release3, err := s.Acquire(context.TODO(), 0)
+	require.Nil(t, err)
+	release3()
+}
+
+func TestDeleteProviderState(t *testing.T) {
+	s := GetProviderState("default/delete-test")
+	s.Breaker.Record(true, "unavailable", 1, time.Minute)
+
+	DeleteProviderState("default/delete-test")
+
+	recreated := GetProviderState("default/delete-test")
+	require.NotSame(t, s, recreated)
+	state, failures, _, _ := recreated.Breaker.Status()
+	require.Equal(t, StateClosed, state)
+	require.Equal(t, 0, failures)
+}
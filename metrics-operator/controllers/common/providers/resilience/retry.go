@@ -0,0 +1,146 @@
+package resilience
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const maxBackoff = 30 * time.Second
+
+type contextKey struct{}
+
+// Settings define how the requests made on behalf of a single query are retried
+type Settings struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+}
+
+// tracker holds the retry settings of a query and records whether the provider was unavailable while executing it
+type tracker struct {
+	settings    Settings
+	unavailable atomic.Bool
+}
+
+// WithSettings returns a context carrying the retry settings for the requests made with it,
+// and a function reporting whether any of these requests found the provider unavailable,
+// i.e. failed with a network error or a retryable status code after all retries.
+func WithSettings(ctx context.Context, settings Settings) (context.Context, func() bool) {
+	t := &tracker{settings: settings}
+	return context.WithValue(ctx, contextKey{}, t), t.unavailable.Load
+}
+
+func fromContext(ctx context.Context) (*tracker, bool) {
+	t, ok := ctx.Value(contextKey{}).(*tracker)
+	return t, ok
+}
+
+// RetryTransport is an http.RoundTripper retrying requests which fail with a retryable status code,
+// using an exponential backoff. The retry settings are taken from the context of the request,
+// requests without settings are passed through unchanged.
+type RetryTransport struct {
+	Base http.RoundTripper
+}
+
+func NewRetryTransport(base http.RoundTripper) *RetryTransport {
+	return &RetryTransport{Base: base}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tracker, ok := fromContext(req.Context())
+	if !ok {
+		return t.Base.RoundTrip(req)
+	}
+
+	backoff := tracker.settings.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		res, err := t.Base.RoundTrip(req)
+		if err != nil {
+			tracker.unavailable.Store(true)
+			return nil, err
+		}
+		if !IsRetryableStatus(res.StatusCode) {
+			return res, nil
+		}
+		if attempt >= tracker.settings.MaxRetries || !canRetry(req) {
+			tracker.unavailable.Store(true)
+			return res, nil
+		}
+
+		wait := retryAfter(res, backoff)
+		drainBody(res)
+		if err := sleep(req.Context(), wait); err != nil {
+			tracker.unavailable.Store(true)
+			return nil, err
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// IsRetryableStatus returns whether a request failing with the status code should be retried
+func IsRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func canRetry(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewind returns a copy of the request with a fresh body
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	newReq := req.Clone(req.Context())
+	newReq.Body = body
+	return newReq, nil
+}
+
+// retryAfter returns the delay requested by the Retry-After header of the response, capped at the maximum backoff,
+// or the given backoff if the header is not set
+func retryAfter(res *http.Response, backoff time.Duration) time.Duration {
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return backoff
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, maxBackoff)
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return min(max(time.Until(date), 0), maxBackoff)
+	}
+	return backoff
+}
+
+func drainBody(res *http.Response) {
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
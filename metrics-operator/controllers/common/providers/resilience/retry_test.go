@@ -0,0 +1,151 @@
+package resilience
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T, statusCodes ...int) (*httptest.Server, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(calls.Add(1)) - 1
+		body, _ := io.ReadAll(r.Body)
+		statusCode := http.StatusOK
+		if call < len(statusCodes) {
+			statusCode = statusCodes[call]
+		}
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(svr.Close)
+	return svr, calls
+}
+
+func TestRetryTransport_RetriesRetryableStatusCodes(t *testing.T) {
+	svr, calls := newServer(t, http.StatusTooManyRequests, http.StatusServiceUnavailable)
+	client := http.Client{Transport: NewRetryTransport(http.DefaultTransport)}
+
+	ctx, unavailable := WithSettings(context.TODO(), Settings{MaxRetries: 3, InitialBackoff: time.Millisecond})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, svr.URL, strings.NewReader("my-query"))
+	require.Nil(t, err)
+
+	res, err := client.Do(req)
+	require.Nil(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.Nil(t, err)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "my-query", string(body))
+	require.Equal(t, int32(3), calls.Load())
+	require.False(t, unavailable())
+}
+
+func TestRetryTransport_ExhaustedRetries(t *testing.T) {
+	svr, calls := newServer(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	client := http.Client{Transport: NewRetryTransport(http.DefaultTransport)}
+
+	ctx, unavailable := WithSettings(context.TODO(), Settings{MaxRetries: 1, InitialBackoff: time.Millisecond})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, svr.URL, nil)
+	require.Nil(t, err)
+
+	res, err := client.Do(req)
+	require.Nil(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusBadGateway, res.StatusCode)
+	require.Equal(t, int32(2), calls.Load())
+	require.True(t, unavailable())
+}
+
+func TestRetryTransport_NonRetryableStatusCode(t *testing.T) {
+	svr, calls := newServer(t, http.StatusBadRequest)
+	client := http.Client{Transport: NewRetryTransport(http.DefaultTransport)}
+
+	ctx, unavailable := WithSettings(context.TODO(), Settings{MaxRetries: 3, InitialBackoff: time.Millisecond})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, svr.URL, nil)
+	require.Nil(t, err)
+
+	res, err := client.Do(req)
+	require.Nil(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	require.Equal(t, int32(1), calls.Load())
+	require.False(t, unavailable())
+}
+
+func TestRetryTransport_WithoutSettings(t *testing.T) {
+	svr, calls := newServer(t, http.StatusServiceUnavailable)
+	client := http.Client{Transport: NewRetryTransport(http.DefaultTransport)}
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, svr.URL, nil)
+	require.Nil(t, err)
+
+	res, err := client.Do(req)
+	require.Nil(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryTransport_NetworkError(t *testing.T) {
+	svr, _ := newServer(t)
+	svr.Close()
+	client := http.Client{Transport: NewRetryTransport(http.DefaultTransport)}
+
+	ctx, unavailable := WithSettings(context.TODO(), Settings{MaxRetries: 3, InitialBackoff: time.Millisecond})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, svr.URL, nil)
+	require.Nil(t, err)
+
+	_, err = client.Do(req)
+	require.NotNil(t, err)
+	require.True(t, unavailable())
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{
+			name: "no header",
+			want: time.Second,
+		},
+		{
+			name:   "seconds",
+			header: "5",
+			want:   5 * time.Second,
+		},
+		{
+			name:   "capped at maximum backoff",
+			header: "3600",
+			want:   maxBackoff,
+		},
+		{
+			name:   "invalid value",
+			header: "soon",
+			want:   time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				res.Header.Set("Retry-After", tt.header)
+			}
+			require.Equal(t, tt.want, retryAfter(res, time.Second))
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/resilience"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+const ProviderAvailableConditionType = "Available"
+
+const (
+	providerAvailableReason = "QueriesSucceeding"
+	providerDegradedReason  = "CircuitBreakerOpen"
+)
+
+// resilientProvider limits, retries and suspends the queries of a KeptnSLIProvider
+// according to the resilience settings of the KeptnMetricsProvider of each query
+type resilientProvider struct {
+	KeptnSLIProvider
+	k8sClient client.Client
+	log       logr.Logger
+}
+
+func (r *resilientProvider) FetchAnalysisValue(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+	var value string
+	err := r.execute(ctx, *provider, func(ctx context.Context) error {
+		var err error
+		value, err = r.KeptnSLIProvider.FetchAnalysisValue(ctx, query, analysis, provider)
+		return err
+	})
+	return value, err
+}
+
+func (r *resilientProvider) EvaluateQuery(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+	var value string
+	var raw []byte
+	err := r.execute(ctx, provider, func(ctx context.Context) error {
+		var err error
+		value, raw, err = r.KeptnSLIProvider.EvaluateQuery(ctx, metric, provider)
+		return err
+	})
+	return value, raw, err
+}
+
+func (r *resilientProvider) EvaluateQueryForStep(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
+	var values []string
+	var raw []byte
+	err := r.execute(ctx, provider, func(ctx context.Context) error {
+		var err error
+		values, raw, err = r.KeptnSLIProvider.EvaluateQueryForStep(ctx, metric, provider)
+		return err
+	})
+	return values, raw, err
+}
+
+func (r *resilientProvider) FetchAnalysisSeries(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider, step time.Duration) ([]string, error) {
+	var values []string
+	err := r.execute(ctx, *provider, func(ctx context.Context) error {
+		var err error
+		values, err = FetchAnalysisSeries(ctx, r.KeptnSLIProvider, query, analysis, provider, step)
+		return err
+	})
+	return values, err
+}
+
+func (r *resilientProvider) EvaluateQueryResults(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]metricsapi.MetricResult, []byte, error) {
+	if !supportsQueryResults(r.KeptnSLIProvider) {
+		// do not count unsupported queries as failures of the provider
+		return EvaluateQueryResults(ctx, r.KeptnSLIProvider, metric, provider)
+	}
+	var results []metricsapi.MetricResult
+	var raw []byte
+	err := r.execute(ctx, provider, func(ctx context.Context) error {
+		var err error
+		results, raw, err = EvaluateQueryResults(ctx, r.KeptnSLIProvider, metric, provider)
+		return err
+	})
+	return results, raw, err
+}
+
+func (r *resilientProvider) execute(ctx context.Context, provider metricsapi.KeptnMetricsProvider, query func(ctx context.Context) error) error {
+	spec := provider.Spec.Resilience
+	if spec == nil {
+		return query(ctx)
+	}
+
+	state := resilience.GetProviderState(providerStateKey(provider.Namespace, provider.Name))
+	if spec.FailureThreshold > 0 && !state.Breaker.Allow() {
+		_, _, lastErr, openUntil := state.Breaker.Status()
+		return fmt.Errorf("%w until %s, last error: %s", resilience.ErrCircuitOpen, openUntil.UTC().Format(time.RFC3339), lastErr)
+	}
+
+	release, err := state.Acquire(ctx, spec.MaxConcurrentQueries)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	queryCtx, unavailable := resilience.WithSettings(ctx, resilience.Settings{
+		MaxRetries:     spec.MaxRetries,
+		InitialBackoff: spec.InitialBackoff.Duration,
+	})
+	err = query(queryCtx)
+
+	if spec.FailureThreshold <= 0 {
+		return err
+	}
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+	}
+	if state.Breaker.Record(unavailable(), errMsg, spec.FailureThreshold, spec.OpenDuration.Duration) {
+		r.updateStatus(ctx, provider, state.Breaker)
+	}
+	return err
+}
+
+// ProviderDeletedHandler drops the circuit breaker and concurrency limit of deleted KeptnMetricsProviders,
+// so that no state is kept for them and a provider created with the same name starts with a closed breaker
+var ProviderDeletedHandler = handler.Funcs{
+	DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+		resilience.DeleteProviderState(providerStateKey(e.Object.GetNamespace(), e.Object.GetName()))
+	},
+}
+
+func providerStateKey(namespace string, name string) string {
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+// updateStatus reflects the state of the circuit breaker in the Available condition of the KeptnMetricsProvider
+func (r *resilientProvider) updateStatus(ctx context.Context, provider metricsapi.KeptnMetricsProvider, breaker *resilience.CircuitBreaker) {
+	state, failures, lastErr, openUntil := breaker.Status()
+	condition := metav1.Condition{
+		Type:    ProviderAvailableConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  providerAvailableReason,
+		Message: "provider is available",
+	}
+	if state == resilience.StateOpen {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = providerDegradedReason
+		condition.Message = fmt.Sprintf("provider degraded: %d consecutive queries failed, queries are suspended until %s, last error: %s",
+			failures, openUntil.UTC().Format(time.RFC3339), lastErr)
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &metricsapi.KeptnMetricsProvider{}
+		if err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: provider.Namespace, Name: provider.Name}, latest); err != nil {
+			return err
+		}
+		condition.ObservedGeneration = latest.Generation
+		meta.SetStatusCondition(&latest.Status.Conditions, condition)
+		return r.k8sClient.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		r.log.Error(err, "could not update the status of the KeptnMetricsProvider", "provider", provider.Name, "namespace", provider.Namespace)
+	}
+}
@@ -0,0 +1,173 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	k8sfake "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/fake"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/fake"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/resilience"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestResilientProvider_CircuitBreaker(t *testing.T) {
+	healthy := atomic.Bool{}
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	httpClient := http.Client{Transport: resilience.NewRetryTransport(http.DefaultTransport)}
+	mock := &fake.KeptnSLIProviderMock{
+		EvaluateQueryFunc: func(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, svr.URL, nil)
+			if err != nil {
+				return "", nil, err
+			}
+			res, err := httpClient.Do(req)
+			if err != nil {
+				return "", nil, err
+			}
+			defer res.Body.Close()
+			if res.StatusCode != http.StatusOK {
+				return "", nil, fmt.Errorf("unexpected status code %d", res.StatusCode)
+			}
+			return "1", nil, nil
+		},
+	}
+
+	provider := &metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "breaker-test",
+			Namespace: "default",
+		},
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			Type: PrometheusProviderType,
+			Resilience: &metricsapi.ResilienceSpec{
+				MaxRetries:       1,
+				InitialBackoff:   metav1.Duration{Duration: time.Millisecond},
+				FailureThreshold: 2,
+				OpenDuration:     metav1.Duration{Duration: 50 * time.Millisecond},
+			},
+		},
+	}
+	k8sClient := k8sfake.NewClient(provider)
+	r := &resilientProvider{KeptnSLIProvider: mock, k8sClient: k8sClient, log: logr.Discard()}
+
+	getCondition := func() *metav1.Condition {
+		latest := &metricsapi.KeptnMetricsProvider{}
+		require.Nil(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "breaker-test"}, latest))
+		return meta.FindStatusCondition(latest.Status.Conditions, ProviderAvailableConditionType)
+	}
+
+	// the provider is unavailable until the failure threshold is reached
+	for i := 0; i < 2; i++ {
+		_, _, err := r.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{}, *provider)
+		require.NotNil(t, err)
+		require.False(t, errors.Is(err, resilience.ErrCircuitOpen))
+	}
+	require.Len(t, mock.EvaluateQueryCalls(), 2)
+
+	condition := getCondition()
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, providerDegradedReason, condition.Reason)
+
+	// queries are suspended while the breaker is open
+	_, _, err := r.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{}, *provider)
+	require.ErrorIs(t, err, resilience.ErrCircuitOpen)
+	require.Len(t, mock.EvaluateQueryCalls(), 2)
+
+	// the provider recovers and the probe after the open duration closes the breaker
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	value, _, err := r.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{}, *provider)
+	require.Nil(t, err)
+	require.Equal(t, "1", value)
+
+	condition = getCondition()
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, providerAvailableReason, condition.Reason)
+}
+
+func TestResilientProvider_QueryErrorsDoNotOpenBreaker(t *testing.T) {
+	mock := &fake.KeptnSLIProviderMock{
+		EvaluateQueryFunc: func(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+			return "", nil, errors.New("invalid query")
+		},
+	}
+	provider := metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "query-error-test",
+			Namespace: "default",
+		},
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			Type: PrometheusProviderType,
+			Resilience: &metricsapi.ResilienceSpec{
+				FailureThreshold: 1,
+				OpenDuration:     metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+	r := &resilientProvider{KeptnSLIProvider: mock, k8sClient: k8sfake.NewClient(), log: logr.Discard()}
+
+	for i := 0; i < 3; i++ {
+		_, _, err := r.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{}, provider)
+		require.EqualError(t, err, "invalid query")
+	}
+	require.Len(t, mock.EvaluateQueryCalls(), 3)
+}
+
+func TestResilientProvider_WithoutResilienceSpec(t *testing.T) {
+	mock := &fake.KeptnSLIProviderMock{
+		FetchAnalysisValueFunc: func(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+			return "", errors.New("unavailable")
+		},
+	}
+	provider := &metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "no-resilience-test",
+			Namespace: "default",
+		},
+	}
+	r := &resilientProvider{KeptnSLIProvider: mock, k8sClient: k8sfake.NewClient(), log: logr.Discard()}
+
+	for i := 0; i < 10; i++ {
+		_, err := r.FetchAnalysisValue(context.TODO(), "query", metricsapi.Analysis{}, provider)
+		require.EqualError(t, err, "unavailable")
+	}
+	require.Len(t, mock.FetchAnalysisValueCalls(), 10)
+}
+
+func TestProviderDeletedHandler(t *testing.T) {
+	provider := &metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deleted-test",
+			Namespace: "default",
+		},
+	}
+	state := resilience.GetProviderState("default/deleted-test")
+
+	ProviderDeletedHandler.Update(context.TODO(), event.UpdateEvent{ObjectOld: provider, ObjectNew: provider}, nil)
+	require.Same(t, state, resilience.GetProviderState("default/deleted-test"))
+
+	ProviderDeletedHandler.Delete(context.TODO(), event.DeleteEvent{Object: provider}, nil)
+	require.NotSame(t, state, resilience.GetProviderState("default/deleted-test"))
+}
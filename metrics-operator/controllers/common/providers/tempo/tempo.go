@@ -0,0 +1,140 @@
+package tempo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const queryRangePath = "/api/metrics/query_range"
+const defaultInterval = 5 * time.Minute
+
+var errNoValues = errors.New("no values in query result")
+var errTooManyValues = errors.New("too many values in query result")
+
+type KeptnTempoProvider struct {
+	Log       logr.Logger
+	K8sClient client.Client
+	Getter    prometheus.IRoundTripper
+}
+
+type tempoResponse struct {
+	Series []tempoSeries `json:"series"`
+}
+
+type tempoSeries struct {
+	PromLabels string        `json:"promLabels,omitempty"`
+	Samples    []tempoSample `json:"samples"`
+}
+
+type tempoSample struct {
+	TimestampMs string  `json:"timestampMs"`
+	Value       float64 `json:"value"`
+}
+
+func NewTempoProvider(log logr.Logger, k8sClient client.Client) *KeptnTempoProvider {
+	return &KeptnTempoProvider{
+		Log:       log,
+		K8sClient: k8sClient,
+		Getter:    prometheus.RoundTripperRetriever{},
+	}
+}
+
+// FetchAnalysisValue evaluates the TraceQL metrics query over the timeframe of the Analysis
+func (t *KeptnTempoProvider) FetchAnalysisValue(ctx context.Context, query string, analysis metricsapi.Analysis, provider *metricsapi.KeptnMetricsProvider) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	from, to := analysis.GetFrom(), analysis.GetTo()
+	values, _, err := t.queryRange(ctx, query, *provider, from, to, to.Sub(from))
+	if err != nil {
+		return "", err
+	}
+	return values[len(values)-1], nil
+}
+
+// EvaluateQuery fetches the SLI values from Tempo provider.
+// As TraceQL metrics queries are always evaluated over a time range, the last value within the interval of the
+// metric is returned, with the interval defaulting to 5 minutes.
+func (t *KeptnTempoProvider) EvaluateQuery(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	interval := defaultInterval
+	if metric.Spec.Range != nil && metric.Spec.Range.Interval != "" {
+		var err error
+		interval, err = time.ParseDuration(metric.Spec.Range.Interval)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	queryTime := time.Now().UTC()
+	values, b, err := t.queryRange(ctx, metric.Spec.Query, provider, queryTime.Add(-interval), queryTime, interval)
+	if err != nil {
+		return "", b, err
+	}
+	return values[len(values)-1], b, nil
+}
+
+// EvaluateQueryForStep fetches the SLI values within the range of the metric from Tempo provider
+func (t *KeptnTempoProvider) EvaluateQueryForStep(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	interval, err := time.ParseDuration(metric.Spec.Range.Interval)
+	if err != nil {
+		return nil, nil, err
+	}
+	step, err := time.ParseDuration(metric.Spec.Range.Step)
+	if err != nil {
+		return nil, nil, err
+	}
+	queryTime := time.Now().UTC()
+	values, _, err := t.queryRange(ctx, metric.Spec.Query, provider, queryTime.Add(-interval), queryTime, step)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, nil, err
+	}
+	return values, b, nil
+}
+
+// queryRange returns the values of the single series the TraceQL metrics query yields
+func (t *KeptnTempoProvider) queryRange(ctx context.Context, query string, provider metricsapi.KeptnMetricsProvider, start time.Time, end time.Time, step time.Duration) ([]string, []byte, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+	params.Set("step", step.String())
+
+	b, err := prometheus.GetFromAPI(ctx, t.Log, t.Getter, t.K8sClient, provider, queryRangePath, params)
+	if err != nil {
+		return nil, b, err
+	}
+
+	result := tempoResponse{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, b, err
+	}
+	if len(result.Series) == 0 || len(result.Series[0].Samples) == 0 {
+		return nil, b, errNoValues
+	} else if len(result.Series) > 1 {
+		return nil, b, errTooManyValues
+	}
+
+	values := make([]string, len(result.Series[0].Samples))
+	for i, sample := range result.Series[0].Samples {
+		values[i] = strconv.FormatFloat(sample.Value, 'f', -1, 64)
+	}
+	return values, b, nil
+}
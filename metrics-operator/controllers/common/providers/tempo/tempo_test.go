@@ -0,0 +1,180 @@
+package tempo
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const seriesPayload = `{"series":[{"promLabels":"{}","samples":[{"timestampMs":"1700000000000","value":0.5},{"timestampMs":"1700000060000","value":1.5},{"timestampMs":"1700000120000","value":2}]}]}`
+const multipleSeriesPayload = `{"series":[{"promLabels":"{service=\"a\"}","samples":[{"timestampMs":"1700000000000","value":1}]},{"promLabels":"{service=\"b\"}","samples":[{"timestampMs":"1700000000000","value":2}]}]}`
+const emptyPayload = `{"series":[]}`
+
+func TestEvaluateQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		rangeSpc *metricsapi.RangeSpec
+		wantStep string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "default interval",
+			payload:  seriesPayload,
+			wantStep: "5m0s",
+			want:     "2",
+		},
+		{
+			name:     "interval of metric",
+			payload:  seriesPayload,
+			rangeSpc: &metricsapi.RangeSpec{Interval: "10m"},
+			wantStep: "10m0s",
+			want:     "2",
+		},
+		{
+			name:     "no values",
+			payload:  emptyPayload,
+			wantStep: "5m0s",
+			wantErr:  errNoValues.Error(),
+		},
+		{
+			name:     "too many values",
+			payload:  multipleSeriesPayload,
+			wantStep: "5m0s",
+			wantErr:  errTooManyValues.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, queryRangePath, r.URL.Path)
+				require.Equal(t, "{ } | rate()", r.URL.Query().Get("q"))
+				require.Equal(t, tt.wantStep, r.URL.Query().Get("step"))
+				_, err := w.Write([]byte(tt.payload))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			provider := NewTempoProvider(ctrl.Log.WithName("testytest"), fake.NewClient())
+			metric := metricsapi.KeptnMetric{
+				Spec: metricsapi.KeptnMetricSpec{
+					Query: "{ } | rate()",
+					Range: tt.rangeSpc,
+				},
+			}
+			p := metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{TargetServer: svr.URL}}
+
+			value, _, err := provider.EvaluateQuery(context.TODO(), metric, p)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestEvaluateQuery_StatusCode(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte("invalid TraceQL query"))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	provider := NewTempoProvider(ctrl.Log.WithName("testytest"), fake.NewClient())
+	p := metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{TargetServer: svr.URL}}
+
+	_, _, err := provider.EvaluateQuery(context.TODO(), metricsapi.KeptnMetric{Spec: metricsapi.KeptnMetricSpec{Query: "my-query"}}, p)
+	require.ErrorContains(t, err, "status code 400")
+}
+
+func TestEvaluateQueryForStep(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "1m0s", r.URL.Query().Get("step"))
+		_, err := w.Write([]byte(seriesPayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	provider := NewTempoProvider(ctrl.Log.WithName("testytest"), fake.NewClient())
+	metric := metricsapi.KeptnMetric{
+		Spec: metricsapi.KeptnMetricSpec{
+			Query: "{ } | rate()",
+			Range: &metricsapi.RangeSpec{
+				Interval: "5m",
+				Step:     "1m",
+			},
+		},
+	}
+	p := metricsapi.KeptnMetricsProvider{Spec: metricsapi.KeptnMetricsProviderSpec{TargetServer: svr.URL}}
+
+	values, raw, err := provider.EvaluateQueryForStep(context.TODO(), metric, p)
+	require.Nil(t, err)
+	require.Equal(t, []string{"0.5", "1.5", "2"}, values)
+	require.Equal(t, []byte(`["0.5","1.5","2"]`), raw)
+}
+
+func TestFetchAnalysisValueWithAuth(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoded := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:password"))
+		if r.Header.Get("Authorization") != encoded {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		require.Equal(t, strconv.FormatInt(from.Unix(), 10), r.URL.Query().Get("start"))
+		require.Equal(t, strconv.FormatInt(to.Unix(), 10), r.URL.Query().Get("end"))
+		require.Equal(t, "1h0m0s", r.URL.Query().Get("step"))
+		_, err := w.Write([]byte(seriesPayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempo-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"user":     []byte("user"),
+			"password": []byte("password"),
+		},
+	}
+	provider := NewTempoProvider(ctrl.Log.WithName("testytest"), fake.NewClient(secret))
+	p := &metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			TargetServer: svr.URL,
+			SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "tempo-secret"},
+			},
+		},
+	}
+	analysis := metricsapi.Analysis{
+		Status: metricsapi.AnalysisStatus{
+			Timeframe: metricsapi.Timeframe{
+				From: metav1.Time{Time: from},
+				To:   metav1.Time{Time: to},
+			},
+		},
+	}
+
+	value, err := provider.FetchAnalysisValue(context.TODO(), "{ } | rate()", analysis, p)
+	require.Nil(t, err)
+	require.Equal(t, "2", value)
+}
@@ -0,0 +1,88 @@
+package slo
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// windowPlaceholder is the name of the placeholder in the queries of a ServiceLevelObjective
+// which is replaced with the duration of the window
+const windowPlaceholder = "window"
+
+// ErrorBudget contains the compliance of a ServiceLevelObjective within its window
+type ErrorBudget struct {
+	// SLI is the ratio of good events
+	SLI float64
+	// Remaining is the ratio of the error budget which has not been consumed yet
+	Remaining float64
+}
+
+// AllowedErrorRate returns the ratio of bad events allowed by a target given in percent
+func AllowedErrorRate(target float64) (float64, error) {
+	if target <= 0 || target >= 100 {
+		return 0, fmt.Errorf("the target must be a percentage between 0 and 100, got %v", target)
+	}
+	return 1 - target/100, nil
+}
+
+// computeSLI returns the ratio of good events, which is 1 if no events have been recorded
+func computeSLI(good, total float64) float64 {
+	if total <= 0 {
+		return 1
+	}
+	return clamp(good/total, 0, 1)
+}
+
+// ComputeErrorBudget returns the SLI and the remaining error budget for the given numbers of good and total events
+func ComputeErrorBudget(good, total, allowedErrorRate float64) ErrorBudget {
+	sli := computeSLI(good, total)
+	return ErrorBudget{
+		SLI:       sli,
+		Remaining: 1 - (1-sli)/allowedErrorRate,
+	}
+}
+
+// ComputeBurnRate returns the ratio between the error rate and the allowed error rate
+func ComputeBurnRate(good, total, allowedErrorRate float64) float64 {
+	return (1 - computeSLI(good, total)) / allowedErrorRate
+}
+
+// GenerateQuery replaces the window placeholder of the query with the given duration
+func GenerateQuery(query string, window time.Duration) (string, error) {
+	tmpl, err := template.New("").Parse(query)
+	if err != nil {
+		return "", fmt.Errorf("could not create a template: %w", err)
+	}
+
+	var resultBuf bytes.Buffer
+	if err := tmpl.Execute(&resultBuf, map[string]string{windowPlaceholder: FormatWindow(window)}); err != nil {
+		return "", fmt.Errorf("could not template the window: %w", err)
+	}
+	return resultBuf.String(), nil
+}
+
+// FormatWindow formats a duration without trailing zero units, e.g. '1h' instead of '1h0m0s',
+// so that it can be used in the range selectors of the queries
+func FormatWindow(window time.Duration) string {
+	s := window.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func clamp(value, low, high float64) float64 {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
@@ -0,0 +1,88 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeErrorBudget(t *testing.T) {
+	tests := []struct {
+		name          string
+		good          float64
+		total         float64
+		wantSLI       float64
+		wantRemaining float64
+	}{
+		{
+			name:          "no errors",
+			good:          1000,
+			total:         1000,
+			wantSLI:       1,
+			wantRemaining: 1,
+		},
+		{
+			name:          "half of the budget consumed",
+			good:          9995,
+			total:         10000,
+			wantSLI:       0.9995,
+			wantRemaining: 0.5,
+		},
+		{
+			name:          "budget exceeded",
+			good:          9980,
+			total:         10000,
+			wantSLI:       0.998,
+			wantRemaining: -1,
+		},
+		{
+			name:          "no events",
+			good:          0,
+			total:         0,
+			wantSLI:       1,
+			wantRemaining: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := AllowedErrorRate(99.9)
+			require.Nil(t, err)
+			budget := ComputeErrorBudget(tt.good, tt.total, allowed)
+			require.InDelta(t, tt.wantSLI, budget.SLI, 0.000001)
+			require.InDelta(t, tt.wantRemaining, budget.Remaining, 0.000001)
+		})
+	}
+}
+
+func TestComputeBurnRate(t *testing.T) {
+	allowed, err := AllowedErrorRate(99)
+	require.Nil(t, err)
+	require.InDelta(t, 0.0, ComputeBurnRate(100, 100, allowed), 0.000001)
+	require.InDelta(t, 1.0, ComputeBurnRate(99, 100, allowed), 0.000001)
+	require.InDelta(t, 14.4, ComputeBurnRate(856, 1000, allowed), 0.000001)
+}
+
+func TestAllowedErrorRate(t *testing.T) {
+	_, err := AllowedErrorRate(100)
+	require.EqualError(t, err, "the target must be a percentage between 0 and 100, got 100")
+	_, err = AllowedErrorRate(0)
+	require.NotNil(t, err)
+}
+
+func TestGenerateQuery(t *testing.T) {
+	query, err := GenerateQuery("sum(increase(http_requests_total[{{.window}}]))", 720*time.Hour)
+	require.Nil(t, err)
+	require.Equal(t, "sum(increase(http_requests_total[720h]))", query)
+
+	_, err = GenerateQuery("sum(increase(http_requests_total[{{.window}]))", time.Hour)
+	require.NotNil(t, err)
+}
+
+func TestFormatWindow(t *testing.T) {
+	require.Equal(t, "1h", FormatWindow(time.Hour))
+	require.Equal(t, "1h30m", FormatWindow(90*time.Minute))
+	require.Equal(t, "5m", FormatWindow(5*time.Minute))
+	require.Equal(t, "45s", FormatWindow(45*time.Second))
+	require.Equal(t, "1m30s", FormatWindow(90*time.Second))
+}
@@ -0,0 +1,162 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/pkg/expression"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var ErrCompositeCycle = errors.New("composite KeptnMetric depends on itself")
+var ErrCompositeInputFailed = errors.New("input of composite KeptnMetric could not be evaluated")
+var ErrCompositeInputNoValue = errors.New("input of composite KeptnMetric has no value")
+
+// reconcileComposite computes the value of a composite metric from the current values of its inputs
+func (r *KeptnMetricReconciler) reconcileComposite(ctx context.Context, metric *metricsapi.KeptnMetric, requestInfo map[string]string) (ctrl.Result, error) {
+	value, rawValue, err := r.evaluateComposite(ctx, metric)
+
+	// inputs trigger the computation when their value changes, the requeue only catches missed updates
+	reconcile := r.updateMetric(metric, value, nil, rawValue, ctrl.Result{Requeue: true, RequeueAfter: getCompositeRequeueInterval(metric)}, err)
+
+	if err := r.Client.Status().Update(ctx, metric); err != nil {
+		r.Log.Error(err, "Failed to update the Metric status", "requestInfo", requestInfo)
+		return ctrl.Result{}, err
+	}
+	return reconcile, nil
+}
+
+// getCompositeRequeueInterval returns the fetch interval of the composite metric,
+// falling back to 10 seconds if none is set
+func getCompositeRequeueInterval(metric *metricsapi.KeptnMetric) time.Duration {
+	if metric.Spec.FetchIntervalSeconds == 0 {
+		return 10 * time.Second
+	}
+	return time.Second * time.Duration(metric.Spec.FetchIntervalSeconds)
+}
+
+func (r *KeptnMetricReconciler) evaluateComposite(ctx context.Context, metric *metricsapi.KeptnMetric) (string, []byte, error) {
+	cycle, err := metricsapi.FindCompositeCycle(ctx, r.Client, metric)
+	if err != nil {
+		return "", nil, err
+	}
+	if cycle != nil {
+		return "", nil, fmt.Errorf("%w: %s", ErrCompositeCycle, strings.Join(cycle, " -> "))
+	}
+
+	expr, err := expression.Parse(metric.Spec.Composite.Expression)
+	if err != nil {
+		return "", nil, err
+	}
+
+	values := make(map[string]float64, len(metric.Spec.Composite.Inputs))
+	rawValues := make(map[string]string, len(metric.Spec.Composite.Inputs))
+	for _, input := range metric.Spec.Composite.Inputs {
+		value, err := r.getInputValue(ctx, metric, input)
+		if err != nil {
+			return "", nil, err
+		}
+		floatValue, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("could not parse value %s of input %s: %w", value, input.Name, err)
+		}
+		values[input.Name] = floatValue
+		rawValues[input.Name] = value
+	}
+
+	result, err := expr.Evaluate(values)
+	if err != nil {
+		return "", nil, err
+	}
+	rawValue, err := json.Marshal(rawValues)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("%v", result), rawValue, nil
+}
+
+// getInputValue returns the current value of the KeptnMetric referenced by the input,
+// or the error which prevented the KeptnMetric from being evaluated
+func (r *KeptnMetricReconciler) getInputValue(ctx context.Context, metric *metricsapi.KeptnMetric, input metricsapi.CompositeInput) (string, error) {
+	key := metric.GetInputKey(input)
+	inputMetric := &metricsapi.KeptnMetric{}
+	if err := r.Client.Get(ctx, key, inputMetric); err != nil {
+		return "", fmt.Errorf("could not retrieve KeptnMetric %s of input %s: %w", key, input.Name, err)
+	}
+
+	value, errMsg := getLatestResult(inputMetric)
+	if errMsg != "" {
+		return "", fmt.Errorf("%w: input %s (KeptnMetric %s): %s", ErrCompositeInputFailed, input.Name, key, errMsg)
+	}
+	if value == "" {
+		return "", fmt.Errorf("%w: input %s (KeptnMetric %s)", ErrCompositeInputNoValue, input.Name, key)
+	}
+	return value, nil
+}
+
+// getLatestResult returns the latest value of the metric and the error of its latest evaluation
+func getLatestResult(metric *metricsapi.KeptnMetric) (string, string) {
+	if results := metric.Status.IntervalResults; len(results) > 0 {
+		// metrics storing their results only report them in the interval results
+		return results[len(results)-1].Value, results[len(results)-1].ErrMsg
+	}
+	return metric.Status.Value, metric.Status.ErrMsg
+}
+
+// getCompositeMetricsForInput returns the composite metrics that use the given KeptnMetric as input
+func (r *KeptnMetricReconciler) getCompositeMetricsForInput(ctx context.Context, obj client.Object) []reconcile.Request {
+	metrics := &metricsapi.KeptnMetricList{}
+	if err := r.Client.List(ctx, metrics); err != nil {
+		r.Log.Error(err, "Failed to list the Metrics")
+		return nil
+	}
+
+	input := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	var requests []reconcile.Request
+	for i := range metrics.Items {
+		metric := &metrics.Items[i]
+		if !metric.IsComposite() {
+			continue
+		}
+		for _, in := range metric.Spec.Composite.Inputs {
+			if metric.GetInputKey(in) == input {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: metric.Namespace, Name: metric.Name},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// inputChangedPredicate passes updates of KeptnMetrics whose value or error changed
+var inputChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldMetric, ok := e.ObjectOld.(*metricsapi.KeptnMetric)
+		if !ok {
+			return false
+		}
+		newMetric, ok := e.ObjectNew.(*metricsapi.KeptnMetric)
+		if !ok {
+			return false
+		}
+		oldValue, oldErrMsg := getLatestResult(oldMetric)
+		newValue, newErrMsg := getLatestResult(newMetric)
+		return oldValue != newValue || oldErrMsg != newErrMsg
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
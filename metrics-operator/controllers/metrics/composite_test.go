@@ -0,0 +1,191 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/fake"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newInputMetric(name string, status metricsapi.KeptnMetricStatus) *metricsapi.KeptnMetric {
+	return &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: metricsapi.KeptnMetricSpec{
+			Provider:             metricsapi.ProviderRef{Name: "provider"},
+			Query:                name,
+			FetchIntervalSeconds: 10,
+		},
+		Status: status,
+	}
+}
+
+func newCompositeMetric(name string, expression string, inputs ...string) *metricsapi.KeptnMetric {
+	metric := &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: metricsapi.KeptnMetricSpec{
+			FetchIntervalSeconds: 10,
+			Composite:            &metricsapi.CompositeSpec{Expression: expression},
+		},
+	}
+	for _, input := range inputs {
+		metric.Spec.Composite.Inputs = append(metric.Spec.Composite.Inputs, metricsapi.CompositeInput{
+			Name:           input,
+			KeptnMetricRef: metricsapi.ObjectReference{Name: input},
+		})
+	}
+	return metric
+}
+
+func TestKeptnMetricReconciler_ReconcileComposite(t *testing.T) {
+	tests := []struct {
+		name       string
+		metric     *metricsapi.KeptnMetric
+		inputs     []*metricsapi.KeptnMetric
+		wantValue  string
+		wantRaw    string
+		wantErrMsg string
+	}{
+		{
+			name:   "ratio of two inputs",
+			metric: newCompositeMetric("error-rate", "errors / requests * 100", "errors", "requests"),
+			inputs: []*metricsapi.KeptnMetric{
+				newInputMetric("errors", metricsapi.KeptnMetricStatus{Value: "5"}),
+				newInputMetric("requests", metricsapi.KeptnMetricStatus{Value: "200"}),
+			},
+			wantValue: "2.5",
+			wantRaw:   `{"errors":"5","requests":"200"}`,
+		},
+		{
+			name:   "input with stored results",
+			metric: newCompositeMetric("error-rate", "errors / requests * 100", "errors", "requests"),
+			inputs: []*metricsapi.KeptnMetric{
+				newInputMetric("errors", metricsapi.KeptnMetricStatus{Value: "5"}),
+				newInputMetric("requests", metricsapi.KeptnMetricStatus{IntervalResults: []metricsapi.IntervalResult{
+					{Value: "100"},
+					{Value: "50"},
+				}}),
+			},
+			wantValue: "10",
+			wantRaw:   `{"errors":"5","requests":"50"}`,
+		},
+		{
+			name:   "failed input",
+			metric: newCompositeMetric("error-rate", "errors / requests * 100", "errors", "requests"),
+			inputs: []*metricsapi.KeptnMetric{
+				newInputMetric("errors", metricsapi.KeptnMetricStatus{ErrMsg: "provider unavailable"}),
+				newInputMetric("requests", metricsapi.KeptnMetricStatus{Value: "200"}),
+			},
+			wantErrMsg: "input of composite KeptnMetric could not be evaluated: input errors (KeptnMetric default/errors): provider unavailable",
+		},
+		{
+			name:   "input without value",
+			metric: newCompositeMetric("error-rate", "errors / requests * 100", "errors", "requests"),
+			inputs: []*metricsapi.KeptnMetric{
+				newInputMetric("errors", metricsapi.KeptnMetricStatus{Value: "5"}),
+				newInputMetric("requests", metricsapi.KeptnMetricStatus{}),
+			},
+			wantErrMsg: "input of composite KeptnMetric has no value: input requests (KeptnMetric default/requests)",
+		},
+		{
+			name:   "missing input",
+			metric: newCompositeMetric("error-rate", "errors / requests * 100", "errors", "requests"),
+			inputs: []*metricsapi.KeptnMetric{
+				newInputMetric("errors", metricsapi.KeptnMetricStatus{Value: "5"}),
+			},
+			wantErrMsg: `could not retrieve KeptnMetric default/requests of input requests: keptnmetrics.metrics.keptn.sh "requests" not found`,
+		},
+		{
+			name:   "division by zero",
+			metric: newCompositeMetric("error-rate", "errors / requests * 100", "errors", "requests"),
+			inputs: []*metricsapi.KeptnMetric{
+				newInputMetric("errors", metricsapi.KeptnMetricStatus{Value: "5"}),
+				newInputMetric("requests", metricsapi.KeptnMetricStatus{Value: "0"}),
+			},
+			wantErrMsg: "division by zero",
+		},
+		{
+			name:   "cycle",
+			metric: newCompositeMetric("a", "b * 2", "b"),
+			inputs: []*metricsapi.KeptnMetric{
+				newCompositeMetric("b", "a / 2", "a"),
+			},
+			wantErrMsg: "composite KeptnMetric depends on itself: default/a -> default/b -> default/a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClient(tt.metric)
+			for _, input := range tt.inputs {
+				require.Nil(t, client.Create(context.TODO(), input))
+				if input.Status.Value != "" || input.Status.ErrMsg != "" || len(input.Status.IntervalResults) > 0 {
+					require.Nil(t, client.Status().Update(context.TODO(), input))
+				}
+			}
+			r := &KeptnMetricReconciler{
+				Client: client,
+				Scheme: client.Scheme(),
+				Log:    testr.New(t),
+			}
+
+			result, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: tt.metric.Name},
+			})
+			require.Nil(t, err)
+			require.Equal(t, controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second}, result)
+
+			got := &metricsapi.KeptnMetric{}
+			require.Nil(t, client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: tt.metric.Name}, got))
+			require.Equal(t, tt.wantValue, got.Status.Value)
+			require.Equal(t, tt.wantErrMsg, got.Status.ErrMsg)
+			if tt.wantRaw != "" {
+				require.JSONEq(t, tt.wantRaw, string(got.Status.RawValue))
+			}
+		})
+	}
+}
+
+func TestKeptnMetricReconciler_ReconcileComposite_RequeuesAfterFetchInterval(t *testing.T) {
+	metric := newCompositeMetric("double", "requests * 2", "requests")
+	metric.Spec.FetchIntervalSeconds = 60
+	client := fake.NewClient(metric, newInputMetric("requests", metricsapi.KeptnMetricStatus{}))
+	r := &KeptnMetricReconciler{
+		Client: client,
+		Scheme: client.Scheme(),
+		Log:    testr.New(t),
+	}
+
+	result, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "double"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, controllerruntime.Result{Requeue: true, RequeueAfter: time.Minute}, result)
+
+	require.Equal(t, 10*time.Second, getCompositeRequeueInterval(&metricsapi.KeptnMetric{}))
+}
+
+func TestKeptnMetricReconciler_getCompositeMetricsForInput(t *testing.T) {
+	client := fake.NewClient(
+		newInputMetric("requests", metricsapi.KeptnMetricStatus{}),
+		newCompositeMetric("error-rate", "errors / requests", "errors", "requests"),
+		newCompositeMetric("availability", "100 - error_rate", "error_rate"),
+		newCompositeMetric("double", "requests * 2", "requests"),
+	)
+	r := &KeptnMetricReconciler{
+		Client: client,
+		Log:    testr.New(t),
+	}
+
+	requests := r.getCompositeMetricsForInput(context.TODO(), newInputMetric("requests", metricsapi.KeptnMetricStatus{}))
+	require.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "error-rate"}},
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "double"}},
+	}, requests)
+}
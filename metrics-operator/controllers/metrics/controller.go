@@ -18,6 +18,7 @@ package metrics
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
@@ -28,23 +29,28 @@ import (
 	ctrlcommon "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/aggregation"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers"
-	"k8s.io/apimachinery/pkg/api/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 const MB = 1 << (10 * 2)
 
+var ErrUnsupportedAggregation = errors.New("unsupported aggregation")
+
 // KeptnMetricReconciler reconciles a KeptnMetric object
 type KeptnMetricReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
 	providers.ProviderFactory
 }
 
@@ -54,7 +60,9 @@ type KeptnMetricReconciler struct {
 // +kubebuilder:rbac:groups=metrics.keptn.sh,resources=keptnmetrics/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=metrics.keptn.sh,resources=keptnmetrics/finalizers,verbs=update
 // +kubebuilder:rbac:groups=metrics.keptn.sh,resources=keptnmetricsproviders,verbs=get;list;watch;
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=keptnmetricsproviders/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // role
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
@@ -70,7 +78,7 @@ func (r *KeptnMetricReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	metric := &metricsapi.KeptnMetric{}
 
 	if err := r.Client.Get(ctx, req.NamespacedName, metric); err != nil {
-		if errors.IsNotFound(err) {
+		if k8serrors.IsNotFound(err) {
 			// taking down all associated K8s resources is handled by K8s
 			r.Log.Info("Metric resource not found. Ignoring since object must be deleted", "requestInfo", requestInfo)
 			return ctrl.Result{}, nil
@@ -79,6 +87,10 @@ func (r *KeptnMetricReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
+	if metric.IsComposite() {
+		return r.reconcileComposite(ctx, metric, requestInfo)
+	}
+
 	fetchTime := metric.Status.LastUpdated.Add(time.Second * time.Duration(metric.Spec.FetchIntervalSeconds))
 	if time.Now().Before(fetchTime) {
 		diff := time.Until(fetchTime)
@@ -88,7 +100,7 @@ func (r *KeptnMetricReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	metricsProvider, err := r.fetchProvider(ctx, types.NamespacedName{Name: metric.Spec.Provider.Name, Namespace: metric.Namespace})
 	if err != nil {
-		if errors.IsNotFound(err) {
+		if k8serrors.IsNotFound(err) {
 			r.Log.Info(err.Error()+", ignoring error since object must be deleted", "requestInfo", requestInfo)
 			return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
 		}
@@ -103,9 +115,9 @@ func (r *KeptnMetricReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 	reconcile := ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}
 
-	value, rawValue, err := r.getResults(ctx, metric, provider, metricsProvider)
+	value, results, rawValue, err := r.getResults(ctx, metric, provider, metricsProvider)
 
-	reconcile = r.updateMetric(metric, value, rawValue, reconcile, err)
+	reconcile = r.updateMetric(metric, value, results, rawValue, reconcile, err)
 
 	if err := r.Client.Status().Update(ctx, metric); err != nil {
 		r.Log.Error(err, "Failed to update the Metric status", "requestInfo", requestInfo)
@@ -115,7 +127,7 @@ func (r *KeptnMetricReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return reconcile, err
 }
 
-func (r *KeptnMetricReconciler) updateMetric(metric *metricsapi.KeptnMetric, value string, rawValue []byte, reconcile ctrl.Result, err error) ctrl.Result {
+func (r *KeptnMetricReconciler) updateMetric(metric *metricsapi.KeptnMetric, value string, results []metricsapi.MetricResult, rawValue []byte, reconcile ctrl.Result, err error) ctrl.Result {
 	if metric.Spec.Range != nil && metric.Spec.Range.StoredResults > 0 {
 		intervalResult := metricsapi.IntervalResult{
 			LastUpdated: metav1.Time{Time: time.Now().UTC()},
@@ -142,19 +154,44 @@ func (r *KeptnMetricReconciler) updateMetric(metric *metricsapi.KeptnMetric, val
 		} else {
 			metric.Status.ErrMsg = ""
 			metric.Status.Value = value
+			metric.Status.Results = results
 			metric.Status.RawValue = cupSize(rawValue)
 		}
 	}
 	metric.Status.LastUpdated = metav1.Time{Time: time.Now().UTC()}
+	if err == nil {
+		r.recordHistory(metric, value, metric.Status.LastUpdated.Time)
+	}
 
 	return reconcile
 }
 
-func (r *KeptnMetricReconciler) getResults(ctx context.Context, metric *metricsapi.KeptnMetric, provider providers.KeptnSLIProvider, metricsProvider *metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+// getResults returns the value of the metric, or the values of all series with their labels
+// if the query of a metric without range returned more than one series
+func (r *KeptnMetricReconciler) getResults(ctx context.Context, metric *metricsapi.KeptnMetric, provider providers.KeptnSLIProvider, metricsProvider *metricsapi.KeptnMetricsProvider) (string, []metricsapi.MetricResult, []byte, error) {
 	if metric.Spec.Range != nil && metric.Spec.Range.Step != "" {
-		return r.getStepQueryResults(ctx, metric, provider, metricsProvider)
+		if metric.Spec.Range.RollingWindow {
+			value, rawValue, err := r.getRollingWindowResults(ctx, metric, provider, metricsProvider)
+			return value, nil, rawValue, err
+		}
+		value, rawValue, err := r.getStepQueryResults(ctx, metric, provider, metricsProvider)
+		return value, nil, rawValue, err
 	}
-	return r.getQueryResults(ctx, metric, provider, metricsProvider)
+	if metric.Spec.Range == nil {
+		results, rawValue, err := providers.EvaluateQueryResults(ctx, provider, *metric, *metricsProvider)
+		if err == nil {
+			if len(results) == 1 {
+				return results[0].Value, nil, cupSize(rawValue), nil
+			}
+			return "", results, cupSize(rawValue), nil
+		}
+		if !errors.Is(err, providers.ErrResultsNotSupported) {
+			r.Log.Error(err, "Failed to evaluate the query", "Response from provider was:", (string)(rawValue))
+			return "", nil, cupSize(rawValue), err
+		}
+	}
+	value, rawValue, err := r.getQueryResults(ctx, metric, provider, metricsProvider)
+	return value, nil, rawValue, err
 }
 func (r *KeptnMetricReconciler) getQueryResults(ctx context.Context, metric *metricsapi.KeptnMetric, provider providers.KeptnSLIProvider, metricsProvider *metricsapi.KeptnMetricsProvider) (string, []byte, error) {
 	value, rawValue, err := provider.EvaluateQuery(ctx, *metric, *metricsProvider)
@@ -170,7 +207,69 @@ func (r *KeptnMetricReconciler) getStepQueryResults(ctx context.Context, metric
 		r.Log.Error(err, "Failed to evaluate the query", "Response from provider was:", (string)(rawValue))
 		return "", cupSize(rawValue), err
 	}
-	aggValue, err := aggregateValues(value, metric.Spec.Range.Aggregation)
+	if metric.Spec.Range.RollingWindow {
+		metric.Status.RollingWindow = &metricsapi.RollingWindow{
+			Values:     value,
+			End:        metav1.Time{Time: time.Now().UTC()},
+			Generation: metric.Generation,
+		}
+	} else {
+		metric.Status.RollingWindow = nil
+	}
+	aggValue, err := aggregateValues(value, *metric.Spec.Range)
+	if err != nil {
+		return "", nil, err
+	}
+	return aggValue, cupSize(rawValue), nil
+}
+
+// getRollingWindowResults merges the values of the time passed since the last evaluation into the rolling window
+// stored in the status of the metric and aggregates the values of the window, instead of querying the whole interval
+func (r *KeptnMetricReconciler) getRollingWindowResults(ctx context.Context, metric *metricsapi.KeptnMetric, provider providers.KeptnSLIProvider, metricsProvider *metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+	interval, err := time.ParseDuration(metric.Spec.Range.Interval)
+	if err != nil {
+		return "", nil, err
+	}
+	step, err := time.ParseDuration(metric.Spec.Range.Step)
+	if err != nil {
+		return "", nil, err
+	}
+	window := metric.Status.RollingWindow
+	now := time.Now().UTC()
+	if step <= 0 || window == nil || window.Generation != metric.Generation || now.Sub(window.End.Time) >= interval {
+		return r.getStepQueryResults(ctx, metric, provider, metricsProvider)
+	}
+
+	newSteps := int(now.Sub(window.End.Time) / step)
+	if newSteps == 0 {
+		// keep the raw value of the last query, consumers of the metric rely on it being set
+		value, err := aggregateValues(window.Values, *metric.Spec.Range)
+		return value, metric.Status.RawValue, err
+	}
+
+	// only query the steps that are not part of the window yet
+	stepMetric := metric.DeepCopy()
+	stepMetric.Spec.Range.Interval = (time.Duration(newSteps) * step).String()
+	values, rawValue, err := provider.EvaluateQueryForStep(ctx, *stepMetric, *metricsProvider)
+	if err != nil {
+		r.Log.Error(err, "Failed to evaluate the query", "Response from provider was:", (string)(rawValue))
+		return "", cupSize(rawValue), err
+	}
+	if len(values) > newSteps {
+		values = values[len(values)-newSteps:]
+	}
+
+	merged := append(append([]string{}, window.Values...), values...)
+	if size := int(interval/step) + 1; len(merged) > size {
+		merged = merged[len(merged)-size:]
+	}
+	metric.Status.RollingWindow = &metricsapi.RollingWindow{
+		Values:     merged,
+		End:        metav1.Time{Time: window.End.Add(time.Duration(newSteps) * step)},
+		Generation: metric.Generation,
+	}
+
+	aggValue, err := aggregateValues(merged, *metric.Spec.Range)
 	if err != nil {
 		return "", nil, err
 	}
@@ -191,6 +290,14 @@ func cupSize(value []byte) []byte {
 func (r *KeptnMetricReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&metricsapi.KeptnMetric{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// recompute composite metrics when the value of one of their inputs changes
+		Watches(
+			&metricsapi.KeptnMetric{},
+			handler.EnqueueRequestsFromMapFunc(r.getCompositeMetricsForInput),
+			builder.WithPredicates(inputChangedPredicate),
+		).
+		// drop the resilience state of deleted providers
+		Watches(&metricsapi.KeptnMetricsProvider{}, providers.ProviderDeletedHandler).
 		Complete(r)
 }
 
@@ -202,13 +309,13 @@ func (r *KeptnMetricReconciler) fetchProvider(ctx context.Context, namespacedMet
 	return provider, nil
 }
 
-func aggregateValues(stringSlice []string, aggFunc string) (string, error) {
+func aggregateValues(stringSlice []string, rangeSpec metricsapi.RangeSpec) (string, error) {
 	floatSlice, err := stringSliceToFloatSlice(stringSlice)
 	if err != nil {
 		return "", err
 	}
 	var aggValue float64
-	switch aggFunc {
+	switch rangeSpec.Aggregation {
 	case "max":
 		aggValue = aggregation.CalculateMax(floatSlice)
 	case "min":
@@ -217,12 +324,36 @@ func aggregateValues(stringSlice []string, aggFunc string) (string, error) {
 		aggValue = aggregation.CalculateMedian(floatSlice)
 	case "avg":
 		aggValue = aggregation.CalculateAverage(floatSlice)
-	case "p90":
-		aggValue = aggregation.CalculatePercentile(sort.Float64Slice(floatSlice), 90)
-	case "p95":
-		aggValue = aggregation.CalculatePercentile(sort.Float64Slice(floatSlice), 95)
-	case "p99":
-		aggValue = aggregation.CalculatePercentile(sort.Float64Slice(floatSlice), 99)
+	case "sum":
+		aggValue = aggregation.CalculateSum(floatSlice)
+	case "count":
+		aggValue = aggregation.CalculateCount(floatSlice)
+	case "stddev":
+		aggValue = aggregation.CalculateStdDev(floatSlice)
+	case "last":
+		aggValue = aggregation.CalculateLast(floatSlice)
+	case "increase":
+		aggValue = aggregation.CalculateIncrease(floatSlice)
+	case "rate":
+		step, err := time.ParseDuration(rangeSpec.Step)
+		if err != nil {
+			return "", err
+		}
+		aggValue = aggregation.CalculateRate(floatSlice, step)
+	case "ewma":
+		smoothingFactor, err := rangeSpec.GetSmoothingFactor()
+		if err != nil {
+			return "", err
+		}
+		aggValue = aggregation.CalculateEWMA(floatSlice, smoothingFactor)
+	default:
+		perc, ok := rangeSpec.GetPercentile()
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedAggregation, rangeSpec.Aggregation)
+		}
+		sortedSlice := sort.Float64Slice(append([]float64{}, floatSlice...))
+		sortedSlice.Sort()
+		aggValue = aggregation.CalculatePercentile(sortedSlice, perc)
 	}
 	return fmt.Sprintf("%v", aggValue), nil
 }
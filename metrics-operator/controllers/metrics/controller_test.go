@@ -457,6 +457,289 @@ func TestKeptnMetricReconciler_Reconcile(t *testing.T) {
 	}
 }
 
+// resultsProviderMock is a KeptnSLIProvider which also implements providers.KeptnSLIResultsProvider
+type resultsProviderMock struct {
+	*providersfake.KeptnSLIProviderMock
+	results []metricsapi.MetricResult
+}
+
+func (p *resultsProviderMock) EvaluateQueryResults(_ context.Context, _ metricsapi.KeptnMetric, _ metricsapi.KeptnMetricsProvider) ([]metricsapi.MetricResult, []byte, error) {
+	return p.results, []byte("raw"), nil
+}
+
+func TestKeptnMetricReconciler_ReconcileResults(t *testing.T) {
+	tests := []struct {
+		name        string
+		results     []metricsapi.MetricResult
+		wantValue   string
+		wantResults []metricsapi.MetricResult
+	}{
+		{
+			name: "multiple series",
+			results: []metricsapi.MetricResult{
+				{Labels: map[string]string{"pod": "pod-1"}, Value: "1"},
+				{Labels: map[string]string{"pod": "pod-2"}, Value: "2"},
+			},
+			wantValue: "",
+			wantResults: []metricsapi.MetricResult{
+				{Labels: map[string]string{"pod": "pod-1"}, Value: "1"},
+				{Labels: map[string]string{"pod": "pod-2"}, Value: "2"},
+			},
+		},
+		{
+			name: "single series",
+			results: []metricsapi.MetricResult{
+				{Labels: map[string]string{"pod": "pod-1"}, Value: "1"},
+			},
+			wantValue:   "1",
+			wantResults: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := &metricsapi.KeptnMetric{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mymetric",
+					Namespace: "default",
+				},
+				Spec: metricsapi.KeptnMetricSpec{
+					Provider: metricsapi.ProviderRef{
+						Name: "provider-name",
+					},
+					Query:                "",
+					FetchIntervalSeconds: 10,
+				},
+			}
+			provider := &metricsapi.KeptnMetricsProvider{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "provider-name",
+					Namespace: "default",
+				},
+				Spec: metricsapi.KeptnMetricsProviderSpec{
+					TargetServer: "http://keptn.sh",
+					Type:         "prometheus",
+				},
+			}
+			client := fake.NewClient(metric, provider)
+			r := &KeptnMetricReconciler{
+				Client: client,
+				Scheme: client.Scheme(),
+				Log:    testr.New(t),
+				ProviderFactory: func(provider *metricsapi.KeptnMetricsProvider, log logr.Logger, k8sClient k8sclient.Client) (providers.KeptnSLIProvider, error) {
+					return &resultsProviderMock{
+						KeptnSLIProviderMock: &providersfake.KeptnSLIProviderMock{},
+						results:              tt.results,
+					}, nil
+				},
+			}
+			_, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "mymetric"},
+			})
+			require.Nil(t, err)
+
+			got := &metricsapi.KeptnMetric{}
+			err = client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "mymetric"}, got)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantValue, got.Status.Value)
+			require.Equal(t, tt.wantResults, got.Status.Results)
+			require.Equal(t, []byte("raw"), got.Status.RawValue)
+			require.Empty(t, got.Status.ErrMsg)
+		})
+	}
+}
+
+func TestKeptnMetricReconciler_getRollingWindowResults(t *testing.T) {
+	now := time.Now().UTC()
+	tests := []struct {
+		name          string
+		window        *metricsapi.RollingWindow
+		queryValues   []string
+		wantInterval  string
+		wantQueried   bool
+		wantValue     string
+		wantWindowLen int
+		wantEnd       time.Time
+	}{
+		{
+			name:          "no window yet queries the whole interval",
+			window:        nil,
+			queryValues:   []string{"1", "2", "3", "4", "5", "6"},
+			wantInterval:  "5m",
+			wantQueried:   true,
+			wantValue:     "21",
+			wantWindowLen: 6,
+		},
+		{
+			name: "outdated window queries the whole interval",
+			window: &metricsapi.RollingWindow{
+				Values: []string{"1", "1", "1", "1", "1", "1"},
+				End:    metav1.Time{Time: now.Add(-10 * time.Minute)},
+			},
+			queryValues:   []string{"1", "2", "3", "4", "5", "6"},
+			wantInterval:  "5m",
+			wantQueried:   true,
+			wantValue:     "21",
+			wantWindowLen: 6,
+		},
+		{
+			name: "window merges the new steps",
+			window: &metricsapi.RollingWindow{
+				Values: []string{"1", "2", "3", "4", "5", "6"},
+				End:    metav1.Time{Time: now.Add(-2*time.Minute - 10*time.Second)},
+			},
+			queryValues:   []string{"6", "7", "8"},
+			wantInterval:  "2m0s",
+			wantQueried:   true,
+			wantValue:     "33",
+			wantWindowLen: 6,
+			wantEnd:       now.Add(-10 * time.Second),
+		},
+		{
+			name: "window rolls over the oldest steps",
+			window: &metricsapi.RollingWindow{
+				Values: []string{"1", "2", "3", "4", "5", "6"},
+				End:    metav1.Time{Time: now.Add(-4*time.Minute - 30*time.Second)},
+			},
+			queryValues:   []string{"7", "8", "9", "10"},
+			wantInterval:  "4m0s",
+			wantQueried:   true,
+			wantValue:     "45",
+			wantWindowLen: 6,
+			wantEnd:       now.Add(-30 * time.Second),
+		},
+		{
+			name: "provider returning more values than new steps keeps only the newest",
+			window: &metricsapi.RollingWindow{
+				Values: []string{"1", "2", "3", "4", "5", "6"},
+				End:    metav1.Time{Time: now.Add(-time.Minute - 5*time.Second)},
+			},
+			queryValues:   []string{"6", "7"},
+			wantInterval:  "1m0s",
+			wantQueried:   true,
+			wantValue:     "27",
+			wantWindowLen: 6,
+			wantEnd:       now.Add(-5 * time.Second),
+		},
+		{
+			name: "window younger than a step is not queried",
+			window: &metricsapi.RollingWindow{
+				Values: []string{"1", "2", "3", "4", "5", "6"},
+				End:    metav1.Time{Time: now.Add(-10 * time.Second)},
+			},
+			wantQueried:   false,
+			wantValue:     "21",
+			wantWindowLen: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := &metricsapi.KeptnMetric{
+				Spec: metricsapi.KeptnMetricSpec{
+					Range: &metricsapi.RangeSpec{
+						Interval:      "5m",
+						Step:          "1m",
+						Aggregation:   "sum",
+						RollingWindow: true,
+					},
+				},
+				Status: metricsapi.KeptnMetricStatus{
+					RollingWindow: tt.window,
+					RawValue:      []byte("previous raw"),
+				},
+			}
+			mock := &providersfake.KeptnSLIProviderMock{
+				EvaluateQueryForStepFunc: func(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
+					return tt.queryValues, []byte("raw"), nil
+				},
+			}
+			r := &KeptnMetricReconciler{Log: testr.New(t)}
+
+			value, rawValue, err := r.getRollingWindowResults(context.TODO(), metric, mock, &metricsapi.KeptnMetricsProvider{})
+			require.Nil(t, err)
+			require.Equal(t, tt.wantValue, value)
+			require.Len(t, metric.Status.RollingWindow.Values, tt.wantWindowLen)
+
+			calls := mock.EvaluateQueryForStepCalls()
+			if !tt.wantQueried {
+				require.Empty(t, calls)
+				require.Equal(t, []byte("previous raw"), rawValue)
+				return
+			}
+			require.Equal(t, []byte("raw"), rawValue)
+			require.Len(t, calls, 1)
+			require.Equal(t, tt.wantInterval, calls[0].Metric.Spec.Range.Interval)
+			if !tt.wantEnd.IsZero() {
+				// the window end advances by whole steps so it does not drift
+				require.True(t, tt.wantEnd.Equal(metric.Status.RollingWindow.End.Time))
+				return
+			}
+			require.WithinDuration(t, now, metric.Status.RollingWindow.End.Time, time.Minute)
+		})
+	}
+}
+
+func TestKeptnMetricReconciler_Reconcile_RollingWindowKeepsRawValue(t *testing.T) {
+	metric := &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mymetric",
+			Namespace: "default",
+		},
+		Spec: metricsapi.KeptnMetricSpec{
+			Provider:             metricsapi.ProviderRef{Name: "provider-name"},
+			Query:                "my-query",
+			FetchIntervalSeconds: 10,
+			Range: &metricsapi.RangeSpec{
+				Interval:      "5m",
+				Step:          "1m",
+				Aggregation:   "sum",
+				RollingWindow: true,
+			},
+		},
+	}
+	provider := &metricsapi.KeptnMetricsProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "provider-name",
+			Namespace: "default",
+		},
+		Spec: metricsapi.KeptnMetricsProviderSpec{
+			TargetServer: "http://keptn.sh",
+			Type:         "prometheus",
+		},
+	}
+	client := fake.NewClient(metric, provider)
+	mock := &providersfake.KeptnSLIProviderMock{
+		EvaluateQueryForStepFunc: func(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) ([]string, []byte, error) {
+			return []string{"1", "2", "3", "4", "5", "6"}, []byte("raw"), nil
+		},
+	}
+	r := &KeptnMetricReconciler{
+		Client: client,
+		Scheme: client.Scheme(),
+		Log:    testr.New(t),
+		ProviderFactory: func(provider *metricsapi.KeptnMetricsProvider, log logr.Logger, k8sClient k8sclient.Client) (providers.KeptnSLIProvider, error) {
+			return mock, nil
+		},
+	}
+	req := controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "mymetric"}}
+
+	_, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+
+	// the fetch interval has passed, but no new step is due yet
+	got := &metricsapi.KeptnMetric{}
+	require.Nil(t, client.Get(context.TODO(), req.NamespacedName, got))
+	got.Status.LastUpdated = metav1.Time{Time: time.Now().Add(-time.Minute)}
+	require.Nil(t, client.Status().Update(context.TODO(), got))
+
+	_, err = r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	require.Len(t, mock.EvaluateQueryForStepCalls(), 1)
+
+	require.Nil(t, client.Get(context.TODO(), req.NamespacedName, got))
+	require.Equal(t, "21", got.Status.Value)
+	require.Equal(t, []byte("raw"), got.Status.RawValue)
+}
+
 func Test_cupSize(t *testing.T) {
 	myVeryBigSlice := make([]byte, MB+1)
 	mySmallSlice := []byte("I am small")
@@ -476,8 +759,11 @@ func Test_AggregateValues(t *testing.T) {
 	tests := []struct {
 		name        string
 		aggFunc     string
+		step        string
+		smoothing   string
 		stringSlice []string
 		want        string
+		wantErr     error
 	}{
 		{
 			name:        "test-max-for-even-length",
@@ -606,18 +892,95 @@ func Test_AggregateValues(t *testing.T) {
 			want:        "0",
 		},
 		{
-			name:        "wrong-aggFunc",
+			name:        "test-p50-for-unsorted-values",
 			aggFunc:     "p50",
+			stringSlice: []string{"4", "1", "3", "2"},
+			want:        "2",
+		},
+		{
+			name:        "test-p99.9-for-unsorted-values",
+			aggFunc:     "p99.9",
+			stringSlice: []string{"5", "1", "3", "2", "4"},
+			want:        "5",
+		},
+		{
+			name:        "test-sum",
+			aggFunc:     "sum",
+			stringSlice: []string{"1", "2", "3", "4"},
+			want:        "10",
+		},
+		{
+			name:        "test-count",
+			aggFunc:     "count",
+			stringSlice: []string{"1", "2", "3", "4"},
+			want:        "4",
+		},
+		{
+			name:        "test-stddev",
+			aggFunc:     "stddev",
+			stringSlice: []string{"2", "4", "4", "4", "5", "5", "7", "9"},
+			want:        "2",
+		},
+		{
+			name:        "test-last",
+			aggFunc:     "last",
 			stringSlice: []string{"1", "2", "3", "4"},
+			want:        "4",
+		},
+		{
+			name:        "test-increase-with-counter-reset",
+			aggFunc:     "increase",
+			stringSlice: []string{"1", "3", "6", "2", "4"},
+			want:        "9",
+		},
+		{
+			name:        "test-rate",
+			aggFunc:     "rate",
+			step:        "10s",
+			stringSlice: []string{"0", "10", "20", "30"},
+			want:        "1",
+		},
+		{
+			name:        "test-ewma-with-default-smoothing-factor",
+			aggFunc:     "ewma",
+			stringSlice: []string{"4", "8", "4"},
+			want:        "5",
+		},
+		{
+			name:        "test-ewma-with-smoothing-factor",
+			aggFunc:     "ewma",
+			smoothing:   "1",
+			stringSlice: []string{"4", "8", "4"},
+			want:        "4",
+		},
+		{
+			name:        "test-sum-empty-string",
+			aggFunc:     "sum",
+			stringSlice: []string(nil),
 			want:        "0",
 		},
+		{
+			name:        "wrong-aggFunc",
+			aggFunc:     "mode",
+			stringSlice: []string{"1", "2", "3", "4"},
+			want:        "",
+			wantErr:     ErrUnsupportedAggregation,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Log(tt.name)
-			res, err := aggregateValues(tt.stringSlice, tt.aggFunc)
+			res, err := aggregateValues(tt.stringSlice, metricsapi.RangeSpec{
+				Aggregation:     tt.aggFunc,
+				Step:            tt.step,
+				SmoothingFactor: tt.smoothing,
+			})
 			require.Equal(t, tt.want, res)
-			require.Nil(t, err)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.Nil(t, err)
+			}
 		})
 	}
 }
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/anomaly"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	anomalyDetectedReason     = "AnomalyDetected"
+	anomalyResolvedReason     = "AnomalyResolved"
+	withinBaselineReason      = "WithinBaseline"
+	insufficientHistoryReason = "InsufficientHistory"
+	detectionFailedReason     = "DetectionFailed"
+)
+
+// recordHistory checks the new value of the metric for anomalies against the history of the metric,
+// if anomaly detection is enabled, and adds the value to the history
+func (r *KeptnMetricReconciler) recordHistory(metric *metricsapi.KeptnMetric, value string, now time.Time) {
+	historySpec := metric.Spec.History
+	if historySpec == nil {
+		metric.Status.History = nil
+		meta.RemoveStatusCondition(&metric.Status.Conditions, metricsapi.AnomalousConditionType)
+		return
+	}
+	if value == "" {
+		return
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		r.Log.Error(err, "Could not record non-numeric value in the history", "metric", metric.Name)
+		return
+	}
+
+	if historySpec.AnomalyDetection != nil {
+		r.detectAnomaly(metric, floatValue, now)
+	} else {
+		meta.RemoveStatusCondition(&metric.Status.Conditions, metricsapi.AnomalousConditionType)
+	}
+
+	history := metric.Status.History
+	if len(history) > 0 && now.Sub(history[len(history)-1].Timestamp.Time) < historySpec.Interval.Duration {
+		return
+	}
+	history = append(history, metricsapi.HistoryEntry{
+		Value:     value,
+		Timestamp: metav1.Time{Time: now},
+	})
+	maxEntries := int(historySpec.MaxEntries)
+	if maxEntries == 0 {
+		maxEntries = metricsapi.DefaultMaxHistoryEntries
+	}
+	if len(history) > maxEntries {
+		history = history[len(history)-maxEntries:]
+	}
+	metric.Status.History = history
+}
+
+// detectAnomaly reflects whether the value is an anomaly in the Anomalous condition of the metric
+// and emits an event when an anomaly is detected or resolved
+func (r *KeptnMetricReconciler) detectAnomaly(metric *metricsapi.KeptnMetric, value float64, now time.Time) {
+	// entries of the history are at least one fetch interval apart
+	tolerance := time.Duration(metric.Spec.FetchIntervalSeconds) * time.Second
+	if metric.Spec.History.Interval.Duration > tolerance {
+		tolerance = metric.Spec.History.Interval.Duration
+	}
+
+	wasAnomalous := meta.IsStatusConditionTrue(metric.Status.Conditions, metricsapi.AnomalousConditionType)
+	condition := metav1.Condition{
+		Type:               metricsapi.AnomalousConditionType,
+		ObservedGeneration: metric.Generation,
+	}
+
+	result, err := anomaly.Detect(*metric.Spec.History.AnomalyDetection, metric.Status.History, value, now, tolerance)
+	switch {
+	case errors.Is(err, anomaly.ErrInsufficientSamples):
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = insufficientHistoryReason
+		condition.Message = err.Error()
+	case err != nil:
+		r.Log.Error(err, "Could not detect anomalies", "metric", metric.Name)
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = detectionFailedReason
+		condition.Message = err.Error()
+	case result.Anomalous:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = anomalyDetectedReason
+		condition.Message = fmt.Sprintf("value %g is an anomaly: %s", value, result)
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = withinBaselineReason
+		condition.Message = fmt.Sprintf("value %g is within the baseline: %s", value, result)
+	}
+	meta.SetStatusCondition(&metric.Status.Conditions, condition)
+
+	if condition.Status == metav1.ConditionTrue && !wasAnomalous {
+		r.Recorder.Event(metric, "Warning", anomalyDetectedReason, condition.Message)
+	} else if condition.Status != metav1.ConditionTrue && wasAnomalous {
+		r.Recorder.Event(metric, "Normal", anomalyResolvedReason, condition.Message)
+	}
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestKeptnMetricReconciler_recordHistory(t *testing.T) {
+	now := time.Now().UTC()
+	metric := &metricsapi.KeptnMetric{
+		Spec: metricsapi.KeptnMetricSpec{
+			FetchIntervalSeconds: 10,
+			History: &metricsapi.HistorySpec{
+				MaxEntries: 3,
+				Interval:   metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+	r := &KeptnMetricReconciler{Log: testr.New(t)}
+
+	for i := 0; i < 5; i++ {
+		r.recordHistory(metric, strconv.Itoa(i), now.Add(time.Duration(i)*time.Minute))
+	}
+	require.Len(t, metric.Status.History, 3)
+	require.Equal(t, "2", metric.Status.History[0].Value)
+	require.Equal(t, "4", metric.Status.History[2].Value)
+
+	// values within the interval of the latest entry are not recorded
+	r.recordHistory(metric, "5", now.Add(4*time.Minute+30*time.Second))
+	require.Len(t, metric.Status.History, 3)
+	require.Equal(t, "4", metric.Status.History[2].Value)
+
+	// non-numeric values are not recorded
+	r.recordHistory(metric, "not-a-number", now.Add(10*time.Minute))
+	require.Equal(t, "4", metric.Status.History[2].Value)
+
+	// the history is removed if it is disabled
+	metric.Spec.History = nil
+	r.recordHistory(metric, "6", now.Add(11*time.Minute))
+	require.Empty(t, metric.Status.History)
+}
+
+func TestKeptnMetricReconciler_recordHistoryWithAnomalyDetection(t *testing.T) {
+	now := time.Now().UTC()
+	metric := &metricsapi.KeptnMetric{
+		Spec: metricsapi.KeptnMetricSpec{
+			FetchIntervalSeconds: 10,
+			History: &metricsapi.HistorySpec{
+				AnomalyDetection: &metricsapi.AnomalyDetectionSpec{
+					Method:     metricsapi.AnomalyDetectionMethodZScore,
+					MinSamples: 4,
+				},
+			},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &KeptnMetricReconciler{Log: testr.New(t), Recorder: recorder}
+
+	values := []string{"10", "12", "8", "10"}
+	for i, value := range values {
+		r.recordHistory(metric, value, now.Add(time.Duration(i)*10*time.Second))
+	}
+	condition := meta.FindStatusCondition(metric.Status.Conditions, metricsapi.AnomalousConditionType)
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionUnknown, condition.Status)
+	require.Equal(t, insufficientHistoryReason, condition.Reason)
+
+	r.recordHistory(metric, "11", now.Add(40*time.Second))
+	condition = meta.FindStatusCondition(metric.Status.Conditions, metricsapi.AnomalousConditionType)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, withinBaselineReason, condition.Reason)
+	require.Empty(t, recorder.Events)
+
+	r.recordHistory(metric, "100", now.Add(50*time.Second))
+	condition = meta.FindStatusCondition(metric.Status.Conditions, metricsapi.AnomalousConditionType)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, anomalyDetectedReason, condition.Reason)
+	require.Contains(t, <-recorder.Events, "Warning AnomalyDetected value 100 is an anomaly")
+
+	// a value within the baseline resolves the anomaly
+	r.recordHistory(metric, "10", now.Add(60*time.Second))
+	condition = meta.FindStatusCondition(metric.Status.Conditions, metricsapi.AnomalousConditionType)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Contains(t, <-recorder.Events, "Normal AnomalyResolved")
+	require.Len(t, metric.Status.History, 7)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	ctrlcommon "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers"
+	slocommon "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/slo"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ServiceLevelObjectiveReconciler reconciles a ServiceLevelObjective object
+type ServiceLevelObjectiveReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	providers.ProviderFactory
+}
+
+// clusterrole
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=servicelevelobjectives,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=servicelevelobjectives/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=keptnmetricsproviders,verbs=get;list;watch;
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
+func (r *ServiceLevelObjectiveReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	requestInfo := ctrlcommon.GetRequestInfo(req)
+	r.Log.Info("Reconciling ServiceLevelObjective", "requestInfo", requestInfo)
+	slo := &metricsapi.ServiceLevelObjective{}
+
+	if err := r.Client.Get(ctx, req.NamespacedName, slo); err != nil {
+		if errors.IsNotFound(err) {
+			r.Log.Info("ServiceLevelObjective resource not found. Ignoring since object must be deleted", "requestInfo", requestInfo)
+			return ctrl.Result{}, nil
+		}
+		r.Log.Error(err, "Failed to get the ServiceLevelObjective", "requestInfo", requestInfo)
+		return ctrl.Result{}, nil
+	}
+
+	fetchTime := slo.Status.LastUpdated.Add(slo.GetFetchInterval())
+	if time.Now().Before(fetchTime) {
+		r.Log.Info("ServiceLevelObjective has not been updated for the configured interval. Skipping", "requestInfo", requestInfo)
+		return ctrl.Result{Requeue: true, RequeueAfter: time.Until(fetchTime)}, nil
+	}
+
+	metricsProvider := &metricsapi.KeptnMetricsProvider{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: slo.Spec.Provider.Name, Namespace: slo.Namespace}, metricsProvider); err != nil {
+		r.Log.Error(err, "Failed to retrieve the provider", "requestInfo", requestInfo)
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+	}
+	provider, err := r.ProviderFactory(metricsProvider, r.Log, r.Client)
+	if err != nil {
+		r.Log.Error(err, "Failed to get the correct Metric Provider", "requestInfo", requestInfo)
+		return ctrl.Result{Requeue: false}, err
+	}
+
+	if err := r.updateErrorBudget(ctx, slo, provider, metricsProvider); err != nil {
+		r.Log.Error(err, "Failed to compute the error budget", "requestInfo", requestInfo)
+		slo.Status.ErrMsg = err.Error()
+	} else {
+		slo.Status.ErrMsg = ""
+	}
+	slo.Status.LastUpdated = metav1.Time{Time: time.Now().UTC()}
+
+	if err := r.Client.Status().Update(ctx, slo); err != nil {
+		r.Log.Error(err, "Failed to update the ServiceLevelObjective status", "requestInfo", requestInfo)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true, RequeueAfter: slo.GetFetchInterval()}, nil
+}
+
+// updateErrorBudget runs the queries of the ServiceLevelObjective for its window and burn rate windows
+// and stores the resulting compliance in its status. The status is only updated if all queries succeeded.
+func (r *ServiceLevelObjectiveReconciler) updateErrorBudget(ctx context.Context, slo *metricsapi.ServiceLevelObjective, provider providers.KeptnSLIProvider, metricsProvider *metricsapi.KeptnMetricsProvider) error {
+	allowed, err := slocommon.AllowedErrorRate(slo.Spec.Target.AsApproximateFloat64())
+	if err != nil {
+		return err
+	}
+
+	good, total, err := r.fetchEvents(ctx, slo, slo.GetWindow(), provider, metricsProvider)
+	if err != nil {
+		return err
+	}
+	budget := slocommon.ComputeErrorBudget(good, total, allowed)
+
+	burnRates := make([]metricsapi.BurnRate, 0, len(slo.GetBurnRateWindows()))
+	for _, window := range slo.GetBurnRateWindows() {
+		good, total, err := r.fetchEvents(ctx, slo, window.Duration, provider, metricsProvider)
+		if err != nil {
+			return err
+		}
+		burnRates = append(burnRates, metricsapi.BurnRate{
+			Window: window,
+			Value:  formatFloat(slocommon.ComputeBurnRate(good, total, allowed)),
+		})
+	}
+
+	slo.Status.SLI = formatFloat(budget.SLI * 100)
+	slo.Status.ErrorBudgetRemaining = formatFloat(budget.Remaining * 100)
+	slo.Status.Exhausted = budget.Remaining <= 0
+	slo.Status.BurnRates = burnRates
+	return nil
+}
+
+// fetchEvents returns the number of good and total events of the ServiceLevelObjective within the given window
+func (r *ServiceLevelObjectiveReconciler) fetchEvents(ctx context.Context, slo *metricsapi.ServiceLevelObjective, window time.Duration, provider providers.KeptnSLIProvider, metricsProvider *metricsapi.KeptnMetricsProvider) (float64, float64, error) {
+	good, err := r.evaluateQuery(ctx, slo, slo.Spec.GoodQuery, window, provider, metricsProvider)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not retrieve the good events for window %s: %w", slocommon.FormatWindow(window), err)
+	}
+	total, err := r.evaluateQuery(ctx, slo, slo.Spec.TotalQuery, window, provider, metricsProvider)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not retrieve the total events for window %s: %w", slocommon.FormatWindow(window), err)
+	}
+	return good, total, nil
+}
+
+func (r *ServiceLevelObjectiveReconciler) evaluateQuery(ctx context.Context, slo *metricsapi.ServiceLevelObjective, query string, window time.Duration, provider providers.KeptnSLIProvider, metricsProvider *metricsapi.KeptnMetricsProvider) (float64, error) {
+	q, err := slocommon.GenerateQuery(query, window)
+	if err != nil {
+		return 0, err
+	}
+	// the queries are run the same way as the query of a KeptnMetric, so that every provider type is supported
+	metric := metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      slo.Name,
+			Namespace: slo.Namespace,
+		},
+		Spec: metricsapi.KeptnMetricSpec{
+			Provider: slo.Spec.Provider,
+			Query:    q,
+		},
+	}
+	value, _, err := provider.EvaluateQuery(ctx, metric, *metricsProvider)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// formatFloat rounds the value to four decimals to avoid exposing floating point inaccuracies in the status
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1e4)/1e4, 'f', -1, 64)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ServiceLevelObjectiveReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metricsapi.ServiceLevelObjective{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,169 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/fake"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers"
+	providersfake "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers/fake"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestServiceLevelObjectiveReconciler_Reconcile(t *testing.T) {
+	// number of good and total events per window
+	events := map[string][2]string{
+		"720h": {"99950", "100000"},
+		"1h":   {"990", "1000"},
+		"6h":   {"6000", "6000"},
+	}
+
+	tests := []struct {
+		name          string
+		target        string
+		queryErr      error
+		wantSLI       string
+		wantRemaining string
+		wantExhausted bool
+		wantBurnRates []string
+		wantErrMsg    string
+	}{
+		{
+			name:          "budget remaining",
+			target:        "99.9",
+			wantSLI:       "99.95",
+			wantRemaining: "50",
+			wantBurnRates: []string{"10", "0"},
+		},
+		{
+			name:          "budget exhausted",
+			target:        "99.99",
+			wantSLI:       "99.95",
+			wantRemaining: "-400",
+			wantExhausted: true,
+			wantBurnRates: []string{"100", "0"},
+		},
+		{
+			name:       "query error",
+			target:     "99.9",
+			queryErr:   errors.New("provider unavailable"),
+			wantErrMsg: "could not retrieve the good events for window 720h: provider unavailable",
+		},
+		{
+			name:       "invalid target",
+			target:     "100",
+			wantErrMsg: "the target must be a percentage between 0 and 100, got 100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slo := &metricsapi.ServiceLevelObjective{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-slo",
+					Namespace: "default",
+				},
+				Spec: metricsapi.ServiceLevelObjectiveSpec{
+					Provider:   metricsapi.ProviderRef{Name: "my-provider"},
+					GoodQuery:  "good[{{.window}}]",
+					TotalQuery: "total[{{.window}}]",
+					Target:     resource.MustParse(tt.target),
+				},
+			}
+			provider := &metricsapi.KeptnMetricsProvider{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-provider",
+					Namespace: "default",
+				},
+				Spec: metricsapi.KeptnMetricsProviderSpec{
+					Type: "prometheus",
+				},
+			}
+
+			mock := &providersfake.KeptnSLIProviderMock{
+				EvaluateQueryFunc: func(ctx context.Context, metric metricsapi.KeptnMetric, provider metricsapi.KeptnMetricsProvider) (string, []byte, error) {
+					if tt.queryErr != nil {
+						return "", nil, tt.queryErr
+					}
+					query := metric.Spec.Query
+					window := query[strings.Index(query, "[")+1 : len(query)-1]
+					if strings.HasPrefix(query, "good") {
+						return events[window][0], nil, nil
+					}
+					return events[window][1], nil, nil
+				},
+			}
+
+			k8sClient := fake.NewClient(slo, provider)
+			r := &ServiceLevelObjectiveReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+				Log:    testr.New(t),
+				ProviderFactory: func(provider *metricsapi.KeptnMetricsProvider, log logr.Logger, k8sClient client.Client) (providers.KeptnSLIProvider, error) {
+					return mock, nil
+				},
+			}
+
+			req := controllerruntime.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-slo"},
+			}
+			got, err := r.Reconcile(context.TODO(), req)
+			require.Nil(t, err)
+			require.Equal(t, time.Minute, got.RequeueAfter)
+
+			result := &metricsapi.ServiceLevelObjective{}
+			require.Nil(t, k8sClient.Get(context.TODO(), req.NamespacedName, result))
+			require.Equal(t, tt.wantErrMsg, result.Status.ErrMsg)
+			require.False(t, result.Status.LastUpdated.IsZero())
+			require.Equal(t, tt.wantSLI, result.Status.SLI)
+			require.Equal(t, tt.wantRemaining, result.Status.ErrorBudgetRemaining)
+			require.Equal(t, tt.wantExhausted, result.Status.Exhausted)
+			require.Len(t, result.Status.BurnRates, len(tt.wantBurnRates))
+			for i, burnRate := range tt.wantBurnRates {
+				require.Equal(t, burnRate, result.Status.BurnRates[i].Value)
+			}
+
+			// the status is not updated again before the fetch interval has passed
+			calls := len(mock.EvaluateQueryCalls())
+			got, err = r.Reconcile(context.TODO(), req)
+			require.Nil(t, err)
+			require.Greater(t, got.RequeueAfter, time.Duration(0))
+			require.Len(t, mock.EvaluateQueryCalls(), calls)
+		})
+	}
+}
+
+func TestServiceLevelObjectiveReconciler_ProviderNotFound(t *testing.T) {
+	slo := &metricsapi.ServiceLevelObjective{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-slo",
+			Namespace: "default",
+		},
+		Spec: metricsapi.ServiceLevelObjectiveSpec{
+			Provider: metricsapi.ProviderRef{Name: "my-provider"},
+		},
+	}
+	k8sClient := fake.NewClient(slo)
+	r := &ServiceLevelObjectiveReconciler{
+		Client:          k8sClient,
+		Scheme:          k8sClient.Scheme(),
+		Log:             testr.New(t),
+		ProviderFactory: providers.NewProvider,
+	}
+
+	got, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-slo"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, 10*time.Second, got.RequeueAfter)
+}
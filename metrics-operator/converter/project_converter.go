@@ -0,0 +1,179 @@
+package converter
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"time"
+
+	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	sliFileName = "sli.yaml"
+	sloFileName = "slo.yaml"
+)
+
+// ProjectConverter converts the sli.yaml and slo.yaml files of a whole Keptn v1 project directory
+type ProjectConverter struct {
+	// BaselineOffset is the offset of the baseline timeframe the values are compared with
+	// when converting comparison criteria, e.g. '<=+10%'
+	BaselineOffset time.Duration
+}
+
+func NewProjectConverter() *ProjectConverter {
+	return &ProjectConverter{
+		BaselineOffset: defaultBaselineOffset,
+	}
+}
+
+// Convert converts each directory of the project containing a slo.yaml file, e.g. '<stage>/<service>/slo.yaml',
+// into an AnalysisDefinition, and the SLIs of the sli.yaml files located in the same directory or in its
+// subdirectories, e.g. '<stage>/<service>/prometheus/sli.yaml', into AnalysisValueTemplates.
+// The names of all resources are prefixed with the path of the directory, e.g. 'production-carts',
+// or with the name of the project if the slo.yaml file is located in the root of the project.
+func (c *ProjectConverter) Convert(project fs.FS, projectName string, provider string, namespace string) (string, error) {
+	sliConverter := NewSLIConverter()
+	if err := sliConverter.validateInput(provider, namespace); err != nil {
+		return "", err
+	}
+	sloConverter := NewSLOConverter()
+	sloConverter.BaselineOffset = c.BaselineOffset
+
+	sloDirs, sliFiles, err := findProjectFiles(project)
+	if err != nil {
+		return "", err
+	}
+	if len(sloDirs) == 0 {
+		return "", fmt.Errorf("no %s files found in project '%s'", sloFileName, projectName)
+	}
+
+	result := ""
+	for _, dir := range sloDirs {
+		prefix := projectName
+		if dir != "." {
+			prefix = dir
+		}
+		prefix = ConvertResourceName(prefix)
+		if err := ValidateResourceName(prefix); err != nil {
+			return "", err
+		}
+
+		indicators, err := readIndicators(project, getSLIFiles(dir, sloDirs, sliFiles))
+		if err != nil {
+			return "", err
+		}
+		templates := sliConverter.convertMapToAnalysisValueTemplate(indicators, provider, namespace)
+		sort.Slice(templates, func(i, j int) bool {
+			return templates[i].Name < templates[j].Name
+		})
+
+		definition, err := readAnalysisDefinition(project, path.Join(dir, sloFileName), sloConverter, prefix, namespace)
+		if err != nil {
+			return "", err
+		}
+
+		resources := make([]interface{}, 0, len(templates)+1)
+		for _, template := range templates {
+			template.Name = prefix + "-" + template.Name
+			resources = append(resources, template)
+		}
+		resources = append(resources, definition)
+
+		for _, resource := range resources {
+			yamlData, err := yaml.Marshal(resource)
+			if err != nil {
+				return "", fmt.Errorf("error marshalling data: %s", err.Error())
+			}
+			result += "---\n"
+			result += string(yamlData)
+		}
+	}
+
+	return result, nil
+}
+
+// findProjectFiles returns the sorted directories containing a slo.yaml file
+// and the paths of all sli.yaml files of the project
+func findProjectFiles(project fs.FS) ([]string, []string, error) {
+	sloDirs := []string{}
+	sliFiles := []string{}
+	err := fs.WalkDir(project, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch d.Name() {
+		case sloFileName:
+			sloDirs = append(sloDirs, path.Dir(p))
+		case sliFileName:
+			sliFiles = append(sliFiles, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading project: %s", err.Error())
+	}
+	sort.Strings(sloDirs)
+	sort.Strings(sliFiles)
+	return sloDirs, sliFiles, nil
+}
+
+// getSLIFiles returns the sli.yaml files located in the given directory or its direct subdirectories,
+// excluding subdirectories which contain a slo.yaml file themselves
+func getSLIFiles(dir string, sloDirs []string, sliFiles []string) []string {
+	result := []string{}
+	for _, file := range sliFiles {
+		fileDir := path.Dir(file)
+		if fileDir == dir || (path.Dir(fileDir) == dir && fileDir != "." && !slices.Contains(sloDirs, fileDir)) {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+// readIndicators merges the indicators of the given sli.yaml files
+func readIndicators(project fs.FS, files []string) (map[string]string, error) {
+	indicators := map[string]string{}
+	for _, file := range files {
+		fileContent, err := fs.ReadFile(project, file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading file content: %s", err.Error())
+		}
+		content := &SLI{}
+		if err := yaml.Unmarshal(fileContent, content); err != nil {
+			return nil, fmt.Errorf("error unmarshalling file content of %s: %s", file, err.Error())
+		}
+		for name, query := range content.Indicators {
+			indicators[name] = query
+		}
+	}
+	return indicators, nil
+}
+
+// readAnalysisDefinition converts the given slo.yaml file into an AnalysisDefinition
+// whose objectives reference the AnalysisValueTemplates with the given prefix
+func readAnalysisDefinition(project fs.FS, file string, sloConverter *SLOConverter, prefix string, namespace string) (*metricsapi.AnalysisDefinition, error) {
+	fileContent, err := fs.ReadFile(project, file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file content: %s", err.Error())
+	}
+	content := &SLO{}
+	if err := yaml.Unmarshal(fileContent, content); err != nil {
+		return nil, fmt.Errorf("error unmarshalling file content of %s: %s", file, err.Error())
+	}
+	definition, err := sloConverter.convertSLO(content, prefix, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error converting %s: %w", file, err)
+	}
+	for i := range definition.Spec.Objectives {
+		ref := &definition.Spec.Objectives[i].AnalysisValueTemplateRef
+		ref.Name = prefix + "-" + ref.Name
+	}
+	return definition, nil
+}
@@ -0,0 +1,136 @@
+package converter
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+const projectSLIContent = `---
+spec_version: "1.0"
+indicators:
+  throughput: "sum(rate(http_requests_total{service='$SERVICE'}[$DURATION_SECONDS]))"
+  response_time_p95: "histogram_quantile(0.95, sum(rate(http_response_time_bucket{service='$SERVICE'}[$DURATION_SECONDS])) by (le))"`
+
+const projectSLOContent = `---
+spec_version: "0.1.1"
+comparison:
+  aggregate_function: "avg"
+  compare_with: "single_result"
+  include_result_with_score: "pass"
+  number_of_comparison_results: 1
+objectives:
+  - sli: "response_time_p95"
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "<600"
+  - sli: "throughput"
+total_score:
+  pass: "90%"
+  warning: "75%"`
+
+const expectedProjectOutput = `---
+apiVersion: metrics.keptn.sh/v1
+kind: AnalysisValueTemplate
+metadata:
+  creationTimestamp: null
+  name: production-carts-response-time-p95
+spec:
+  provider:
+    name: prometheus
+    namespace: keptn
+  query: histogram_quantile(0.95, sum(rate(http_response_time_bucket{service='{{.service}}'}[$DURATION_SECONDS]))
+    by (le))
+---
+apiVersion: metrics.keptn.sh/v1
+kind: AnalysisValueTemplate
+metadata:
+  creationTimestamp: null
+  name: production-carts-throughput
+spec:
+  provider:
+    name: prometheus
+    namespace: keptn
+  query: sum(rate(http_requests_total{service='{{.service}}'}[$DURATION_SECONDS]))
+---
+apiVersion: metrics.keptn.sh/v1
+kind: AnalysisDefinition
+metadata:
+  creationTimestamp: null
+  name: production-carts
+spec:
+  objectives:
+  - analysisValueTemplateRef:
+      name: production-carts-response-time-p95
+      namespace: keptn
+    target:
+      failure:
+        greaterThanOrEqual:
+          fixedValue: "600"
+    weight: 1
+  - analysisValueTemplateRef:
+      name: production-carts-response-time-p95
+      namespace: keptn
+    baseline:
+      delta: percentage
+      offset: 24h0m0s
+    target:
+      failure:
+        greaterThan:
+          fixedValue: "10"
+    weight: 1
+  - analysisValueTemplateRef:
+      name: production-carts-throughput
+      namespace: keptn
+    target: {}
+    weight: 2
+  totalScore:
+    passPercentage: 90
+    warningPercentage: 75
+`
+
+func TestProjectConverter_Convert(t *testing.T) {
+	project := fstest.MapFS{
+		"production/carts/slo.yaml":            {Data: []byte(projectSLOContent)},
+		"production/carts/prometheus/sli.yaml": {Data: []byte(projectSLIContent)},
+	}
+
+	c := NewProjectConverter()
+
+	// no provider nor namespace
+	res, err := c.Convert(project, "sockshop", "", "")
+	require.NotNil(t, err)
+	require.Equal(t, "", res)
+
+	// no slo files
+	res, err = c.Convert(fstest.MapFS{}, "sockshop", "prometheus", "keptn")
+	require.NotNil(t, err)
+	require.Equal(t, "", res)
+
+	// invalid file content
+	res, err = c.Convert(fstest.MapFS{"slo.yaml": {Data: []byte("invalid")}}, "sockshop", "prometheus", "keptn")
+	require.NotNil(t, err)
+	require.Equal(t, "", res)
+
+	// happy path
+	res, err = c.Convert(project, "sockshop", "prometheus", "keptn")
+	require.Nil(t, err)
+	require.Equal(t, expectedProjectOutput, res)
+}
+
+func TestGetSLIFiles(t *testing.T) {
+	sloDirs := []string{".", "production", "production/carts"}
+	sliFiles := []string{
+		"dynatrace/sli.yaml",
+		"production/carts/prometheus/sli.yaml",
+		"production/carts/sli.yaml",
+		"production/sli.yaml",
+		"staging/carts/sli.yaml",
+	}
+
+	require.Equal(t, []string{"dynatrace/sli.yaml"}, getSLIFiles(".", sloDirs, sliFiles))
+	require.Equal(t, []string{"production/sli.yaml"}, getSLIFiles("production", sloDirs, sliFiles))
+	require.Equal(t, []string{"production/carts/prometheus/sli.yaml", "production/carts/sli.yaml"}, getSLIFiles("production/carts", sloDirs, sliFiles))
+}
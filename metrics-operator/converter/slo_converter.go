@@ -3,8 +3,10 @@ package converter
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
 	"gopkg.in/inf.v0"
@@ -13,11 +15,19 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// defaultBaselineOffset is the offset of the baseline used for comparison criteria if none is configured
+const defaultBaselineOffset = 24 * time.Hour
+
 type SLOConverter struct {
+	// BaselineOffset is the offset of the baseline timeframe the values are compared with
+	// when converting comparison criteria, e.g. '<=+10%'
+	BaselineOffset time.Duration
 }
 
 func NewSLOConverter() *SLOConverter {
-	return &SLOConverter{}
+	return &SLOConverter{
+		BaselineOffset: defaultBaselineOffset,
+	}
 }
 
 type SLO struct {
@@ -42,6 +52,21 @@ type Criteria struct {
 	Operators []string `yaml:"criteria,omitempty" json:"criteria,omitempty"`
 }
 
+// criteriaKind distinguishes criteria comparing the value with a fixed value from comparison criteria,
+// which compare the value with the value of previous evaluations
+type criteriaKind int
+
+const (
+	// fixedCriteria compare the value with a fixed value, e.g. '<600'
+	fixedCriteria criteriaKind = iota
+	// absoluteComparisonCriteria compare the absolute change of the value, e.g. '<=+50'
+	absoluteComparisonCriteria
+	// percentageComparisonCriteria compare the change of the value in percent, e.g. '<=+10%'
+	percentageComparisonCriteria
+)
+
+var criteriaKinds = []criteriaKind{fixedCriteria, absoluteComparisonCriteria, percentageComparisonCriteria}
+
 func (o *Objective) hasNotSupportedCriteria() bool {
 	// no pass criteria -> informative
 	if len(o.Pass) == 0 {
@@ -111,23 +136,82 @@ func (c *SLOConverter) convertSLO(sloContent *SLO, name string, namespace string
 				PassPercentage:    passPercentage,
 				WarningPercentage: warnPercentage,
 			},
-			// create a slice of size of len(sloContent.Objectives), but reserve capacity for
-			// double the size, as some objectives may be twice there (conversion of criteria with logical AND)
-			Objectives: make([]metricsapi.Objective, len(sloContent.Objectives)),
+			// reserve capacity for the objectives, some objectives may be there multiple times
+			// if they contain both fixed and comparison criteria
+			Objectives: make([]metricsapi.Objective, 0, len(sloContent.Objectives)),
 		},
 	}
 
 	// convert objectives one after another
-	indexObjectives := 0
+	converted := make([][]metricsapi.Objective, 0, len(sloContent.Objectives))
+	weightFactor := 1
 	for _, o := range sloContent.Objectives {
-		// remove criteria, which contain % in their operators
-		o = cleanupObjective(o)
+		objectives, err := c.convertObjective(o, namespace)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, objectives)
+		weightFactor = lcm(weightFactor, len(objectives))
+	}
+
+	// objectives which are split up by kind of criteria share the weight of the original objective,
+	// all weights are scaled so that the shares are integers and the total score is not changed
+	for _, objectives := range converted {
+		if weightFactor > 1 {
+			for i := range objectives {
+				objectives[i].Weight = getWeight(objectives[i].Weight) * weightFactor / len(objectives)
+			}
+		}
+		definition.Spec.Objectives = append(definition.Spec.Objectives, objectives...)
+	}
+	return definition, nil
+}
+
+// getWeight returns the weight of an objective, which is 1 if it is not set
+func getWeight(weight int) int {
+	if weight == 0 {
+		return 1
+	}
+	return weight
+}
+
+// lcm returns the least common multiple of the given positive integers
+func lcm(a, b int) int {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return a / x * b
+}
+
+// convertObjective converts an objective into one objective per kind of criteria it contains.
+// Comparison criteria are converted into objectives which compare the value with a baseline.
+// The values of these objectives are retrieved separately, as their baselines differ.
+// Objectives without any pass criteria are converted into a single informative objective.
+func (c *SLOConverter) convertObjective(o *Objective, namespace string) ([]metricsapi.Objective, error) {
+	filtered := make([]*Objective, 0, len(criteriaKinds))
+	baselines := make([]*metricsapi.Baseline, 0, len(criteriaKinds))
+	for _, kind := range criteriaKinds {
+		f := filterObjective(o, kind)
+		if len(f.Pass) == 0 {
+			continue
+		}
+		filtered = append(filtered, f)
+		baselines = append(baselines, c.newBaseline(kind))
+	}
+	if len(filtered) == 0 {
+		filtered = append(filtered, filterObjective(o, fixedCriteria))
+		baselines = append(baselines, nil)
+	}
+
+	objectives := make([]metricsapi.Objective, 0, len(filtered))
+	for i, f := range filtered {
 		// set up target
-		target, err := setupTarget(o)
+		target, err := setupTarget(f)
 		if err != nil {
 			return nil, err
 		}
-		objective := metricsapi.Objective{
+		objectives = append(objectives, metricsapi.Objective{
 			AnalysisValueTemplateRef: metricsapi.ObjectReference{
 				Name:      ConvertResourceName(o.Name),
 				Namespace: namespace,
@@ -135,11 +219,28 @@ func (c *SLOConverter) convertSLO(sloContent *SLO, name string, namespace string
 			KeyObjective: o.KeySLI,
 			Weight:       o.Weight,
 			Target:       *target,
+			Baseline:     baselines[i],
+		})
+	}
+	return objectives, nil
+}
+
+// newBaseline returns the baseline used to convert criteria of the given kind
+func (c *SLOConverter) newBaseline(kind criteriaKind) *metricsapi.Baseline {
+	switch kind {
+	case absoluteComparisonCriteria:
+		return &metricsapi.Baseline{
+			Offset: v1.Duration{Duration: c.BaselineOffset},
+			Delta:  metricsapi.DeltaAbsolute,
 		}
-		definition.Spec.Objectives[indexObjectives] = objective
-		indexObjectives++
+	case percentageComparisonCriteria:
+		return &metricsapi.Baseline{
+			Offset: v1.Duration{Duration: c.BaselineOffset},
+			Delta:  metricsapi.DeltaPercentage,
+		}
+	default:
+		return nil
 	}
-	return definition, nil
 }
 
 func (c *SLOConverter) validateInput(analysisDef, namespace string) error {
@@ -327,27 +428,32 @@ func createUnboundedInterval(op string) (*Interval, error) {
 	}, nil
 }
 
-func cleanupObjective(o *Objective) *Objective {
-	o.Pass = cleanupCriteria(o.Pass)
-	o.Warning = cleanupCriteria(o.Warning)
-	return o
+// filterObjective returns a copy of the objective which contains only the criteria of the given kind
+func filterObjective(o *Objective, kind criteriaKind) *Objective {
+	return &Objective{
+		Name:    o.Name,
+		KeySLI:  o.KeySLI,
+		Weight:  o.Weight,
+		Pass:    filterCriteria(o.Pass, kind),
+		Warning: filterCriteria(o.Warning, kind),
+	}
 }
 
-// remove % operators from criterium structure
-// if criteria did have only % operators, remove it from structure
-func cleanupCriteria(criteria []Criteria) []Criteria {
+// keep only the operators of the given kind in the criterium structure
+// if a criterium does not have any operators of this kind or is a duplicate, remove it from structure
+func filterCriteria(criteria []Criteria, kind criteriaKind) []Criteria {
 	newCriteria := make([]Criteria, 0, len(criteria))
 	for _, c := range criteria {
 		operators := make([]string, 0, len(c.Operators))
 		for _, op := range c.Operators {
-			// keep only criteria with real values, not percentage
-			if !strings.Contains(op, "%") {
-				// remove unneeded whitespaces from criteria string
-				operators = append(operators, strings.Replace(op, " ", "", -1))
+			// remove unneeded whitespaces from criteria string
+			op = strings.Replace(op, " ", "", -1)
+			if getCriteriaKind(op) == kind {
+				operators = append(operators, normalizeOperator(op, kind))
 			}
 		}
-		// if criterium does have operator, store it
-		if len(operators) > 0 {
+		// if criterium does have operator and is not already present, store it
+		if len(operators) > 0 && !containsCriteria(newCriteria, operators) {
 			newCriteria = append(newCriteria, Criteria{Operators: operators})
 		}
 	}
@@ -355,6 +461,39 @@ func cleanupCriteria(criteria []Criteria) []Criteria {
 	return newCriteria
 }
 
+// getCriteriaKind returns whether the operator compares with a fixed value or with previous values,
+// which is indicated by a sign or a % symbol, e.g. '<=+10%'
+func getCriteriaKind(op string) criteriaKind {
+	value := strings.TrimLeft(op, "<>=")
+	if strings.HasSuffix(value, "%") {
+		return percentageComparisonCriteria
+	}
+	if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
+		return absoluteComparisonCriteria
+	}
+	return fixedCriteria
+}
+
+// normalizeOperator removes the + sign and the % symbol from the value of comparison criteria,
+// e.g. '<=+10%' is normalized to '<=10'
+func normalizeOperator(op string, kind criteriaKind) string {
+	if kind == fixedCriteria {
+		return op
+	}
+	value := strings.TrimLeft(op, "<>=")
+	operator := strings.TrimSuffix(op, value)
+	return operator + strings.TrimPrefix(strings.TrimSuffix(value, "%"), "+")
+}
+
+func containsCriteria(criteria []Criteria, operators []string) bool {
+	for _, c := range criteria {
+		if slices.Equal(c.Operators, operators) {
+			return true
+		}
+	}
+	return false
+}
+
 // check if operator is valid and split it to operator and value
 func decodeOperatorAndValue(op string) (string, string, error) {
 	operators := []string{"<=", "<", ">=", ">"}
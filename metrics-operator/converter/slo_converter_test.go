@@ -3,6 +3,7 @@ package converter
 
 import (
 	"testing"
+	"time"
 
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
 	"github.com/stretchr/testify/require"
@@ -107,7 +108,7 @@ spec:
         notInRange:
           highBound: "800"
           lowBound: "600"
-    weight: 2
+    weight: 4
   - analysisValueTemplateRef:
       name: response-time-p91
       namespace: default
@@ -116,7 +117,7 @@ spec:
         inRange:
           highBound: "800"
           lowBound: "600"
-    weight: 5
+    weight: 10
   - analysisValueTemplateRef:
       name: response-time-p80
       namespace: default
@@ -128,7 +129,7 @@ spec:
         notInRange:
           highBound: "800"
           lowBound: "600"
-    weight: 2
+    weight: 4
   - analysisValueTemplateRef:
       name: response-time-p70
       namespace: default
@@ -140,7 +141,7 @@ spec:
         inRange:
           highBound: "800"
           lowBound: "600"
-    weight: 2
+    weight: 4
   - analysisValueTemplateRef:
       name: response-time-p95
       namespace: default
@@ -152,6 +153,20 @@ spec:
         greaterThanOrEqual:
           fixedValue: "800"
     weight: 1
+  - analysisValueTemplateRef:
+      name: response-time-p95
+      namespace: default
+    baseline:
+      delta: percentage
+      offset: 24h0m0s
+    target:
+      failure:
+        greaterThan:
+          fixedValue: "100"
+      warning:
+        greaterThan:
+          fixedValue: "75"
+    weight: 1
   - analysisValueTemplateRef:
       name: cpu
       namespace: default
@@ -160,14 +175,35 @@ spec:
         inRange:
           highBound: "100"
           lowBound: "80"
+    weight: 1
+  - analysisValueTemplateRef:
+      name: cpu
+      namespace: default
+    baseline:
+      delta: percentage
+      offset: 24h0m0s
+    target:
+      failure:
+        greaterThan:
+          fixedValue: "100"
+    weight: 1
   - analysisValueTemplateRef:
       name: throughput
       namespace: default
-    target: {}
+    baseline:
+      delta: percentage
+      offset: 24h0m0s
+    target:
+      failure:
+        notInRange:
+          highBound: "100"
+          lowBound: "-80"
+    weight: 2
   - analysisValueTemplateRef:
       name: error-rate
       namespace: default
     target: {}
+    weight: 2
   totalScore:
     passPercentage: 100
     warningPercentage: 65
@@ -1010,10 +1046,11 @@ func TestRemovePercentage(t *testing.T) {
 	}
 }
 
-func TestCleanupCriteria(t *testing.T) {
+func TestFilterCriteria(t *testing.T) {
 	tests := []struct {
 		name string
 		in   []Criteria
+		kind criteriaKind
 		out  []Criteria
 	}{
 		{
@@ -1088,16 +1125,70 @@ func TestCleanupCriteria(t *testing.T) {
 			},
 			out: []Criteria{},
 		},
+		{
+			name: "percentage comparison criteria",
+			in: []Criteria{
+				{
+					Operators: []string{"<100", "<= +10%", ">=-5%"},
+				},
+				{
+					Operators: []string{"<+5"},
+				},
+			},
+			kind: percentageComparisonCriteria,
+			out: []Criteria{
+				{
+					Operators: []string{"<=10", ">=-5"},
+				},
+			},
+		},
+		{
+			name: "absolute comparison criteria",
+			in: []Criteria{
+				{
+					Operators: []string{"<100", "<+5"},
+				},
+				{
+					Operators: []string{">-20", "<10%"},
+				},
+			},
+			kind: absoluteComparisonCriteria,
+			out: []Criteria{
+				{
+					Operators: []string{"<5"},
+				},
+				{
+					Operators: []string{">-20"},
+				},
+			},
+		},
+		{
+			name: "duplicate criteria",
+			in: []Criteria{
+				{
+					Operators: []string{"<=+100%", ">=100"},
+				},
+				{
+					Operators: []string{"<=+100%", "<=80"},
+				},
+			},
+			kind: percentageComparisonCriteria,
+			out: []Criteria{
+				{
+					Operators: []string{"<=100"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			require.Equal(t, tt.out, cleanupCriteria(tt.in))
+			require.Equal(t, tt.out, filterCriteria(tt.in, tt.kind))
 		})
 	}
 }
 
-func TestCleanupObjective(t *testing.T) {
+func TestFilterObjective(t *testing.T) {
 	tests := []struct {
 		name string
 		in   *Objective
@@ -1175,7 +1266,7 @@ func TestCleanupObjective(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			require.Equal(t, tt.out, cleanupObjective(tt.in))
+			require.Equal(t, tt.out, filterObjective(tt.in, fixedCriteria))
 		})
 	}
 }
@@ -1717,7 +1808,7 @@ func TestConvertSLO(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "objectives with percentage only - informative",
+			name: "objectives with percentage comparison criteria",
 			slo: &SLO{
 				TotalScore: Score{
 					Pass:    "50",
@@ -1767,21 +1858,46 @@ func TestConvertSLO(t *testing.T) {
 					},
 					Objectives: []metricsapi.Objective{
 						{
-							Target:       metricsapi.Target{},
+							Target: metricsapi.Target{
+								Failure: &metricsapi.Operator{
+									GreaterThan: &metricsapi.OperatorValue{
+										FixedValue: *resource.NewDecimalQuantity(*dec15, resource.DecimalSI),
+									},
+								},
+								Warning: &metricsapi.Operator{
+									GreaterThanOrEqual: &metricsapi.OperatorValue{
+										FixedValue: *resource.NewDecimalQuantity(*dec10, resource.DecimalSI),
+									},
+								},
+							},
 							Weight:       10,
 							KeyObjective: true,
 							AnalysisValueTemplateRef: metricsapi.ObjectReference{
 								Name:      "criteria",
 								Namespace: "default",
 							},
+							Baseline: &metricsapi.Baseline{
+								Offset: v1.Duration{Duration: 24 * time.Hour},
+								Delta:  metricsapi.DeltaPercentage,
+							},
 						},
 						{
-							Target: metricsapi.Target{},
+							Target: metricsapi.Target{
+								Failure: &metricsapi.Operator{
+									GreaterThanOrEqual: &metricsapi.OperatorValue{
+										FixedValue: *resource.NewDecimalQuantity(*dec10, resource.DecimalSI),
+									},
+								},
+							},
 							Weight: 5,
 							AnalysisValueTemplateRef: metricsapi.ObjectReference{
 								Name:      "criteria2",
 								Namespace: "default",
 							},
+							Baseline: &metricsapi.Baseline{
+								Offset: v1.Duration{Duration: 24 * time.Hour},
+								Delta:  metricsapi.DeltaPercentage,
+							},
 						},
 					},
 				},
@@ -1842,6 +1958,35 @@ func TestConvertSLO(t *testing.T) {
 
 }
 
+func TestConvertObjective(t *testing.T) {
+	c := NewSLOConverter()
+	c.BaselineOffset = time.Hour
+
+	objectives, err := c.convertObjective(&Objective{
+		Name: "response_time",
+		Pass: []Criteria{
+			{
+				Operators: []string{"<600", "<=+50"},
+			},
+		},
+		Weight: 2,
+	}, "default")
+
+	require.Nil(t, err)
+	require.Len(t, objectives, 2)
+
+	require.Nil(t, objectives[0].Baseline)
+	require.Equal(t, "600", objectives[0].Target.Failure.GreaterThanOrEqual.FixedValue.String())
+
+	require.Equal(t, &metricsapi.Baseline{
+		Offset: v1.Duration{Duration: time.Hour},
+		Delta:  metricsapi.DeltaAbsolute,
+	}, objectives[1].Baseline)
+	require.Equal(t, "50", objectives[1].Target.Failure.GreaterThan.FixedValue.String())
+	require.Equal(t, "response-time", objectives[1].AnalysisValueTemplateRef.Name)
+	require.Equal(t, 2, objectives[1].Weight)
+}
+
 func TestDecodeOperatorAndValue(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -2308,3 +2453,41 @@ func TestSLOConverter_validateInput(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertSLO_SplitObjectivesShareWeight(t *testing.T) {
+	c := NewSLOConverter()
+	definition, err := c.convertSLO(&SLO{
+		Objectives: []*Objective{
+			{
+				Name:   "response_time_p95",
+				Weight: 3,
+				Pass:   []Criteria{{Operators: []string{"<=+10%", "<600"}}},
+			},
+			{
+				Name:   "errors",
+				Weight: 2,
+				Pass:   []Criteria{{Operators: []string{"<=+5", "<=+10%", "<10"}}},
+			},
+			{
+				Name: "throughput",
+				Pass: []Criteria{{Operators: []string{">100"}}},
+			},
+		},
+		TotalScore: Score{Pass: "90%", Warning: "75%"},
+	}, "defname", "default")
+	require.Nil(t, err)
+
+	weights := map[string]int{}
+	for _, o := range definition.Spec.Objectives {
+		weights[o.AnalysisValueTemplateRef.Name] += o.Weight
+	}
+	// the weights are scaled by 6, the least common multiple of 2 and 3 split objectives
+	require.Len(t, definition.Spec.Objectives, 6)
+	require.Equal(t, map[string]int{
+		"response-time-p95": 18,
+		"errors":            12,
+		"throughput":        6,
+	}, weights)
+	require.Equal(t, 9, definition.Spec.Objectives[0].Weight)
+	require.Equal(t, 4, definition.Spec.Objectives[2].Weight)
+}
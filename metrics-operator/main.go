@@ -23,6 +23,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -37,6 +38,7 @@ import (
 	analysistypes "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/analysis/types"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/providers"
 	metricscontroller "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/metrics"
+	slocontroller "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/slo"
 	"github.com/keptn/lifecycle-toolkit/metrics-operator/converter"
 	keptnserver "github.com/keptn/lifecycle-toolkit/metrics-operator/pkg/metrics"
 	analysismetrics "github.com/keptn/lifecycle-toolkit/metrics-operator/pkg/metrics/analysis"
@@ -67,13 +69,14 @@ func init() {
 }
 
 type envConfig struct {
-	PodNamespace                  string `envconfig:"POD_NAMESPACE" default:""`
-	PodName                       string `envconfig:"POD_NAME" default:""`
-	KeptnMetricControllerLogLevel int    `envconfig:"METRICS_CONTROLLER_LOG_LEVEL" default:"0"`
-	AnalysisControllerLogLevel    int    `envconfig:"ANALYSIS_CONTROLLER_LOG_LEVEL" default:"0"`
-	ExposeKeptnMetrics            bool   `envconfig:"EXPOSE_KEPTN_METRICS" default:"true"`
-	EnableCustomMetricsAPIService bool   `envconfig:"ENABLE_CUSTOM_METRICS_API_SERVICE" default:"true"`
-	CertManagerEnabled            bool   `envconfig:"CERT_MANAGER_ENABLED" default:"true"`
+	PodNamespace                  string        `envconfig:"POD_NAMESPACE" default:""`
+	PodName                       string        `envconfig:"POD_NAME" default:""`
+	KeptnMetricControllerLogLevel int           `envconfig:"METRICS_CONTROLLER_LOG_LEVEL" default:"0"`
+	AnalysisControllerLogLevel    int           `envconfig:"ANALYSIS_CONTROLLER_LOG_LEVEL" default:"0"`
+	ProviderQueryCacheTTL         time.Duration `envconfig:"PROVIDER_QUERY_CACHE_TTL" default:"10s"`
+	ExposeKeptnMetrics            bool          `envconfig:"EXPOSE_KEPTN_METRICS" default:"true"`
+	EnableCustomMetricsAPIService bool          `envconfig:"ENABLE_CUSTOM_METRICS_API_SERVICE" default:"true"`
+	CertManagerEnabled            bool          `envconfig:"CERT_MANAGER_ENABLED" default:"true"`
 }
 
 //nolint:gocyclo,funlen
@@ -88,6 +91,8 @@ func main() {
 	var namespace string
 	var SLOFilePath string
 	var analysisDefinition string
+	var projectPath string
+	var baselineOffset time.Duration
 	var enableLeaderElection bool
 	var disableWebhook bool
 	var probeAddr string
@@ -97,6 +102,8 @@ func main() {
 	flag.StringVar(&SLOFilePath, "convert-slo", "", "The path the the SLO file to be converted")
 	flag.StringVar(&analysisDefinition, "analysis-definition-name", "", "The name of AnalysisDefinition to be created")
 	flag.StringVar(&namespace, "analysis-value-template-namespace", "", "The namespace of the referenced AnalysisValueTemplate")
+	flag.StringVar(&projectPath, "convert-project", "", "The path to the Keptn v1 project directory to be converted")
+	flag.DurationVar(&baselineOffset, "baseline-offset", 24*time.Hour, "The offset of the baseline timeframe used to convert comparison criteria")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&disableWebhook, "disable-webhook", false, "Disable the registration of webhooks.")
@@ -125,7 +132,7 @@ func main() {
 
 	if SLOFilePath != "" {
 		// convert
-		content, err := convertSLO(SLOFilePath, analysisDefinition, namespace)
+		content, err := convertSLO(SLOFilePath, analysisDefinition, namespace, baselineOffset)
 		if err != nil {
 			log.Fatalf("failed to covert SLOs: %s", err.Error())
 			return
@@ -135,6 +142,18 @@ func main() {
 		return
 	}
 
+	if projectPath != "" {
+		// convert
+		content, err := convertProject(projectPath, provider, namespace, baselineOffset)
+		if err != nil {
+			log.Fatalf("failed to covert project: %s", err.Error())
+			return
+		}
+		// write out converted result
+		fmt.Print(content)
+		return
+	}
+
 	if env.EnableCustomMetricsAPIService {
 		// Start the custom metrics adapter
 		go startCustomMetricsAdapter(env.PodNamespace)
@@ -186,19 +205,33 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	keptnserver.StartServerManager(ctx, mgr.GetClient(), openfeature.NewClient("keptn"), env.ExposeKeptnMetrics, metricServerTickerInterval)
+	keptnserver.StartServerManager(ctx, mgr.GetClient(), openfeature.NewClient("keptn"), env.ExposeKeptnMetrics, metricServerTickerInterval, ctrl.Log.WithName("Keptn Metrics Server"))
+
+	providers.GetQueryCache(ctrl.Log.WithName("Provider Query Cache")).SetTTL(env.ProviderQueryCacheTTL)
 
 	metricsLogger := ctrl.Log.WithName("KeptnMetric Controller")
 	if err = (&metricscontroller.KeptnMetricReconciler{
 		Client:          mgr.GetClient(),
 		Scheme:          mgr.GetScheme(),
 		Log:             metricsLogger.V(env.KeptnMetricControllerLogLevel),
-		ProviderFactory: providers.NewProvider,
+		Recorder:        mgr.GetEventRecorderFor("keptnmetric-controller"),
+		ProviderFactory: providers.NewCachedProvider,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KeptnMetric")
 		os.Exit(1)
 	}
 
+	sloLogger := ctrl.Log.WithName("ServiceLevelObjective Controller")
+	if err = (&slocontroller.ServiceLevelObjectiveReconciler{
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Log:             sloLogger.V(env.KeptnMetricControllerLogLevel),
+		ProviderFactory: providers.NewCachedProvider,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ServiceLevelObjective")
+		os.Exit(1)
+	}
+
 	analysisLogger := ctrl.Log.WithName("KeptnAnalysis Controller")
 	targetEval := analysis.NewTargetEvaluator(&analysis.OperatorEvaluator{})
 	objEval := analysis.NewObjectiveEvaluator(&targetEval)
@@ -270,6 +303,10 @@ func setupValidationWebhooks(mgr manager.Manager) {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Analysis")
 		os.Exit(1)
 	}
+	if err := (&metricsapi.ServiceLevelObjective{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ServiceLevelObjective")
+		os.Exit(1)
+	}
 }
 
 func setupProbes(mgr manager.Manager) {
@@ -309,7 +346,7 @@ func convertSLI(SLIFilePath, provider, namespace string) (string, error) {
 	return content, nil
 }
 
-func convertSLO(SLOFilePath, analysisDefinition, namespace string) (string, error) {
+func convertSLO(SLOFilePath, analysisDefinition, namespace string, baselineOffset time.Duration) (string, error) {
 	//read file content
 	fileContent, err := os.ReadFile(SLOFilePath)
 	if err != nil {
@@ -318,6 +355,7 @@ func convertSLO(SLOFilePath, analysisDefinition, namespace string) (string, erro
 
 	// convert
 	c := converter.NewSLOConverter()
+	c.BaselineOffset = baselineOffset
 	content, err := c.Convert(fileContent, analysisDefinition, namespace)
 	if err != nil {
 		return "", err
@@ -325,3 +363,20 @@ func convertSLO(SLOFilePath, analysisDefinition, namespace string) (string, erro
 
 	return content, nil
 }
+
+func convertProject(projectPath, provider, namespace string, baselineOffset time.Duration) (string, error) {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading project path: %s", err.Error())
+	}
+
+	// convert
+	c := converter.NewProjectConverter()
+	c.BaselineOffset = baselineOffset
+	content, err := c.Convert(os.DirFS(absPath), filepath.Base(absPath), provider, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	return content, nil
+}
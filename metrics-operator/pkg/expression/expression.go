@@ -0,0 +1,130 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+var ErrInvalidExpression = errors.New("invalid expression")
+var ErrUnknownVariable = errors.New("unknown variable")
+var ErrDivisionByZero = errors.New("division by zero")
+
+// Expression is an arithmetic expression over numbers and variables,
+// supporting the operators +, -, *, / and parentheses
+type Expression struct {
+	root ast.Expr
+}
+
+// Parse parses the given arithmetic expression
+func Parse(expression string) (*Expression, error) {
+	root, err := parser.ParseExpr(expression)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidExpression, err.Error())
+	}
+	if err := validate(root); err != nil {
+		return nil, err
+	}
+	return &Expression{root: root}, nil
+}
+
+// Variables returns the names of all variables used in the expression, in order of their first occurrence
+func (e *Expression) Variables() []string {
+	var variables []string
+	seen := map[string]bool{}
+	ast.Inspect(e.root, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Ident); ok && !seen[ident.Name] {
+			seen[ident.Name] = true
+			variables = append(variables, ident.Name)
+		}
+		return true
+	})
+	return variables
+}
+
+// Evaluate computes the value of the expression for the given values of the variables
+func (e *Expression) Evaluate(values map[string]float64) (float64, error) {
+	return evaluate(e.root, values)
+}
+
+func validate(node ast.Expr) error {
+	switch n := node.(type) {
+	case *ast.BasicLit:
+		if n.Kind != token.INT && n.Kind != token.FLOAT {
+			return fmt.Errorf("%w: unsupported literal %s", ErrInvalidExpression, n.Value)
+		}
+		return nil
+	case *ast.Ident:
+		return nil
+	case *ast.ParenExpr:
+		return validate(n.X)
+	case *ast.UnaryExpr:
+		if n.Op != token.SUB && n.Op != token.ADD {
+			return fmt.Errorf("%w: unsupported operator %s", ErrInvalidExpression, n.Op)
+		}
+		return validate(n.X)
+	case *ast.BinaryExpr:
+		switch n.Op {
+		case token.ADD, token.SUB, token.MUL, token.QUO:
+		default:
+			return fmt.Errorf("%w: unsupported operator %s", ErrInvalidExpression, n.Op)
+		}
+		if err := validate(n.X); err != nil {
+			return err
+		}
+		return validate(n.Y)
+	default:
+		return fmt.Errorf("%w: only numbers, variables, +, -, *, / and parentheses are supported", ErrInvalidExpression)
+	}
+}
+
+func evaluate(node ast.Expr, values map[string]float64) (float64, error) {
+	switch n := node.(type) {
+	case *ast.BasicLit:
+		return strconv.ParseFloat(n.Value, 64)
+	case *ast.Ident:
+		value, ok := values[n.Name]
+		if !ok {
+			return 0, fmt.Errorf("%w: %s", ErrUnknownVariable, n.Name)
+		}
+		return value, nil
+	case *ast.ParenExpr:
+		return evaluate(n.X, values)
+	case *ast.UnaryExpr:
+		value, err := evaluate(n.X, values)
+		if err != nil {
+			return 0, err
+		}
+		if n.Op == token.SUB {
+			return -value, nil
+		}
+		return value, nil
+	case *ast.BinaryExpr:
+		x, err := evaluate(n.X, values)
+		if err != nil {
+			return 0, err
+		}
+		y, err := evaluate(n.Y, values)
+		if err != nil {
+			return 0, err
+		}
+		switch n.Op {
+		case token.ADD:
+			return x + y, nil
+		case token.SUB:
+			return x - y, nil
+		case token.MUL:
+			return x * y, nil
+		default:
+			if y == 0 {
+				return 0, ErrDivisionByZero
+			}
+			return x / y, nil
+		}
+	default:
+		return 0, ErrInvalidExpression
+	}
+}
@@ -0,0 +1,94 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression_Evaluate(t *testing.T) {
+	values := map[string]float64{
+		"errors":   5,
+		"requests": 200,
+		"other_2":  -1,
+	}
+	tests := []struct {
+		name          string
+		expression    string
+		want          float64
+		wantParseErr  error
+		wantEvalErr   error
+		wantVariables []string
+	}{
+		{
+			name:          "ratio in percent",
+			expression:    "errors / requests * 100",
+			want:          2.5,
+			wantVariables: []string{"errors", "requests"},
+		},
+		{
+			name:          "parentheses and unary minus",
+			expression:    "-(errors + other_2) * 2.5",
+			want:          -10,
+			wantVariables: []string{"errors", "other_2"},
+		},
+		{
+			name:          "operator precedence",
+			expression:    "1 + requests / 100 - 3",
+			want:          0,
+			wantVariables: []string{"requests"},
+		},
+		{
+			name:          "repeated variable",
+			expression:    "errors * errors",
+			want:          25,
+			wantVariables: []string{"errors"},
+		},
+		{
+			name:         "unsupported operator",
+			expression:   "errors % requests",
+			wantParseErr: ErrInvalidExpression,
+		},
+		{
+			name:         "function call",
+			expression:   "max(errors, requests)",
+			wantParseErr: ErrInvalidExpression,
+		},
+		{
+			name:         "syntax error",
+			expression:   "errors /",
+			wantParseErr: ErrInvalidExpression,
+		},
+		{
+			name:          "unknown variable",
+			expression:    "errors / unknown",
+			wantEvalErr:   ErrUnknownVariable,
+			wantVariables: []string{"errors", "unknown"},
+		},
+		{
+			name:          "division by zero",
+			expression:    "errors / (requests - 200)",
+			wantEvalErr:   ErrDivisionByZero,
+			wantVariables: []string{"errors", "requests"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.expression)
+			if tt.wantParseErr != nil {
+				require.ErrorIs(t, err, tt.wantParseErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantVariables, expr.Variables())
+
+			got, err := expr.Evaluate(values)
+			if tt.wantEvalErr != nil {
+				require.ErrorIs(t, err, tt.wantEvalErr)
+				return
+			}
+			require.Nil(t, err)
+			require.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
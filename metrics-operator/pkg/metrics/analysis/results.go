@@ -12,10 +12,16 @@ import (
 
 const analysisResultMetricName = "keptn_analysis_result"
 const objectiveResultMetricName = "keptn_objective_result"
+const analysisPassMetricName = "keptn_analysis_pass"
+const analysisWarningMetricName = "keptn_analysis_warning"
+const analysisPassRatioMetricName = "keptn_analysis_pass_ratio"
 
 type Metrics struct {
-	AnalysisResult  *prometheus.GaugeVec
-	ObjectiveResult *prometheus.GaugeVec
+	AnalysisResult    *prometheus.GaugeVec
+	ObjectiveResult   *prometheus.GaugeVec
+	AnalysisPass      *prometheus.GaugeVec
+	AnalysisWarning   *prometheus.GaugeVec
+	AnalysisPassRatio *prometheus.GaugeVec
 }
 
 // use singleton pattern here to avoid registering the same metrics on Prometheus multiple times
@@ -37,35 +43,57 @@ type resultsReporter struct {
 }
 
 func (r *resultsReporter) initialize(ctx context.Context, res chan analysistypes.AnalysisCompletion) {
-	labelNamesAnalysis := []string{"name", "namespace", "from", "to"}
-	a := prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Name: analysisResultMetricName,
-		Help: "Result of Analysis",
-	}, labelNamesAnalysis)
-	err := prometheus.Register(a)
+	r.metrics = newMetrics()
 
-	if err != nil {
+	if err := prometheus.Register(r.metrics.AnalysisResult); err != nil {
 		klog.Errorf("Could not register Analysis results as Prometheus metric: %v", err)
 	}
-
-	labelNames := []string{"name", "namespace", "analysis_name", "analysis_namespace", "key_objective", "weight", "from", "to"}
-	o := prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Name: objectiveResultMetricName,
-		Help: "Result of the Analysis Objective",
-	}, labelNames)
-	err = prometheus.Register(o)
-	if err != nil {
+	if err := prometheus.Register(r.metrics.ObjectiveResult); err != nil {
 		klog.Errorf("Could not register Analysis Objective results as Prometheus metric: %v", err)
 	}
-
-	r.metrics = Metrics{
-		AnalysisResult:  a,
-		ObjectiveResult: o,
+	if err := prometheus.Register(r.metrics.AnalysisPass); err != nil {
+		klog.Errorf("Could not register Analysis pass state as Prometheus metric: %v", err)
+	}
+	if err := prometheus.Register(r.metrics.AnalysisWarning); err != nil {
+		klog.Errorf("Could not register Analysis warning state as Prometheus metric: %v", err)
+	}
+	if err := prometheus.Register(r.metrics.AnalysisPassRatio); err != nil {
+		klog.Errorf("Could not register Analysis pass ratio as Prometheus metric: %v", err)
 	}
 
 	go r.watchForResults(ctx, res)
 }
 
+func newMetrics() Metrics {
+	labelNamesAnalysis := []string{"name", "namespace", "from", "to"}
+	labelNames := []string{"name", "namespace", "analysis_name", "analysis_namespace", "key_objective", "weight", "from", "to"}
+	// the state of an Analysis is exposed without the timeframe, so that recurring runs update the same series
+	labelNamesState := []string{"name", "namespace"}
+
+	return Metrics{
+		AnalysisResult: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: analysisResultMetricName,
+			Help: "Result of Analysis",
+		}, labelNamesAnalysis),
+		ObjectiveResult: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: objectiveResultMetricName,
+			Help: "Result of the Analysis Objective",
+		}, labelNames),
+		AnalysisPass: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: analysisPassMetricName,
+			Help: "Whether the latest run of the Analysis passed",
+		}, labelNamesState),
+		AnalysisWarning: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: analysisWarningMetricName,
+			Help: "Whether the latest run of the Analysis returned a warning",
+		}, labelNamesState),
+		AnalysisPassRatio: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: analysisPassRatioMetricName,
+			Help: "Ratio of passed runs in the history of a scheduled Analysis",
+		}, labelNamesState),
+	}
+}
+
 func (r *resultsReporter) watchForResults(ctx context.Context, res chan analysistypes.AnalysisCompletion) {
 	for {
 		select {
@@ -82,6 +110,20 @@ func (r *resultsReporter) reportResult(finishedAnalysis analysistypes.AnalysisCo
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
+	if finishedAnalysis.Analysis.IsScheduled() {
+		// only the results of the latest run of a scheduled Analysis are kept, since the timeframe
+		// labels would otherwise create new series with every run
+		r.metrics.AnalysisResult.DeletePartialMatch(prometheus.Labels{
+			"name":      finishedAnalysis.Analysis.Name,
+			"namespace": finishedAnalysis.Analysis.Namespace,
+		})
+		r.metrics.ObjectiveResult.DeletePartialMatch(prometheus.Labels{
+			"analysis_name":      finishedAnalysis.Analysis.Name,
+			"analysis_namespace": finishedAnalysis.Analysis.Namespace,
+		})
+	}
+	r.reportState(finishedAnalysis)
+
 	fromTimestamp := finishedAnalysis.Analysis.GetFrom().String()
 	toTimestamp := finishedAnalysis.Analysis.GetTo().String()
 	labelsAnalysis := prometheus.Labels{
@@ -116,3 +158,43 @@ func (r *resultsReporter) reportResult(finishedAnalysis analysistypes.AnalysisCo
 		}
 	}
 }
+
+func (r *resultsReporter) reportState(finishedAnalysis analysistypes.AnalysisCompletion) {
+	labels := prometheus.Labels{
+		"name":      finishedAnalysis.Analysis.Name,
+		"namespace": finishedAnalysis.Analysis.Namespace,
+	}
+	if m, err := r.metrics.AnalysisPass.GetMetricWith(labels); err == nil {
+		m.Set(boolToFloat(finishedAnalysis.Result.Pass))
+	} else {
+		klog.Errorf("unable to set value for analysis pass metric: %v", err)
+	}
+	if m, err := r.metrics.AnalysisWarning.GetMetricWith(labels); err == nil {
+		m.Set(boolToFloat(finishedAnalysis.Result.Warning))
+	} else {
+		klog.Errorf("unable to set value for analysis warning metric: %v", err)
+	}
+
+	history := finishedAnalysis.Analysis.Status.History
+	if len(history) == 0 {
+		return
+	}
+	passed := 0
+	for _, run := range history {
+		if run.Pass {
+			passed++
+		}
+	}
+	if m, err := r.metrics.AnalysisPassRatio.GetMetricWith(labels); err == nil {
+		m.Set(float64(passed) / float64(len(history)))
+	} else {
+		klog.Errorf("unable to set value for analysis pass ratio metric: %v", err)
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -8,6 +8,7 @@ import (
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
 	analysistypes "github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/analysis/types"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	io_prometheus_client "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -93,3 +94,61 @@ func TestResultsReporter(t *testing.T) {
 	require.Len(t, objectiveMetric.Metric, 1)
 	require.Equal(t, 10.0, *objectiveMetric.Metric[0].Gauge.Value)
 }
+
+func TestResultsReporter_ScheduledAnalysis(t *testing.T) {
+	r := &resultsReporter{metrics: newMetrics()}
+
+	analysis := metricsapi.Analysis{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-scheduled-analysis",
+			Namespace: "my-namespace",
+		},
+		Spec: metricsapi.AnalysisSpec{
+			Schedule: &metricsapi.AnalysisSchedule{
+				Interval: metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+	}
+	objectiveResults := []analysistypes.ObjectiveResult{
+		{
+			Objective: metricsapi.Objective{
+				AnalysisValueTemplateRef: metricsapi.ObjectReference{
+					Name:      "my-av",
+					Namespace: "my-namespace",
+				},
+			},
+			Value: 10,
+		},
+	}
+
+	// first run passes
+	analysis.Status.Timeframe = metricsapi.Timeframe{
+		From: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+		To:   metav1.Now(),
+	}
+	analysis.Status.History = []metricsapi.AnalysisRunResult{{Pass: true}}
+	r.reportResult(analysistypes.AnalysisCompletion{
+		Result:   analysistypes.AnalysisResult{ObjectiveResults: objectiveResults, Pass: true},
+		Analysis: analysis,
+	})
+
+	// second run returns a warning
+	analysis.Status.Timeframe = metricsapi.Timeframe{
+		From: metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+		To:   metav1.NewTime(time.Now().Add(5 * time.Minute)),
+	}
+	analysis.Status.History = []metricsapi.AnalysisRunResult{{Warning: true}, {Pass: true}}
+	r.reportResult(analysistypes.AnalysisCompletion{
+		Result:   analysistypes.AnalysisResult{ObjectiveResults: objectiveResults, Warning: true},
+		Analysis: analysis,
+	})
+
+	// only the latest run is exposed with its timeframe
+	require.Equal(t, 1, testutil.CollectAndCount(r.metrics.AnalysisResult))
+	require.Equal(t, 1, testutil.CollectAndCount(r.metrics.ObjectiveResult))
+
+	labels := prometheus.Labels{"name": "my-scheduled-analysis", "namespace": "my-namespace"}
+	require.Equal(t, 0.0, testutil.ToFloat64(r.metrics.AnalysisPass.With(labels)))
+	require.Equal(t, 1.0, testutil.ToFloat64(r.metrics.AnalysisWarning.With(labels)))
+	require.Equal(t, 0.5, testutil.ToFloat64(r.metrics.AnalysisPassRatio.With(labels)))
+}
@@ -12,20 +12,29 @@ import (
 	"unicode"
 
 	"github.com/benbjohnson/clock"
+	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
+	"github.com/keptn/lifecycle-toolkit/metrics-operator/controllers/common/slo"
 	"github.com/open-feature/go-sdk/openfeature"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type Metrics struct {
 	gauges map[string]prometheus.Gauge
+	slo    sloMetrics
+}
+
+// sloMetrics exposes the status of all ServiceLevelObjectives
+type sloMetrics struct {
+	sli                  *prometheus.GaugeVec
+	errorBudgetRemaining *prometheus.GaugeVec
+	burnRate             *prometheus.GaugeVec
 }
 
 var instance *serverManager
@@ -38,17 +47,19 @@ type serverManager struct {
 	exposeMetrics bool
 	k8sClient     client.Client
 	metrics       Metrics
+	log           logr.Logger
 }
 
 // StartServerManager starts a server manager to expose metrics and runs until
 // the context is cancelled (i.e. an env variable gets changes and pod is restarted)
-func StartServerManager(ctx context.Context, client client.Client, ofClient *openfeature.Client, exposeMetrics bool, interval time.Duration) {
+func StartServerManager(ctx context.Context, client client.Client, ofClient *openfeature.Client, exposeMetrics bool, interval time.Duration, log logr.Logger) {
 	smOnce.Do(func() {
 		instance = &serverManager{
 			ticker:        clock.New().Ticker(interval),
 			ofClient:      ofClient,
 			exposeMetrics: exposeMetrics,
 			k8sClient:     client,
+			log:           log,
 		}
 		instance.start(ctx)
 	})
@@ -60,12 +71,12 @@ func (m *serverManager) start(ctx context.Context) {
 			select {
 			case <-ctx.Done():
 				if err := m.shutDownServer(); err != nil {
-					klog.Errorf("Error during server shutdown: %v", err)
+					m.log.Error(err, "Error during server shutdown")
 				}
 				return
 			case <-m.ticker.C:
 				if err := m.setup(); err != nil {
-					klog.Errorf("Error during server setup: %v", err)
+					m.log.Error(err, "Error during server setup")
 				}
 			}
 		}
@@ -89,7 +100,7 @@ func (m *serverManager) setup() error {
 	var serverEnabled bool
 	var err error
 
-	klog.Infof("Checking configuration of keptn-metrics server")
+	m.log.Info("Checking configuration of keptn-metrics server")
 
 	for i := 0; i < maxRetries; i++ {
 		serverEnabled, err = m.ofClient.BooleanValue(context.TODO(), "keptn.gms.expose", m.exposeMetrics, openfeature.EvaluationContext{})
@@ -104,14 +115,15 @@ func (m *serverManager) setup() error {
 		break
 	}
 
-	klog.Infof("Keptn Metrics server enabled: %v", serverEnabled)
+	m.log.Info("Keptn Metrics server enabled", "enabled", serverEnabled)
 
 	if serverEnabled && m.server == nil {
 
 		m.metrics.gauges = make(map[string]prometheus.Gauge)
+		m.metrics.slo = newSLOMetrics(m.log)
 
-		klog.Infof("serving Prometheus metrics at localhost:9999/metrics")
-		klog.Infof("serving KeptnMetrics at localhost:9999/api/v1/metrics/{namespace}/{metric}")
+		m.log.Info("serving Prometheus metrics at localhost:9999/metrics")
+		m.log.Info("serving KeptnMetrics at localhost:9999/api/v1/metrics/{namespace}/{metric}")
 
 		router := mux.NewRouter()
 		router.Path("/metrics").Handler(promhttp.Handler())
@@ -127,7 +139,7 @@ func (m *serverManager) setup() error {
 		go func() {
 			err := m.server.ListenAndServe()
 			if err != nil {
-				klog.Errorf("could not start keptn-metrics server: %v", err)
+				m.log.Error(err, "could not start keptn-metrics server")
 			}
 		}()
 
@@ -155,11 +167,15 @@ func (m *serverManager) returnMetric(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data := map[string]string{
+	data := map[string]interface{}{
 		"namespace": namespace,
 		"metric":    metric,
 		"value":     metricObj.Status.Value,
 	}
+	if len(metricObj.Status.Results) > 0 {
+		// multi-dimensional KeptnMetrics provide the value of each series with its labels
+		data["results"] = metricObj.Status.Results
+	}
 
 	err = json.NewEncoder(w).Encode(data)
 	if err != nil {
@@ -182,6 +198,11 @@ func (m *serverManager) recordMetrics() {
 			}
 			for _, metric := range list.Items {
 				normName := normalizeMetricName(metric.Name)
+				if len(metric.Status.Results) > 0 {
+					// multi-dimensional KeptnMetrics have no single value, their series are only served by the API
+					m.removeGauge(normName)
+					continue
+				}
 				if _, ok := m.metrics.gauges[normName]; !ok {
 					m.metrics.gauges[normName] = prometheus.NewGauge(prometheus.GaugeOpts{
 						Name: normName,
@@ -195,11 +216,82 @@ func (m *serverManager) recordMetrics() {
 				val, _ := strconv.ParseFloat(metric.Status.Value, 64)
 				m.metrics.gauges[normName].Set(val)
 			}
+			m.recordSLOMetrics()
 			<-time.After(10 * time.Second)
 		}
 	}()
 }
 
+// removeGauge unregisters the gauge with the given name, if it has been registered
+func (m *serverManager) removeGauge(name string) {
+	if gauge, ok := m.metrics.gauges[name]; ok {
+		prometheus.Unregister(gauge)
+		delete(m.metrics.gauges, name)
+	}
+}
+
+func newSLOMetrics(log logr.Logger) sloMetrics {
+	labelNames := []string{"name", "namespace"}
+	return sloMetrics{
+		sli: registerGaugeVec(log, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "keptn_slo_sli",
+			Help: "Percentage of good events within the window of the ServiceLevelObjective",
+		}, labelNames)),
+		errorBudgetRemaining: registerGaugeVec(log, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "keptn_slo_error_budget_remaining",
+			Help: "Percentage of the error budget of the ServiceLevelObjective which has not been consumed yet",
+		}, labelNames)),
+		burnRate: registerGaugeVec(log, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "keptn_slo_burn_rate",
+			Help: "Rate at which the error budget of the ServiceLevelObjective is consumed within the window",
+		}, append(labelNames, "window"))),
+	}
+}
+
+// registerGaugeVec registers the GaugeVec, or returns the already registered one if the server has been restarted
+func registerGaugeVec(log logr.Logger, vec *prometheus.GaugeVec) *prometheus.GaugeVec {
+	if err := prometheus.Register(vec); err != nil {
+		are := prometheus.AlreadyRegisteredError{}
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(*prometheus.GaugeVec); ok {
+				return existing
+			}
+		}
+		log.Error(err, "failed to register metric")
+	}
+	return vec
+}
+
+func (m *serverManager) recordSLOMetrics() {
+	list := metricsapi.ServiceLevelObjectiveList{}
+	if err := m.k8sClient.List(context.Background(), &list); err != nil {
+		m.log.Error(err, "failed to list service level objectives")
+		return
+	}
+	// reset the gauges so that deleted ServiceLevelObjectives and burn rate windows are not exposed anymore
+	m.metrics.slo.sli.Reset()
+	m.metrics.slo.errorBudgetRemaining.Reset()
+	m.metrics.slo.burnRate.Reset()
+	for _, objective := range list.Items {
+		labels := prometheus.Labels{"name": objective.Name, "namespace": objective.Namespace}
+		if val, err := strconv.ParseFloat(objective.Status.SLI, 64); err == nil {
+			m.metrics.slo.sli.With(labels).Set(val)
+		}
+		if val, err := strconv.ParseFloat(objective.Status.ErrorBudgetRemaining, 64); err == nil {
+			m.metrics.slo.errorBudgetRemaining.With(labels).Set(val)
+		}
+		for _, burnRate := range objective.Status.BurnRates {
+			if val, err := strconv.ParseFloat(burnRate.Value, 64); err == nil {
+				m.metrics.slo.burnRate.With(prometheus.Labels{
+					"name":      objective.Name,
+					"namespace": objective.Namespace,
+					"window":    slo.FormatWindow(burnRate.Window.Duration),
+				}).Set(val)
+			}
+		}
+	}
+}
+
 // normalizeMetricName removes all characters from the name
 // of the metric that are not digits nor letters and
 // substitues them with underscore (_)
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/go-logr/logr"
 	metricsapi "github.com/keptn/lifecycle-toolkit/metrics-operator/api/v1"
 	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -70,7 +72,26 @@ func TestMetricServer_happyPath(t *testing.T) {
 			},
 		},
 	}
-	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&metric).Build()
+	var multiDimensionalMetric = metricsapi.KeptnMetric{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "sample-pod-metric",
+			Namespace: "keptn-system",
+		},
+		Spec: metricsapi.KeptnMetricSpec{
+			Provider: metricsapi.ProviderRef{
+				Name: "prometheus",
+			},
+			Query:                "query",
+			FetchIntervalSeconds: 5,
+		},
+		Status: metricsapi.KeptnMetricStatus{
+			Results: []metricsapi.MetricResult{
+				{Labels: map[string]string{"pod": "pod-1"}, Value: "1"},
+				{Labels: map[string]string{"pod": "pod-2"}, Value: "2"},
+			},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&metric, &multiDimensionalMetric).Build()
 
 	tInstance := &serverManager{
 		ticker:        clock.New().Ticker(3 * time.Second),
@@ -103,6 +124,8 @@ func TestMetricServer_happyPath(t *testing.T) {
 	newStr := buf.String()
 
 	require.Contains(t, newStr, "# TYPE sample_metric gauge")
+	// multi-dimensional metrics have no single value to be exposed as gauge
+	require.NotContains(t, newStr, "sample_pod_metric")
 
 	require.Eventually(t, func() bool {
 		cli := &http.Client{}
@@ -119,6 +142,22 @@ func TestMetricServer_happyPath(t *testing.T) {
 	newStr = buf.String()
 
 	require.Contains(t, newStr, "\"metric\":\"sample-metric\",\"namespace\":\"keptn-system\",\"value\":\"12\"")
+	require.NotContains(t, newStr, "results")
+
+	require.Eventually(t, func() bool {
+		cli := &http.Client{}
+		req, _ := http.NewRequestWithContext(context.TODO(), http.MethodGet, "http://localhost:9999/api/v1/metrics/keptn-system/sample-pod-metric", nil)
+		resp, err = cli.Do(req)
+		return err == nil
+	}, 10*time.Second, time.Second)
+
+	defer resp.Body.Close()
+
+	buf = new(bytes.Buffer)
+	_, err = buf.ReadFrom(resp.Body)
+	require.Nil(t, err)
+
+	require.Contains(t, buf.String(), "\"results\":[{\"labels\":{\"pod\":\"pod-1\"},\"value\":\"1\"},{\"labels\":{\"pod\":\"pod-2\"},\"value\":\"2\"}]")
 }
 
 func TestMetricServer_noMetric(t *testing.T) {
@@ -151,3 +190,37 @@ func TestMetricServer_noMetric(t *testing.T) {
 	require.Equal(t, 404, stat)
 
 }
+
+func TestMetricServer_recordSLOMetrics(t *testing.T) {
+	slo := metricsapi.ServiceLevelObjective{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "availability",
+			Namespace: "keptn-system",
+		},
+		Status: metricsapi.ServiceLevelObjectiveStatus{
+			SLI:                  "99.95",
+			ErrorBudgetRemaining: "50",
+			BurnRates: []metricsapi.BurnRate{
+				{Window: v1.Duration{Duration: time.Hour}, Value: "2.5"},
+			},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&slo).Build()
+
+	tInstance := &serverManager{
+		k8sClient: k8sClient,
+		metrics: Metrics{
+			slo: newSLOMetrics(logr.Discard()),
+		},
+	}
+	tInstance.recordSLOMetrics()
+
+	require.Equal(t, 99.95, testutil.ToFloat64(tInstance.metrics.slo.sli.WithLabelValues("availability", "keptn-system")))
+	require.Equal(t, 50.0, testutil.ToFloat64(tInstance.metrics.slo.errorBudgetRemaining.WithLabelValues("availability", "keptn-system")))
+	require.Equal(t, 2.5, testutil.ToFloat64(tInstance.metrics.slo.burnRate.WithLabelValues("availability", "keptn-system", "1h")))
+
+	require.Nil(t, k8sClient.Delete(context.TODO(), &slo))
+	tInstance.recordSLOMetrics()
+
+	require.Equal(t, 0, testutil.CollectAndCount(tInstance.metrics.slo.sli))
+}